@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/callrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/controller"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/store"
+	"github.com/mesos/mesos-go/api/v1/lib/resourcefilters"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+// ownerLabel is the key of the reservation label that identifies the resources that the framework has
+// reserved; its value is the name of the framework.
+const ownerLabel = "owner"
+
+// Config configures a batch framework.
+type Config struct {
+	Name          string
+	User          string
+	Role          string // resources are reserved for this role; must not be "*"
+	Principal     string
+	Jobs          int
+	TasksPerJob   int
+	MaxAttempts   int // number of times that a job is attempted before it's considered failed
+	Command       string
+	TaskResources mesos.Resources // per task, unreserved
+	ExecResources mesos.Resources // of the default executor of every job, unreserved
+	VolumeSize    float64         // MB of disk of the persistent volume that the tasks of a job share
+	RefuseSeconds time.Duration
+}
+
+type (
+	// job is a group of tasks that's launched atomically (a "pod"); the tasks share a persistent volume.
+	job struct {
+		id       string
+		attempts int
+		agentID  string
+		running  int  // number of non-terminal tasks
+		failed   bool // whether any task has failed
+	}
+
+	// batch is a framework that runs a queue of jobs. The first job that's launched on an agent reserves
+	// resources on it and creates a persistent volume; subsequent jobs reuse the reservation and the data
+	// of the volume. The volume is destroyed, and the resources unreserved, once the queue is empty.
+	// All state is manipulated by the (single-threaded) event loop.
+	batch struct {
+		config    Config
+		caller    calls.Caller
+		declines  *offers.DeclinePolicy
+		fid       store.Singleton
+		done      chan struct{}
+		queue     []*job
+		running   map[string]*job // by agent ID
+		tasks     map[string]*job // by task ID
+		reserved  map[string]bool // agents w/ reservations of the framework
+		succeeded int
+		failed    int
+		volumes   int
+	}
+)
+
+func newBatch(config Config, caller calls.Caller) *batch {
+	b := &batch{
+		config:   config,
+		fid:      store.NewInMemorySingleton(),
+		done:     make(chan struct{}),
+		running:  make(map[string]*job),
+		tasks:    make(map[string]*job),
+		reserved: make(map[string]bool),
+		declines: offers.NewDeclinePolicy(offers.RefuseFor(config.RefuseSeconds)),
+	}
+	for i := 0; i < config.Jobs; i++ {
+		b.queue = append(b.queue, &job{id: config.Name + "-job-" + strconv.Itoa(i)})
+	}
+	b.caller = callrules.New(callrules.WithFrameworkID(store.GetIgnoreErrors(b.fid))).Caller(caller)
+	return b
+}
+
+// Done returns a chan that's closed once all jobs have completed and all reservations have been released.
+func (b *batch) Done() <-chan struct{} { return b.done }
+
+// Run subscribes to the master and runs the framework until the context is canceled.
+func (b *batch) Run(ctx context.Context, opts ...controller.Option) error {
+	info := &mesos.FrameworkInfo{
+		User: b.config.User,
+		Name: b.config.Name,
+		Role: &b.config.Role,
+		Capabilities: []mesos.FrameworkInfo_Capability{
+			{Type: mesos.FrameworkInfo_Capability_RESERVATION_REFINEMENT},
+		},
+	}
+	if b.config.Principal != "" {
+		info.Principal = &b.config.Principal
+	}
+	return controller.Run(ctx, info, b.caller, append([]controller.Option{
+		controller.WithEventHandler(b.handler()),
+		controller.WithFrameworkID(store.GetIgnoreErrors(b.fid)),
+	}, opts...)...)
+}
+
+func (b *batch) handler() events.Handler {
+	return eventrules.New(
+		controller.LiftErrors().DropOnError(),
+	).Handle(events.Handlers{
+		scheduler.Event_SUBSCRIBED: controller.TrackSubscription(b.fid, 0),
+		scheduler.Event_OFFERS:     events.HandlerFunc(b.offers),
+		scheduler.Event_UPDATE:     controller.AckStatusUpdates(b.caller).AndThen().HandleF(b.update),
+	}.Otherwise(func(context.Context, *scheduler.Event) error { return nil }))
+}
+
+// reservation returns the reservation that the framework makes; it's labeled so that the framework
+// recognizes its own reservations among those of other frameworks of the same role.
+func (b *batch) reservation() mesos.Resource_ReservationInfo {
+	return resources.DynamicReservation(b.config.Role, b.config.Principal,
+		mesos.Label{Key: ownerLabel, Value: &b.config.Name})
+}
+
+// owned accepts the resources that are (most recently) reserved by the framework.
+func (b *batch) owned(r *mesos.Resource) bool {
+	rs := r.GetReservations()
+	if len(rs) == 0 || rs[len(rs)-1].GetRole() != b.config.Role {
+		return false
+	}
+	for _, l := range rs[len(rs)-1].GetLabels().GetLabels() {
+		if l.Key == ownerLabel && l.GetValue() == b.config.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *batch) offers(ctx context.Context, e *scheduler.Event) error {
+	var declined offers.Slice
+	for i := range e.GetOffers().GetOffers() {
+		offer := &e.GetOffers().GetOffers()[i]
+		ops, err := b.operations(offer)
+		if err != nil {
+			return err
+		}
+		if len(ops) == 0 {
+			declined = append(declined, *offer)
+			continue
+		}
+		accept := calls.Accept(ops.WithOffers(offer.ID)).With(b.declines.Filters(offer.GetAllocationInfo().GetRole()))
+		if err = calls.CallNoData(ctx, b.caller, accept); err != nil {
+			return err
+		}
+	}
+	if err := b.declines.Decline(ctx, b.caller, declined); err != nil {
+		return err
+	}
+	if len(b.queue) == 0 && len(b.running) == 0 && len(b.reserved) == 0 {
+		select {
+		case <-b.done:
+		default:
+			log.Printf("all jobs completed: %d succeeded, %d failed", b.succeeded, b.failed)
+			close(b.done)
+			return calls.CallNoData(ctx, b.caller, &scheduler.Call{Type: scheduler.Call_TEARDOWN})
+		}
+	}
+	return nil
+}
+
+// operations returns the operations to apply to the given offer; one job is run on an agent at a time.
+func (b *batch) operations(offer *mesos.Offer) (calls.OfferOperations, error) {
+	var (
+		agentID = offer.AgentID.Value
+		owned   = resourcefilters.Select(resourcefilters.Filter(b.owned), offer.Resources...)
+		volumes = resourcefilters.Select(resourcefilters.Filter(resourcefilters.PersistentVolumes), owned...)
+	)
+	if len(owned) > 0 {
+		b.reserved[agentID] = true
+	}
+	if b.running[agentID] != nil {
+		return nil, nil
+	}
+	if len(owned) > 0 && (len(b.queue) == 0 || len(volumes) == 0) {
+		// release everything (the data of the volume is discarded) once the queue is empty; reservations
+		// w/o volume, e.g. leftovers of a failed CREATE, are released right away
+		return b.release(agentID, owned, volumes), nil
+	}
+	if len(b.queue) == 0 {
+		return nil, nil
+	}
+
+	var (
+		ri     = b.reservation()
+		wanted = b.config.ExecResources.Clone()
+		ops    calls.OfferOperations
+		volume mesos.Resource
+	)
+	for i := 0; i < b.config.TasksPerJob; i++ {
+		wanted.Add(b.config.TaskResources...)
+	}
+	if len(volumes) > 0 {
+		// reuse the reservation (and the volume) of a previous job
+		reserved, err := resources.Refine(wanted, ri)
+		if err != nil {
+			return nil, err
+		}
+		volume = volumes[0]
+		if !resources.ContainsAll(owned, append(reserved, volume)) {
+			return nil, nil
+		}
+	} else {
+		unreserved := resourcefilters.Select(resourcefilters.Filter(resourcefilters.Unreserved), offer.Resources...)
+		disk := resources.NewDisk(b.config.VolumeSize).Resource
+		if !resources.ContainsAll(unreserved, wanted.Plus(disk)) {
+			return nil, nil
+		}
+		reserve, err := resources.Refine(wanted.Plus(disk), ri)
+		if err != nil {
+			return nil, err
+		}
+		reservedDisk, err := resources.Refine(mesos.Resources{disk}, ri)
+		if err != nil {
+			return nil, err
+		}
+		b.volumes++
+		volume = reservedDisk[0]
+		volume.Disk = &mesos.Resource_DiskInfo{
+			Persistence: &mesos.Resource_DiskInfo_Persistence{ID: b.config.Name + "-volume-" + strconv.Itoa(b.volumes)},
+			Volume:      &mesos.Volume{ContainerPath: volumePath, Mode: mesos.RW.Enum()},
+		}
+		if b.config.Principal != "" {
+			volume.Disk.Persistence.Principal = &b.config.Principal
+		}
+		b.reserved[agentID] = true
+		log.Println("reserving resources on agent", agentID)
+		ops = append(ops, calls.OpReserve(reserve...), calls.OpCreate(volume))
+	}
+
+	j := b.queue[0]
+	b.queue = b.queue[1:]
+	executor, tasks, err := b.taskGroup(j, offer.AgentID, volume)
+	if err != nil {
+		return nil, err
+	}
+	j.attempts++
+	j.agentID = agentID
+	j.running = len(tasks)
+	j.failed = false
+	b.running[agentID] = j
+	for i := range tasks {
+		b.tasks[tasks[i].TaskID.Value] = j
+	}
+	log.Printf("launching job %s (attempt %d) on agent %s", j.id, j.attempts, agentID)
+	return append(ops, calls.OpLaunchGroup(executor, tasks...)), nil
+}
+
+// release returns the operations that destroy the volumes of the framework on the given agent, and
+// unreserve its resources.
+func (b *batch) release(agentID string, owned, volumes mesos.Resources) (ops calls.OfferOperations) {
+	delete(b.reserved, agentID)
+	if len(volumes) > 0 {
+		ops = append(ops, calls.OpDestroy(volumes...))
+	}
+	unreserve := owned.Clone()
+	for i := range unreserve {
+		unreserve[i].Disk = nil
+	}
+	log.Println("releasing reservation on agent", agentID)
+	return append(ops, calls.OpUnreserve(unreserve...))
+}
+
+// volumePath is the path of the persistent volume, relative to the sandbox of the executor; the tasks of
+// a job mount it at the same path of their own sandboxes.
+const volumePath = "data"
+
+// taskGroup returns the default executor and the tasks of the given job; the executor holds the volume,
+// which is shared w/ the tasks.
+func (b *batch) taskGroup(j *job, agentID mesos.AgentID, volume mesos.Resource) (mesos.ExecutorInfo, []mesos.TaskInfo, error) {
+	ri := b.reservation()
+	execResources, err := resources.Refine(b.config.ExecResources, ri)
+	if err != nil {
+		return mesos.ExecutorInfo{}, nil, err
+	}
+	taskResources, err := resources.Refine(b.config.TaskResources, ri)
+	if err != nil {
+		return mesos.ExecutorInfo{}, nil, err
+	}
+	executor := mesos.ExecutorInfo{
+		Type:        mesos.ExecutorInfo_DEFAULT,
+		ExecutorID:  mesos.ExecutorID{Value: j.id + "." + strconv.Itoa(j.attempts+1)},
+		FrameworkID: &mesos.FrameworkID{Value: store.GetIgnoreErrors(b.fid)()},
+		Resources:   append(execResources, volume),
+	}
+	mode := mesos.RW
+	tasks := make([]mesos.TaskInfo, b.config.TasksPerJob)
+	for i := range tasks {
+		id := fmt.Sprintf("%s.%d.%d", j.id, j.attempts+1, i)
+		tasks[i] = mesos.TaskInfo{
+			TaskID:    mesos.TaskID{Value: id},
+			Name:      id,
+			AgentID:   agentID,
+			Resources: taskResources.Clone(),
+			Command:   &mesos.CommandInfo{Value: &b.config.Command},
+			Container: &mesos.ContainerInfo{
+				Type: mesos.ContainerInfo_MESOS.Enum(),
+				Volumes: []mesos.Volume{{
+					ContainerPath: volumePath,
+					Mode:          &mode,
+					Source: &mesos.Volume_Source{
+						Type: mesos.Volume_Source_SANDBOX_PATH,
+						SandboxPath: &mesos.Volume_Source_SandboxPath{
+							Type: mesos.Volume_Source_SandboxPath_PARENT,
+							Path: volumePath,
+						},
+					},
+				}},
+			},
+		}
+	}
+	return executor, tasks, nil
+}
+
+// update tracks the tasks of running jobs; a job is complete once all of its tasks have terminated, and
+// it's requeued if any of them failed.
+func (b *batch) update(_ context.Context, e *scheduler.Event) error {
+	status := e.GetUpdate().GetStatus()
+	j := b.tasks[status.TaskID.Value]
+	if j == nil || !status.GetState().IsTerminal() {
+		return nil
+	}
+	delete(b.tasks, status.TaskID.Value)
+	if status.GetState() != mesos.TASK_FINISHED {
+		j.failed = true
+	}
+	if j.running--; j.running > 0 {
+		return nil
+	}
+	delete(b.running, j.agentID)
+	switch {
+	case !j.failed:
+		b.succeeded++
+		log.Printf("job %s succeeded", j.id)
+	case j.attempts < b.config.MaxAttempts:
+		b.queue = append(b.queue, j)
+		log.Printf("job %s failed, retrying: %s %s", j.id, status.GetState(), status.GetMessage())
+	default:
+		b.failed++
+		log.Printf("job %s failed: %s %s", j.id, status.GetState(), status.GetMessage())
+	}
+	return nil
+}
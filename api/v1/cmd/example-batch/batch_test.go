@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/mesostest"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+func TestBatch(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		master      = mesostest.NewMaster()
+		config      = Config{
+			Name:          "test",
+			User:          "root",
+			Role:          "batch",
+			Principal:     "batch",
+			Jobs:          2,
+			TasksPerJob:   2,
+			MaxAttempts:   2,
+			Command:       "true",
+			TaskResources: mesos.Resources{resources.NewCPUs(0.5).Resource, resources.NewMemory(32).Resource},
+			ExecResources: mesos.Resources{resources.NewCPUs(0.1).Resource, resources.NewMemory(32).Resource},
+			VolumeSize:    64,
+		}
+		b = newBatch(config, httpsched.NewCaller(httpcli.New(
+			httpcli.Endpoint(master.Endpoint()),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf]),
+		)))
+		unreserved = mesos.Resources{
+			resources.NewCPUs(4).Resource,
+			resources.NewMemory(1024).Resource,
+			resources.NewDisk(1024).Resource,
+		}
+	)
+	defer cancel()
+	defer master.Close()
+
+	go b.Run(ctx)
+	fid, err := master.WaitSubscribed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// waitFor waits for the master to receive a call of the given type, and returns it
+	seen := 0
+	waitFor := func(ct scheduler.Call_Type) scheduler.Call {
+		for {
+			calls := master.Calls()
+			for ; seen < len(calls); seen++ {
+				if calls[seen].GetType() == ct {
+					seen++
+					return calls[seen-1]
+				}
+			}
+			select {
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for %v, calls: %v", ct, calls)
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	offer := func(id string, rs mesos.Resources) {
+		if err := master.Offer(fid, mesos.Offer{
+			ID:        mesos.OfferID{Value: id},
+			AgentID:   mesos.AgentID{Value: "a1"},
+			Hostname:  "a1",
+			Resources: rs,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opTypes := func(call scheduler.Call) (types []mesos.Offer_Operation_Type) {
+		for _, op := range call.GetAccept().GetOperations() {
+			types = append(types, op.GetType())
+		}
+		return
+	}
+	// launched returns the tasks of the LAUNCH_GROUP operation of the call, after checking that the tasks
+	// share the persistent volume of the executor
+	launched := func(call scheduler.Call) ([]mesos.TaskInfo, mesos.Resource) {
+		ops := call.GetAccept().GetOperations()
+		group := ops[len(ops)-1].GetLaunchGroup()
+		if group == nil || len(group.TaskGroup.Tasks) != config.TasksPerJob {
+			t.Fatalf("unexpected operations %v", ops)
+		}
+		var volume *mesos.Resource
+		for i := range group.Executor.Resources {
+			if r := &group.Executor.Resources[i]; r.GetDisk().GetPersistence() != nil {
+				volume = r
+			}
+		}
+		if volume == nil || group.Executor.GetType() != mesos.ExecutorInfo_DEFAULT {
+			t.Fatalf("unexpected executor %v", group.Executor)
+		}
+		for _, task := range group.TaskGroup.Tasks {
+			if vs := task.GetContainer().GetVolumes(); len(vs) != 1 || vs[0].GetSource().GetSandboxPath().GetPath() != volumePath {
+				t.Fatalf("unexpected task %v", task)
+			}
+		}
+		return group.TaskGroup.Tasks, *volume
+	}
+	update := func(task mesos.TaskInfo, state mesos.TaskState) {
+		if err := master.Update(fid, mesos.TaskStatus{
+			TaskID:  task.TaskID,
+			AgentID: &task.AgentID,
+			State:   state.Enum(),
+			UUID:    []byte(task.TaskID.Value + state.String()),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(scheduler.Call_ACKNOWLEDGE)
+	}
+
+	// the first job reserves resources and creates a volume
+	offer("o1", unreserved)
+	call := waitFor(scheduler.Call_ACCEPT)
+	types := opTypes(call)
+	if len(types) != 3 || types[0] != mesos.Offer_Operation_RESERVE || types[1] != mesos.Offer_Operation_CREATE {
+		t.Fatalf("unexpected operations %v", types)
+	}
+	tasks, volume := launched(call)
+	for _, task := range tasks {
+		update(task, mesos.TASK_FINISHED)
+	}
+	var reserved mesos.Resources
+	for _, r := range call.GetAccept().GetOperations()[0].GetReserve().GetResources() {
+		if r.Name != "disk" {
+			reserved = append(reserved, r)
+		}
+	}
+	reserved = append(reserved, volume)
+
+	// the second job reuses the reservation; it fails once, and is retried
+	for attempt := 0; attempt < 2; attempt++ {
+		offer("o2", append(reserved.Clone(), unreserved...))
+		call = waitFor(scheduler.Call_ACCEPT)
+		if types = opTypes(call); len(types) != 1 {
+			t.Fatalf("unexpected operations %v", types)
+		}
+		tasks, v := launched(call)
+		if v.GetDisk().GetPersistence().GetID() != volume.GetDisk().GetPersistence().GetID() {
+			t.Fatalf("expected volume %v instead of %v", volume, v)
+		}
+		state := mesos.TASK_FINISHED
+		if attempt == 0 {
+			state = mesos.TASK_FAILED
+		}
+		for _, task := range tasks {
+			update(task, state)
+		}
+	}
+
+	// the queue is empty: the volume is destroyed, the resources are unreserved and the framework is done
+	offer("o3", append(reserved.Clone(), unreserved...))
+	call = waitFor(scheduler.Call_ACCEPT)
+	if types = opTypes(call); len(types) != 2 || types[0] != mesos.Offer_Operation_DESTROY || types[1] != mesos.Offer_Operation_UNRESERVE {
+		t.Fatalf("unexpected operations %v", types)
+	}
+	waitFor(scheduler.Call_TEARDOWN)
+	select {
+	case <-b.Done():
+	case <-ctx.Done():
+		t.Fatal("expected the framework to be done")
+	}
+	if b.succeeded != 2 || b.failed != 0 {
+		t.Fatalf("unexpected results: %d succeeded, %d failed", b.succeeded, b.failed)
+	}
+}
@@ -0,0 +1,10 @@
+// example-batch is a framework that runs a queue of jobs, and serves as an example (and a smoke test) of
+// task groups, persistent volumes and dynamic reservations. Every job is a group of tasks (a "pod") that's
+// launched w/ the default executor; the tasks share a persistent volume that's mounted at "data" in their
+// sandboxes. The first job that's launched on an agent reserves resources for the role of the framework
+// and creates the volume, w/ a single ACCEPT call that pipelines RESERVE, CREATE and LAUNCH_GROUP
+// operations; subsequent jobs reuse the reservation, and the data of the volume. Once the queue is empty
+// the volumes are destroyed, the resources are unreserved and the framework tears itself down.
+//
+//	example-batch -role batch -principal batch -jobs 10 -tasks 3 -command 'date >> data/log'
+package main
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/controller"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func main() {
+	var (
+		config = Config{
+			Name:          "example-batch",
+			Role:          "batch",
+			Jobs:          3,
+			TasksPerJob:   2,
+			MaxAttempts:   3,
+			Command:       "date >> data/log",
+			VolumeSize:    64,
+			RefuseSeconds: 5 * time.Second,
+		}
+		master   = flag.String("master", "http://127.0.0.1:5050/api/v1/scheduler", "URL of the scheduler API endpoint of the mesos master")
+		cpus     = flag.Float64("cpus", 0.1, "CPUs per task")
+		mem      = flag.Float64("mem", 32, "Memory (MB) per task")
+		execCPUs = flag.Float64("exec-cpus", 0.1, "CPUs of the executor of a job")
+		execMem  = flag.Float64("exec-mem", 32, "Memory (MB) of the executor of a job")
+	)
+	flag.StringVar(&config.Name, "name", config.Name, "Framework name")
+	flag.StringVar(&config.User, "user", config.User, "User that tasks run as")
+	flag.StringVar(&config.Role, "role", config.Role, "Role that resources are reserved for; must not be \"*\"")
+	flag.StringVar(&config.Principal, "principal", config.Principal, "Framework principal, also used for reservations and volumes")
+	flag.IntVar(&config.Jobs, "jobs", config.Jobs, "Number of jobs to run")
+	flag.IntVar(&config.TasksPerJob, "tasks", config.TasksPerJob, "Number of tasks per job")
+	flag.IntVar(&config.MaxAttempts, "max-attempts", config.MaxAttempts, "Number of times that a job is attempted before it's considered failed")
+	flag.StringVar(&config.Command, "command", config.Command, "Shell command that tasks run")
+	flag.Float64Var(&config.VolumeSize, "volume-size", config.VolumeSize, "Size (MB) of the persistent volume that the tasks of a job share")
+	flag.DurationVar(&config.RefuseSeconds, "refuse", config.RefuseSeconds, "Duration for which unused offers are declined")
+	flag.Parse()
+
+	config.TaskResources = mesos.Resources{
+		resources.NewCPUs(*cpus).Resource,
+		resources.NewMemory(*mem).Resource,
+	}
+	config.ExecResources = mesos.Resources{
+		resources.NewCPUs(*execCPUs).Resource,
+		resources.NewMemory(*execMem).Resource,
+	}
+
+	caller := httpsched.NewCaller(httpcli.New(
+		httpcli.Endpoint(*master),
+		httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf]),
+	))
+	ctx, cancel := context.WithCancel(context.Background())
+	b := newBatch(config, caller)
+	go func() {
+		<-b.Done()
+		cancel()
+	}()
+	err := b.Run(ctx,
+		controller.WithRegistrationTokens(backoff.Notifier(time.Second, 15*time.Second, ctx.Done())),
+		controller.WithSubscriptionTerminated(func(err error) {
+			if err != nil && err != io.EOF {
+				log.Println(err)
+			}
+		}),
+	)
+	if err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
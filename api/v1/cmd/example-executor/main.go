@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
 	"io"
 	"log"
 	"net/url"
@@ -10,13 +9,13 @@ import (
 	"time"
 
 	"github.com/mesos/mesos-go/api/v1/lib"
-	"github.com/mesos/mesos-go/api/v1/lib/backoff"
-	"github.com/mesos/mesos-go/api/v1/lib/encoding"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
 	"github.com/mesos/mesos-go/api/v1/lib/executor"
 	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
 	"github.com/mesos/mesos-go/api/v1/lib/executor/config"
 	"github.com/mesos/mesos-go/api/v1/lib/executor/events"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/controller"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/eventrules"
 	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
 	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpexec"
 	"github.com/pborman/uuid"
@@ -27,26 +26,24 @@ const (
 	httpTimeout = 10 * time.Second
 )
 
-var errMustAbort = errors.New("received abort signal from mesos, will attempt to re-subscribe")
-
 func main() {
 	cfg, err := config.FromEnv()
 	if err != nil {
 		log.Fatal("failed to load configuration: " + err.Error())
 	}
-	log.Printf("configuration loaded: %+v", cfg)
+	redacted := cfg
+	if redacted.AuthenticationToken != "" {
+		redacted.AuthenticationToken = "<redacted>"
+	}
+	log.Printf("configuration loaded: %+v", redacted)
 	run(cfg)
 	os.Exit(0)
 }
 
-func maybeReconnect(cfg config.Config) <-chan struct{} {
-	if cfg.Checkpoint {
-		return backoff.Notifier(1*time.Second, cfg.SubscriptionBackoffMax*3/4, nil)
-	}
-	return nil
-}
-
 func run(cfg config.Config) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	var (
 		apiURL = url.URL{
 			Scheme: "http", // TODO(jdef) make this configurable
@@ -56,7 +53,7 @@ func run(cfg config.Config) {
 		http = httpcli.New(
 			httpcli.Endpoint(apiURL.String()),
 			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf]),
-			httpcli.Do(httpcli.With(httpcli.Timeout(httpTimeout))),
+			httpcli.Do(httpcli.With(httpcli.Timeout(httpTimeout), httpexec.AuthConfigOpt(cfg))),
 		)
 		callOptions = executor.CallOptions{
 			calls.Framework(cfg.FrameworkID),
@@ -70,49 +67,38 @@ func run(cfg config.Config) {
 			unackedTasks:   make(map[mesos.TaskID]mesos.TaskInfo),
 			unackedUpdates: make(map[string]executor.Call_Update),
 			failedTasks:    make(map[mesos.TaskID]mesos.TaskStatus),
+			shutdown:       cancel,
 		}
 		subscriber = calls.SenderWith(
 			httpexec.NewSender(http.Send, httpcli.Close(true)),
 			callOptions...,
 		)
-		shouldReconnect = maybeReconnect(cfg)
-		disconnected    = time.Now()
-		handler         = buildEventHandler(state)
 	)
-	for {
-		func() {
-			subscribe := calls.Subscribe(unacknowledgedTasks(state), unacknowledgedUpdates(state))
-
+	err := controller.Run(
+		ctx,
+		subscriber,
+		controller.WithEventHandler(buildEventHandler(state)),
+		controller.WithSubscribe(func() *executor.Call {
 			log.Println("subscribing to agent for events..")
-			resp, err := subscriber.Send(context.TODO(), calls.NonStreaming(subscribe))
-			if resp != nil {
-				defer resp.Close()
-			}
-			if err == nil {
-				// we're officially connected, start decoding events
-				err = eventLoop(state, resp, handler)
-				disconnected = time.Now()
-			}
+			return calls.Subscribe(unacknowledgedTasks(state), unacknowledgedUpdates(state))
+		}),
+		controller.WithRegistrationTokens(controller.RegistrationTokens(cfg, ctx.Done())),
+		controller.WithRecoveryTimeout(cfg.RecoveryTimeout),
+		controller.WithSubscriptionTerminated(func(err error) {
 			if err != nil && err != io.EOF {
 				log.Println(err)
 			} else {
 				log.Println("disconnected")
 			}
-		}()
-		if state.shouldQuit {
-			log.Println("gracefully shutting down because we were told to")
-			return
-		}
-		if !cfg.Checkpoint {
-			log.Println("gracefully exiting because framework checkpointing is NOT enabled")
-			return
-		}
-		if time.Now().Sub(disconnected) > cfg.RecoveryTimeout {
-			log.Printf("failed to re-establish subscription with agent within %v, aborting", cfg.RecoveryTimeout)
-			return
-		}
-		log.Println("waiting for reconnect timeout")
-		<-shouldReconnect // wait for some amount of time before retrying subscription
+		}),
+	)
+	switch {
+	case state.shouldQuit:
+		log.Println("gracefully shutting down because we were told to")
+	case err == controller.ErrRecoveryTimeout:
+		log.Printf("failed to re-establish subscription with agent within %v, aborting", cfg.RecoveryTimeout)
+	case !cfg.Checkpoint:
+		log.Println("gracefully exiting because framework checkpointing is NOT enabled")
 	}
 }
 
@@ -138,23 +124,11 @@ func unacknowledgedUpdates(state *internalState) (result []executor.Call_Update)
 	return
 }
 
-func eventLoop(state *internalState, decoder encoding.Decoder, h events.Handler) (err error) {
-	log.Println("listening for events from agent...")
-	ctx := context.TODO()
-	for err == nil && !state.shouldQuit {
-		// housekeeping
-		sendFailedTasks(state)
-
-		var e executor.Event
-		if err = decoder.Decode(&e); err == nil {
-			err = h.HandleEvent(ctx, &e)
-		}
-	}
-	return err
-}
-
 func buildEventHandler(state *internalState) events.Handler {
-	return events.HandlerFuncs{
+	return eventrules.New(
+		housekeeping(state),
+		controller.LiftErrors(),
+	).Handle(events.HandlerFuncs{
 		executor.Event_SUBSCRIBED: func(_ context.Context, e *executor.Event) error {
 			log.Println("SUBSCRIBED")
 			state.framework = e.Subscribed.FrameworkInfo
@@ -182,16 +156,25 @@ func buildEventHandler(state *internalState) events.Handler {
 		executor.Event_SHUTDOWN: func(_ context.Context, e *executor.Event) error {
 			log.Println("SHUTDOWN received")
 			state.shouldQuit = true
+			state.shutdown()
 			return nil
 		},
 		executor.Event_ERROR: func(_ context.Context, e *executor.Event) error {
-			log.Println("ERROR received")
-			return errMustAbort
+			log.Println("ERROR received, will attempt to re-subscribe")
+			return nil
 		},
 	}.Otherwise(func(_ context.Context, e *executor.Event) error {
 		log.Fatal("unexpected event", e)
 		return nil
-	})
+	}))
+}
+
+// housekeeping returns a rule that attempts to (re)send failed task updates prior to processing each event.
+func housekeeping(state *internalState) eventrules.Rule {
+	return func(ctx context.Context, e *executor.Event, err error, chain eventrules.Chain) (context.Context, *executor.Event, error) {
+		sendFailedTasks(state)
+		return chain(ctx, e, err)
+	}
 }
 
 func sendFailedTasks(state *internalState) {
@@ -271,4 +254,5 @@ type internalState struct {
 	unackedUpdates map[string]executor.Call_Update
 	failedTasks    map[mesos.TaskID]mesos.TaskStatus // send updates for these as we can
 	shouldQuit     bool
+	shutdown       context.CancelFunc
 }
@@ -276,7 +276,11 @@ func statusUpdate(state *internalState) events.HandlerFunc {
 func tryReviveOffers(ctx context.Context, state *internalState) {
 	// limit the rate at which we request offer revival
 	select {
-	case <-state.reviveTokens:
+	case _, ok := <-state.reviveTokens:
+		if !ok {
+			// no more revival
+			return
+		}
 		// not done yet, revive offers!
 		err := calls.CallNoData(ctx, state.cli, calls.Revive())
 		if err != nil {
@@ -29,14 +29,12 @@ func (u *URL) Set(value string) error {
 type codec struct{ encoding.Codec }
 
 func (c *codec) Set(value string) error {
-	v := strings.ToLower(value)
-	for _, codec := range codecs.ByMediaType {
-		if v == codec.Name {
-			c.Codec = codec
-			return nil
-		}
+	codec, ok := codecs.DefaultRegistry.ByName(strings.ToLower(value))
+	if !ok {
+		return fmt.Errorf("bad codec %q", value)
 	}
-	return fmt.Errorf("bad codec %q", value)
+	c.Codec = codec
+	return nil
 }
 
 type Labels []mesos.Label
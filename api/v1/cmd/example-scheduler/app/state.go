@@ -7,7 +7,6 @@ import (
 	"log"
 	"math/rand"
 	"net/http"
-	"os"
 	"time"
 
 	proto "github.com/gogo/protobuf/proto"
@@ -105,13 +104,13 @@ func buildWantsExecutorResources(config Config) (r mesos.Resources) {
 	return
 }
 
-func buildHTTPSched(cfg Config, creds credentials) calls.Caller {
+func buildHTTPSched(cfg Config, creds httpcli.CredentialsFunc) calls.Caller {
 	var authConfigOpt httpcli.ConfigOpt
 	// TODO(jdef) make this auth-mode configuration more pluggable
 	if cfg.authMode == AuthModeBasic {
 		log.Println("configuring HTTP Basic authentication")
 		// TODO(jdef) this needs testing once mesos 0.29 is available
-		authConfigOpt = httpcli.BasicAuth(creds.username, creds.password)
+		authConfigOpt = httpcli.Authentication(httpcli.BasicAuthenticatee(creds))
 	}
 	cli := httpcli.New(
 		httpcli.Endpoint(cfg.url),
@@ -164,28 +163,27 @@ func buildFrameworkInfo(cfg Config) *mesos.FrameworkInfo {
 	return frameworkInfo
 }
 
-func loadCredentials(userConfig credentials) (result credentials, err error) {
-	result = userConfig
-	if result.password != "" {
-		// this is the path to a file containing the password
-		_, err = os.Stat(result.password)
-		if err != nil {
-			return
-		}
-		var f *os.File
-		f, err = os.Open(result.password)
-		if err != nil {
-			return
-		}
-		defer f.Close()
-		var bytes []byte
-		bytes, err = ioutil.ReadAll(f)
-		if err != nil {
-			return
+// loadCredentials returns a CredentialsFunc that yields the configured username and the password that's
+// read from the password file, if any. The file is re-read upon every authentication so that a rotated
+// password is picked up; it's read once up front so that a misconfiguration fails fast.
+func loadCredentials(userConfig credentials) (httpcli.CredentialsFunc, error) {
+	cf := func() (result httpcli.Credentials, err error) {
+		result.Principal = userConfig.username
+		if userConfig.password != "" {
+			// this is the path to a file containing the password
+			var bytes []byte
+			bytes, err = ioutil.ReadFile(userConfig.password)
+			if err != nil {
+				return
+			}
+			result.Secret = string(bytes)
 		}
-		result.password = string(bytes)
+		return
 	}
-	return
+	if _, err := cf(); err != nil {
+		return nil, err
+	}
+	return cf, nil
 }
 
 func newInternalState(cfg Config, shutdown func()) (*internalState, error) {
@@ -0,0 +1,10 @@
+// example-service is a framework that keeps a number of instances of a command task running, and serves
+// as an example of the high-level scheduler runtime (see package
+// github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/controller). Instances that terminate are
+// replaced upon subsequent offers. The framework ID is persisted to a file so that a restarted framework
+// fails over, and adopts the instances that are still running via reconciliation. Instances are killed
+// (and relaunched elsewhere) before inverse offers for agents that are scheduled for maintenance are
+// accepted.
+//
+//	example-service -instances 3 -command 'sleep 3600' -fid-file /tmp/example-service.fid
+package main
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/controller"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/store"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func main() {
+	var (
+		config = Config{
+			Name:              "example-service",
+			Instances:         1,
+			Command:           "sleep 3600",
+			FailoverTimeout:   time.Hour,
+			RefuseSeconds:     5 * time.Second,
+			ReconcileInterval: 10 * time.Minute,
+		}
+		master  = flag.String("master", "http://127.0.0.1:5050/api/v1/scheduler", "URL of the scheduler API endpoint of the mesos master")
+		fidFile = flag.String("fid-file", "", "Path of the file that the framework ID is persisted to; a framework fails over if the file exists")
+		cpus    = flag.Float64("cpus", 0.1, "CPUs per instance")
+		mem     = flag.Float64("mem", 32, "Memory (MB) per instance")
+	)
+	flag.StringVar(&config.Name, "name", config.Name, "Framework name")
+	flag.StringVar(&config.User, "user", config.User, "User that instances run as")
+	flag.StringVar(&config.Role, "role", config.Role, "Framework role")
+	flag.IntVar(&config.Instances, "instances", config.Instances, "Number of instances to keep running")
+	flag.StringVar(&config.Command, "command", config.Command, "Shell command that instances run")
+	flag.DurationVar(&config.FailoverTimeout, "failover-timeout", config.FailoverTimeout, "Framework failover timeout")
+	flag.DurationVar(&config.RefuseSeconds, "refuse", config.RefuseSeconds, "Duration for which unused offers are declined")
+	flag.DurationVar(&config.ReconcileInterval, "reconcile-interval", config.ReconcileInterval, "Interval of periodic task reconciliation; 0 disables it")
+	flag.Parse()
+
+	config.TaskResources = mesos.Resources{
+		resources.NewCPUs(*cpus).Resource,
+		resources.NewMemory(*mem).Resource,
+	}
+
+	fid, err := frameworkIDStore(*fidFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	caller := httpsched.NewCaller(httpcli.New(
+		httpcli.Endpoint(*master),
+		httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf]),
+	))
+
+	ctx := context.Background()
+	err = newService(config, caller, fid).Run(ctx,
+		controller.WithRegistrationTokens(backoff.Notifier(time.Second, 15*time.Second, ctx.Done())),
+		controller.WithSubscriptionTerminated(func(err error) {
+			if err != nil && err != io.EOF {
+				log.Println(err)
+				return
+			}
+			log.Println("disconnected")
+		}),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// frameworkIDStore returns a store that's initialized w/ the framework ID that's been persisted to path,
+// if any, and that persists the framework ID once it's been assigned.
+func frameworkIDStore(path string) (store.Singleton, error) {
+	s := store.NewInMemorySingleton()
+	if path == "" {
+		return s, nil
+	}
+	if b, err := ioutil.ReadFile(path); err == nil {
+		if id := strings.TrimSpace(string(b)); id != "" {
+			log.Println("failing over framework", id)
+			s.Set(id)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return store.DecorateSingleton(s, store.DoSet().AndThen(func(_ store.Setter, v string, _ error) error {
+		return ioutil.WriteFile(path, []byte(v), 0644)
+	})), nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/callrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/controller"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/tasks"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/store"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+// Config configures a service framework.
+type Config struct {
+	Name              string
+	User              string
+	Role              string
+	Instances         int
+	Command           string
+	TaskResources     mesos.Resources
+	FailoverTimeout   time.Duration
+	RefuseSeconds     time.Duration
+	ReconcileInterval time.Duration
+}
+
+// service is a framework that keeps a number of instances of a command task running: instances that
+// terminate are relaunched, tasks that are running when the framework fails over are adopted via
+// reconciliation, and instances are migrated off of agents that are scheduled for maintenance.
+type service struct {
+	config   Config
+	caller   calls.Caller
+	fid      store.Singleton
+	tracker  *tasks.Tracker
+	declines *offers.DeclinePolicy
+	seq      int
+
+	mu          sync.Mutex
+	maintenance map[string]bool // agents whose inverse offers are being processed
+}
+
+func newService(config Config, caller calls.Caller, fid store.Singleton) *service {
+	s := &service{
+		config:      config,
+		fid:         fid,
+		tracker:     tasks.NewTracker(),
+		declines:    offers.NewDeclinePolicy(offers.RefuseFor(config.RefuseSeconds)),
+		maintenance: make(map[string]bool),
+	}
+	s.caller = callrules.New(callrules.WithFrameworkID(store.GetIgnoreErrors(fid))).Caller(caller)
+	return s
+}
+
+func (s *service) frameworkInfo() *mesos.FrameworkInfo {
+	// tasks must survive agent restarts in order to be adopted after a failover
+	checkpoint := true
+	info := &mesos.FrameworkInfo{
+		User:       s.config.User,
+		Name:       s.config.Name,
+		Checkpoint: &checkpoint,
+	}
+	if s.config.Role != "" {
+		info.Role = &s.config.Role
+	}
+	if s.config.FailoverTimeout > 0 {
+		timeout := s.config.FailoverTimeout.Seconds()
+		info.FailoverTimeout = &timeout
+	}
+	if id, _ := s.fid.Get(); id != "" {
+		info.ID = &mesos.FrameworkID{Value: id}
+	}
+	return info
+}
+
+// Run subscribes to the master and runs the framework until the context is canceled.
+func (s *service) Run(ctx context.Context, opts ...controller.Option) error {
+	if s.config.ReconcileInterval > 0 {
+		go s.reconcileEvery(ctx, s.config.ReconcileInterval)
+	}
+	return controller.Run(ctx, s.frameworkInfo(), s.caller, append([]controller.Option{
+		controller.WithEventHandler(s.handler()),
+		controller.WithFrameworkID(store.GetIgnoreErrors(s.fid)),
+	}, opts...)...)
+}
+
+func (s *service) handler() events.Handler {
+	return eventrules.New(
+		controller.LiftErrors().DropOnError(),
+	).Handle(events.Handlers{
+		scheduler.Event_SUBSCRIBED: eventrules.New(
+			controller.TrackSubscription(s.fid, s.config.FailoverTimeout),
+		).HandleF(s.subscribed),
+		scheduler.Event_OFFERS:         events.HandlerFunc(s.offers),
+		scheduler.Event_INVERSE_OFFERS: events.HandlerFunc(s.inverseOffers),
+		scheduler.Event_UPDATE:         controller.AckStatusUpdates(s.caller).AndThen().HandleF(s.update),
+	}.Otherwise(func(context.Context, *scheduler.Event) error { return nil }))
+}
+
+// subscribed reconciles the state of all tasks: after a failover the master reports the tasks that are
+// still running, and they're adopted by update.
+func (s *service) subscribed(ctx context.Context, e *scheduler.Event) error {
+	log.Println("subscribed as", e.GetSubscribed().GetFrameworkID().GetValue())
+	return s.reconcile(ctx)
+}
+
+// reconcile requests the status of the tracked tasks (explicit reconciliation) as well as that of any
+// other tasks of the framework (implicit reconciliation).
+func (s *service) reconcile(ctx context.Context) error {
+	known := make(map[string]string)
+	for _, t := range s.tracker.Tasks() {
+		known[t.Info.TaskID.Value] = t.Info.AgentID.Value
+	}
+	if len(known) > 0 {
+		if err := calls.CallNoData(ctx, s.caller, calls.Reconcile(calls.ReconcileTasks(known))); err != nil {
+			return err
+		}
+	}
+	return calls.CallNoData(ctx, s.caller, calls.Reconcile())
+}
+
+func (s *service) reconcileEvery(ctx context.Context, d time.Duration) {
+	t := time.NewTicker(d)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := s.reconcile(ctx); err != nil {
+				log.Println("reconciliation failed:", err)
+			}
+		}
+	}
+}
+
+// offers launches as many instances as are missing, on agents that aren't scheduled for maintenance;
+// offers that aren't used are declined.
+func (s *service) offers(ctx context.Context, e *scheduler.Event) error {
+	var (
+		available = offers.Slice(e.GetOffers().GetOffers()).Filter(offers.ByUnavailability(
+			func(u *mesos.Unavailability) bool { return u == nil },
+		))
+		unused = offers.NewIndex(e.GetOffers().GetOffers(), nil)
+		wanted = s.config.TaskResources
+	)
+	for i := range available {
+		var (
+			offer     = &available[i]
+			remaining = mesos.Resources(offer.Resources)
+			launch    []mesos.TaskInfo
+		)
+		for s.tracker.Len()+len(launch) < s.config.Instances {
+			found := resources.Find(wanted, remaining...)
+			if len(found) == 0 {
+				break
+			}
+			remaining = resources.Subtract(remaining, found...)
+			s.seq++
+			launch = append(launch, mesos.TaskInfo{
+				TaskID:    mesos.TaskID{Value: fmt.Sprintf("%s.%d.%d", s.config.Name, time.Now().Unix(), s.seq)},
+				Name:      s.config.Name + "-" + strconv.Itoa(s.seq),
+				AgentID:   offer.AgentID,
+				Resources: found,
+				Command:   &mesos.CommandInfo{Value: &s.config.Command},
+			})
+		}
+		if len(launch) == 0 {
+			continue
+		}
+		delete(unused, offer.ID)
+		s.tracker.Launched(launch...)
+		accept := calls.Accept(calls.OfferOperations{calls.OpLaunch(launch...)}.WithOffers(offer.ID))
+		if err := calls.CallNoData(ctx, s.caller, accept.With(s.declines.Filters(offer.GetAllocationInfo().GetRole()))); err != nil {
+			return err
+		}
+		log.Printf("launched %d instance(s) on %s", len(launch), offer.Hostname)
+	}
+	return s.declines.Decline(ctx, s.caller, unused.ToSlice())
+}
+
+// inverseOffers migrates instances off of the agents that are scheduled for maintenance: the instances
+// are killed (and relaunched elsewhere) before the inverse offers are accepted.
+func (s *service) inverseOffers(ctx context.Context, e *scheduler.Event) error {
+	for agentID, inverse := range offers.InverseSlice(e.GetInverseOffers().GetInverseOffers()).GroupByAgent() {
+		s.mu.Lock()
+		busy := s.maintenance[agentID]
+		s.maintenance[agentID] = true
+		s.mu.Unlock()
+		if busy {
+			continue
+		}
+		var victims []mesos.TaskID
+		for _, t := range s.tracker.Tasks() {
+			if t.Info.AgentID.Value == agentID {
+				victims = append(victims, t.Info.TaskID)
+			}
+		}
+		// killing blocks until the tasks terminate, which is reported by subsequent events
+		go func(agentID string, inverse offers.InverseSlice, victims []mesos.TaskID) {
+			defer func() {
+				s.mu.Lock()
+				delete(s.maintenance, agentID)
+				s.mu.Unlock()
+			}()
+			for _, id := range victims {
+				if err := s.tracker.Kill(ctx, s.caller, id); err != nil && err != tasks.ErrUnknownTask {
+					log.Printf("failed to kill %s for maintenance of agent %s: %v", id.Value, agentID, err)
+					inverse.Decline(ctx, s.caller)
+					return
+				}
+			}
+			if err := inverse.Accept(ctx, s.caller); err != nil {
+				log.Println("failed to accept inverse offers:", err)
+				return
+			}
+			log.Printf("migrated %d instance(s) off of agent %s", len(victims), agentID)
+		}(agentID, inverse, victims)
+	}
+	return nil
+}
+
+// update tracks the status of instances, including those that are reported by reconciliation after a
+// failover. Instances that terminate are replaced upon subsequent offers.
+func (s *service) update(_ context.Context, e *scheduler.Event) error {
+	status := e.GetUpdate().GetStatus()
+	if _, ok := s.tracker.Get(status.TaskID); !ok {
+		if status.GetState().IsTerminal() || status.GetState() == mesos.TASK_UNKNOWN {
+			return nil
+		}
+		// a task that was launched before a failover: adopt it
+		info := mesos.TaskInfo{TaskID: status.TaskID}
+		if status.AgentID != nil {
+			info.AgentID = *status.AgentID
+		}
+		s.tracker.Launched(info)
+	}
+	if status.GetState() == mesos.TASK_UNKNOWN {
+		// the master doesn't know of the task: it's never going to run
+		status.State = mesos.TASK_LOST.Enum()
+	}
+	s.tracker.Update(status)
+	if status.GetState().IsTerminal() {
+		log.Printf("instance %s terminated: %s %s", status.TaskID.Value, status.GetState(), status.GetMessage())
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/store"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/mesostest"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+func TestService(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		master      = mesostest.NewMaster()
+		config      = Config{
+			Name:          "test",
+			User:          "root",
+			Instances:     2,
+			Command:       "true",
+			TaskResources: mesos.Resources{resources.NewCPUs(1).Resource, resources.NewMemory(64).Resource},
+		}
+		fid = store.NewInMemorySingleton()
+		svc = newService(config, httpsched.NewCaller(httpcli.New(
+			httpcli.Endpoint(master.Endpoint()),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf]),
+		)), fid)
+	)
+	defer cancel()
+	defer master.Close()
+
+	go svc.Run(ctx)
+	frameworkID, err := master.WaitSubscribed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// waitFor waits for the master to receive a call of the given type, and returns it
+	seen := 0
+	waitFor := func(ct scheduler.Call_Type) scheduler.Call {
+		for {
+			calls := master.Calls()
+			for ; seen < len(calls); seen++ {
+				if calls[seen].GetType() == ct {
+					seen++
+					return calls[seen-1]
+				}
+			}
+			select {
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for %v, calls: %v", ct, calls)
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	waitFor(scheduler.Call_RECONCILE)
+
+	// two instances fit into the first offer, the second offer is declined
+	offer := func(id, agent string) mesos.Offer {
+		return mesos.Offer{
+			ID:        mesos.OfferID{Value: id},
+			AgentID:   mesos.AgentID{Value: agent},
+			Hostname:  agent,
+			Resources: mesos.Resources{resources.NewCPUs(2).Resource, resources.NewMemory(128).Resource},
+		}
+	}
+	if err = master.Offer(frameworkID, offer("o1", "a1"), offer("o2", "a2")); err != nil {
+		t.Fatal(err)
+	}
+	accept := waitFor(scheduler.Call_ACCEPT)
+	var launched []mesos.TaskInfo
+	for _, op := range accept.GetAccept().GetOperations() {
+		launched = append(launched, op.GetLaunch().GetTaskInfos()...)
+	}
+	if len(launched) != 2 || accept.GetAccept().GetOfferIDs()[0].Value != "o1" {
+		t.Fatalf("unexpected accept call %v", accept)
+	}
+	if decline := waitFor(scheduler.Call_DECLINE); decline.GetDecline().GetOfferIDs()[0].Value != "o2" {
+		t.Fatalf("unexpected decline call %v", decline)
+	}
+	for _, task := range launched {
+		if err = master.Update(frameworkID, mesos.TaskStatus{
+			TaskID:  task.TaskID,
+			AgentID: &task.AgentID,
+			State:   mesos.TASK_RUNNING.Enum(),
+			UUID:    []byte(task.TaskID.Value),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(scheduler.Call_ACKNOWLEDGE)
+	}
+
+	// agent a1 is scheduled for maintenance: both instances are killed before the inverse offer is accepted
+	if err = master.Send(frameworkID, &scheduler.Event{
+		Type: scheduler.Event_INVERSE_OFFERS,
+		InverseOffers: &scheduler.Event_InverseOffers{InverseOffers: []mesos.InverseOffer{{
+			OfferID:     mesos.OfferID{Value: "i1"},
+			AgentID:     &mesos.AgentID{Value: "a1"},
+			FrameworkID: frameworkID,
+		}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for range launched {
+		kill := waitFor(scheduler.Call_KILL)
+		if err = master.Update(frameworkID, mesos.TaskStatus{
+			TaskID:  kill.GetKill().TaskID,
+			AgentID: kill.GetKill().AgentID,
+			State:   mesos.TASK_KILLED.Enum(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if call := waitFor(scheduler.Call_ACCEPT_INVERSE_OFFERS); call.GetAcceptInverseOffers().GetInverseOfferIDs()[0].Value != "i1" {
+		t.Fatalf("unexpected call %v", call)
+	}
+
+	// the instances are relaunched elsewhere; offers of agents that are scheduled for maintenance are declined
+	drained := offer("o3", "a1")
+	drained.Unavailability = &mesos.Unavailability{Start: mesos.TimeInfo{Nanoseconds: time.Now().UnixNano()}}
+	if err = master.Offer(frameworkID, drained, offer("o4", "a2")); err != nil {
+		t.Fatal(err)
+	}
+	if accept = waitFor(scheduler.Call_ACCEPT); accept.GetAccept().GetOfferIDs()[0].Value != "o4" {
+		t.Fatalf("unexpected accept call %v", accept)
+	}
+	if decline := waitFor(scheduler.Call_DECLINE); decline.GetDecline().GetOfferIDs()[0].Value != "o3" {
+		t.Fatalf("unexpected decline call %v", decline)
+	}
+	if svc.tracker.Len() != config.Instances {
+		t.Fatalf("expected %d instances instead of %d", config.Instances, svc.tracker.Len())
+	}
+	if id, _ := fid.Get(); id != frameworkID.Value {
+		t.Fatalf("expected framework ID %q instead of %q", frameworkID.Value, id)
+	}
+}
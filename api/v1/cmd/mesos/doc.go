@@ -0,0 +1,28 @@
+// mesos is a command line tool for operators of a Mesos cluster, built on the operator API clients of
+// packages httpmaster and httpagent.
+//
+// Usage:
+//
+//	mesos [-master URL] [-timeout DURATION] COMMAND [ARGS...]
+//
+// Commands:
+//
+//	agents                          list the agents of the cluster
+//	frameworks                      list the frameworks of the cluster
+//	tasks [-all] [-framework ID]    list tasks; -all includes completed tasks
+//	logs [-file NAME] [-f] TASK     print (or follow) a file of the sandbox of a task, "stdout" by default
+//	exec [-i] [-t] [-image NAME] TASK COMMAND [ARGS...]
+//	                                run a command in a debug container that's nested within the container
+//	                                of a task; -i attaches stdin and -t allocates a TTY
+//	maintenance status              show the status of machines that are draining or down
+//	maintenance schedule [-file F]  print the maintenance schedule, or replace it w/ the JSON in F
+//	maintenance add -start T -duration D MACHINE...
+//	maintenance remove MACHINE...   add machines to, or remove them from, the maintenance schedule
+//	maintenance down|up MACHINE...  start or stop the maintenance of machines
+//	quota                           list quotas
+//	quota set [-force] ROLE RESOURCES
+//	quota remove ROLE               set or remove the quota of a role; e.g. "quota set dev cpus:4;mem:1024"
+//	teardown [-y] FRAMEWORK         tear down a framework, after asking for confirmation (unless -y)
+//
+// Machines are specified as "hostname", "hostname=ip" or "=ip".
+package main
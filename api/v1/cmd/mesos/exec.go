@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/builder"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent"
+)
+
+// exitCode is returned by commands that want mesos to exit w/ a specific code.
+type exitCode int
+
+func (c exitCode) Error() string { return fmt.Sprintf("exit code %d", int(c)) }
+
+func execCmd(ctx context.Context, e *env, args []string) error {
+	var (
+		fs    = flags("exec")
+		stdin = fs.Bool("i", false, "")
+		tty   = fs.Bool("t", false, "")
+		image = fs.String("image", "", "")
+	)
+	if fs.Parse(args) != nil || fs.NArg() < 2 {
+		return errUsage
+	}
+	var (
+		taskID = mesos.TaskID{Value: fs.Arg(0)}
+		argv   = fs.Args()[1:]
+		shell  = false
+		cmd    = &mesos.CommandInfo{Shell: &shell, Value: &argv[0], Arguments: argv}
+		opts   = []httpagent.ExecOpt{httpagent.ExecStdout(e.stdout), httpagent.ExecStderr(e.stderr)}
+	)
+	if *image != "" {
+		ci, err := builder.MesosContainer().DockerImage(*image).Build()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, httpagent.ExecContainer(ci))
+	}
+	if *stdin {
+		opts = append(opts, httpagent.ExecStdin(e.stdin))
+	}
+	agent, err := agentOf(ctx, e, taskID)
+	if err != nil {
+		return err
+	}
+	if *tty {
+		rows, columns, resize, restore, err := e.terminal()
+		if err != nil {
+			return err
+		}
+		defer restore()
+		opts = append(opts, httpagent.ExecTTY(rows, columns), httpagent.ExecResize(resize))
+	}
+	wait, err := agent.Exec(ctx, taskID, cmd, opts...)
+	if err != nil {
+		return err
+	}
+	if wait.ExitStatus == nil {
+		return fmt.Errorf("the command ended w/o an exit status: %s", wait.GetMessage())
+	}
+	if code := exitStatus(wait.GetExitStatus()); code != 0 {
+		return exitCode(code)
+	}
+	return nil
+}
+
+// exitStatus converts the wait status of a process into the exit code of a shell: processes that are
+// terminated by a signal yield 128 plus the number of the signal.
+func exitStatus(status int32) int {
+	if signal := status & 0x7f; signal != 0 {
+		return 128 + int(signal)
+	}
+	return int(status>>8) & 0xff
+}
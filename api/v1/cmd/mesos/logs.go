@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+)
+
+func logs(ctx context.Context, e *env, args []string) error {
+	var (
+		fs     = flags("logs")
+		file   = fs.String("file", "stdout", "")
+		follow = fs.Bool("f", false, "")
+		last   = fs.Uint64("last", 0, "")
+	)
+	if fs.Parse(args) != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+	taskID := mesos.TaskID{Value: fs.Arg(0)}
+	agent, err := agentOf(ctx, e, taskID)
+	if err != nil {
+		return err
+	}
+	if *follow {
+		opts := []httpagent.TailOpt{httpagent.TailFromStart()}
+		if *last > 0 {
+			opts = []httpagent.TailOpt{httpagent.TailLast(*last)}
+		}
+		return agent.Tail(ctx, taskID, *file, e.stdout, opts...)
+	}
+
+	sandbox, err := agent.TaskSandbox(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	var (
+		name   = path.Join(sandbox, *file)
+		zero   uint64
+		offset uint64
+		chunk  = uint64(httpagent.DefaultTailChunkSize)
+	)
+	size, _, err := agent.ReadFile(ctx, name, 0, &zero)
+	if err != nil {
+		return err
+	}
+	if *last > 0 && size > *last {
+		offset = size - *last
+	}
+	for offset < size {
+		_, data, err := agent.ReadFile(ctx, name, offset, &chunk)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			break
+		}
+		if _, err = e.stdout.Write(data); err != nil {
+			return err
+		}
+		offset += uint64(len(data))
+	}
+	return nil
+}
+
+// agentOf returns a client of the agent that runs the given task.
+func agentOf(ctx context.Context, e *env, taskID mesos.TaskID) (httpagent.API, error) {
+	tasks, err := e.master.GetTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var agentID *mesos.AgentID
+	for _, ts := range [][]mesos.Task{tasks.GetTasks(), tasks.GetCompletedTasks(), tasks.GetUnreachableTasks()} {
+		for i := range ts {
+			if ts[i].TaskID == taskID {
+				agentID = &ts[i].AgentID
+			}
+		}
+	}
+	if agentID == nil {
+		return nil, fmt.Errorf("task %q is not known to the master", taskID.Value)
+	}
+	agent, err := e.master.GetAgent(ctx, *agentID)
+	if err != nil {
+		return nil, err
+	}
+	return e.agent(agentURL(agent)), nil
+}
+
+// agentURL returns the base URL of the operator API of the agent. The address of the PID of the agent is
+// preferred over its hostname, which may not be resolvable.
+func agentURL(agent *master.Response_GetAgents_Agent) string {
+	if pid := agent.GetPID(); strings.Contains(pid, "@") {
+		return "http://" + pid[strings.LastIndex(pid, "@")+1:]
+	}
+	info := &agent.AgentInfo
+	return "http://" + net.JoinHostPort(info.Hostname, strconv.Itoa(int(info.GetPort())))
+}
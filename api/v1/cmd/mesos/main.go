@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
+)
+
+type (
+	// env is the environment that commands run in.
+	env struct {
+		master httpmaster.API
+		agent  func(url string) httpagent.API // returns a client of the agent at the given base URL
+		stdin  io.Reader
+		stdout io.Writer
+		stderr io.Writer
+
+		// terminal puts the terminal into raw mode; see rawTerminal
+		terminal func() (rows, columns uint32, resize <-chan mesos.TTYInfo_WindowSize, restore func(), err error)
+	}
+
+	command struct {
+		usage string
+		run   func(ctx context.Context, e *env, args []string) error
+	}
+)
+
+// errUsage is returned by commands that are invoked w/ invalid arguments.
+var errUsage = errors.New("invalid arguments")
+
+var commands = map[string]command{
+	"agents":      {"agents", agents},
+	"exec":        {"exec [-i] [-t] [-image NAME] TASK COMMAND [ARGS...]", execCmd},
+	"frameworks":  {"frameworks", frameworks},
+	"tasks":       {"tasks [-all] [-framework ID]", tasks},
+	"logs":        {"logs [-file NAME] [-f] [-last BYTES] TASK", logs},
+	"maintenance": {"maintenance status|schedule|add|remove|down|up [ARGS...]", maintenanceCmd},
+	"quota":       {"quota [set [-force] ROLE RESOURCES | remove ROLE]", quotaCmd},
+	"teardown":    {"teardown [-y] FRAMEWORK", teardown},
+}
+
+func main() {
+	var (
+		fs      = flag.NewFlagSet("mesos", flag.ExitOnError)
+		master  = fs.String("master", "http://127.0.0.1:5050", "Base URL of the mesos master")
+		timeout = fs.Duration("timeout", 0, "Timeout of the command; 0 disables it (useful w/ logs -f)")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: mesos [flags] COMMAND [ARGS...]\n\nflags:")
+		fs.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "\ncommands:")
+		var names []string
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintln(os.Stderr, "  "+commands[name].usage)
+		}
+	}
+	fs.Parse(os.Args[1:])
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	e := &env{
+		master: httpmaster.NewClient(httpmaster.NewSender(operatorClient(*master).Send)),
+		agent: func(url string) httpagent.API {
+			return httpagent.NewClient(httpagent.NewSender(operatorClient(url).Send))
+		},
+		stdin:    os.Stdin,
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+		terminal: rawTerminal,
+	}
+	if err := cmd.run(ctx, e, fs.Args()[1:]); err != nil {
+		if err == errUsage {
+			fmt.Fprintln(os.Stderr, "usage: mesos", cmd.usage)
+			os.Exit(2)
+		}
+		if code, ok := err.(exitCode); ok {
+			os.Exit(int(code))
+		}
+		fmt.Fprintln(os.Stderr, "mesos:", err)
+		os.Exit(1)
+	}
+}
+
+func operatorClient(baseURL string) *httpcli.Client {
+	return httpcli.New(httpcli.Endpoint(strings.TrimSuffix(baseURL, "/") + "/api/v1"))
+}
+
+// flags returns a flag set for the flags of a command; parse errors are returned (rather than exiting).
+func flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	return fs
+}
+
+// table returns a writer that aligns tab-separated columns; it must be flushed.
+func table(w io.Writer, header ...string) *tabwriter.Writer {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	return tw
+}
+
+// formatTime formats the given unix time in nanoseconds; zero is formatted as "-".
+func formatTime(nanos int64) string {
+	if nanos == 0 {
+		return "-"
+	}
+	return time.Unix(0, nanos).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/maintenance"
+)
+
+func maintenanceCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch sub, args := args[0], args[1:]; sub {
+	case "status":
+		if len(args) > 0 {
+			return errUsage
+		}
+		return maintenanceStatus(ctx, e)
+	case "schedule":
+		return maintenanceSchedule(ctx, e, args)
+	case "add":
+		return maintenanceAdd(ctx, e, args)
+	case "remove":
+		machines, err := parseMachines(args)
+		if err != nil {
+			return err
+		}
+		s, err := e.master.GetMaintenanceSchedule(ctx)
+		if err != nil {
+			return err
+		}
+		return e.master.UpdateMaintenanceSchedule(ctx, s.Without(machines...))
+	case "down", "up":
+		machines, err := parseMachines(args)
+		if err != nil {
+			return err
+		}
+		if sub == "down" {
+			return e.master.StartMaintenance(ctx, machines...)
+		}
+		return e.master.StopMaintenance(ctx, machines...)
+	}
+	return errUsage
+}
+
+func maintenanceStatus(ctx context.Context, e *env) error {
+	status, err := e.master.GetMaintenanceStatus(ctx)
+	if err != nil {
+		return err
+	}
+	tw := table(e.stdout, "MACHINE", "MODE", "FRAMEWORK", "STATUS", "TIME")
+	for _, m := range status.GetDownMachines() {
+		fmt.Fprintf(tw, "%s\tDOWN\t-\t-\t-\n", formatMachine(m))
+	}
+	for _, m := range status.GetDrainingMachines() {
+		if len(m.Statuses) == 0 {
+			fmt.Fprintf(tw, "%s\tDRAINING\t-\t-\t-\n", formatMachine(m.ID))
+		}
+		for _, s := range m.Statuses {
+			fmt.Fprintf(tw, "%s\tDRAINING\t%s\t%s\t%s\n", formatMachine(m.ID), s.FrameworkID.Value, s.GetStatus(),
+				formatTime(s.Timestamp.Nanoseconds))
+		}
+	}
+	return tw.Flush()
+}
+
+func maintenanceSchedule(ctx context.Context, e *env, args []string) error {
+	var (
+		fs   = flags("maintenance schedule")
+		file = fs.String("file", "", "")
+	)
+	if fs.Parse(args) != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+	if *file == "" {
+		s, err := e.master.GetMaintenanceSchedule(ctx)
+		if err != nil {
+			return err
+		}
+		b, err := json.MarshalIndent(&s, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(e.stdout, "%s\n", b)
+		return err
+	}
+	b, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var s maintenance.Schedule
+	if err = json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("failed to parse schedule %q: %v", *file, err)
+	}
+	return e.master.UpdateMaintenanceSchedule(ctx, s)
+}
+
+func maintenanceAdd(ctx context.Context, e *env, args []string) error {
+	var (
+		fs       = flags("maintenance add")
+		start    = fs.String("start", "", "")
+		duration = fs.Duration("duration", time.Hour, "")
+	)
+	if fs.Parse(args) != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+	t := time.Now()
+	if *start != "" {
+		var err error
+		if t, err = time.Parse(time.RFC3339, *start); err != nil {
+			return err
+		}
+	}
+	machines, err := parseMachines(fs.Args())
+	if err != nil {
+		return err
+	}
+	s, err := e.master.GetMaintenanceSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	// a machine may only be part of a single window
+	s = s.Without(machines...)
+	s.Windows = append(s.Windows, maintenance.NewWindow(mesos.NewUnavailability(t, *duration), machines...))
+	return e.master.UpdateMaintenanceSchedule(ctx, s)
+}
+
+// parseMachines parses machines that are specified as "hostname", "hostname=ip" or "=ip".
+func parseMachines(args []string) ([]mesos.MachineID, error) {
+	if len(args) == 0 {
+		return nil, errUsage
+	}
+	machines := make([]mesos.MachineID, 0, len(args))
+	for _, arg := range args {
+		hostname, ip := arg, ""
+		if i := strings.Index(arg, "="); i >= 0 {
+			hostname, ip = arg[:i], arg[i+1:]
+		}
+		if hostname == "" && ip == "" {
+			return nil, fmt.Errorf("invalid machine %q", arg)
+		}
+		machines = append(machines, maintenance.Machine(hostname, ip))
+	}
+	return machines, nil
+}
+
+func formatMachine(m mesos.MachineID) string {
+	if m.GetIP() == "" {
+		return m.GetHostname()
+	}
+	return m.GetHostname() + "=" + m.GetIP()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
+	"github.com/mesos/mesos-go/api/v1/lib/maintenance"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/mesostest/mock"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func newEnv(m *mock.Master, a *mock.Agent, stdin string) (*env, *bytes.Buffer) {
+	var stdout bytes.Buffer
+	return &env{
+		master: m,
+		agent: func(url string) httpagent.API {
+			if url != "http://10.0.0.1:5051" {
+				panic("unexpected agent URL " + url)
+			}
+			return a
+		},
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stdout,
+	}, &stdout
+}
+
+func TestTasksAndLogs(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		task = func(id, fid string) mesos.Task {
+			return mesos.Task{TaskID: mesos.TaskID{Value: id}, FrameworkID: mesos.FrameworkID{Value: fid}, AgentID: mesos.AgentID{Value: "a1"}, State: mesos.TASK_RUNNING.Enum()}
+		}
+		pid    = "slave(1)@10.0.0.1:5051"
+		stdout = "hello\nworld\n"
+		m      = &mock.Master{
+			GetTasksFunc: func(context.Context) (*master.Response_GetTasks, error) {
+				return &master.Response_GetTasks{
+					Tasks:          []mesos.Task{task("t1", "f1"), task("t2", "f2")},
+					CompletedTasks: []mesos.Task{task("t3", "f1")},
+				}, nil
+			},
+			GetAgentFunc: func(_ context.Context, id mesos.AgentID) (*master.Response_GetAgents_Agent, error) {
+				return &master.Response_GetAgents_Agent{AgentInfo: mesos.AgentInfo{ID: &id, Hostname: "h1"}, PID: &pid}, nil
+			},
+		}
+		a = &mock.Agent{
+			TaskSandboxFunc: func(context.Context, mesos.TaskID) (string, error) { return "/sandbox", nil },
+			ReadFileFunc: func(_ context.Context, path string, offset uint64, length *uint64) (uint64, []byte, error) {
+				if path != "/sandbox/stdout" {
+					t.Fatalf("unexpected path %q", path)
+				}
+				end := offset + *length
+				if end > uint64(len(stdout)) {
+					end = uint64(len(stdout))
+				}
+				return uint64(len(stdout)), []byte(stdout[offset:end]), nil
+			},
+		}
+	)
+	e, out := newEnv(m, a, "")
+	if err := tasks(ctx, e, []string{"-framework", "f1", "-all"}); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 3 || !strings.HasPrefix(lines[1], "t1 ") || !strings.HasPrefix(lines[2], "t3 ") {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	for args, expected := range map[string]string{"t1": stdout, "-last 6 t1": "world\n"} {
+		out.Reset()
+		if err := logs(ctx, e, strings.Fields(args)); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != expected {
+			t.Fatalf("expected %q instead of %q", expected, out)
+		}
+	}
+	if err := logs(ctx, e, []string{"t4"}); err == nil {
+		t.Fatal("expected an error for an unknown task")
+	}
+}
+
+func TestExec(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		pid    = "slave(1)@10.0.0.1:5051"
+		status int32
+		m      = &mock.Master{
+			GetTasksFunc: func(context.Context) (*master.Response_GetTasks, error) {
+				return &master.Response_GetTasks{Tasks: []mesos.Task{{TaskID: mesos.TaskID{Value: "t1"}, AgentID: mesos.AgentID{Value: "a1"}}}}, nil
+			},
+			GetAgentFunc: func(_ context.Context, id mesos.AgentID) (*master.Response_GetAgents_Agent, error) {
+				return &master.Response_GetAgents_Agent{AgentInfo: mesos.AgentInfo{ID: &id}, PID: &pid}, nil
+			},
+		}
+		a = &mock.Agent{
+			ExecFunc: func(_ context.Context, id mesos.TaskID, cmd *mesos.CommandInfo, _ ...httpagent.ExecOpt) (*agent.Response_WaitNestedContainer, error) {
+				if id.Value != "t1" || cmd.GetShell() || cmd.GetValue() != "ls" || strings.Join(cmd.Arguments, " ") != "ls -l /" {
+					t.Fatalf("unexpected task %v, command %v", id, cmd)
+				}
+				return &agent.Response_WaitNestedContainer{ExitStatus: &status}, nil
+			},
+		}
+		e, _ = newEnv(m, a, "")
+	)
+	for s, expected := range map[int32]error{0: nil, 2 << 8: exitCode(2), 9: exitCode(137)} {
+		status = s
+		if err := execCmd(ctx, e, []string{"-i", "t1", "ls", "-l", "/"}); err != expected {
+			t.Fatalf("status %d: expected %v instead of %v", s, expected, err)
+		}
+	}
+	if err := execCmd(ctx, e, []string{"t1"}); err != errUsage {
+		t.Fatalf("expected %v instead of %v", errUsage, err)
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		h1, h2   = maintenance.Machine("h1", ""), maintenance.Machine("h2", "10.0.0.2")
+		schedule = maintenance.NewSchedule(maintenance.NewWindow(mesos.Unavailability{}, h1))
+		m        = &mock.Master{
+			GetMaintenanceScheduleFunc: func(context.Context) (maintenance.Schedule, error) { return schedule, nil },
+			UpdateMaintenanceScheduleFunc: func(_ context.Context, s maintenance.Schedule) error {
+				schedule = s
+				return nil
+			},
+		}
+		e, _ = newEnv(m, nil, "")
+	)
+	if err := maintenanceCmd(ctx, e, []string{"add", "-duration", "2h", "h1", "h2=10.0.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(schedule.Windows) != 1 || len(schedule.Windows[0].MachineIDs) != 2 || schedule.WindowOf(h2) == nil {
+		t.Fatalf("unexpected schedule %v", schedule)
+	}
+	if err := maintenanceCmd(ctx, e, []string{"remove", "h1"}); err != nil {
+		t.Fatal(err)
+	}
+	if ms := schedule.Machines(); len(ms) != 1 || !ms[0].Equal(&h2) {
+		t.Fatalf("unexpected schedule %v", schedule)
+	}
+	if err := maintenanceCmd(ctx, e, []string{"down", "h2=10.0.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if inv := m.Invocations(); inv[len(inv)-1].Method != "StartMaintenance" || !inv[len(inv)-1].Args[1].([]mesos.MachineID)[0].Equal(&h2) {
+		t.Fatalf("unexpected invocation %v", inv[len(inv)-1])
+	}
+	if err := maintenanceCmd(ctx, e, []string{"down", "="}); err == nil {
+		t.Fatal("expected an error for an invalid machine")
+	}
+}
+
+func TestQuotaAndTeardown(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		requested quota.QuotaRequest
+		confirmed bool
+		m         = &mock.Master{
+			SetQuotaFunc: func(_ context.Context, qr quota.QuotaRequest) error {
+				requested = qr
+				return nil
+			},
+			ConfirmTeardownFunc: func(_ context.Context, id mesos.FrameworkID, confirm func(*httpmaster.TeardownSummary) bool) (bool, error) {
+				confirmed = confirm(&httpmaster.TeardownSummary{Framework: &master.Response_GetFrameworks_Framework{}, ActiveTasks: 2})
+				return confirmed, nil
+			},
+		}
+	)
+	e, _ := newEnv(m, nil, "")
+	if err := quotaCmd(ctx, e, []string{"set", "-force", "dev", "cpus:4;mem:1024"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := mesos.Resources{resources.NewCPUs(4).Resource, resources.NewMemory(1024).Resource}
+	if requested.GetRole() != "dev" || !requested.GetForce() || !resources.Equivalent(requested.Guarantee, expected) {
+		t.Fatalf("unexpected quota request %v", requested)
+	}
+	if err := quotaCmd(ctx, e, []string{"set", "dev"}); err != errUsage {
+		t.Fatalf("expected %v instead of %v", errUsage, err)
+	}
+
+	for answer, expected := range map[string]bool{"y\n": true, "\n": false} {
+		e, out := newEnv(m, nil, answer)
+		if err := teardown(ctx, e, []string{"f1"}); err != nil {
+			t.Fatal(err)
+		}
+		if confirmed != expected || !strings.Contains(out.String(), "2 active task(s)") {
+			t.Fatalf("answer %q: unexpected confirmation %t, output %q", answer, confirmed, out)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func quotaCmd(ctx context.Context, e *env, args []string) error {
+	if len(args) == 0 {
+		status, err := e.master.GetQuota(ctx)
+		if err != nil {
+			return err
+		}
+		tw := table(e.stdout, "ROLE", "PRINCIPAL", "GUARANTEE")
+		for _, q := range status.GetInfos() {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", q.GetRole(), q.GetPrincipal(), mesos.Resources(q.Guarantee))
+		}
+		return tw.Flush()
+	}
+	switch sub, args := args[0], args[1:]; sub {
+	case "set":
+		var (
+			fs    = flags("quota set")
+			force = fs.Bool("force", false, "")
+		)
+		if fs.Parse(args) != nil || fs.NArg() != 2 {
+			return errUsage
+		}
+		role := fs.Arg(0)
+		guarantee, err := resources.Parse(fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		return e.master.SetQuota(ctx, quota.QuotaRequest{Role: &role, Force: force, Guarantee: guarantee})
+	case "remove":
+		if len(args) != 1 {
+			return errUsage
+		}
+		return e.master.RemoveQuota(ctx, args[0])
+	}
+	return errUsage
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func agents(ctx context.Context, e *env, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	resp, err := e.master.GetAgents(ctx)
+	if err != nil {
+		return err
+	}
+	tw := table(e.stdout, "ID", "HOSTNAME", "ACTIVE", "VERSION", "RESOURCES")
+	for _, a := range resp.GetAgents() {
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", a.AgentInfo.GetID().GetValue(), a.AgentInfo.Hostname,
+			a.Active, a.Version, mesos.Resources(a.TotalResources))
+	}
+	return tw.Flush()
+}
+
+func frameworks(ctx context.Context, e *env, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	resp, err := e.master.GetFrameworks(ctx)
+	if err != nil {
+		return err
+	}
+	tw := table(e.stdout, "ID", "NAME", "USER", "ROLES", "ACTIVE", "CONNECTED")
+	for _, f := range resp.GetFrameworks() {
+		info := &f.FrameworkInfo
+		roles := info.GetRoles()
+		if len(roles) == 0 {
+			roles = []string{info.GetRole()}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%t\n", info.GetID().GetValue(), info.Name, info.User,
+			strings.Join(roles, ","), f.Active, f.Connected)
+	}
+	return tw.Flush()
+}
+
+func tasks(ctx context.Context, e *env, args []string) error {
+	var (
+		fs        = flags("tasks")
+		all       = fs.Bool("all", false, "")
+		framework = fs.String("framework", "", "")
+	)
+	if fs.Parse(args) != nil || fs.NArg() > 0 {
+		return errUsage
+	}
+	resp, err := e.master.GetTasks(ctx)
+	if err != nil {
+		return err
+	}
+	lists := [][]mesos.Task{resp.GetPendingTasks(), resp.GetTasks(), resp.GetUnreachableTasks()}
+	if *all {
+		lists = append(lists, resp.GetCompletedTasks())
+	}
+	tw := table(e.stdout, "ID", "NAME", "FRAMEWORK", "AGENT", "STATE")
+	for _, ts := range lists {
+		for _, t := range ts {
+			if *framework != "" && t.FrameworkID.Value != *framework {
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", t.TaskID.Value, t.Name, t.FrameworkID.Value, t.AgentID.Value, t.GetState())
+		}
+	}
+	return tw.Flush()
+}
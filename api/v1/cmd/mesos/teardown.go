@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
+)
+
+func teardown(ctx context.Context, e *env, args []string) error {
+	var (
+		fs  = flags("teardown")
+		yes = fs.Bool("y", false, "")
+	)
+	if fs.Parse(args) != nil || fs.NArg() != 1 {
+		return errUsage
+	}
+	id := mesos.FrameworkID{Value: fs.Arg(0)}
+	done, err := e.master.ConfirmTeardown(ctx, id, func(s *httpmaster.TeardownSummary) bool {
+		if *yes {
+			return true
+		}
+		fmt.Fprintf(e.stdout, "tear down framework %s (%s) w/ %d active task(s)? [y/N] ",
+			id.Value, s.Framework.FrameworkInfo.Name, s.ActiveTasks)
+		answer, _ := bufio.NewReader(e.stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return answer == "y" || answer == "yes"
+	})
+	if err != nil {
+		return err
+	}
+	if done {
+		fmt.Fprintln(e.stdout, "framework", id.Value, "was torn down")
+	}
+	return nil
+}
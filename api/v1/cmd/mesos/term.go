@@ -0,0 +1,76 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// rawTerminal puts the terminal of stdin into raw mode via stty(1) and returns its size, along w/ a channel
+// of changes to its size; restore returns the terminal to its original mode.
+func rawTerminal() (rows, columns uint32, resize <-chan mesos.TTYInfo_WindowSize, restore func(), err error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return 0, 0, nil, nil, fmt.Errorf("failed to configure the terminal: %v", err)
+	}
+	if rows, columns, err = terminalSize(); err != nil {
+		return 0, 0, nil, nil, err
+	}
+	if _, err = stty("raw", "-echo"); err != nil {
+		return 0, 0, nil, nil, fmt.Errorf("failed to configure the terminal: %v", err)
+	}
+	var (
+		ch      = make(chan mesos.TTYInfo_WindowSize, 1)
+		winch   = make(chan os.Signal, 1)
+		stopped = make(chan struct{})
+	)
+	signal.Notify(winch, syscall.SIGWINCH)
+	go func() {
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-winch:
+				if rows, columns, err := terminalSize(); err == nil {
+					select {
+					case ch <- mesos.TTYInfo_WindowSize{Rows: rows, Columns: columns}:
+					case <-stopped:
+						return
+					}
+				}
+			}
+		}
+	}()
+	restore = func() {
+		signal.Stop(winch)
+		close(stopped)
+		stty(strings.TrimSpace(saved))
+	}
+	return rows, columns, ch, restore, nil
+}
+
+func terminalSize() (rows, columns uint32, err error) {
+	size, err := stty("size")
+	if err == nil {
+		_, err = fmt.Sscan(size, &rows, &columns)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to determine the size of the terminal: %v", err)
+	}
+	return rows, columns, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func rawTerminal() (rows, columns uint32, resize <-chan mesos.TTYInfo_WindowSize, restore func(), err error) {
+	return 0, 0, nil, nil, errors.New("TTYs are not supported on windows")
+}
@@ -0,0 +1,7 @@
+// mrecord is a reverse proxy that records the calls, responses and events that are exchanged between a
+// Mesos API client and a Mesos master (or agent), one JSON object per line; see package
+// github.com/mesos/mesos-go/api/v1/lib/extras/recorder. Point a framework at the proxy instead of the
+// (leading) master:
+//
+//	mrecord -listen 127.0.0.1:5051 -master http://127.0.0.1:5050 -out session.jsonl
+package main
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/mesos/mesos-go/api/v1/lib/extras/recorder"
+)
+
+var (
+	listen = flag.String("listen", "127.0.0.1:5051", "Address that the proxy listens on")
+	master = flag.String("master", "http://127.0.0.1:5050", "URL of the mesos master (or agent) that requests are forwarded to")
+	out    = flag.String("out", "", "Path of the file that records are appended to; defaults to stdout")
+)
+
+func main() {
+	flag.Parse()
+
+	target, err := url.Parse(*master)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := os.Stdout
+	if *out != "" {
+		if w, err = os.OpenFile(*out, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	sink := recorder.JSONLines(w, func(err error) { log.Println("failed to record:", err) })
+
+	log.Printf("recording requests to %s, listening on %s", target, *listen)
+	log.Fatal(http.ListenAndServe(*listen, recorder.New(target, sink)))
+}
@@ -9,12 +9,15 @@ import (
 	"os"
 	"strconv"
 	"text/template"
+	"time"
 
 	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/master/controller"
 	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
 	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
 	"github.com/mesos/mesos-go/api/v1/lib/master"
-	"github.com/mesos/mesos-go/api/v1/lib/master/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/master/events"
 )
 
 var (
@@ -56,98 +59,86 @@ func main() {
 	if *agentTemplate != "" {
 		agentTemp = template.Must(template.New("agent").Parse(*agentTemplate))
 	}
-	err := watch(taskTemp, frameworkTemp, agentTemp)(cli.Send(ctx, calls.NonStreaming(calls.Subscribe())))
+	err := controller.Run(
+		ctx,
+		cli,
+		controller.WithEventHandler(watch(taskTemp, frameworkTemp, agentTemp)),
+		controller.WithRegistrationTokens(backoff.Notifier(1*time.Second, 15*time.Second, ctx.Done())),
+		controller.WithSubscriptionTerminated(func(err error) {
+			if err != nil && err != io.EOF {
+				fmt.Fprintln(os.Stderr, "subscription terminated:", err)
+			}
+		}),
+	)
 	if err != nil {
 		panic(err)
 	}
 }
 
-func watch(taskTemp, frameworkTemp, agentTemp *template.Template) func(mesos.Response, error) error {
-	return func(resp mesos.Response, err error) error {
-		defer func() {
-			if resp != nil {
-				resp.Close()
+func watch(taskTemp, frameworkTemp, agentTemp *template.Template) events.HandlerFunc {
+	return func(_ context.Context, e *master.Event) error {
+		switch t := e.GetType(); t {
+		case master.Event_TASK_ADDED:
+			if !*taskEvents {
+				return nil
+			}
+			if taskTemp != nil {
+				return taskTemp.Execute(os.Stdout, e)
+			}
+			task := e.GetTaskAdded().Task
+			fmt.Println(t.String(), task.GetFrameworkID(), task.GetTaskID(), task.GetState(), task.GetLabels().Format(), mesos.Resources(task.GetResources()))
+		case master.Event_TASK_UPDATED:
+			if !*taskEvents {
+				return nil
+			}
+			if taskTemp != nil {
+				return taskTemp.Execute(os.Stdout, e)
+			}
+			task := e.GetTaskUpdated().GetStatus()
+			fmt.Println(t.String(), task.GetTaskID(), task.GetState(), task.GetLabels().Format())
+		case master.Event_AGENT_ADDED:
+			if !*agentEvents {
+				return nil
+			}
+			if agentTemp != nil {
+				return agentTemp.Execute(os.Stdout, e)
+			}
+			fmt.Println(t.String(), e.GetAgentAdded().String())
+		case master.Event_AGENT_REMOVED:
+			if !*agentEvents {
+				return nil
+			}
+			if agentTemp != nil {
+				return agentTemp.Execute(os.Stdout, e)
+			}
+			fmt.Println(t.String(), e.GetAgentRemoved().String())
+		case master.Event_FRAMEWORK_ADDED:
+			if !*frameworkEvents {
+				return nil
+			}
+			if frameworkTemp != nil {
+				return frameworkTemp.Execute(os.Stdout, e)
+			}
+			fmt.Println(t.String(), e.GetFrameworkAdded().String())
+		case master.Event_FRAMEWORK_UPDATED:
+			if !*frameworkEvents {
+				return nil
+			}
+			if frameworkTemp != nil {
+				return frameworkTemp.Execute(os.Stdout, e)
 			}
-		}()
-		for err == nil {
-			var e master.Event
-			if err := resp.Decode(&e); err != nil {
-				if err == io.EOF {
-					err = nil
-					break
-				}
-				continue
+			fmt.Println(t.String(), e.GetFrameworkUpdated().String())
+		case master.Event_FRAMEWORK_REMOVED:
+			if !*frameworkEvents {
+				return nil
 			}
-			switch t := e.GetType(); t {
-			case master.Event_TASK_ADDED:
-				if !*taskEvents {
-					continue
-				}
-				if taskTemp != nil {
-					err = taskTemp.Execute(os.Stdout, e)
-					continue
-				}
-				task := e.GetTaskAdded().Task
-				fmt.Println(t.String(), task.GetFrameworkID(), task.GetTaskID(), task.GetState(), task.GetLabels().Format(), mesos.Resources(task.GetResources()))
-			case master.Event_TASK_UPDATED:
-				if !*taskEvents {
-					continue
-				}
-				if taskTemp != nil {
-					err = taskTemp.Execute(os.Stdout, e)
-					continue
-				}
-				task := e.GetTaskUpdated().GetStatus()
-				fmt.Println(t.String(), task.GetTaskID(), task.GetState(), task.GetLabels().Format())
-			case master.Event_AGENT_ADDED:
-				if !*agentEvents {
-					continue
-				}
-				if agentTemp != nil {
-					err = agentTemp.Execute(os.Stdout, e)
-					continue
-				}
-				fmt.Println(t.String(), e.GetAgentAdded().String())
-			case master.Event_AGENT_REMOVED:
-				if !*agentEvents {
-					continue
-				}
-				if agentTemp != nil {
-					err = agentTemp.Execute(os.Stdout, e)
-					continue
-				}
-				fmt.Println(t.String(), e.GetAgentRemoved().String())
-			case master.Event_FRAMEWORK_ADDED:
-				if !*frameworkEvents {
-					continue
-				}
-				if frameworkTemp != nil {
-					err = frameworkTemp.Execute(os.Stdout, e)
-					continue
-				}
-				fmt.Println(t.String(), e.GetFrameworkAdded().String())
-			case master.Event_FRAMEWORK_UPDATED:
-				if !*frameworkEvents {
-					continue
-				}
-				if frameworkTemp != nil {
-					err = frameworkTemp.Execute(os.Stdout, e)
-					continue
-				}
-				fmt.Println(t.String(), e.GetFrameworkUpdated().String())
-			case master.Event_FRAMEWORK_REMOVED:
-				if !*frameworkEvents {
-					continue
-				}
-				if frameworkTemp != nil {
-					err = frameworkTemp.Execute(os.Stdout, e)
-					continue
-				}
-				fmt.Println(t.String(), e.GetFrameworkRemoved().String())
-			default:
-				// noop
+			if frameworkTemp != nil {
+				return frameworkTemp.Execute(os.Stdout, e)
 			}
+			fmt.Println(t.String(), e.GetFrameworkRemoved().String())
+		default:
+			// noop
 		}
-		return err
+		return nil
 	}
 }
@@ -0,0 +1,186 @@
+// Package prom exports the metrics of a scheduler client to Prometheus. The metrics are collected by way
+// of call and event rules, a subscription termination handler, and an HTTP client option, and are
+// registered on a prometheus.Registerer that's provided by the user:
+//
+//	m := prom.NewScheduler("my_framework")
+//	if err := m.Register(prometheus.DefaultRegisterer); err != nil {
+//		log.Fatal(err)
+//	}
+//	cli := httpsched.NewCaller(httpcli.New(..., m.HTTPClientOpt()))
+//	caller := m.CallRule().Caller(cli)
+//	handler := m.EventRule().Handle(myHandler)
+//	controller.Run(ctx, framework, caller,
+//		controller.WithEventHandler(handler),
+//		controller.WithSubscriptionTerminated(m.SubscriptionTerminated))
+//
+// The package lives alongside the commands, rather than in the library, because the library doesn't vendor
+// the Prometheus client.
+package prom
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/metrics"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/callrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Scheduler is a collection of Prometheus metrics for a scheduler client.
+type Scheduler struct {
+	CallCount      *prometheus.CounterVec   // outgoing calls, by type
+	CallErrorCount *prometheus.CounterVec   // failed outgoing calls, by type
+	CallLatency    *prometheus.HistogramVec // call latency in seconds, by type
+	EventCount     *prometheus.CounterVec   // events received, by type
+	EventErrors    *prometheus.CounterVec   // event processing errors, by type
+	EventLatency   *prometheus.HistogramVec // event processing latency in seconds, by type
+	OffersReceived prometheus.Counter       // individual offers received
+	OffersDeclined prometheus.Counter       // individual offers declined
+	Reconnects     prometheus.Counter       // terminated subscriptions
+	Redirects      prometheus.Counter       // HTTP redirects to another master
+	AckBacklog     prometheus.Gauge         // status updates that have yet to be acknowledged
+}
+
+// NewScheduler creates scheduler metrics w/ the given namespace (e.g. the name of the framework); they
+// should be registered w/ Prometheus, see Register.
+func NewScheduler(namespace string) *Scheduler {
+	const subsystem = "scheduler"
+	var (
+		counter = func(name, help string) prometheus.Counter {
+			return prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+			})
+		}
+		counterVec = func(name, help string) *prometheus.CounterVec {
+			return prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+			}, []string{"type"})
+		}
+		histogramVec = func(name, help string) *prometheus.HistogramVec {
+			return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: namespace, Subsystem: subsystem, Name: name, Help: help,
+			}, []string{"type"})
+		}
+	)
+	return &Scheduler{
+		CallCount:      counterVec("calls_total", "The number of outgoing calls, by type."),
+		CallErrorCount: counterVec("call_errors_total", "The number of failed outgoing calls, by type."),
+		CallLatency:    histogramVec("call_latency_seconds", "Time to execute calls, by type."),
+		EventCount:     counterVec("events_total", "The number of events received, by type."),
+		EventErrors:    counterVec("event_errors_total", "The number of event processing errors, by type."),
+		EventLatency:   histogramVec("event_latency_seconds", "Time to process events, by type."),
+		OffersReceived: counter("offers_received_total", "The number of individual offers received."),
+		OffersDeclined: counter("offers_declined_total", "The number of individual offers declined."),
+		Reconnects:     counter("reconnects_total", "The number of terminated subscriptions."),
+		Redirects:      counter("redirects_total", "The number of HTTP redirects to another master."),
+		AckBacklog: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: subsystem, Name: "ack_backlog",
+			Help: "The number of status updates that have yet to be acknowledged.",
+		}),
+	}
+}
+
+// Register registers the metrics on the given Registerer, for example prometheus.DefaultRegisterer. The
+// metrics that were registered are unregistered should the registration of any of them fail.
+func (m *Scheduler) Register(r prometheus.Registerer) error {
+	cs := m.Collectors()
+	for i, c := range cs {
+		if err := r.Register(c); err != nil {
+			for _, registered := range cs[:i] {
+				r.Unregister(registered)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Collectors returns the metrics, for registration w/ Prometheus; see also Register.
+func (m *Scheduler) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.CallCount, m.CallErrorCount, m.CallLatency,
+		m.EventCount, m.EventErrors, m.EventLatency,
+		m.OffersReceived, m.OffersDeclined, m.Reconnects, m.Redirects, m.AckBacklog,
+	}
+}
+
+func counters(v *prometheus.CounterVec) metrics.Counter {
+	return func(labels ...string) { v.WithLabelValues(labels...).Inc() }
+}
+
+func seconds(v *prometheus.HistogramVec) metrics.Watcher {
+	return func(us float64, labels ...string) {
+		v.WithLabelValues(labels...).Observe(us / float64(time.Second/time.Microsecond))
+	}
+}
+
+// CallRule returns a rule that records call counts, errors and latency, declined offers, and
+// acknowledgements. The latency and errors are those of the remainder of the chain, which is expected to
+// include the Caller; see callrules.Rule.Caller.
+func (m *Scheduler) CallRule() callrules.Rule {
+	var (
+		harness = metrics.NewHarness(counters(m.CallCount), counters(m.CallErrorCount), seconds(m.CallLatency), time.Now)
+		timed   = callrules.Metrics(harness, nil)
+	)
+	return func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error, ch callrules.Chain) (context.Context, *scheduler.Call, mesos.Response, error) {
+		return timed(ctx, c, r, err, func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error) (context.Context, *scheduler.Call, mesos.Response, error) {
+			ctx, c, r, err = ch(ctx, c, r, err)
+			if err == nil {
+				switch c.GetType() {
+				case scheduler.Call_DECLINE:
+					m.OffersDeclined.Add(float64(len(c.GetDecline().GetOfferIDs())))
+				case scheduler.Call_ACKNOWLEDGE:
+					m.AckBacklog.Dec()
+				}
+			}
+			return ctx, c, r, err
+		})
+	}
+}
+
+// EventRule returns a rule that records event counts, errors and latency, received offers, and status
+// updates that require acknowledgement. The latency and errors are those of the remainder of the chain,
+// which is expected to include the event handler; see eventrules.Rule.Handle.
+func (m *Scheduler) EventRule() eventrules.Rule {
+	var (
+		harness = metrics.NewHarness(counters(m.EventCount), counters(m.EventErrors), seconds(m.EventLatency), time.Now)
+		timed   = eventrules.Metrics(harness, nil)
+	)
+	return func(ctx context.Context, e *scheduler.Event, err error, ch eventrules.Chain) (context.Context, *scheduler.Event, error) {
+		switch e.GetType() {
+		case scheduler.Event_OFFERS:
+			m.OffersReceived.Add(float64(len(e.GetOffers().GetOffers())))
+		case scheduler.Event_UPDATE:
+			if e.GetUpdate().GetStatus().UUID != nil {
+				m.AckBacklog.Inc()
+			}
+		}
+		return timed(ctx, e, err, ch)
+	}
+}
+
+// SubscriptionTerminated counts reconnects; it's intended for use w/ the scheduler controller's
+// WithSubscriptionTerminated option. Unacknowledged updates are resent by Mesos upon reconnection,
+// so the ack backlog is reset.
+func (m *Scheduler) SubscriptionTerminated(error) {
+	m.Reconnects.Inc()
+	m.AckBacklog.Set(0)
+}
+
+// HTTPClientOpt returns an httpcli.Opt that counts HTTP redirects.
+func (m *Scheduler) HTTPClientOpt() httpcli.Opt {
+	return httpcli.WrapDoer(func(f httpcli.DoFunc) httpcli.DoFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			res, err := f(req)
+			if err == nil && res.StatusCode == http.StatusTemporaryRedirect {
+				m.Redirects.Inc()
+			}
+			return res, err
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package prom
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func value(t *testing.T, m prometheus.Metric) float64 {
+	var d dto.Metric
+	if err := m.Write(&d); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case d.Counter != nil:
+		return d.Counter.GetValue()
+	case d.Gauge != nil:
+		return d.Gauge.GetValue()
+	}
+	t.Fatalf("unexpected metric %v", &d)
+	return 0
+}
+
+func TestScheduler(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		m       = NewScheduler("test")
+		failing = true
+		caller  = m.CallRule().CallerF(func(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+			if c.GetType() == scheduler.Call_REVIVE && failing {
+				return nil, errors.New("revive failed")
+			}
+			return nil, nil
+		})
+		handler = m.EventRule().HandleF(func(_ context.Context, e *scheduler.Event) error {
+			if e.GetType() == scheduler.Event_OFFERS {
+				return errors.New("offers rejected")
+			}
+			return nil
+		})
+	)
+	if n := len(m.Collectors()); n != 11 {
+		t.Fatalf("expected 11 collectors instead of %d", n)
+	}
+	r := prometheus.NewRegistry()
+	if err := m.Register(r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.Register(r); err == nil {
+		t.Fatal("expected an error upon duplicate registration")
+	}
+
+	caller.Call(ctx, calls.Decline(mesos.OfferID{Value: "o1"}, mesos.OfferID{Value: "o2"}))
+	caller.Call(ctx, calls.Revive())
+	failing = false
+	caller.Call(ctx, calls.Revive())
+
+	handler.Eval(ctx, &scheduler.Event{
+		Type:   scheduler.Event_OFFERS,
+		Offers: &scheduler.Event_Offers{Offers: make([]mesos.Offer, 3)},
+	}, nil, eventrules.ChainIdentity)
+	for _, uuid := range [][]byte{[]byte("u1"), nil} {
+		handler.Eval(ctx, &scheduler.Event{
+			Type:   scheduler.Event_UPDATE,
+			Update: &scheduler.Event_Update{Status: mesos.TaskStatus{UUID: uuid}},
+		}, nil, eventrules.ChainIdentity)
+	}
+
+	for i, tc := range []struct {
+		metric prometheus.Metric
+		want   float64
+	}{
+		{m.CallCount.WithLabelValues("decline"), 1},
+		{m.CallCount.WithLabelValues("revive"), 2},
+		{m.CallErrorCount.WithLabelValues("revive"), 1},
+		{m.OffersDeclined, 2},
+		{m.EventCount.WithLabelValues("offers"), 1},
+		{m.EventCount.WithLabelValues("update"), 2},
+		{m.EventErrors.WithLabelValues("offers"), 1},
+		{m.EventErrors.WithLabelValues("update"), 0},
+		{m.OffersReceived, 3},
+		{m.AckBacklog, 1},
+	} {
+		if got := value(t, tc.metric); got != tc.want {
+			t.Errorf("test case %d: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+
+	caller.Call(ctx, calls.Acknowledge("a1", "t1", []byte("u1")))
+	if got := value(t, m.AckBacklog); got != 0 {
+		t.Fatalf("expected an empty ack backlog instead of %v", got)
+	}
+	handler.Eval(ctx, &scheduler.Event{
+		Type:   scheduler.Event_UPDATE,
+		Update: &scheduler.Event_Update{Status: mesos.TaskStatus{UUID: []byte("u2")}},
+	}, nil, eventrules.ChainIdentity)
+	m.SubscriptionTerminated(errors.New("disconnected"))
+	if got := value(t, m.AckBacklog); got != 0 {
+		t.Fatalf("expected the ack backlog to be reset instead of %v", got)
+	}
+	if got := value(t, m.Reconnects); got != 1 {
+		t.Fatalf("expected 1 reconnect instead of %v", got)
+	}
+}
+
+func TestScheduler_HTTPClientOpt(t *testing.T) {
+	var (
+		m      = NewScheduler("test")
+		status = http.StatusTemporaryRedirect
+		client = httpcli.New(
+			httpcli.Do(func(*http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			}),
+			m.HTTPClientOpt(),
+		)
+	)
+	client.Do(calls.Revive())
+	status = http.StatusOK
+	client.Do(calls.Revive())
+	if got := value(t, m.Redirects); got != 1 {
+		t.Fatalf("expected 1 redirect instead of %v", got)
+	}
+}
@@ -218,6 +218,26 @@ func AttachContainerInputTTY(t *mesos.TTYInfo) *agent.Call {
 	}
 }
 
+// AttachContainerInputHeartbeat returns a Call that informs the agent that the input stream is alive and
+// that the next heartbeat should be expected within the given interval.
+func AttachContainerInputHeartbeat(interval time.Duration) *agent.Call {
+	return &agent.Call{
+		Type: agent.Call_ATTACH_CONTAINER_INPUT,
+		AttachContainerInput: &agent.Call_AttachContainerInput{
+			Type: agent.Call_AttachContainerInput_PROCESS_IO,
+			ProcessIO: &agent.ProcessIO{
+				Type: agent.ProcessIO_CONTROL,
+				Control: &agent.ProcessIO_Control{
+					Type: agent.ProcessIO_Control_HEARTBEAT,
+					Heartbeat: &agent.ProcessIO_Control_Heartbeat{
+						Interval: &mesos.DurationInfo{Nanoseconds: interval.Nanoseconds()},
+					},
+				},
+			},
+		},
+	}
+}
+
 func AddResourceProviderConfig(rpi mesos.ResourceProviderInfo) *agent.Call {
 	return &agent.Call{
 		Type: agent.Call_ADD_RESOURCE_PROVIDER_CONFIG,
@@ -1,23 +1,22 @@
 package backoff
 
 import (
-	"fmt"
 	"reflect"
 	"time"
 )
 
-func BurstNotifier(burst int, minWait, maxWait time.Duration, until <-chan struct{}) <-chan struct{} {
+func BurstNotifier(burst int, minWait, maxWait time.Duration, until <-chan struct{}, opts ...Option) <-chan struct{} {
 	if burst < 1 {
 		return nil // no limit
 	}
 	if burst == 1 {
-		return Notifier(minWait, maxWait, until)
+		return Notifier(minWait, maxWait, until, opts...)
 	}
 
 	// build a synamic select/case statement based on burst size
 	cases := make([]reflect.SelectCase, burst+1)
 	for i := 0; i < burst; i++ {
-		ch := Notifier(minWait, maxWait, until)
+		ch := Notifier(minWait, maxWait, until, opts...)
 		cases[i].Dir = reflect.SelectRecv
 		cases[i].Chan = reflect.ValueOf(ch)
 	}
@@ -28,12 +27,20 @@ func BurstNotifier(burst int, minWait, maxWait time.Duration, until <-chan struc
 	tokens := make(chan struct{})
 	go func() {
 		defer close(tokens)
-		for {
-			i, _, _ := reflect.Select(cases)
+		for open := burst; ; {
+			i, _, ok := reflect.Select(cases)
 			if i == burst {
 				// special case: this is the "until" chan
 				return
 			}
+			if !ok {
+				// the budget of the child bucket is exhausted; stop once all of them are
+				if open--; open == 0 {
+					return
+				}
+				cases[i].Chan = reflect.Value{}
+				continue
+			}
 			// otherwise we got a signal from a child bucket that we need to forward
 			select {
 			case tokens <- struct{}{}:
@@ -48,42 +55,39 @@ func BurstNotifier(burst int, minWait, maxWait time.Duration, until <-chan struc
 // Notifier returns a chan that yields a struct{}{} every so often. the wait period
 // between structs is between minWait and maxWait. greedy consumers that continuously read
 // from the returned chan will see the wait period generally increase.
+// The wait periods may be randomized by specifying a Jitter via WithJitter.
+//
+// Notifier is a chan-oriented wrapper around Backoff; new code should prefer Backoff, which
+// supports cancellation via context.Context. The returned chan is closed once the budget of the
+// Backoff is exhausted (see MaxElapsed), so consumers should check whether a token was received.
 //
 // Note: this func panics if minWait is a non-positive value to avoid busy-looping.
-func Notifier(minWait, maxWait time.Duration, until <-chan struct{}) <-chan struct{} {
-	// TODO(jdef) add jitter to this func
-	if maxWait < minWait {
-		maxWait, minWait = minWait, maxWait
-	}
-	if minWait <= 0 {
-		panic(fmt.Sprintf("illegal value for minWait: %v", minWait))
-	}
+func Notifier(minWait, maxWait time.Duration, until <-chan struct{}, opts ...Option) <-chan struct{} {
+	b := New(minWait, maxWait, opts...)
 	tokens := make(chan struct{})
 	limiter := tokens
 	go func() {
-		d := 0 * time.Second
-		t := time.NewTimer(d)
+		t := time.NewTimer(0)
 		defer t.Stop()
 		for {
+			var d time.Duration
 			select {
 			case limiter <- struct{}{}:
-				d *= 2
-				if d > maxWait {
-					d = maxWait
-				}
+				d = b.NextDelay()
 				limiter = nil
 			case <-t.C:
 				if limiter != nil {
-					d /= 2
+					d = b.relax()
 				} else {
+					d = b.current()
 					limiter = tokens
 				}
 			case <-until:
 				return
 			}
-			// important to have non-zero minWait otherwise we busy-loop
-			if d == 0 {
-				d = minWait
+			if d == Stop {
+				close(tokens) // budget exhausted: no more tokens
+				return
 			}
 			t.Reset(d)
 		}
@@ -0,0 +1,49 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/internal/race"
+)
+
+func BenchmarkNextDelay(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"exponential", nil},
+		{"full-jitter", []Option{WithJitter(FullJitter)}},
+		{"decorrelated-jitter", []Option{WithJitter(DecorrelatedJitter)}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			bo := New(time.Millisecond, time.Minute, bc.opts...)
+			for i := 0; i < b.N; i++ {
+				if i%16 == 0 {
+					bo.Reset()
+				}
+				bo.NextDelay()
+			}
+		})
+	}
+}
+
+func BenchmarkBudget(b *testing.B) {
+	b.ReportAllocs()
+	budget := NewBudget(100, time.Millisecond)
+	for i := 0; i < b.N; i++ {
+		budget.Allow()
+	}
+}
+
+// TestAllocs asserts that computing backoff delays doesn't allocate.
+func TestAllocs(t *testing.T) {
+	if race.Enabled {
+		t.Skip("allocation counts are skewed by the race detector")
+	}
+	bo := New(time.Millisecond, time.Minute, WithJitter(FullJitter))
+	if n := testing.AllocsPerRun(100, func() { bo.NextDelay() }); n > 0 {
+		t.Errorf("NextDelay: %v allocations, expected none", n)
+	}
+}
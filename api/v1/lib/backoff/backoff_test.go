@@ -0,0 +1,200 @@
+package backoff
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	const (
+		minWait = time.Second
+		maxWait = 10 * time.Second
+	)
+	for i := 0; i < 1000; i++ {
+		if d := FullJitter(4*time.Second, 0, minWait, maxWait); d < minWait || d >= 4*time.Second {
+			t.Fatalf("full jitter out of bounds: %v", d)
+		}
+		if d := EqualJitter(4*time.Second, 0, minWait, maxWait); d < 2*time.Second || d >= 4*time.Second {
+			t.Fatalf("equal jitter out of bounds: %v", d)
+		}
+		if d := EqualJitter(time.Second, 0, minWait, maxWait); d != minWait {
+			t.Fatalf("equal jitter below minWait: %v", d)
+		}
+		if d := DecorrelatedJitter(0, 2*time.Second, minWait, maxWait); d < minWait || d >= 6*time.Second {
+			t.Fatalf("decorrelated jitter out of bounds: %v", d)
+		}
+		if d := DecorrelatedJitter(0, 8*time.Second, minWait, maxWait); d < minWait || d > maxWait {
+			t.Fatalf("decorrelated jitter out of bounds: %v", d)
+		}
+	}
+	if d := DecorrelatedJitter(0, 0, minWait, maxWait); d != minWait {
+		t.Fatalf("expected initial decorrelated jitter of minWait instead of %v", d)
+	}
+}
+
+func TestNotifierWithJitter(t *testing.T) {
+	var (
+		until = make(chan struct{})
+		calls = make(chan time.Duration, 100)
+		j     = func(delay, prev, minWait, maxWait time.Duration) time.Duration {
+			select {
+			case calls <- prev:
+			default:
+			}
+			return 0 // out of bounds: clamped to minWait
+		}
+	)
+	defer close(until)
+
+	ch := Notifier(time.Millisecond, 10*time.Millisecond, until, WithJitter(j))
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a token")
+		}
+	}
+	if prev := <-calls; prev != 0 {
+		t.Fatalf("expected an initial prev delay of zero instead of %v", prev)
+	}
+	if prev := <-calls; prev != time.Millisecond {
+		t.Fatalf("expected a clamped prev delay instead of %v", prev)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	b := New(time.Second, 5*time.Second)
+	for i, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if d := b.NextDelay(); d != expected {
+			t.Fatalf("delay %d: expected %v instead of %v", i, expected, d)
+		}
+	}
+	b.Reset()
+	if d := b.NextDelay(); d != time.Second {
+		t.Fatalf("expected minWait after Reset instead of %v", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := New(time.Hour, time.Hour).Wait(ctx); err != context.Canceled {
+		t.Fatalf("expected context.Canceled instead of %v", err)
+	}
+	if err := New(time.Millisecond, time.Millisecond).Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for a non-positive minWait")
+		}
+	}()
+	New(0, time.Second)
+}
+
+func TestStrategies(t *testing.T) {
+	const min = time.Second
+	for name, tc := range map[string]struct {
+		s        Strategy
+		expected []time.Duration
+	}{
+		"exponential": {Exponential, []time.Duration{1, 2, 4, 8, 10, 10}},
+		"constant":    {Constant, []time.Duration{1, 1, 1, 1, 1, 1}},
+		"linear":      {Linear(3 * time.Second), []time.Duration{1, 4, 7, 10, 10, 10}},
+		"fibonacci":   {Fibonacci, []time.Duration{1, 1, 2, 3, 5, 8, 10}},
+	} {
+		b := New(min, 10*time.Second, WithStrategy(tc.s))
+		for i, x := range tc.expected {
+			if d := b.NextDelay(); d != x*time.Second {
+				t.Errorf("%s: delay %d: expected %v instead of %v", name, i, x*time.Second, d)
+			}
+		}
+	}
+	for i, tc := range []struct {
+		s        Strategy
+		attempt  int
+		minWait  time.Duration
+		expected time.Duration
+	}{
+		{Exponential, 30, 10 * time.Second, 10 * time.Second << 29},
+		{Exponential, 31, 10 * time.Second, math.MaxInt64},
+		{Exponential, 33, 5 * time.Second, math.MaxInt64},
+		{Exponential, 1000, time.Second, math.MaxInt64},
+		{Fibonacci, 40, time.Second, 102334155 * time.Second},
+		{Fibonacci, 60, time.Second, math.MaxInt64},
+		{Fibonacci, 1000, time.Second, math.MaxInt64},
+	} {
+		if d := tc.s.Delay(tc.attempt, tc.minWait); d != tc.expected {
+			t.Errorf("test case %d: expected %v instead of %v", i, tc.expected, d)
+		}
+	}
+	for _, s := range []Strategy{Exponential, Fibonacci} {
+		b := New(5*time.Second, time.Minute, WithStrategy(s))
+		for i := 0; i < 100; i++ {
+			b.NextDelay()
+		}
+		if d := b.NextDelay(); d != time.Minute {
+			t.Errorf("expected a delay of maxWait after 100 attempts instead of %v", d)
+		}
+	}
+}
+
+func TestNotifierBudget(t *testing.T) {
+	for _, ch := range []<-chan struct{}{
+		Notifier(time.Millisecond, time.Millisecond, nil, MaxElapsed(5*time.Millisecond)),
+		BurstNotifier(2, time.Millisecond, time.Millisecond, nil, MaxElapsed(5*time.Millisecond)),
+	} {
+		timeout := time.After(5 * time.Second)
+	loop:
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					break loop
+				}
+			case <-timeout:
+				t.Fatal("timed out waiting for the tokens chan to be closed")
+			}
+		}
+	}
+}
+
+func TestMaxElapsed(t *testing.T) {
+	b := New(time.Second, time.Minute, MaxElapsed(3*time.Second))
+	for i, expected := range []time.Duration{time.Second, 2 * time.Second, Stop} {
+		if d := b.NextDelay(); d != expected {
+			t.Fatalf("delay %d: expected %v instead of %v", i, expected, d)
+		}
+	}
+	if err := b.Wait(context.Background()); err != ErrExhausted {
+		t.Fatalf("expected ErrExhausted instead of %v", err)
+	}
+	b.Reset()
+	if d := b.NextDelay(); d != time.Second {
+		t.Fatalf("expected the budget to be replenished upon Reset, got %v", d)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	var (
+		now = time.Unix(0, 0)
+		b   = NewBudget(2, time.Minute)
+	)
+	b.now = func() time.Time { return now }
+	if !b.Allow() || !b.Allow() {
+		t.Fatal("expected the first two retries to be allowed")
+	}
+	if b.Allow() || b.Remaining() != 0 {
+		t.Fatal("expected the third retry to be denied")
+	}
+	now = now.Add(time.Minute)
+	if b.Remaining() != 2 || !b.Allow() {
+		t.Fatal("expected retries to be allowed once the window has passed")
+	}
+
+	var unlimited *Budget
+	if !unlimited.Allow() || unlimited.Remaining() != -1 {
+		t.Fatal("expected a nil budget to allow retries")
+	}
+}
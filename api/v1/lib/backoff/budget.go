@@ -0,0 +1,64 @@
+package backoff
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned by retry loops once a retry is denied by a Budget.
+var ErrBudgetExceeded = errors.New("retry budget exceeded")
+
+// Budget limits the number of retries within a sliding window of time. A single Budget may be shared by
+// multiple subsystems (e.g. scheduler re-subscription and HTTP redirects) in order to prevent retry
+// amplification, where every layer of a client retries upon the failures of the layers below it.
+// A Budget is safe for concurrent use. A nil Budget allows any number of retries.
+type Budget struct {
+	mu      sync.Mutex
+	max     int
+	window  time.Duration
+	retries []time.Time // times of the retries within the window, oldest first
+	now     func() time.Time
+}
+
+// NewBudget returns a Budget that allows up to max retries within any window of the given duration.
+func NewBudget(max int, window time.Duration) *Budget {
+	return &Budget{max: max, window: window, now: time.Now}
+}
+
+// Allow returns true, and records a retry, if the budget allows for another retry.
+func (b *Budget) Allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.expire(now)
+	if len(b.retries) >= b.max {
+		return false
+	}
+	b.retries = append(b.retries, now)
+	return true
+}
+
+// Remaining returns the number of retries that are currently allowed by the budget; -1 for a nil Budget.
+func (b *Budget) Remaining() int {
+	if b == nil {
+		return -1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expire(b.now())
+	return b.max - len(b.retries)
+}
+
+func (b *Budget) expire(now time.Time) {
+	i := 0
+	for i < len(b.retries) && now.Sub(b.retries[i]) >= b.window {
+		i++
+	}
+	if i > 0 {
+		b.retries = append(b.retries[:0], b.retries[i:]...)
+	}
+}
@@ -0,0 +1,110 @@
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backoff computes increasing delays, between a minimum and a maximum, according to a Strategy. Delays
+// are optionally randomized by a Jitter. A Backoff is not safe for concurrent use.
+type Backoff struct {
+	minWait, maxWait time.Duration
+	cfg              config
+	attempt          int
+	prev             time.Duration // most recent (maybe randomized) delay
+	start            time.Time     // time of the first delay, for budget tracking
+}
+
+// New returns a Backoff w/ delays between minWait and maxWait; unless otherwise specified, delays grow
+// exponentially.
+//
+// Note: this func panics if minWait is a non-positive value to avoid busy-looping.
+func New(minWait, maxWait time.Duration, opts ...Option) *Backoff {
+	if maxWait < minWait {
+		maxWait, minWait = minWait, maxWait
+	}
+	if minWait <= 0 {
+		panic(fmt.Sprintf("illegal value for minWait: %v", minWait))
+	}
+	b := &Backoff{minWait: minWait, maxWait: maxWait, cfg: config{strategy: Exponential}}
+	for _, o := range opts {
+		if o != nil {
+			o(&b.cfg)
+		}
+	}
+	return b
+}
+
+// NextDelay returns the next delay, as computed by the Strategy of the Backoff (minWait initially);
+// Stop if the budget of the Backoff is exhausted.
+func (b *Backoff) NextDelay() time.Duration {
+	b.attempt++
+	return b.delay()
+}
+
+// Reset restores the initial state of the Backoff: the next delay is minWait, and the budget is
+// replenished.
+func (b *Backoff) Reset() {
+	b.attempt, b.prev = 0, 0
+	b.start = time.Time{}
+}
+
+// Wait blocks for the next delay, or until the context is canceled, in which case the context's error
+// is returned. Returns ErrExhausted if the budget of the Backoff is exhausted.
+func (b *Backoff) Wait(ctx context.Context) error {
+	d := b.NextDelay()
+	if d == Stop {
+		return ErrExhausted
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// relax steps the Strategy back by one attempt, e.g. after a period of inactivity, and returns the
+// next delay.
+func (b *Backoff) relax() time.Duration {
+	if b.attempt > 1 {
+		b.attempt--
+	}
+	return b.delay()
+}
+
+// current returns the current delay, re-randomized.
+func (b *Backoff) current() time.Duration { return b.delay() }
+
+func (b *Backoff) delay() time.Duration {
+	if b.attempt < 1 {
+		b.attempt = 1
+	}
+	d := b.cfg.strategy.Delay(b.attempt, b.minWait)
+	// important to have non-zero minWait otherwise we busy-loop
+	if d < b.minWait {
+		d = b.minWait
+	}
+	if d > b.maxWait {
+		d = b.maxWait
+	}
+	if b.cfg.jitter != nil {
+		if d = b.cfg.jitter(d, b.prev, b.minWait, b.maxWait); d < b.minWait {
+			d = b.minWait
+		}
+	}
+	if b.cfg.maxElapsed > 0 {
+		now := time.Now()
+		if b.start.IsZero() {
+			b.start = now
+		}
+		if now.Add(d).Sub(b.start) > b.cfg.maxElapsed {
+			return Stop
+		}
+	}
+	b.prev = d
+	return d
+}
@@ -0,0 +1,61 @@
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+type (
+	// Jitter randomizes a backoff delay so that many clients that back off at the same time, for example
+	// after a master failover, don't retry in lockstep. It's given the (un-randomized) delay, the most
+	// recent randomized delay (zero initially) and the bounds of the delay, and returns the randomized
+	// delay. Implementations should respect the bounds.
+	Jitter func(delay, prev, minWait, maxWait time.Duration) time.Duration
+
+	// Option is a functional option for a Backoff or a Notifier.
+	Option func(*config)
+
+	config struct {
+		jitter     Jitter
+		strategy   Strategy
+		maxElapsed time.Duration
+	}
+)
+
+// WithJitter returns an Option that randomizes the delays between the tokens of a Notifier.
+func WithJitter(j Jitter) Option {
+	return func(c *config) { c.jitter = j }
+}
+
+// FullJitter returns a random delay between minWait and the given delay.
+func FullJitter(delay, _, minWait, _ time.Duration) time.Duration {
+	return between(minWait, delay)
+}
+
+// EqualJitter returns a random delay between half of the given delay and the delay itself, though no
+// less than minWait.
+func EqualJitter(delay, _, minWait, _ time.Duration) time.Duration {
+	d := between(delay/2, delay)
+	if d < minWait {
+		d = minWait
+	}
+	return d
+}
+
+// DecorrelatedJitter returns a random delay between minWait and three times the previous delay, though no
+// more than maxWait. The un-randomized delay is ignored: delays grow w/ the previous (randomized) delay.
+func DecorrelatedJitter(_, prev, minWait, maxWait time.Duration) time.Duration {
+	d := between(minWait, prev*3)
+	if d > maxWait {
+		d = maxWait
+	}
+	return d
+}
+
+// between returns a random duration in the range [lo, hi); lo if the range is empty.
+func between(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(rand.Int63n(int64(hi-lo)))
+}
@@ -0,0 +1,95 @@
+package backoff
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Stop is returned by Backoff.NextDelay once the budget of a Backoff is exhausted; see MaxElapsed.
+const Stop time.Duration = -1
+
+// ErrExhausted is returned by Backoff.Wait once the budget of a Backoff is exhausted; see MaxElapsed.
+var ErrExhausted = errors.New("backoff budget exhausted")
+
+type (
+	// Strategy computes the (un-randomized) delay before the given attempt, counting from 1, w/ respect
+	// to the minimum delay of a Backoff. Delays are bounded by the minimum and maximum delays of the
+	// Backoff.
+	Strategy interface {
+		Delay(attempt int, minWait time.Duration) time.Duration
+	}
+
+	// StrategyFunc is the functional adaptation of the Strategy interface.
+	StrategyFunc func(attempt int, minWait time.Duration) time.Duration
+)
+
+// Delay implements Strategy.
+func (f StrategyFunc) Delay(attempt int, minWait time.Duration) time.Duration {
+	return f(attempt, minWait)
+}
+
+var (
+	// Exponential doubles the delay upon every attempt: minWait, 2*minWait, 4*minWait, ...
+	// It's the default Strategy of a Backoff. The delay saturates at the max time.Duration rather than
+	// overflowing; it's bounded by maxWait anyway.
+	Exponential = StrategyFunc(func(attempt int, minWait time.Duration) time.Duration {
+		if minWait <= 0 {
+			return minWait
+		}
+		d := minWait
+		for i := 1; i < attempt; i++ {
+			if d > math.MaxInt64>>1 {
+				return math.MaxInt64
+			}
+			d <<= 1
+		}
+		return d
+	})
+
+	// Constant always delays for minWait.
+	Constant = StrategyFunc(func(_ int, minWait time.Duration) time.Duration { return minWait })
+
+	// Fibonacci grows the delay along the Fibonacci sequence: minWait, minWait, 2*minWait, 3*minWait,
+	// 5*minWait, ... The delay saturates at the max time.Duration rather than overflowing.
+	Fibonacci = StrategyFunc(func(attempt int, minWait time.Duration) time.Duration {
+		if minWait <= 0 {
+			return minWait
+		}
+		a, b := int64(1), int64(1)
+		for i := 2; i < attempt; i++ {
+			if a > math.MaxInt64-b {
+				return math.MaxInt64
+			}
+			a, b = b, a+b
+		}
+		if b > math.MaxInt64/int64(minWait) {
+			return math.MaxInt64
+		}
+		return minWait * time.Duration(b)
+	})
+)
+
+// Linear returns a Strategy that grows the delay by a fixed step upon every attempt: minWait,
+// minWait+step, minWait+2*step, ...
+func Linear(step time.Duration) Strategy {
+	return StrategyFunc(func(attempt int, minWait time.Duration) time.Duration {
+		return minWait + step*time.Duration(attempt-1)
+	})
+}
+
+// WithStrategy returns an Option that sets the Strategy of a Backoff; a nil Strategy is ignored.
+func WithStrategy(s Strategy) Option {
+	return func(c *config) {
+		if s != nil {
+			c.strategy = s
+		}
+	}
+}
+
+// MaxElapsed returns an Option that limits the total amount of time that a Backoff may spend: once
+// the next delay would exceed the budget, measured from the first delay (or the most recent Reset),
+// the Backoff stops. The chan of a Notifier is closed once its budget is exhausted.
+func MaxElapsed(d time.Duration) Option {
+	return func(c *config) { c.maxElapsed = d }
+}
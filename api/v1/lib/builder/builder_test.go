@@ -0,0 +1,310 @@
+package builder_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/builder"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestTask(t *testing.T) {
+	agent := mesos.AgentID{Value: "agent1"}
+	ti, err := builder.Task("web", "web-1").
+		Agent(agent).
+		CPU(0.5).Mem(64).Mem(64).
+		Command("echo hello").
+		Docker("busybox").
+		Labels(mesos.Label{Key: "k"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ti.Name != "web" || ti.TaskID.Value != "web-1" || ti.AgentID != agent {
+		t.Errorf("unexpected task identity: %v", ti)
+	}
+	if want := (mesos.Resources{rez.NewCPUs(0.5).Resource, rez.NewMemory(128).Resource}); !rez.Equivalent(ti.Resources, want) {
+		t.Errorf("expected resources %v instead of %v", want, ti.Resources)
+	}
+	if ti.Command.GetValue() != "echo hello" || !ti.Command.GetShell() {
+		t.Errorf("unexpected command %v", ti.Command)
+	}
+	if ti.Container.GetDocker().GetImage() != "busybox" || ti.Container.GetType() != mesos.ContainerInfo_DOCKER {
+		t.Errorf("unexpected container %v", ti.Container)
+	}
+
+	for i, tb := range []*builder.TaskBuilder{
+		builder.Task("a", ""),
+		builder.Task("a", "a"),
+		builder.Task("a", "a").Command("x").Executor(builder.CustomExecutor("e", builder.Shell("y"))),
+		builder.Task("a", "a").Command("x").CPU(-1),
+		builder.Task("a", "a").Executor(builder.DefaultExecutor("e").Command(builder.Shell("y"))),
+	} {
+		if _, err := tb.Build(); err == nil {
+			t.Errorf("test case %d: expected a validation error", i)
+		}
+	}
+}
+
+func TestTaskGroup(t *testing.T) {
+	tg, err := builder.TaskGroup(
+		builder.Task("a", "a").Command("x"),
+		builder.Task("b", "b").CommandInfo(builder.Exec("/bin/echo", "echo", "hi").User("nobody").Env("A", "1")),
+	).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tg.Tasks) != 2 || tg.Tasks[1].Command.GetShell() || tg.Tasks[1].Command.GetUser() != "nobody" {
+		t.Fatalf("unexpected task group %v", tg)
+	}
+
+	for i, gb := range []*builder.TaskGroupBuilder{
+		builder.TaskGroup(),
+		builder.TaskGroup(builder.Task("a", "a").Executor(builder.CustomExecutor("e", builder.Shell("y")))),
+		builder.TaskGroup(builder.Task("a", "a").Command("x").MesosContainer(builder.MesosContainer().Network("n"))),
+	} {
+		if _, err := gb.Build(); err == nil {
+			t.Errorf("test case %d: expected a validation error", i)
+		}
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	env := builder.Environment("A", "1", "B", "2").Set("A", "3").Build()
+	var got []string
+	for _, v := range env.Variables {
+		got = append(got, v.Name+"="+v.GetValue())
+	}
+	if want := []string{"A=3", "B=2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v instead of %v", want, got)
+	}
+}
+
+func TestDocker(t *testing.T) {
+	ports := rez.Build().Name(rez.NamePorts).Ranges(rez.BuildRanges().Span(31000, 31001).Span(31005, 31005).Ranges).Resource
+	db := builder.Docker("nginx").
+		Network(mesos.ContainerInfo_DockerInfo_BRIDGE).
+		MapPorts(mesos.Resources{ports}, 80, 443, 8080).
+		Parameter("label", "a=b").
+		Volume("/data", "/tmp/data", mesos.RO)
+
+	ti, err := builder.Task("a", "a").CPU(1).Resources(ports).Command("x").DockerContainer(db).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docker := ti.Container.GetDocker()
+	var got [][2]uint32
+	for _, pm := range docker.GetPortMappings() {
+		got = append(got, [2]uint32{pm.HostPort, pm.ContainerPort})
+	}
+	if want := [][2]uint32{{31000, 80}, {31001, 443}, {31005, 8080}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected port mappings %v instead of %v", want, got)
+	}
+	if len(docker.GetParameters()) != 1 || len(ti.Container.GetVolumes()) != 1 {
+		t.Errorf("unexpected container %v", ti.Container)
+	}
+
+	for i, tb := range []*builder.TaskBuilder{
+		// not enough ports
+		builder.Task("a", "a").Command("x").DockerContainer(builder.Docker("nginx").
+			Network(mesos.ContainerInfo_DockerInfo_BRIDGE).MapPorts(mesos.Resources{ports}, 1, 2, 3, 4)),
+		// port mappings w/ HOST networking
+		builder.Task("a", "a").Command("x").Resources(ports).DockerContainer(builder.Docker("nginx").
+			PortMapping(31000, 80, "tcp")),
+		// host port not allocated to the task
+		builder.Task("a", "a").Command("x").Resources(ports).DockerContainer(builder.Docker("nginx").
+			Network(mesos.ContainerInfo_DockerInfo_BRIDGE).PortMapping(31002, 80, "tcp")),
+		builder.Task("a", "a").Command("x").DockerContainer(builder.Docker("")),
+	} {
+		if _, err := tb.Build(); err == nil {
+			t.Errorf("test case %d: expected a validation error", i)
+		}
+	}
+}
+
+func TestMesosContainer(t *testing.T) {
+	var (
+		ports  = rez.Build().Name(rez.NamePorts).Ranges(rez.BuildRanges().Span(31000, 31000).Ranges).Resource
+		volume = rez.NewDisk(64).Reserve("role", "p").Persistence("v1", "p").Volume("data", mesos.RW).Resource
+	)
+	cb := builder.MesosContainer().
+		DockerImage("alpine").
+		EffectiveCapabilities(mesos.CapabilityInfo_NET_BIND_SERVICE).
+		RLimit(mesos.RLimitInfo_RLimit_RLMT_NOFILE, 1024, 4096).
+		Unlimited(mesos.RLimitInfo_RLimit_RLMT_CORE).
+		RLimit(mesos.RLimitInfo_RLimit_RLMT_NOFILE, 2048, 4096).
+		Network("overlay", mesos.Label{Key: "k"}).
+		PortMapping("overlay", 31000, 80, "tcp").
+		SandboxVolume("/shared", "shared", true, mesos.RW)
+
+	ti, err := builder.Task("a", "a").Command("x").Resources(ports, volume).MesosContainer(cb).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ci := ti.Container
+	if ci.GetMesos().GetImage().GetDocker().GetName() != "alpine" {
+		t.Errorf("unexpected image %v", ci.GetMesos())
+	}
+	if limits := ci.GetRlimitInfo().GetRlimits(); len(limits) != 2 || limits[0].GetSoft() != 2048 || limits[1].Soft != nil {
+		t.Errorf("unexpected rlimits %v", limits)
+	}
+	if v := ci.GetVolumes(); len(v) != 1 || v[0].GetSource().GetSandboxPath().GetType() != mesos.Volume_Source_SandboxPath_PARENT {
+		t.Errorf("unexpected volumes %v", v)
+	}
+
+	ci, err = builder.MesosContainer().
+		AppcImage("coreos.com/etcd").
+		HostVolume("/data", "/mnt/data", mesos.RO).
+		HostVolume("scratch", "", mesos.RW).
+		Hostname("debug").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci.GetType() != mesos.ContainerInfo_MESOS || ci.GetMesos().GetImage().GetAppc().GetName() != "coreos.com/etcd" {
+		t.Errorf("unexpected container %v", ci)
+	}
+	if v := ci.GetVolumes(); len(v) != 2 || v[0].GetHostPath() != "/mnt/data" || v[1].HostPath != nil || ci.GetHostname() != "debug" {
+		t.Errorf("unexpected container %v", ci)
+	}
+
+	for i, tb := range []*builder.TaskBuilder{
+		builder.Task("a", "a").Command("x").MesosContainer(builder.MesosContainer().
+			RLimit(mesos.RLimitInfo_RLimit_RLMT_NOFILE, 2, 1)),
+		builder.Task("a", "a").Command("x").MesosContainer(builder.MesosContainer().
+			PortMapping("overlay", 31000, 80, "")),
+		builder.Task("a", "a").Command("x").MesosContainer(builder.MesosContainer().
+			Network("overlay").PortMapping("overlay", 31000, 80, "")),
+		builder.Task("a", "a").Command("x").Resources(rez.NewDisk(64).Persistence("v1", "").Volume("data", mesos.RW).Resource),
+		builder.Task("a", "a").Command("x").Resources(rez.NewDisk(64).Reserve("role", "").Persistence("v1", "").Resource),
+	} {
+		if _, err := tb.Build(); err == nil {
+			t.Errorf("test case %d: expected a validation error", i)
+		}
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	ti, err := builder.Task("a", "a").Command("x").HealthCheck(
+		builder.HTTPHealthCheck(8080, "/health").
+			Scheme("https").
+			Delay(5 * time.Second).
+			Interval(1500 * time.Millisecond).
+			ConsecutiveFailures(5),
+	).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := ti.HealthCheck
+	if hc.Type != mesos.HealthCheck_HTTP || hc.HTTP.Port != 8080 || hc.HTTP.GetPath() != "/health" || hc.HTTP.GetScheme() != "https" {
+		t.Errorf("unexpected health check %v", hc)
+	}
+	if hc.GetDelaySeconds() != 5 || hc.GetIntervalSeconds() != 1.5 || hc.GetConsecutiveFailures() != 5 {
+		t.Errorf("unexpected health check settings %v", hc)
+	}
+	if hc.GetTimeoutSeconds() != 20 || hc.GetGracePeriodSeconds() != 10 {
+		t.Errorf("expected Mesos defaults for unset settings: %v", hc)
+	}
+	if hc := builder.TCPHealthCheck(22).Build(); hc.Type != mesos.HealthCheck_TCP || hc.TCP.Port != 22 {
+		t.Errorf("unexpected health check %v", hc)
+	}
+	if hc := builder.CommandHealthCheck(builder.Shell("true")).Build(); hc.Type != mesos.HealthCheck_COMMAND || hc.Command.GetValue() != "true" {
+		t.Errorf("unexpected health check %v", hc)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ti, err := builder.Task("a", "a").Command("x").
+		Check(builder.CommandCheck(builder.Shell("test -f ready")).Interval(time.Second).Timeout(3 * time.Second)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := ti.Check
+	if c.Type != mesos.CheckInfo_COMMAND || c.Command.Command.GetValue() != "test -f ready" {
+		t.Errorf("unexpected check %v", c)
+	}
+	if c.GetIntervalSeconds() != 1 || c.GetTimeoutSeconds() != 3 || c.GetDelaySeconds() != 15 {
+		t.Errorf("unexpected check settings %v", c)
+	}
+	if c := builder.HTTPCheck(80, "/ready").Build(); c.Type != mesos.CheckInfo_HTTP || c.HTTP.GetPath() != "/ready" {
+		t.Errorf("unexpected check %v", c)
+	}
+	if c := builder.TCPCheck(22).Build(); c.Type != mesos.CheckInfo_TCP || c.TCP.Port != 22 {
+		t.Errorf("unexpected check %v", c)
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	ti, err := builder.Task("a", "a").
+		CommandInfo(builder.Shell("x").SecretEnv("TOKEN", builder.SecretReference("/db/token", "password"))).
+		MesosContainer(builder.MesosContainer().SecretVolume("cert.pem", builder.SecretValue([]byte("pem")))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := ti.Command.GetEnvironment().GetVariables()[0]
+	if v.GetType() != mesos.Environment_Variable_SECRET || v.Secret.GetReference().GetKey() != "password" {
+		t.Errorf("unexpected variable %v", v)
+	}
+	if _, ok := ti.Command.GetEnvironment().Get("TOKEN"); ok {
+		t.Error("secret variables don't have a plain value")
+	}
+	if src := ti.Container.GetVolumes()[0].GetSource(); src.GetType() != mesos.Volume_Source_SECRET || string(src.GetSecret().GetValue().GetData()) != "pem" {
+		t.Errorf("unexpected volume source %v", src)
+	}
+
+	secretVolume := mesos.Volume{
+		ContainerPath: "x",
+		Mode:          mesos.RO.Enum(),
+		Source:        &mesos.Volume_Source{Type: mesos.Volume_Source_SECRET, Secret: builder.SecretValue(nil)},
+	}
+	docker, _ := builder.Docker("busybox").Build()
+	docker.Volumes = append(docker.Volumes, secretVolume)
+
+	for i, tb := range []*builder.TaskBuilder{
+		builder.Task("a", "a").CommandInfo(builder.Shell("x").SecretEnv("A", &mesos.Secret{Type: mesos.Secret_REFERENCE})),
+		builder.Task("a", "a").CommandInfo(builder.Shell("x").SecretEnv("A", builder.SecretReference("", ""))),
+		builder.Task("a", "a").CommandInfo(builder.Shell("x").SecretEnv("A", &mesos.Secret{})),
+		builder.Task("a", "a").Command("x").Container(docker),
+	} {
+		if _, err := tb.Build(); err == nil {
+			t.Errorf("test case %d: expected a validation error", i)
+		}
+	}
+	if _, err := builder.CustomExecutor("e", builder.Shell("x").SecretEnv("A", &mesos.Secret{})).Build(); err == nil {
+		t.Error("expected a validation error for the executor")
+	}
+}
+
+func TestURI(t *testing.T) {
+	for i, tc := range []struct {
+		value               string
+		extract, executable bool
+	}{
+		{"http://x/pkg.tar.gz?token=1", true, false},
+		{"hdfs://x/pkg.TGZ", true, false},
+		{"/opt/app.zip", true, false},
+		{"http://x/run.sh#v2", false, true},
+		{"http://x/config.json", false, false},
+		{"http://x/.tar", false, false},
+	} {
+		u := builder.FetchURI(tc.value).Build()
+		if u.Value != tc.value || u.GetExtract() != tc.extract || u.GetExecutable() != tc.executable || u.GetCache() {
+			t.Errorf("test case %d: unexpected uri %v", i, u)
+		}
+	}
+	u := builder.FetchURI("http://x/run.sh").Extract(true).Cache().OutputFile("run.tar").Build()
+	if !u.GetExtract() || u.GetExecutable() || !u.GetCache() || u.GetOutputFile() != "run.tar" {
+		t.Errorf("unexpected uri %v", u)
+	}
+	if u := builder.URI("http://x/pkg.tgz").Executable().Build(); u.GetExtract() || !u.GetExecutable() {
+		t.Errorf("unexpected uri %v", u)
+	}
+	ci := builder.Shell("./run.sh").Fetch("http://x/run.sh", "http://x/data.tgz").Build()
+	if len(ci.URIs) != 2 || !ci.URIs[0].GetExecutable() || !ci.URIs[1].GetExtract() {
+		t.Errorf("unexpected uris %v", ci.URIs)
+	}
+}
@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// CheckBuilder simplifies construction of CheckInfo objects. Unlike health checks, the results of (generic)
+// checks don't affect the lifecycle of the task; they're reported to the framework via status updates,
+// see TaskStatus.CheckResult.
+type CheckBuilder struct{ mesos.CheckInfo }
+
+// CommandCheck returns a builder for a check that runs the given command.
+func CommandCheck(cmd *CommandBuilder) *CheckBuilder {
+	return &CheckBuilder{mesos.CheckInfo{
+		Type:    mesos.CheckInfo_COMMAND,
+		Command: &mesos.CheckInfo_Command{Command: *cmd.Build()},
+	}}
+}
+
+// HTTPCheck returns a builder for a check that sends a GET request for the path to the given port of
+// the task.
+func HTTPCheck(port uint32, path string) *CheckBuilder {
+	ci := mesos.CheckInfo{
+		Type: mesos.CheckInfo_HTTP,
+		HTTP: &mesos.CheckInfo_Http{Port: port},
+	}
+	if path != "" {
+		ci.HTTP.Path = &path
+	}
+	return &CheckBuilder{ci}
+}
+
+// TCPCheck returns a builder for a check that establishes a TCP connection to the given port of the task.
+func TCPCheck(port uint32) *CheckBuilder {
+	return &CheckBuilder{mesos.CheckInfo{
+		Type: mesos.CheckInfo_TCP,
+		TCP:  &mesos.CheckInfo_Tcp{Port: port},
+	}}
+}
+
+// Delay sets the amount of time to wait before the first check.
+func (cb *CheckBuilder) Delay(d time.Duration) *CheckBuilder {
+	cb.CheckInfo.DelaySeconds = seconds(d)
+	return cb
+}
+
+// Interval sets the amount of time between checks.
+func (cb *CheckBuilder) Interval(d time.Duration) *CheckBuilder {
+	cb.CheckInfo.IntervalSeconds = seconds(d)
+	return cb
+}
+
+// Timeout sets the amount of time to wait for a check to complete.
+func (cb *CheckBuilder) Timeout(d time.Duration) *CheckBuilder {
+	cb.CheckInfo.TimeoutSeconds = seconds(d)
+	return cb
+}
+
+// Build returns a reference to a copy of the CheckInfo.
+func (cb *CheckBuilder) Build() *mesos.CheckInfo {
+	ci := cb.CheckInfo
+	return &ci
+}
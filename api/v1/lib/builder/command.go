@@ -0,0 +1,88 @@
+package builder
+
+import (
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type (
+	// CommandBuilder simplifies construction of CommandInfo objects.
+	CommandBuilder struct{ mesos.CommandInfo }
+
+	// EnvironmentBuilder simplifies construction of Environment objects.
+	EnvironmentBuilder struct{ mesos.Environment }
+)
+
+// Shell returns a builder for a command that's executed via "/bin/sh -c".
+func Shell(value string) *CommandBuilder {
+	return &CommandBuilder{mesos.CommandInfo{
+		Shell: boolp(true),
+		Value: &value,
+	}}
+}
+
+// Exec returns a builder for a command that's executed directly, w/o an intermediate shell. By
+// convention the first argument is the name of the command.
+func Exec(path string, args ...string) *CommandBuilder {
+	return &CommandBuilder{mesos.CommandInfo{
+		Shell:     boolp(false),
+		Value:     &path,
+		Arguments: args,
+	}}
+}
+
+// User sets the user that the command is executed as.
+func (cb *CommandBuilder) User(user string) *CommandBuilder {
+	cb.CommandInfo.User = &user
+	return cb
+}
+
+// Env sets an environment variable for the command.
+func (cb *CommandBuilder) Env(name, value string) *CommandBuilder {
+	eb := EnvironmentBuilder{}
+	if cb.CommandInfo.Environment != nil {
+		eb.Environment = *cb.CommandInfo.Environment
+	}
+	cb.CommandInfo.Environment = eb.Set(name, value).Build()
+	return cb
+}
+
+// URI appends URIs that the fetcher downloads into the sandbox before the command is executed.
+func (cb *CommandBuilder) URI(uris ...mesos.CommandInfo_URI) *CommandBuilder {
+	cb.CommandInfo.URIs = append(cb.CommandInfo.URIs, uris...)
+	return cb
+}
+
+// Build returns a reference to a copy of the CommandInfo.
+func (cb *CommandBuilder) Build() *mesos.CommandInfo {
+	ci := cb.CommandInfo
+	return &ci
+}
+
+// Environment returns a builder for an Environment w/ the given variables, specified as name/value pairs.
+// Panics if the number of arguments is odd.
+func Environment(nameValues ...string) *EnvironmentBuilder {
+	if len(nameValues)%2 != 0 {
+		panic("builder: odd number of environment name/value arguments")
+	}
+	eb := &EnvironmentBuilder{}
+	for i := 0; i < len(nameValues); i += 2 {
+		eb.Set(nameValues[i], nameValues[i+1])
+	}
+	return eb
+}
+
+// Set sets the value of an environment variable, replacing any previous value.
+func (eb *EnvironmentBuilder) Set(name, value string) *EnvironmentBuilder {
+	eb.Environment.Set(name, value)
+	return eb
+}
+
+// Build returns a reference to a copy of the Environment.
+func (eb *EnvironmentBuilder) Build() *mesos.Environment {
+	env := mesos.Environment{
+		Variables: append([]mesos.Environment_Variable(nil), eb.Environment.Variables...),
+	}
+	return &env
+}
+
+func boolp(b bool) *bool { return &b }
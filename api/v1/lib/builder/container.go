@@ -0,0 +1,163 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// ContainerBuilder simplifies construction of ContainerInfo objects for the Mesos containerizer (a.k.a.
+// the Universal Container Runtime). Persistent volumes are specified as disk resources of the task, see
+// resources.Builder.Persistence and resources.Builder.Volume.
+type ContainerBuilder struct {
+	mesos.ContainerInfo
+	err error
+}
+
+// MesosContainer returns a builder for a Mesos container; w/o an image the container shares the root
+// filesystem of the agent.
+func MesosContainer() *ContainerBuilder {
+	return &ContainerBuilder{ContainerInfo: mesos.ContainerInfo{Type: mesos.ContainerInfo_MESOS.Enum()}}
+}
+
+// DockerImage provisions the root filesystem of the container from the given Docker image.
+func (cb *ContainerBuilder) DockerImage(name string) *ContainerBuilder {
+	return cb.image(&mesos.Image{Type: mesos.Image_DOCKER.Enum(), Docker: &mesos.Image_Docker{Name: name}})
+}
+
+// AppcImage provisions the root filesystem of the container from the given Appc image.
+func (cb *ContainerBuilder) AppcImage(name string) *ContainerBuilder {
+	return cb.image(&mesos.Image{Type: mesos.Image_APPC.Enum(), Appc: &mesos.Image_Appc{Name: name}})
+}
+
+func (cb *ContainerBuilder) image(image *mesos.Image) *ContainerBuilder {
+	cb.ContainerInfo.Mesos = &mesos.ContainerInfo_MesosInfo{Image: image}
+	return cb
+}
+
+// EffectiveCapabilities grants the given Linux capabilities to the container.
+func (cb *ContainerBuilder) EffectiveCapabilities(caps ...mesos.CapabilityInfo_Capability) *ContainerBuilder {
+	cb.linux().EffectiveCapabilities = &mesos.CapabilityInfo{Capabilities: caps}
+	return cb
+}
+
+// BoundingCapabilities limits the Linux capabilities that the container may ever acquire.
+func (cb *ContainerBuilder) BoundingCapabilities(caps ...mesos.CapabilityInfo_Capability) *ContainerBuilder {
+	cb.linux().BoundingCapabilities = &mesos.CapabilityInfo{Capabilities: caps}
+	return cb
+}
+
+func (cb *ContainerBuilder) linux() *mesos.LinuxInfo {
+	if cb.ContainerInfo.LinuxInfo == nil {
+		cb.ContainerInfo.LinuxInfo = &mesos.LinuxInfo{}
+	}
+	return cb.ContainerInfo.LinuxInfo
+}
+
+// RLimit sets the soft and hard limits of the given resource limit type. Records an error if the soft
+// limit exceeds the hard limit.
+func (cb *ContainerBuilder) RLimit(t mesos.RLimitInfo_RLimit_Type, soft, hard uint64) *ContainerBuilder {
+	if soft > hard && cb.err == nil {
+		cb.err = fmt.Errorf("rlimit %v: soft limit %d exceeds hard limit %d", t, soft, hard)
+	}
+	return cb.rlimit(mesos.RLimitInfo_RLimit{Type: t, Soft: &soft, Hard: &hard})
+}
+
+// Unlimited removes any limit of the given resource limit type.
+func (cb *ContainerBuilder) Unlimited(t mesos.RLimitInfo_RLimit_Type) *ContainerBuilder {
+	return cb.rlimit(mesos.RLimitInfo_RLimit{Type: t})
+}
+
+func (cb *ContainerBuilder) rlimit(limit mesos.RLimitInfo_RLimit) *ContainerBuilder {
+	if cb.ContainerInfo.RlimitInfo == nil {
+		cb.ContainerInfo.RlimitInfo = &mesos.RLimitInfo{}
+	}
+	limits := cb.ContainerInfo.RlimitInfo.Rlimits
+	for i := range limits {
+		if limits[i].Type == limit.Type {
+			limits[i] = limit
+			return cb
+		}
+	}
+	cb.ContainerInfo.RlimitInfo.Rlimits = append(limits, limit)
+	return cb
+}
+
+// Network joins the container to the named (CNI) network; labels are passed to the network plugin.
+func (cb *ContainerBuilder) Network(name string, labels ...mesos.Label) *ContainerBuilder {
+	ni := mesos.NetworkInfo{Name: &name}
+	if len(labels) > 0 {
+		ni.Labels = &mesos.Labels{Labels: labels}
+	}
+	cb.ContainerInfo.NetworkInfos = append(cb.ContainerInfo.NetworkInfos, ni)
+	return cb
+}
+
+// PortMapping maps a host port to a container port on the named network; protocol is optional (e.g.
+// "tcp" or "udp"). Records an error if the container hasn't joined the network.
+func (cb *ContainerBuilder) PortMapping(network string, hostPort, containerPort uint32, protocol string) *ContainerBuilder {
+	pm := mesos.NetworkInfo_PortMapping{HostPort: hostPort, ContainerPort: containerPort}
+	if protocol != "" {
+		pm.Protocol = &protocol
+	}
+	for i := range cb.ContainerInfo.NetworkInfos {
+		if ni := &cb.ContainerInfo.NetworkInfos[i]; ni.GetName() == network {
+			ni.PortMappings = append(ni.PortMappings, pm)
+			return cb
+		}
+	}
+	if cb.err == nil {
+		cb.err = fmt.Errorf("port mapping for unknown network %q", network)
+	}
+	return cb
+}
+
+// HostVolume mounts the host path into the container at the given path.
+func (cb *ContainerBuilder) HostVolume(containerPath, hostPath string, mode mesos.Volume_Mode) *ContainerBuilder {
+	cb.ContainerInfo.Volumes = append(cb.ContainerInfo.Volumes, volume(containerPath, hostPath, mode))
+	return cb
+}
+
+// volume returns a volume that mounts the host path into a container at the given path; an empty hostPath
+// specifies a volume that's relative to the sandbox.
+func volume(containerPath, hostPath string, mode mesos.Volume_Mode) mesos.Volume {
+	v := mesos.Volume{ContainerPath: containerPath, Mode: mode.Enum()}
+	if hostPath != "" {
+		v.HostPath = &hostPath
+	}
+	return v
+}
+
+// SandboxVolume mounts a path relative to the sandbox of the container into the container at the given
+// path. If parent is true then the path is relative to the sandbox of the parent container, which allows
+// the tasks of a group to share data.
+func (cb *ContainerBuilder) SandboxVolume(containerPath, path string, parent bool, mode mesos.Volume_Mode) *ContainerBuilder {
+	t := mesos.Volume_Source_SandboxPath_SELF
+	if parent {
+		t = mesos.Volume_Source_SandboxPath_PARENT
+	}
+	cb.ContainerInfo.Volumes = append(cb.ContainerInfo.Volumes, mesos.Volume{
+		ContainerPath: containerPath,
+		Mode:          mode.Enum(),
+		Source: &mesos.Volume_Source{
+			Type:        mesos.Volume_Source_SANDBOX_PATH,
+			SandboxPath: &mesos.Volume_Source_SandboxPath{Type: t, Path: path},
+		},
+	})
+	return cb
+}
+
+// Hostname sets the hostname of the container.
+func (cb *ContainerBuilder) Hostname(hostname string) *ContainerBuilder {
+	cb.ContainerInfo.Hostname = &hostname
+	return cb
+}
+
+// Build returns the ContainerInfo, or the first error that was recorded.
+func (cb *ContainerBuilder) Build() (*mesos.ContainerInfo, error) {
+	if cb.err != nil {
+		return nil, cb.err
+	}
+	ci := cb.ContainerInfo
+	return &ci, nil
+}
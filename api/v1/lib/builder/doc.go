@@ -0,0 +1,13 @@
+// Package builder provides fluent construction of the protobuf messages that frameworks send to Mesos
+// when launching workloads, for example:
+//
+//	task, err := builder.Task("web", "web-1").
+//		Agent(offer.AgentID).
+//		CPU(0.5).Mem(128).
+//		Command("python -m SimpleHTTPServer").
+//		Docker("python:2").
+//		Build()
+//
+// Builders accumulate the first error encountered; Build validates the result (for example, that fields
+// that Mesos considers mutually exclusive are not both set) and returns that error.
+package builder
@@ -0,0 +1,142 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+// DockerBuilder simplifies construction of ContainerInfo objects for the Docker containerizer.
+type DockerBuilder struct {
+	mesos.ContainerInfo
+	err error
+}
+
+// Docker returns a builder for a Docker container that runs the given image.
+func Docker(image string) *DockerBuilder {
+	return &DockerBuilder{ContainerInfo: dockerContainer(image)}
+}
+
+func dockerContainer(image string) mesos.ContainerInfo {
+	return mesos.ContainerInfo{
+		Type:   mesos.ContainerInfo_DOCKER.Enum(),
+		Docker: &mesos.ContainerInfo_DockerInfo{Image: image},
+	}
+}
+
+// Network sets the networking mode of the container; defaults to HOST.
+func (db *DockerBuilder) Network(mode mesos.ContainerInfo_DockerInfo_Network) *DockerBuilder {
+	db.ContainerInfo.Docker.Network = mode.Enum()
+	return db
+}
+
+// PortMapping maps a host port to a container port; protocol is optional (e.g. "tcp" or "udp").
+func (db *DockerBuilder) PortMapping(hostPort, containerPort uint32, protocol string) *DockerBuilder {
+	pm := mesos.ContainerInfo_DockerInfo_PortMapping{HostPort: hostPort, ContainerPort: containerPort}
+	if protocol != "" {
+		pm.Protocol = &protocol
+	}
+	db.ContainerInfo.Docker.PortMappings = append(db.ContainerInfo.Docker.PortMappings, pm)
+	return db
+}
+
+// MapPorts maps each of the given container ports to a host port drawn, in order, from the "ports"
+// resources that have been allocated to the task. Records an error if there aren't enough host ports.
+func (db *DockerBuilder) MapPorts(allocated mesos.Resources, containerPorts ...uint32) *DockerBuilder {
+	hostPorts := portRanges(allocated)
+	for _, cp := range containerPorts {
+		if len(hostPorts) == 0 {
+			if db.err == nil {
+				db.err = fmt.Errorf("no host port available for container port %d", cp)
+			}
+			return db
+		}
+		hp := hostPorts[0].Begin
+		hostPorts = hostPorts.Remove(mesos.Value_Range{Begin: hp, End: hp})
+		db.PortMapping(uint32(hp), cp, "")
+	}
+	return db
+}
+
+// Parameter passes an arbitrary parameter, as "--key=value", to the docker CLI.
+func (db *DockerBuilder) Parameter(key, value string) *DockerBuilder {
+	db.ContainerInfo.Docker.Parameters = append(db.ContainerInfo.Docker.Parameters, mesos.Parameter{Key: key, Value: value})
+	return db
+}
+
+// Privileged runs the container in privileged mode.
+func (db *DockerBuilder) Privileged() *DockerBuilder {
+	db.ContainerInfo.Docker.Privileged = boolp(true)
+	return db
+}
+
+// ForcePullImage pulls the image even if it's already present on the agent.
+func (db *DockerBuilder) ForcePullImage() *DockerBuilder {
+	db.ContainerInfo.Docker.ForcePullImage = boolp(true)
+	return db
+}
+
+// Volume mounts the host path into the container at the given path; an empty hostPath specifies
+// a volume that's relative to the sandbox.
+func (db *DockerBuilder) Volume(containerPath, hostPath string, mode mesos.Volume_Mode) *DockerBuilder {
+	db.ContainerInfo.Volumes = append(db.ContainerInfo.Volumes, volume(containerPath, hostPath, mode))
+	return db
+}
+
+// Build validates and returns the ContainerInfo. Port mappings are only supported by the BRIDGE and
+// USER network modes.
+func (db *DockerBuilder) Build() (*mesos.ContainerInfo, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	ci := db.ContainerInfo
+	docker := *ci.Docker
+	ci.Docker = &docker
+	if docker.Image == "" {
+		return nil, errors.New("docker image is required")
+	}
+	if len(docker.PortMappings) > 0 {
+		switch docker.GetNetwork() {
+		case mesos.ContainerInfo_DockerInfo_BRIDGE, mesos.ContainerInfo_DockerInfo_USER:
+		default:
+			return nil, fmt.Errorf("port mappings are not supported by the %v network mode", docker.GetNetwork())
+		}
+	}
+	return &ci, nil
+}
+
+// portRanges returns the (squashed) port ranges of the given resources.
+func portRanges(rs mesos.Resources) (ports mesos.Ranges) {
+	for i := range rs {
+		if resources.NamePorts.Filter(&rs[i]) {
+			ports = append(ports, rs[i].GetRanges().GetRange()...)
+		}
+	}
+	return ports.Sort().Squash()
+}
+
+// validatePortMappings checks that the host ports of the container's (Docker or CNI network) port
+// mappings are allocated to the task (or executor) via its "ports" resources.
+func validatePortMappings(ci *mesos.ContainerInfo, rs mesos.Resources) error {
+	var hostPorts []uint32
+	for _, pm := range ci.GetDocker().GetPortMappings() {
+		hostPorts = append(hostPorts, pm.HostPort)
+	}
+	for _, ni := range ci.GetNetworkInfos() {
+		for _, pm := range ni.GetPortMappings() {
+			hostPorts = append(hostPorts, pm.HostPort)
+		}
+	}
+	if len(hostPorts) == 0 {
+		return nil
+	}
+	ports := portRanges(rs)
+	for _, p := range hostPorts {
+		if ports.Search(uint64(p)) < 0 {
+			return fmt.Errorf("host port %d is not included in the allocated port resources", p)
+		}
+	}
+	return nil
+}
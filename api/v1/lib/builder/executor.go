@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+// ExecutorBuilder simplifies construction of ExecutorInfo objects.
+type ExecutorBuilder struct {
+	mesos.ExecutorInfo
+	err error
+}
+
+// DefaultExecutor returns a builder for the Mesos default executor, which runs task groups.
+func DefaultExecutor(id string) *ExecutorBuilder {
+	return &ExecutorBuilder{ExecutorInfo: mesos.ExecutorInfo{
+		Type:       mesos.ExecutorInfo_DEFAULT,
+		ExecutorID: mesos.ExecutorID{Value: id},
+	}}
+}
+
+// CustomExecutor returns a builder for a framework-provided executor that's launched w/ the given command.
+func CustomExecutor(id string, cmd *CommandBuilder) *ExecutorBuilder {
+	return (&ExecutorBuilder{ExecutorInfo: mesos.ExecutorInfo{
+		Type:       mesos.ExecutorInfo_CUSTOM,
+		ExecutorID: mesos.ExecutorID{Value: id},
+	}}).Command(cmd)
+}
+
+// Name sets the human-readable name of the executor.
+func (eb *ExecutorBuilder) Name(name string) *ExecutorBuilder {
+	eb.ExecutorInfo.Name = &name
+	return eb
+}
+
+// Framework sets the ID of the framework that owns the executor.
+func (eb *ExecutorBuilder) Framework(id mesos.FrameworkID) *ExecutorBuilder {
+	eb.ExecutorInfo.FrameworkID = &id
+	return eb
+}
+
+// Command sets the command that launches the executor.
+func (eb *ExecutorBuilder) Command(cmd *CommandBuilder) *ExecutorBuilder {
+	eb.ExecutorInfo.Command = cmd.Build()
+	return eb
+}
+
+// Container sets the container that the executor runs in.
+func (eb *ExecutorBuilder) Container(ci *mesos.ContainerInfo) *ExecutorBuilder {
+	eb.ExecutorInfo.Container = ci
+	return eb
+}
+
+// Resources adds resources that are consumed by the executor itself.
+func (eb *ExecutorBuilder) Resources(rs ...mesos.Resource) *ExecutorBuilder {
+	for i := range rs {
+		if err := rs[i].Validate(); err != nil && eb.err == nil {
+			eb.err = fmt.Errorf("resource %q: %v", rs[i].Name, err)
+		}
+	}
+	eb.ExecutorInfo.Resources = mesos.Resources(eb.ExecutorInfo.Resources).Plus(rs...)
+	return eb
+}
+
+// CPU adds a cpus resource for the executor.
+func (eb *ExecutorBuilder) CPU(x float64) *ExecutorBuilder {
+	return eb.Resources(resources.NewCPUs(x).Resource)
+}
+
+// Mem adds a mem resource (in MB) for the executor.
+func (eb *ExecutorBuilder) Mem(x float64) *ExecutorBuilder {
+	return eb.Resources(resources.NewMemory(x).Resource)
+}
+
+// Build validates and returns the ExecutorInfo.
+func (eb *ExecutorBuilder) Build() (mesos.ExecutorInfo, error) {
+	if eb.err != nil {
+		return mesos.ExecutorInfo{}, eb.err
+	}
+	ei := eb.ExecutorInfo
+	if ei.ExecutorID.Value == "" {
+		return ei, errors.New("executor ID is required")
+	}
+	switch ei.Type {
+	case mesos.ExecutorInfo_DEFAULT:
+		if ei.Command != nil {
+			return ei, errors.New("command must not be set for the default executor")
+		}
+	case mesos.ExecutorInfo_CUSTOM:
+		if ei.Command == nil {
+			return ei, errors.New("command is required for a custom executor")
+		}
+	}
+	if err := validateSecrets(ei.Command, ei.Container); err != nil {
+		return ei, fmt.Errorf("executor %q: %v", ei.ExecutorID.Value, err)
+	}
+	return ei, nil
+}
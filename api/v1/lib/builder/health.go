@@ -0,0 +1,92 @@
+package builder
+
+import (
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// HealthCheckBuilder simplifies construction of HealthCheck objects. Unset durations and thresholds
+// assume the Mesos defaults.
+type HealthCheckBuilder struct{ mesos.HealthCheck }
+
+// CommandHealthCheck returns a builder for a health check that's healthy while the command exits w/ a
+// status of zero.
+func CommandHealthCheck(cmd *CommandBuilder) *HealthCheckBuilder {
+	return &HealthCheckBuilder{mesos.HealthCheck{
+		Type:    mesos.HealthCheck_COMMAND,
+		Command: cmd.Build(),
+	}}
+}
+
+// HTTPHealthCheck returns a builder for a health check that's healthy while a GET request for the path
+// on the given port of the task yields a 2xx or 3xx response.
+func HTTPHealthCheck(port uint32, path string) *HealthCheckBuilder {
+	hc := mesos.HealthCheck{
+		Type: mesos.HealthCheck_HTTP,
+		HTTP: &mesos.HealthCheck_HTTPCheckInfo{Port: port},
+	}
+	if path != "" {
+		hc.HTTP.Path = &path
+	}
+	return &HealthCheckBuilder{hc}
+}
+
+// TCPHealthCheck returns a builder for a health check that's healthy while a TCP connection can be
+// established to the given port of the task.
+func TCPHealthCheck(port uint32) *HealthCheckBuilder {
+	return &HealthCheckBuilder{mesos.HealthCheck{
+		Type: mesos.HealthCheck_TCP,
+		TCP:  &mesos.HealthCheck_TCPCheckInfo{Port: port},
+	}}
+}
+
+// Scheme sets the scheme (e.g. "https") of an HTTP health check.
+func (hb *HealthCheckBuilder) Scheme(scheme string) *HealthCheckBuilder {
+	if hb.HealthCheck.HTTP != nil {
+		hb.HealthCheck.HTTP.Scheme = &scheme
+	}
+	return hb
+}
+
+// Delay sets the amount of time to wait before the first health check.
+func (hb *HealthCheckBuilder) Delay(d time.Duration) *HealthCheckBuilder {
+	hb.HealthCheck.DelaySeconds = seconds(d)
+	return hb
+}
+
+// Interval sets the amount of time between health checks.
+func (hb *HealthCheckBuilder) Interval(d time.Duration) *HealthCheckBuilder {
+	hb.HealthCheck.IntervalSeconds = seconds(d)
+	return hb
+}
+
+// Timeout sets the amount of time to wait for a health check to complete.
+func (hb *HealthCheckBuilder) Timeout(d time.Duration) *HealthCheckBuilder {
+	hb.HealthCheck.TimeoutSeconds = seconds(d)
+	return hb
+}
+
+// GracePeriod sets the amount of time, after the task is launched, during which failed health checks
+// are ignored.
+func (hb *HealthCheckBuilder) GracePeriod(d time.Duration) *HealthCheckBuilder {
+	hb.HealthCheck.GracePeriodSeconds = seconds(d)
+	return hb
+}
+
+// ConsecutiveFailures sets the number of consecutive failed health checks after which the task is killed.
+func (hb *HealthCheckBuilder) ConsecutiveFailures(n uint32) *HealthCheckBuilder {
+	hb.HealthCheck.ConsecutiveFailures = &n
+	return hb
+}
+
+// Build returns a reference to a copy of the HealthCheck.
+func (hb *HealthCheckBuilder) Build() *mesos.HealthCheck {
+	hc := hb.HealthCheck
+	return &hc
+}
+
+func seconds(d time.Duration) *float64 {
+	x := d.Seconds()
+	return &x
+}
@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// SecretReference returns a secret that's resolved, by name, by the secret resolver module of the agent;
+// key is optional and selects a single value of a multi-valued secret.
+func SecretReference(name, key string) *mesos.Secret {
+	s := &mesos.Secret{
+		Type:      mesos.Secret_REFERENCE,
+		Reference: &mesos.Secret_Reference{Name: name},
+	}
+	if key != "" {
+		s.Reference.Key = &key
+	}
+	return s
+}
+
+// SecretValue returns a secret whose value is passed, as is, to the task.
+func SecretValue(data []byte) *mesos.Secret {
+	return &mesos.Secret{
+		Type:  mesos.Secret_VALUE,
+		Value: &mesos.Secret_Value{Data: data},
+	}
+}
+
+// SetSecret sets the value of an environment variable to the given secret, replacing any previous value.
+func (eb *EnvironmentBuilder) SetSecret(name string, s *mesos.Secret) *EnvironmentBuilder {
+	eb.Environment.SetSecret(name, s)
+	return eb
+}
+
+// SecretEnv sets an environment variable for the command to the value of the given secret.
+func (cb *CommandBuilder) SecretEnv(name string, s *mesos.Secret) *CommandBuilder {
+	eb := EnvironmentBuilder{}
+	if cb.CommandInfo.Environment != nil {
+		eb.Environment = *cb.CommandInfo.Environment
+	}
+	cb.CommandInfo.Environment = eb.SetSecret(name, s).Build()
+	return cb
+}
+
+// SecretVolume mounts a file that contains the value of the secret into the container at the given
+// path; the volume is read-only. Secret volumes are only supported by the Mesos containerizer.
+func (cb *ContainerBuilder) SecretVolume(containerPath string, s *mesos.Secret) *ContainerBuilder {
+	cb.ContainerInfo.Volumes = append(cb.ContainerInfo.Volumes, mesos.Volume{
+		ContainerPath: containerPath,
+		Mode:          mesos.RO.Enum(),
+		Source: &mesos.Volume_Source{
+			Type:   mesos.Volume_Source_SECRET,
+			Secret: s,
+		},
+	})
+	return cb
+}
+
+// validateSecret checks that exactly the member of the secret that corresponds to its type is set.
+func validateSecret(s *mesos.Secret) error {
+	switch s.GetType() {
+	case mesos.Secret_REFERENCE:
+		if s.Reference == nil || s.Value != nil {
+			return errors.New("secret of type REFERENCE must only specify a reference")
+		}
+		if s.Reference.Name == "" {
+			return errors.New("secret reference must specify a name")
+		}
+	case mesos.Secret_VALUE:
+		if s.Value == nil || s.Reference != nil {
+			return errors.New("secret of type VALUE must only specify a value")
+		}
+	default:
+		return fmt.Errorf("unsupported secret type %v", s.GetType())
+	}
+	return nil
+}
+
+// validateSecrets checks the secrets of the environment of the command and of the volumes of the container
+// of a task or executor. Secrets must only be used by SECRET environment variables and SECRET volumes, and
+// secret volumes are not supported by the Docker containerizer.
+func validateSecrets(cmd *mesos.CommandInfo, ci *mesos.ContainerInfo) error {
+	for _, v := range cmd.GetEnvironment().GetVariables() {
+		switch v.GetType() {
+		case mesos.Environment_Variable_SECRET:
+			if v.Value != nil {
+				return fmt.Errorf("environment variable %q: a SECRET variable must not specify a value", v.Name)
+			}
+			if v.Secret == nil {
+				return fmt.Errorf("environment variable %q: a SECRET variable must specify a secret", v.Name)
+			}
+			if err := validateSecret(v.Secret); err != nil {
+				return fmt.Errorf("environment variable %q: %v", v.Name, err)
+			}
+		default:
+			if v.Secret != nil {
+				return fmt.Errorf("environment variable %q: only SECRET variables may specify a secret", v.Name)
+			}
+		}
+	}
+	for _, v := range ci.GetVolumes() {
+		src := v.GetSource()
+		if src.GetType() != mesos.Volume_Source_SECRET {
+			if src.GetSecret() != nil {
+				return fmt.Errorf("volume %q: only SECRET volumes may specify a secret", v.ContainerPath)
+			}
+			continue
+		}
+		if ci.GetType() == mesos.ContainerInfo_DOCKER {
+			return fmt.Errorf("volume %q: secret volumes are not supported by the Docker containerizer", v.ContainerPath)
+		}
+		if src.Secret == nil {
+			return fmt.Errorf("volume %q: a SECRET volume must specify a secret", v.ContainerPath)
+		}
+		if err := validateSecret(src.Secret); err != nil {
+			return fmt.Errorf("volume %q: %v", v.ContainerPath, err)
+		}
+	}
+	return nil
+}
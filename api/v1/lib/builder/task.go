@@ -0,0 +1,230 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+type (
+	// TaskBuilder simplifies construction of TaskInfo objects.
+	TaskBuilder struct {
+		mesos.TaskInfo
+		err error
+	}
+
+	// TaskGroupBuilder simplifies construction of TaskGroupInfo objects.
+	TaskGroupBuilder struct {
+		tasks []*TaskBuilder
+	}
+)
+
+// Task returns a builder for a task w/ the given name and ID.
+func Task(name, id string) *TaskBuilder {
+	return &TaskBuilder{TaskInfo: mesos.TaskInfo{
+		Name:   name,
+		TaskID: mesos.TaskID{Value: id},
+	}}
+}
+
+// Agent sets the ID of the agent that the task is launched on; usually the agent of the offer that's
+// being accepted.
+func (tb *TaskBuilder) Agent(id mesos.AgentID) *TaskBuilder {
+	tb.TaskInfo.AgentID = id
+	return tb
+}
+
+// Resources adds resources that are consumed by the task.
+func (tb *TaskBuilder) Resources(rs ...mesos.Resource) *TaskBuilder {
+	for i := range rs {
+		if err := rs[i].Validate(); err != nil && tb.err == nil {
+			tb.err = fmt.Errorf("resource %q: %v", rs[i].Name, err)
+		}
+	}
+	tb.TaskInfo.Resources = mesos.Resources(tb.TaskInfo.Resources).Plus(rs...)
+	return tb
+}
+
+// CPU adds a cpus resource for the task.
+func (tb *TaskBuilder) CPU(x float64) *TaskBuilder {
+	return tb.Resources(resources.NewCPUs(x).Resource)
+}
+
+// Mem adds a mem resource (in MB) for the task.
+func (tb *TaskBuilder) Mem(x float64) *TaskBuilder {
+	return tb.Resources(resources.NewMemory(x).Resource)
+}
+
+// Disk adds a disk resource (in MB) for the task.
+func (tb *TaskBuilder) Disk(x float64) *TaskBuilder {
+	return tb.Resources(resources.NewDisk(x).Resource)
+}
+
+// GPUs adds a gpus resource for the task.
+func (tb *TaskBuilder) GPUs(n uint) *TaskBuilder {
+	return tb.Resources(resources.NewGPUs(n).Resource)
+}
+
+// Command sets a shell command for the task; see CommandInfo for additional control.
+func (tb *TaskBuilder) Command(value string) *TaskBuilder {
+	return tb.CommandInfo(Shell(value))
+}
+
+// CommandInfo sets the command for the task.
+func (tb *TaskBuilder) CommandInfo(cmd *CommandBuilder) *TaskBuilder {
+	tb.TaskInfo.Command = cmd.Build()
+	return tb
+}
+
+// Executor sets the (custom) executor that runs the task.
+func (tb *TaskBuilder) Executor(eb *ExecutorBuilder) *TaskBuilder {
+	ei, err := eb.Build()
+	if err != nil && tb.err == nil {
+		tb.err = err
+	}
+	tb.TaskInfo.Executor = &ei
+	return tb
+}
+
+// Container sets the container that the task runs in.
+func (tb *TaskBuilder) Container(ci *mesos.ContainerInfo) *TaskBuilder {
+	tb.TaskInfo.Container = ci
+	return tb
+}
+
+// Docker runs the task in a container of the given image, using the Docker containerizer.
+func (tb *TaskBuilder) Docker(image string) *TaskBuilder {
+	ci := dockerContainer(image)
+	return tb.Container(&ci)
+}
+
+// DockerContainer runs the task in the given Docker container.
+func (tb *TaskBuilder) DockerContainer(db *DockerBuilder) *TaskBuilder {
+	ci, err := db.Build()
+	if err != nil && tb.err == nil {
+		tb.err = err
+	}
+	return tb.Container(ci)
+}
+
+// MesosContainer runs the task in the given Mesos container.
+func (tb *TaskBuilder) MesosContainer(cb *ContainerBuilder) *TaskBuilder {
+	ci, err := cb.Build()
+	if err != nil && tb.err == nil {
+		tb.err = err
+	}
+	return tb.Container(ci)
+}
+
+// HealthCheck sets the health check of the task.
+func (tb *TaskBuilder) HealthCheck(hb *HealthCheckBuilder) *TaskBuilder {
+	tb.TaskInfo.HealthCheck = hb.Build()
+	return tb
+}
+
+// Check sets the (generic) check of the task.
+func (tb *TaskBuilder) Check(cb *CheckBuilder) *TaskBuilder {
+	tb.TaskInfo.Check = cb.Build()
+	return tb
+}
+
+// KillPolicy sets the amount of time that the task is allotted to gracefully terminate when it's killed.
+func (tb *TaskBuilder) KillPolicy(gracePeriod time.Duration) *TaskBuilder {
+	tb.TaskInfo.KillPolicy = mesos.NewKillPolicy(gracePeriod)
+	return tb
+}
+
+// Labels adds labels to the task.
+func (tb *TaskBuilder) Labels(labels ...mesos.Label) *TaskBuilder {
+	if tb.TaskInfo.Labels == nil {
+		tb.TaskInfo.Labels = &mesos.Labels{}
+	}
+	tb.TaskInfo.Labels.Labels = append(tb.TaskInfo.Labels.Labels, labels...)
+	return tb
+}
+
+// Data sets opaque data that's delivered to the executor of the task.
+func (tb *TaskBuilder) Data(data []byte) *TaskBuilder {
+	tb.TaskInfo.Data = data
+	return tb
+}
+
+// Build validates and returns the TaskInfo.
+func (tb *TaskBuilder) Build() (mesos.TaskInfo, error) {
+	if tb.err != nil {
+		return mesos.TaskInfo{}, tb.err
+	}
+	ti := tb.TaskInfo
+	if ti.TaskID.Value == "" {
+		return ti, errors.New("task ID is required")
+	}
+	if (ti.Command == nil) == (ti.Executor == nil) {
+		return ti, fmt.Errorf("task %q: exactly one of command or executor must be set", ti.TaskID.Value)
+	}
+	if err := validatePortMappings(ti.Container, ti.Resources); err != nil {
+		return ti, fmt.Errorf("task %q: %v", ti.TaskID.Value, err)
+	}
+	if err := validatePersistentVolumes(ti.Resources); err != nil {
+		return ti, fmt.Errorf("task %q: %v", ti.TaskID.Value, err)
+	}
+	if err := validateSecrets(ti.Command, ti.Container); err != nil {
+		return ti, fmt.Errorf("task %q: %v", ti.TaskID.Value, err)
+	}
+	return ti, nil
+}
+
+// TaskGroup returns a builder for a group of tasks that's launched atomically by the given executor.
+func TaskGroup(tasks ...*TaskBuilder) *TaskGroupBuilder {
+	return &TaskGroupBuilder{tasks: tasks}
+}
+
+// Task adds a task to the group.
+func (gb *TaskGroupBuilder) Task(tb *TaskBuilder) *TaskGroupBuilder {
+	gb.tasks = append(gb.tasks, tb)
+	return gb
+}
+
+// Build validates and returns the TaskGroupInfo. Tasks of a group must specify a command, must not
+// specify an executor (the executor is given by the LAUNCH_GROUP operation), and must not join networks
+// individually.
+func (gb *TaskGroupBuilder) Build() (mesos.TaskGroupInfo, error) {
+	if len(gb.tasks) == 0 {
+		return mesos.TaskGroupInfo{}, errors.New("task group must contain at least one task")
+	}
+	tg := mesos.TaskGroupInfo{Tasks: make([]mesos.TaskInfo, 0, len(gb.tasks))}
+	for _, tb := range gb.tasks {
+		if tb.TaskInfo.Executor != nil {
+			return mesos.TaskGroupInfo{}, fmt.Errorf("task %q: executor must not be set for a task in a group", tb.TaskID.Value)
+		}
+		if len(tb.TaskInfo.Container.GetNetworkInfos()) > 0 {
+			return mesos.TaskGroupInfo{}, fmt.Errorf("task %q: network infos must not be set for a task in a group", tb.TaskID.Value)
+		}
+		ti, err := tb.Build()
+		if err != nil {
+			return mesos.TaskGroupInfo{}, err
+		}
+		tg.Tasks = append(tg.Tasks, ti)
+	}
+	return tg, nil
+}
+
+// validatePersistentVolumes checks that persistent volumes are reserved and are mounted into the container.
+func validatePersistentVolumes(rs mesos.Resources) error {
+	for i := range rs {
+		r := &rs[i]
+		if !r.IsPersistentVolume() {
+			continue
+		}
+		id := r.GetDisk().GetPersistence().GetID()
+		if r.IsUnreserved() {
+			return fmt.Errorf("persistent volume %q must be created from reserved resources", id)
+		}
+		if r.GetDisk().GetVolume() == nil {
+			return fmt.Errorf("persistent volume %q doesn't specify a container path", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// URIBuilder simplifies construction of CommandInfo_URI objects.
+type URIBuilder struct{ mesos.CommandInfo_URI }
+
+// archiveExtensions are the (lowercase) file extensions that the Mesos fetcher knows how to extract.
+var archiveExtensions = []string{".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz", ".gz", ".zip"}
+
+// scriptExtensions are the (lowercase) file extensions of files that are marked executable by FetchURI.
+var scriptExtensions = []string{".sh", ".bash", ".py", ".pl", ".rb"}
+
+// URI returns a builder for a URI that's fetched as is: it's neither extracted, nor made executable,
+// nor cached. Note that the Mesos default is to extract archives; this builder makes that explicit.
+func URI(value string) *URIBuilder {
+	return &URIBuilder{mesos.CommandInfo_URI{
+		Value:   value,
+		Extract: boolp(false),
+	}}
+}
+
+// FetchURI returns a builder for a URI whose flags are derived from the extension of the basename of the
+// URI's path (query parameters and fragments are ignored): archives are extracted, scripts are made
+// executable, and everything else is fetched as is. Use the builder methods to override the defaults.
+func FetchURI(value string) *URIBuilder {
+	ub := URI(value)
+	name := strings.ToLower(uriBase(value))
+	switch {
+	case hasExtension(name, archiveExtensions):
+		ub.CommandInfo_URI.Extract = boolp(true)
+	case hasExtension(name, scriptExtensions):
+		ub.CommandInfo_URI.Executable = boolp(true)
+	}
+	return ub
+}
+
+// Extract determines whether the fetched file is extracted into the sandbox, in case that it's an
+// archive. Extraction is incompatible with Executable.
+func (ub *URIBuilder) Extract(extract bool) *URIBuilder {
+	ub.CommandInfo_URI.Extract = boolp(extract)
+	if extract {
+		ub.CommandInfo_URI.Executable = nil
+	}
+	return ub
+}
+
+// Executable marks the fetched file as executable; it disables extraction.
+func (ub *URIBuilder) Executable() *URIBuilder {
+	ub.CommandInfo_URI.Executable = boolp(true)
+	ub.CommandInfo_URI.Extract = boolp(false)
+	return ub
+}
+
+// Cache directs the fetcher to download the file via the agent's fetcher cache.
+func (ub *URIBuilder) Cache() *URIBuilder {
+	ub.CommandInfo_URI.Cache = boolp(true)
+	return ub
+}
+
+// OutputFile names the local copy of the fetched file, relative to the sandbox, instead of the URI's
+// basename. Since the archive type is detected from the file name, an extracted archive should be given
+// an output file name w/ a matching extension.
+func (ub *URIBuilder) OutputFile(name string) *URIBuilder {
+	ub.CommandInfo_URI.OutputFile = &name
+	return ub
+}
+
+// Build returns a copy of the CommandInfo_URI.
+func (ub *URIBuilder) Build() mesos.CommandInfo_URI {
+	return ub.CommandInfo_URI
+}
+
+// Fetch appends URIs, w/ flags derived by FetchURI, that the fetcher downloads into the sandbox before
+// the command is executed.
+func (cb *CommandBuilder) Fetch(values ...string) *CommandBuilder {
+	for _, v := range values {
+		cb.CommandInfo.URIs = append(cb.CommandInfo.URIs, FetchURI(v).Build())
+	}
+	return cb
+}
+
+// uriBase returns the basename of the path of the given URI; the URI need not include a scheme.
+func uriBase(value string) string {
+	if u, err := url.Parse(value); err == nil && u.Path != "" {
+		value = u.Path
+	}
+	return path.Base(value)
+}
+
+func hasExtension(name string, extensions []string) bool {
+	for _, ext := range extensions {
+		if strings.HasSuffix(name, ext) && len(name) > len(ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package mesos
+
+// CheckResult is the result of a (generic, non-health) check, as reported via a TaskStatus.
+type CheckResult struct {
+	Type CheckInfo_Type
+	// Available is false until the check has produced its first result, and whenever the check
+	// could not be performed (e.g. the check command timed out).
+	Available bool
+	// Succeeded is true if a COMMAND check exited w/ a zero status, an HTTP check yielded a 2xx or 3xx
+	// response, or a TCP check established a connection.
+	Succeeded  bool
+	ExitCode   int32  // COMMAND checks only
+	StatusCode uint32 // HTTP checks only
+}
+
+// CheckResult returns the result of the task's check, as reported by the status; false if the status
+// doesn't report a check result (e.g. the task doesn't specify a check).
+func (s *TaskStatus) CheckResult() (result CheckResult, ok bool) {
+	cs := s.GetCheckStatus()
+	if cs == nil {
+		return
+	}
+	result.Type = cs.GetType()
+	switch result.Type {
+	case CheckInfo_COMMAND:
+		if c := cs.GetCommand(); c != nil && c.ExitCode != nil {
+			result.Available = true
+			result.ExitCode = *c.ExitCode
+			result.Succeeded = result.ExitCode == 0
+		}
+	case CheckInfo_HTTP:
+		if c := cs.GetHTTP(); c != nil && c.StatusCode != nil {
+			result.Available = true
+			result.StatusCode = *c.StatusCode
+			result.Succeeded = result.StatusCode >= 200 && result.StatusCode < 400
+		}
+	case CheckInfo_TCP:
+		if c := cs.GetTCP(); c != nil && c.Succeeded != nil {
+			result.Available = true
+			result.Succeeded = *c.Succeeded
+		}
+	}
+	return result, true
+}
+
+// IsCheckUpdate returns true if the status was generated because the result of the task's check changed,
+// rather than because the state of the task changed.
+func (s *TaskStatus) IsCheckUpdate() bool {
+	return s.GetReason() == REASON_TASK_CHECK_STATUS_UPDATED
+}
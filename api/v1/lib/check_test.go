@@ -0,0 +1,61 @@
+package mesos_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestTaskStatus_CheckResult(t *testing.T) {
+	var (
+		zero, one   = int32(0), int32(1)
+		ok, teapot  = uint32(204), uint32(418)
+		connected   = true
+		status      = func(cs *mesos.CheckStatusInfo) *mesos.TaskStatus { return &mesos.TaskStatus{CheckStatus: cs} }
+		commandType = mesos.CheckInfo_COMMAND
+		httpType    = mesos.CheckInfo_HTTP
+		tcpType     = mesos.CheckInfo_TCP
+	)
+	for i, tc := range []struct {
+		status *mesos.TaskStatus
+		want   mesos.CheckResult
+		ok     bool
+	}{
+		{status: status(nil)},
+		{
+			status: status(&mesos.CheckStatusInfo{Type: &commandType, Command: &mesos.CheckStatusInfo_Command{}}),
+			want:   mesos.CheckResult{Type: commandType},
+			ok:     true,
+		},
+		{
+			status: status(&mesos.CheckStatusInfo{Type: &commandType, Command: &mesos.CheckStatusInfo_Command{ExitCode: &zero}}),
+			want:   mesos.CheckResult{Type: commandType, Available: true, Succeeded: true},
+			ok:     true,
+		},
+		{
+			status: status(&mesos.CheckStatusInfo{Type: &commandType, Command: &mesos.CheckStatusInfo_Command{ExitCode: &one}}),
+			want:   mesos.CheckResult{Type: commandType, Available: true, ExitCode: 1},
+			ok:     true,
+		},
+		{
+			status: status(&mesos.CheckStatusInfo{Type: &httpType, HTTP: &mesos.CheckStatusInfo_Http{StatusCode: &ok}}),
+			want:   mesos.CheckResult{Type: httpType, Available: true, Succeeded: true, StatusCode: ok},
+			ok:     true,
+		},
+		{
+			status: status(&mesos.CheckStatusInfo{Type: &httpType, HTTP: &mesos.CheckStatusInfo_Http{StatusCode: &teapot}}),
+			want:   mesos.CheckResult{Type: httpType, Available: true, StatusCode: teapot},
+			ok:     true,
+		},
+		{
+			status: status(&mesos.CheckStatusInfo{Type: &tcpType, TCP: &mesos.CheckStatusInfo_Tcp{Succeeded: &connected}}),
+			want:   mesos.CheckResult{Type: tcpType, Available: true, Succeeded: true},
+			ok:     true,
+		},
+	} {
+		got, ok := tc.status.CheckResult()
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("test case %d failed: expected (%+v, %v) instead of (%+v, %v)", i, tc.want, tc.ok, got, ok)
+		}
+	}
+}
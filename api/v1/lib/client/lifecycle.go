@@ -0,0 +1,55 @@
+package client
+
+type (
+	// Transition identifies a lifecycle transition of a client's subscription.
+	Transition int
+
+	// LifecycleEvent describes a lifecycle transition of a client.
+	LifecycleEvent struct {
+		Transition Transition
+		Endpoint   string // Endpoint is the API endpoint of the client, if known
+		Previous   string // Previous is the former endpoint of a Redirected client
+		StreamID   string // StreamID identifies the subscription stream, if any
+		Err        error  // Err is the cause of a disconnection, if any
+	}
+
+	// LifecycleListener is notified of the lifecycle transitions of a client. Notifications are delivered
+	// synchronously: listeners should return quickly and must not invoke the client that notifies them.
+	LifecycleListener interface {
+		Transition(LifecycleEvent)
+	}
+
+	// LifecycleListenerFunc is the functional adapter for LifecycleListener.
+	LifecycleListenerFunc func(LifecycleEvent)
+)
+
+const (
+	// Connected indicates that a subscription stream has been established.
+	Connected Transition = iota
+	// Subscribed indicates that the SUBSCRIBED event was received via the subscription stream.
+	Subscribed
+	// Disconnected indicates that a subscription stream has terminated.
+	Disconnected
+	// Redirected indicates that the client has been pointed at a different endpoint, for example
+	// because of a change of the leading master.
+	Redirected
+)
+
+var transitionNames = [...]string{"CONNECTED", "SUBSCRIBED", "DISCONNECTED", "REDIRECTED"}
+
+func (t Transition) String() string {
+	if t >= 0 && int(t) < len(transitionNames) {
+		return transitionNames[t]
+	}
+	return "UNKNOWN"
+}
+
+// Transition implements LifecycleListener.
+func (f LifecycleListenerFunc) Transition(e LifecycleEvent) { f(e) }
+
+// Notify sends the event to the given listener; a nil listener is ignored.
+func Notify(l LifecycleListener, e LifecycleEvent) {
+	if l != nil {
+		l.Transition(e)
+	}
+}
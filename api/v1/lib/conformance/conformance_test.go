@@ -0,0 +1,191 @@
+package conformance
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+type env struct {
+	master string
+	user   string
+	ctx    context.Context
+}
+
+// setup skips the test unless a master is configured, and returns the test environment along w/ a func
+// that releases it.
+func setup(t *testing.T) (*env, func()) {
+	master := strings.TrimSuffix(os.Getenv("MESOS_CONFORMANCE_MASTER"), "/")
+	if master == "" {
+		t.Skip("MESOS_CONFORMANCE_MASTER is not set")
+	}
+	e := &env{master: master, user: os.Getenv("MESOS_CONFORMANCE_USER")}
+	if e.user == "" {
+		e.user = "root"
+	}
+	timeout := 2 * time.Minute
+	if s := os.Getenv("MESOS_CONFORMANCE_TIMEOUT"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			t.Fatalf("illegal MESOS_CONFORMANCE_TIMEOUT: %v", err)
+		}
+		timeout = d
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	e.ctx = ctx
+	return e, cancel
+}
+
+var mediaTypes = []encoding.MediaType{codecs.MediaTypeProtobuf, codecs.MediaTypeJSON}
+
+func TestOperator(t *testing.T) {
+	e, done := setup(t)
+	defer done()
+
+	for _, mt := range mediaTypes {
+		cli := httpmaster.NewClient(httpmaster.NewSender(httpcli.New(
+			httpcli.Endpoint(e.master+"/api/v1"),
+			httpcli.Codec(codecs.ByMediaType[mt]),
+		).Send))
+
+		healthy, err := cli.GetHealth(e.ctx)
+		if err != nil || !healthy {
+			t.Fatalf("%s: GET_HEALTH: %v, %v", mt, healthy, err)
+		}
+		version, err := cli.GetVersion(e.ctx)
+		if err != nil || version.Version == "" {
+			t.Fatalf("%s: GET_VERSION: %v, %v", mt, version, err)
+		}
+		t.Logf("%s: master version %s", mt, version.Version)
+
+		if _, err = cli.GetState(e.ctx); err != nil {
+			t.Fatalf("%s: GET_STATE: %v", mt, err)
+		}
+		agents, err := cli.GetAgents(e.ctx)
+		if err != nil {
+			t.Fatalf("%s: GET_AGENTS: %v", mt, err)
+		}
+		if len(agents.Agents) == 0 {
+			t.Errorf("%s: GET_AGENTS: no agents registered", mt)
+		}
+		if _, err = cli.GetFrameworks(e.ctx); err != nil {
+			t.Fatalf("%s: GET_FRAMEWORKS: %v", mt, err)
+		}
+		if _, err = cli.GetRoles(e.ctx); err != nil {
+			t.Fatalf("%s: GET_ROLES: %v", mt, err)
+		}
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	e, done := setup(t)
+	defer done()
+
+	for _, mt := range mediaTypes {
+		testScheduler(t, e, mt)
+	}
+}
+
+func testScheduler(t *testing.T, e *env, mt encoding.MediaType) {
+	var (
+		caller = httpsched.NewCaller(httpcli.New(
+			httpcli.Endpoint(e.master+"/api/v1/scheduler"),
+			httpcli.Codec(codecs.ByMediaType[mt]),
+		))
+		info = &mesos.FrameworkInfo{Name: "mesos-go-conformance-" + string(mt), User: e.user}
+	)
+	resp, err := caller.Call(e.ctx, calls.Subscribe(info))
+	if err != nil {
+		t.Fatalf("%s: SUBSCRIBE: %v", mt, err)
+	}
+	defer resp.Close()
+
+	next := func(types ...scheduler.Event_Type) *scheduler.Event {
+		for {
+			var ev scheduler.Event
+			if err := resp.Decode(&ev); err != nil {
+				t.Fatalf("%s: failed to decode event: %v", mt, err)
+			}
+			for _, typ := range types {
+				if ev.GetType() == typ {
+					return &ev
+				}
+			}
+			if ev.GetType() == scheduler.Event_ERROR {
+				t.Fatalf("%s: ERROR event: %v", mt, ev.GetError().GetMessage())
+			}
+		}
+	}
+	call := func(c *scheduler.Call) {
+		if err := calls.CallNoData(e.ctx, caller, c); err != nil {
+			t.Fatalf("%s: %v: %v", mt, c.GetType(), err)
+		}
+	}
+
+	fid := next(scheduler.Event_SUBSCRIBED).GetSubscribed().GetFrameworkID().GetValue()
+	if fid == "" {
+		t.Fatalf("%s: SUBSCRIBED w/o a framework ID", mt)
+	}
+	framework := calls.Framework(fid)
+	defer call((&scheduler.Call{Type: scheduler.Call_TEARDOWN}).With(framework))
+
+	// launch a task w/ the first suitable offer, decline all others
+	var (
+		wanted = mesos.Resources{resources.NewCPUs(0.1).Resource, resources.NewMemory(32).Resource}
+		taskID = mesos.TaskID{Value: "conformance-" + fid}
+		agent  mesos.AgentID
+	)
+	for agent.Value == "" {
+		for _, o := range next(scheduler.Event_OFFERS).GetOffers().GetOffers() {
+			if agent.Value != "" || !resources.ContainsAll(o.Resources, wanted) {
+				call(calls.Decline(o.ID).With(framework))
+				continue
+			}
+			agent = o.AgentID
+			task := mesos.TaskInfo{
+				Name:      "conformance",
+				TaskID:    taskID,
+				AgentID:   agent,
+				Resources: wanted,
+				Command:   &mesos.CommandInfo{Value: func(s string) *string { return &s }("sleep 600")},
+			}
+			call(calls.Accept(calls.OfferOperations{calls.OpLaunch(task)}.WithOffers(o.ID)).With(framework))
+		}
+	}
+
+	// acknowledge status updates until the task reaches the given state
+	await := func(state mesos.TaskState) {
+		for {
+			status := next(scheduler.Event_UPDATE).GetUpdate().GetStatus()
+			if status.UUID != nil {
+				call(calls.Acknowledge(agent.Value, status.TaskID.Value, status.UUID).With(framework))
+			}
+			if status.TaskID != taskID {
+				continue
+			}
+			if status.GetState() == state {
+				return
+			}
+			if status.GetState().IsTerminal() {
+				t.Fatalf("%s: task %v terminated unexpectedly: %v", mt, taskID.Value, status)
+			}
+		}
+	}
+	await(mesos.TASK_RUNNING)
+
+	call(calls.Reconcile(calls.ReconcileTasks(map[string]string{taskID.Value: agent.Value})).With(framework))
+	call(calls.Kill(taskID.Value, agent.Value).With(framework))
+	await(mesos.TASK_KILLED)
+}
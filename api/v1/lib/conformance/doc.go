@@ -0,0 +1,15 @@
+// Package conformance holds a test suite that exercises mesos-go against a live Mesos cluster, in order
+// to catch wire-compatibility regressions against the Mesos versions that frameworks actually run.
+// The suite is skipped unless MESOS_CONFORMANCE_MASTER is set to the URL of a master, e.g.
+//
+//	MESOS_CONFORMANCE_MASTER=http://127.0.0.1:5050 go test -v ./api/v1/lib/conformance/
+//
+// Optional environment variables:
+//
+//	MESOS_CONFORMANCE_USER     the user of the test framework and its task (default "root")
+//	MESOS_CONFORMANCE_TIMEOUT  the time limit of the suite, as a time.Duration (default "2m")
+//
+// The scheduler suite subscribes a framework, launches a task w/ 0.1 cpus and 32MB of memory, kills it
+// and tears the framework down; the cluster should have an agent w/ enough unreserved resources. The
+// operator suite issues read-only calls only.
+package conformance
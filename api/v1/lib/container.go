@@ -0,0 +1,69 @@
+package mesos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContainerIDSeparator separates the values of the containers in the textual representation of a
+// nested ContainerID, for example "parent.child".
+const ContainerIDSeparator = "."
+
+// NewContainerID returns a top-level ContainerID w/ the given value.
+func NewContainerID(value string) ContainerID {
+	return ContainerID{Value: value}
+}
+
+// Child returns a ContainerID w/ the given value for a container that's nested within this one; see
+// NewChild to generate the value.
+func (c ContainerID) Child(value string) ContainerID {
+	return ContainerID{Value: value, Parent: &c}
+}
+
+// NewChild returns a ContainerID for a container that's nested within this one, w/ a random (version 4)
+// UUID for its value.
+func (c ContainerID) NewChild() (ContainerID, error) {
+	b, err := NewUUID()
+	if err != nil {
+		return ContainerID{}, err
+	}
+	return c.Child(FormatUUID(b)), nil
+}
+
+// IsNested returns true if the container is nested within another container.
+func (c *ContainerID) IsNested() bool {
+	return c.GetParent() != nil
+}
+
+// Root returns the ID of the top-level container that this container is nested within; returns this
+// container's ID if it's not nested.
+func (c *ContainerID) Root() ContainerID {
+	for c.IsNested() {
+		c = c.Parent
+	}
+	return *c
+}
+
+// Path returns the values of the container IDs from the top-level container down to this container.
+func (c *ContainerID) Path() (path []string) {
+	for ; c != nil; c = c.Parent {
+		path = append(path, c.Value)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return
+}
+
+// ParseContainerID parses the textual representation of a (nested) ContainerID, as produced by Path
+// joined w/ the ContainerIDSeparator.
+func ParseContainerID(s string) (ContainerID, error) {
+	var c *ContainerID
+	for _, value := range strings.Split(s, ContainerIDSeparator) {
+		if value == "" {
+			return ContainerID{}, fmt.Errorf("illegal container ID %q", s)
+		}
+		c = &ContainerID{Value: value, Parent: c}
+	}
+	return *c, nil
+}
@@ -0,0 +1,43 @@
+package mesos_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestContainerID(t *testing.T) {
+	var (
+		root  = mesos.NewContainerID("root")
+		child = root.Child("child")
+	)
+	nested, err := child.NewChild()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.IsNested() || !child.IsNested() || !nested.IsNested() {
+		t.Fatalf("unexpected nesting")
+	}
+	if nested.Value == "" || len(nested.Value) != 36 {
+		t.Fatalf("expected generated UUID instead of %q", nested.Value)
+	}
+	if r := nested.Root(); !r.Equal(&root) {
+		t.Fatalf("expected root %v instead of %v", root, r)
+	}
+	if p, expected := nested.Path(), []string{"root", "child", nested.Value}; !reflect.DeepEqual(p, expected) {
+		t.Fatalf("expected path %v instead of %v", expected, p)
+	}
+	parsed, err := mesos.ParseContainerID("root.child")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parsed.Equal(&child) {
+		t.Fatalf("expected %v instead of %v", child, parsed)
+	}
+	for _, s := range []string{"", "root.", ".child", "root..child"} {
+		if _, err = mesos.ParseContainerID(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package mesos
+
+import "encoding/json"
+
+// SetJSONData stores the JSON encoding of v as the opaque data of the task, for consumption by its
+// executor (see DecodeJSONData).
+func (t *TaskInfo) SetJSONData(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	t.Data = b
+	return nil
+}
+
+// DecodeJSONData decodes the opaque, JSON-encoded, data of the task into v.
+func (t *TaskInfo) DecodeJSONData(v interface{}) error {
+	return json.Unmarshal(t.GetData(), v)
+}
+
+// SetJSONData stores the JSON encoding of v as the opaque data of the status, for consumption by the
+// scheduler (see DecodeJSONData).
+func (s *TaskStatus) SetJSONData(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.Data = b
+	return nil
+}
+
+// DecodeJSONData decodes the opaque, JSON-encoded, data of the status into v.
+func (s *TaskStatus) DecodeJSONData(v interface{}) error {
+	return json.Unmarshal(s.GetData(), v)
+}
@@ -0,0 +1,65 @@
+package mesos_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestEnvironment(t *testing.T) {
+	var env mesos.Environment
+	env.Set("A", "1")
+	env.Set("B", "2")
+	env.Merge(&mesos.Environment{Variables: []mesos.Environment_Variable{
+		{Name: "A", Value: proto.String("3")},
+		{Name: "C", Value: proto.String("4")},
+	}})
+	var got []string
+	for _, v := range env.Variables {
+		got = append(got, v.Name+"="+v.GetValue())
+	}
+	if want := []string{"A=3", "B=2", "C=4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v instead of %v", want, got)
+	}
+	if v, ok := env.Get("C"); !ok || v != "4" {
+		t.Fatalf("unexpected value %q for C", v)
+	}
+	if _, ok := (*mesos.Environment)(nil).Get("A"); ok {
+		t.Fatal("unexpected variable for nil Environment")
+	}
+	env.SetSecret("B", &mesos.Secret{Type: mesos.Secret_REFERENCE})
+	if _, ok := env.Get("B"); ok || len(env.Variables) != 3 || env.Variables[1].GetSecret() == nil {
+		t.Fatalf("unexpected variables %v", env.Variables)
+	}
+}
+
+func TestJSONData(t *testing.T) {
+	type config struct {
+		Port  int
+		Peers []string
+	}
+	in := config{Port: 8080, Peers: []string{"a", "b"}}
+
+	var task mesos.TaskInfo
+	if err := task.SetJSONData(in); err != nil {
+		t.Fatal(err)
+	}
+	var out config
+	if err := task.DecodeJSONData(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("expected %v instead of %v", in, out)
+	}
+
+	var status mesos.TaskStatus
+	if err := status.SetJSONData(map[string]int{"progress": 50}); err != nil {
+		t.Fatal(err)
+	}
+	var progress map[string]int
+	if err := status.DecodeJSONData(&progress); err != nil || progress["progress"] != 50 {
+		t.Fatalf("unexpected data %v (%v)", progress, err)
+	}
+}
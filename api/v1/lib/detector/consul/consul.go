@@ -0,0 +1,204 @@
+// Package consul implements a master detector backed by Consul: the leading master is either recorded
+// in the KV store, or registered as a healthy instance of a service in the service catalog.
+//
+// The detector talks to the Consul HTTP API directly and uses blocking queries, so that leadership
+// changes are reported as soon as Consul observes them.
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+const (
+	// DefaultAddress is the default address of the Consul HTTP API.
+	DefaultAddress = "http://127.0.0.1:8500"
+
+	// DefaultWaitTime is the default maximum duration of a blocking query.
+	DefaultWaitTime = 5 * time.Minute
+
+	// DefaultRetryPeriod is the default amount of time to wait before retrying a failed query.
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+type (
+	// Detector watches the leading master in Consul.
+	Detector struct {
+		address     string
+		token       string
+		client      *http.Client
+		waitTime    time.Duration
+		retryPeriod time.Duration
+		path        string
+		query       url.Values
+		decode      func([]byte) (*mesos.MasterInfo, error)
+	}
+
+	// Opt is a functional option for a Detector.
+	Opt func(*Detector)
+
+	kvPair struct {
+		Value []byte
+	}
+
+	serviceEntry struct {
+		Node struct {
+			Address string
+		}
+		Service struct {
+			ID      string
+			Address string
+			Port    int
+		}
+	}
+)
+
+// Address returns an Opt that sets the address of the Consul HTTP API, e.g. "http://consul:8500".
+func Address(addr string) Opt { return func(d *Detector) { d.address = addr } }
+
+// Token returns an Opt that sets the ACL token that's presented to Consul.
+func Token(token string) Opt { return func(d *Detector) { d.token = token } }
+
+// HTTPClient returns an Opt that sets the HTTP client that's used to query Consul.
+func HTTPClient(c *http.Client) Opt { return func(d *Detector) { d.client = c } }
+
+// WaitTime returns an Opt that sets the maximum duration of a blocking query.
+func WaitTime(t time.Duration) Opt { return func(d *Detector) { d.waitTime = t } }
+
+// RetryPeriod returns an Opt that sets the amount of time to wait before retrying a failed query.
+func RetryPeriod(t time.Duration) Opt { return func(d *Detector) { d.retryPeriod = t } }
+
+// KV returns a Detector for the leading master that's recorded in the Consul KV store under the given
+// key, either as a JSON-encoded MasterInfo or as a "host:port" address.
+func KV(key string, opts ...Opt) *Detector {
+	d := newDetector("/v1/kv/"+key, url.Values{}, opts...)
+	d.decode = func(data []byte) (*mesos.MasterInfo, error) {
+		var pairs []kvPair
+		if err := json.Unmarshal(data, &pairs); err != nil {
+			return nil, err
+		}
+		if len(pairs) == 0 {
+			return nil, fmt.Errorf("consul key %q not found", key)
+		}
+		return detector.ParseMasterInfo(pairs[0].Value)
+	}
+	return d
+}
+
+// Service returns a Detector for the leading master that's registered w/ the Consul service catalog
+// as a healthy instance of the given service, w/ the given tag (for example, "leader").
+func Service(name, tag string, opts ...Opt) *Detector {
+	q := url.Values{"passing": {""}}
+	if tag != "" {
+		q.Set("tag", tag)
+	}
+	d := newDetector("/v1/health/service/"+name, q, opts...)
+	d.decode = func(data []byte) (*mesos.MasterInfo, error) {
+		var entries []serviceEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("no healthy instances of consul service %q", name)
+		}
+		e := entries[0]
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		return detector.MasterInfo(net.JoinHostPort(host, strconv.Itoa(e.Service.Port)))
+	}
+	return d
+}
+
+func newDetector(path string, query url.Values, opts ...Opt) *Detector {
+	d := &Detector{
+		address:     DefaultAddress,
+		client:      http.DefaultClient,
+		waitTime:    DefaultWaitTime,
+		retryPeriod: DefaultRetryPeriod,
+		path:        path,
+		query:       query,
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(d)
+		}
+	}
+	return d
+}
+
+// Leader queries Consul for the leading master. If index is non-zero then the query blocks until the
+// state of the leader changes beyond the given index, or until the wait time elapses. Returns the index
+// of the result.
+func (d *Detector) Leader(ctx context.Context, index uint64) (*mesos.MasterInfo, uint64, error) {
+	q := url.Values{}
+	for k, v := range d.query {
+		q[k] = v
+	}
+	if index > 0 {
+		q.Set("index", strconv.FormatUint(index, 10))
+		q.Set("wait", strconv.Itoa(int(d.waitTime/time.Second))+"s")
+	}
+	req, err := http.NewRequest("GET", d.address+d.path+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if d.token != "" {
+		req.Header.Set("X-Consul-Token", d.token)
+	}
+	res, err := d.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	newIndex, _ := strconv.ParseUint(res.Header.Get("X-Consul-Index"), 10, 64)
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		data = []byte("[]")
+	default:
+		return nil, newIndex, fmt.Errorf("unexpected response status %q from consul", res.Status)
+	}
+	mi, err := d.decode(data)
+	return mi, newIndex, err
+}
+
+// Detect implements detector.Master. It returns an error if the leader cannot be determined initially;
+// subsequent failures are retried.
+func (d *Detector) Detect(ctx context.Context) (<-chan mesos.MasterInfo, error) {
+	var index uint64
+	return detector.Poll(ctx,
+		func(ctx context.Context) (mi *mesos.MasterInfo, err error) {
+			var newIndex uint64
+			mi, newIndex, err = d.Leader(ctx, index)
+			if newIndex < index {
+				newIndex = 0 // the index went backwards, per Consul docs: reset it
+			}
+			index = newIndex
+			return
+		},
+		func(err error) time.Duration {
+			if err != nil {
+				return d.retryPeriod
+			}
+			return 0 // blocking queries wait for changes
+		})
+}
+
+var _ = detector.Master(&Detector{}) // sanity check
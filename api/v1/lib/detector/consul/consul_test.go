@@ -0,0 +1,73 @@
+package consul
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKV(t *testing.T) {
+	var index uint64 = 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/mesos/leader" || r.Header.Get("X-Consul-Token") != "secret" {
+			http.NotFound(w, r)
+			return
+		}
+		i := atomic.AddUint64(&index, 1)
+		if r.URL.Query().Get("index") != "" && r.URL.Query().Get("wait") != "1s" {
+			http.Error(w, "bad wait", http.StatusBadRequest)
+			return
+		}
+		leader := "m1:5050"
+		if i > 2 {
+			leader = `{"id":"m2","ip":0,"address":{"hostname":"m2","port":5051}}`
+		}
+		w.Header().Set("X-Consul-Index", fmt.Sprint(i))
+		fmt.Fprintf(w, `[{"Key":"mesos/leader","Value":%q}]`, base64.StdEncoding.EncodeToString([]byte(leader)))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := KV("mesos/leader", Address(srv.URL), Token("secret"), WaitTime(time.Second))
+	ch, err := d.Detect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"m1:5050", "m2:5051"} {
+		select {
+		case mi := <-ch:
+			if mi.HostPort() != expected {
+				t.Fatalf("expected %q instead of %v", expected, mi)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", expected)
+		}
+	}
+	if _, err = KV("missing", Address(srv.URL)).Detect(ctx); err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+}
+
+func TestService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/health/service/mesos" || q.Get("tag") != "leader" || q["passing"] == nil {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `[{"Node":{"Address":"10.0.0.1"},"Service":{"ID":"m1","Address":"","Port":5050}}]`)
+	}))
+	defer srv.Close()
+
+	mi, _, err := Service("mesos", "leader", Address(srv.URL)).Leader(context.Background(), 0)
+	if err != nil || mi.HostPort() != "10.0.0.1:5050" {
+		t.Fatalf("unexpected leader %v: %v", mi, err)
+	}
+}
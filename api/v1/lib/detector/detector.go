@@ -0,0 +1,23 @@
+package detector
+
+import (
+	"context"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type (
+	// Master detects the leading Mesos master.
+	Master interface {
+		// Detect returns a chan that yields the MasterInfo of the leading master upon every leadership
+		// change, starting w/ the current leader (if known). The chan is closed once the context is
+		// canceled. Returns an error if detection cannot be initiated.
+		Detect(context.Context) (<-chan mesos.MasterInfo, error)
+	}
+
+	// MasterFunc is the functional adaptation of the Master interface.
+	MasterFunc func(context.Context) (<-chan mesos.MasterInfo, error)
+)
+
+// Detect implements Master.
+func (f MasterFunc) Detect(ctx context.Context) (<-chan mesos.MasterInfo, error) { return f(ctx) }
@@ -0,0 +1,96 @@
+package detector_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+func leader(t *testing.T, m detector.Master) mesos.MasterInfo {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := m.Detect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return <-ch
+}
+
+func TestRegistry(t *testing.T) {
+	// masters report themselves as the leader, unless they're down
+	probe := func(_ context.Context, hostport string) (string, error) {
+		if strings.HasPrefix(hostport, "down:") {
+			return "", errors.New("connection refused")
+		}
+		return hostport, nil
+	}
+	r := detector.NewRegistry(detector.WithProber(probe))
+	for spec, expected := range map[string]string{
+		"m1":                  "m1:5050",
+		"m1:5051, m2:5051":    "m1:5051",
+		"down:5051,m2":        "m2:5050",
+		"10.0.0.1:5050":       "10.0.0.1:5050",
+		"[::1]:5050,m2":       "[::1]:5050",
+		" m1.example.com:80 ": "m1.example.com:80",
+	} {
+		m, err := r.New(spec)
+		if err != nil {
+			t.Errorf("spec %q: unexpected error: %v", spec, err)
+			continue
+		}
+		if mi := leader(t, m); mi.HostPort() != expected || mi.ID != expected {
+			t.Errorf("spec %q: expected %q instead of %v", spec, expected, mi)
+		}
+	}
+	for _, spec := range []string{"", "m1:x", ":5050", "m1,m2:x", "zk://zk1/mesos"} {
+		if _, err := r.New(spec); err == nil {
+			t.Errorf("spec %q: expected an error", spec)
+		}
+	}
+
+	errDial := errors.New("dial")
+	r.Register("zk", func(spec string) (detector.Master, error) { return nil, errDial })
+	if _, err := r.New("ZK://zk1/mesos"); err != errDial {
+		t.Errorf("expected the registered factory to be used instead of %v", err)
+	}
+
+	f, err := ioutil.TempFile("", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("m3:5050\n")
+	f.Close()
+
+	m, err := r.New("file://" + f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mi := leader(t, m); mi.HostPort() != "m3:5050" {
+		t.Errorf("unexpected master %v", mi)
+	}
+	if _, err := r.New("file:///does/not/exist"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestParseMasterInfo(t *testing.T) {
+	for data, expected := range map[string]string{
+		"m1:5051\n": "m1:5051",
+		`{"id":"x","ip":0,"port":5050,"hostname":"m2"}`: "m2:5050",
+	} {
+		mi, err := detector.ParseMasterInfo([]byte(data))
+		if err != nil || mi.HostPort() != expected {
+			t.Errorf("%q: unexpected result %v: %v", data, mi, err)
+		}
+	}
+	if _, err := detector.ParseMasterInfo([]byte("{")); err == nil {
+		t.Error("expected an error for malformed json")
+	}
+}
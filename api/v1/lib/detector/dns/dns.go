@@ -0,0 +1,204 @@
+// Package dns implements a master detector that resolves the leading Mesos master via DNS, for example
+// via the "leader.mesos" A record or the "_leader._tcp.mesos" SRV record that are served by Mesos-DNS.
+// It's useful for clusters in which ZooKeeper isn't reachable by frameworks.
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+const (
+	// DefaultInterval is the default period between DNS resolutions.
+	DefaultInterval = 10 * time.Second
+
+	// DefaultMinInterval is the default lower bound of the period between DNS resolutions; it applies
+	// when the TTL of a record is shorter than the resolution interval.
+	DefaultMinInterval = time.Second
+)
+
+type (
+	// Resolver resolves a DNS name to a list of "host:port" master addresses, the first of which is
+	// assumed to be the leader. A Resolver may also report the TTL of the resolved records; zero if
+	// unknown.
+	Resolver interface {
+		Resolve(ctx context.Context, name string) (hostports []string, ttl time.Duration, err error)
+	}
+
+	// ResolverFunc is the functional adaptation of the Resolver interface.
+	ResolverFunc func(ctx context.Context, name string) ([]string, time.Duration, error)
+
+	// Detector periodically resolves the leading master via DNS.
+	Detector struct {
+		name        string
+		resolver    Resolver
+		interval    time.Duration
+		minInterval time.Duration
+	}
+
+	// Opt is a functional option for a Detector.
+	Opt func(*Detector)
+)
+
+// Resolve implements Resolver.
+func (f ResolverFunc) Resolve(ctx context.Context, name string) ([]string, time.Duration, error) {
+	return f(ctx, name)
+}
+
+// HostResolver returns a Resolver that looks up the addresses (A or AAAA records) of a name; masters are
+// expected to listen on the given port. The standard library's resolver doesn't report TTLs.
+func HostResolver(r *net.Resolver, port int) Resolver {
+	return ResolverFunc(func(ctx context.Context, name string) ([]string, time.Duration, error) {
+		addrs, err := r.LookupHost(ctx, name)
+		if err != nil {
+			return nil, 0, err
+		}
+		result := make([]string, len(addrs))
+		for i, a := range addrs {
+			result[i] = net.JoinHostPort(a, strconv.Itoa(port))
+		}
+		return result, 0, nil
+	})
+}
+
+// SRVResolver returns a Resolver that looks up the SRV records of a name, for example
+// "_leader._tcp.mesos". Records are ordered by priority and randomized by weight. The standard
+// library's resolver doesn't report TTLs.
+func SRVResolver(r *net.Resolver) Resolver {
+	return ResolverFunc(func(ctx context.Context, name string) ([]string, time.Duration, error) {
+		_, srvs, err := r.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, 0, err
+		}
+		result := make([]string, len(srvs))
+		for i, srv := range srvs {
+			result[i] = net.JoinHostPort(trimDot(srv.Target), strconv.Itoa(int(srv.Port)))
+		}
+		return result, 0, nil
+	})
+}
+
+func trimDot(s string) string {
+	if n := len(s); n > 0 && s[n-1] == '.' {
+		return s[:n-1]
+	}
+	return s
+}
+
+// Interval returns an Opt that sets the period between DNS resolutions. Records that report a shorter
+// TTL are resolved again once the TTL expires.
+func Interval(d time.Duration) Opt {
+	return func(x *Detector) { x.interval = d }
+}
+
+// MinInterval returns an Opt that sets the lower bound of the period between DNS resolutions.
+func MinInterval(d time.Duration) Opt {
+	return func(x *Detector) { x.minInterval = d }
+}
+
+// WithResolver returns an Opt that sets the Resolver of a Detector.
+func WithResolver(r Resolver) Opt {
+	return func(x *Detector) { x.resolver = r }
+}
+
+// NewDetector returns a Detector that resolves the given name; by default the name is resolved to
+// addresses (A or AAAA records) of masters that listen on detector.DefaultPort.
+func NewDetector(name string, opts ...Opt) *Detector {
+	d := &Detector{
+		name:        name,
+		resolver:    HostResolver(net.DefaultResolver, detector.DefaultPort),
+		interval:    DefaultInterval,
+		minInterval: DefaultMinInterval,
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(d)
+		}
+	}
+	return d
+}
+
+// Factory returns a detector.Factory for URLs of the form "dns://name[:port]", which resolve the name to
+// addresses (A or AAAA records), and "srv://name", which resolve the name's SRV records. For example:
+//
+//	detector.DefaultRegistry.Register("dns", dns.Factory())
+//	detector.DefaultRegistry.Register("srv", dns.Factory())
+func Factory(opts ...Opt) detector.Factory {
+	return func(spec string) (detector.Master, error) {
+		u, err := url.Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		var resolver Resolver
+		switch u.Scheme {
+		case "dns":
+			port := detector.DefaultPort
+			if p := u.Port(); p != "" {
+				if port, err = strconv.Atoi(p); err != nil {
+					return nil, fmt.Errorf("illegal port in url %q", spec)
+				}
+			}
+			resolver = HostResolver(net.DefaultResolver, port)
+		case "srv":
+			resolver = SRVResolver(net.DefaultResolver)
+		default:
+			return nil, fmt.Errorf("unsupported url scheme %q", u.Scheme)
+		}
+		name := u.Hostname()
+		if name == "" {
+			return nil, fmt.Errorf("missing name in url %q", spec)
+		}
+		return NewDetector(name, append([]Opt{WithResolver(resolver)}, opts...)...), nil
+	}
+}
+
+// Leader resolves the leading master.
+func (d *Detector) Leader(ctx context.Context) (*mesos.MasterInfo, time.Duration, error) {
+	hostports, ttl, err := d.resolver.Resolve(ctx, d.name)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(hostports) == 0 {
+		return nil, 0, fmt.Errorf("no master records found for %q", d.name)
+	}
+	mi, err := detector.MasterInfo(hostports[0])
+	return mi, ttl, err
+}
+
+// Detect implements detector.Master. It returns an error if the leader cannot be resolved initially;
+// subsequent resolution errors are retried upon the next interval.
+func (d *Detector) Detect(ctx context.Context) (<-chan mesos.MasterInfo, error) {
+	var ttl time.Duration
+	return detector.Poll(ctx,
+		func(ctx context.Context) (mi *mesos.MasterInfo, err error) {
+			mi, ttl, err = d.Leader(ctx)
+			return
+		},
+		func(err error) time.Duration {
+			if err != nil {
+				return d.next(0)
+			}
+			return d.next(ttl)
+		})
+}
+
+// next returns the amount of time to wait before the next resolution.
+func (d *Detector) next(ttl time.Duration) time.Duration {
+	wait := d.interval
+	if ttl > 0 && ttl < wait {
+		wait = ttl
+	}
+	if wait < d.minInterval {
+		wait = d.minInterval
+	}
+	return wait
+}
+
+var _ = detector.Master(&Detector{}) // sanity check
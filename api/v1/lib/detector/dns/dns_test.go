@@ -0,0 +1,90 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDetect(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		answers = [][]string{
+			{"10.0.0.1:5050", "10.0.0.2:5050"},
+			nil, // resolution error, retried
+			{"10.0.0.1:5050"},
+			{"10.0.0.2:5050"},
+		}
+		resolver = ResolverFunc(func(_ context.Context, name string) ([]string, time.Duration, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if name != "leader.mesos" {
+				return nil, 0, errors.New("unexpected name " + name)
+			}
+			if len(answers) == 0 {
+				return []string{"10.0.0.2:5050"}, time.Millisecond, nil
+			}
+			a := answers[0]
+			answers = answers[1:]
+			if a == nil {
+				return nil, 0, errors.New("servfail")
+			}
+			return a, time.Millisecond, nil
+		})
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+
+	d := NewDetector("leader.mesos", WithResolver(resolver), Interval(time.Millisecond), MinInterval(time.Millisecond))
+	ch, err := d.Detect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"10.0.0.1:5050", "10.0.0.2:5050"} {
+		select {
+		case mi := <-ch:
+			if mi.HostPort() != expected {
+				t.Fatalf("expected %q instead of %q", expected, mi.HostPort())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", expected)
+		}
+	}
+	cancel()
+	for range ch {
+	}
+
+	if _, err := NewDetector("other", WithResolver(resolver)).Detect(context.Background()); err == nil {
+		t.Fatal("expected an initial resolution error")
+	}
+}
+
+func TestNext(t *testing.T) {
+	d := NewDetector("x", Interval(10*time.Second), MinInterval(time.Second))
+	for ttl, expected := range map[time.Duration]time.Duration{
+		0:                10 * time.Second,
+		time.Minute:      10 * time.Second,
+		3 * time.Second:  3 * time.Second,
+		time.Millisecond: time.Second,
+	} {
+		if wait := d.next(ttl); wait != expected {
+			t.Errorf("ttl %v: expected %v instead of %v", ttl, expected, wait)
+		}
+	}
+}
+
+func TestFactory(t *testing.T) {
+	f := Factory()
+	for _, spec := range []string{"dns://leader.mesos", "dns://leader.mesos:5051", "srv://_leader._tcp.mesos"} {
+		if _, err := f(spec); err != nil {
+			t.Errorf("spec %q: unexpected error: %v", spec, err)
+		}
+	}
+	for _, spec := range []string{"dns://leader.mesos:x", "zk://a/mesos", "dns:///x"} {
+		if _, err := f(spec); err == nil {
+			t.Errorf("spec %q: expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Package detector defines the interface that's implemented by Mesos master detectors, along w/ a
+// registry of detector factories keyed by URL scheme. Implementations register themselves w/ a
+// Registry so that frameworks may select a detector by way of a master specification string, as
+// accepted by the --master flag of Mesos itself:
+//
+//	host:port
+//	host1:port1,host2:port2,...
+//	zk://host1:port1,host2:port2,.../path
+//	file:///path/to/file (where the file contains one of the above)
+package detector
@@ -0,0 +1,118 @@
+// Package etcd implements a master detector backed by etcd: the leading master is recorded under a key,
+// either as a JSON-encoded MasterInfo or as a "host:port" address.
+//
+// The detector talks to the JSON gateway of the etcd v3 API directly and polls the key periodically.
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+const (
+	// DefaultEndpoint is the default address of the etcd v3 JSON gateway.
+	DefaultEndpoint = "http://127.0.0.1:2379/v3"
+
+	// DefaultInterval is the default period between reads of the key.
+	DefaultInterval = 5 * time.Second
+)
+
+type (
+	// Detector polls the leading master from etcd.
+	Detector struct {
+		key      string
+		endpoint string
+		token    string
+		client   *http.Client
+		interval time.Duration
+	}
+
+	// Opt is a functional option for a Detector.
+	Opt func(*Detector)
+
+	rangeRequest struct {
+		Key []byte `json:"key"`
+	}
+
+	rangeResponse struct {
+		KVs []struct {
+			Value []byte `json:"value"`
+		} `json:"kvs"`
+	}
+)
+
+// Endpoint returns an Opt that sets the address of the etcd v3 JSON gateway, including the API version
+// prefix (e.g. "http://etcd:2379/v3", or "http://etcd:2379/v3beta" for etcd 3.3).
+func Endpoint(ep string) Opt { return func(d *Detector) { d.endpoint = ep } }
+
+// Token returns an Opt that sets the authentication token that's presented to etcd.
+func Token(token string) Opt { return func(d *Detector) { d.token = token } }
+
+// HTTPClient returns an Opt that sets the HTTP client that's used to query etcd.
+func HTTPClient(c *http.Client) Opt { return func(d *Detector) { d.client = c } }
+
+// Interval returns an Opt that sets the period between reads of the key.
+func Interval(t time.Duration) Opt { return func(d *Detector) { d.interval = t } }
+
+// NewDetector returns a Detector for the leading master that's recorded under the given key.
+func NewDetector(key string, opts ...Opt) *Detector {
+	d := &Detector{
+		key:      key,
+		endpoint: DefaultEndpoint,
+		client:   http.DefaultClient,
+		interval: DefaultInterval,
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(d)
+		}
+	}
+	return d
+}
+
+// Leader reads the leading master from etcd.
+func (d *Detector) Leader(ctx context.Context) (*mesos.MasterInfo, error) {
+	body, err := json.Marshal(rangeRequest{Key: []byte(d.key)})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", d.endpoint+"/kv/range", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.token != "" {
+		req.Header.Set("Authorization", d.token)
+	}
+	res, err := d.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %q from etcd", res.Status)
+	}
+	var rr rangeResponse
+	if err = json.NewDecoder(res.Body).Decode(&rr); err != nil {
+		return nil, err
+	}
+	if len(rr.KVs) == 0 {
+		return nil, fmt.Errorf("etcd key %q not found", d.key)
+	}
+	return detector.ParseMasterInfo(rr.KVs[0].Value)
+}
+
+// Detect implements detector.Master. It returns an error if the leader cannot be read initially;
+// subsequent failures are retried upon the next interval.
+func (d *Detector) Detect(ctx context.Context) (<-chan mesos.MasterInfo, error) {
+	return detector.Poll(ctx, d.Leader, func(error) time.Duration { return d.interval })
+}
+
+var _ = detector.Master(&Detector{}) // sanity check
@@ -0,0 +1,42 @@
+package etcd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Key []byte }
+		if r.URL.Path != "/v3/kv/range" || json.NewDecoder(r.Body).Decode(&req) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if string(req.Key) != "/mesos/leader" {
+			fmt.Fprint(w, `{"header":{"revision":"7"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"header":{"revision":"7"},"kvs":[{"key":"L21lc29zL2xlYWRlcg==","value":%q}]}`,
+			base64.StdEncoding.EncodeToString([]byte("m1:5050")))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := NewDetector("/mesos/leader", Endpoint(srv.URL+"/v3")).Detect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mi := <-ch; mi.HostPort() != "m1:5050" {
+		t.Fatalf("unexpected leader %v", mi)
+	}
+	if _, err = NewDetector("/missing", Endpoint(srv.URL+"/v3")).Leader(ctx); err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+}
@@ -0,0 +1,66 @@
+package detector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// Poll returns a chan that yields the MasterInfo reported by the leader func, initially and then upon
+// every change of the leader's ID. The leader func is invoked repeatedly, waiting for the period that's
+// returned by the wait func between invocations; wait is given the error (if any) of the most recent
+// invocation of leader. The returned chan is closed once the context is canceled. An error is returned
+// if the initial invocation of the leader func fails. Poll simplifies the implementation of detectors.
+func Poll(ctx context.Context, leader func(context.Context) (*mesos.MasterInfo, error), wait func(error) time.Duration) (<-chan mesos.MasterInfo, error) {
+	mi, err := leader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan mesos.MasterInfo, 1)
+	ch <- *mi
+	go func() {
+		defer close(ch)
+		current := mi.ID
+		for {
+			t := time.NewTimer(wait(err))
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return
+			}
+			mi, err = leader(ctx)
+			if err != nil || mi.ID == current {
+				continue
+			}
+			select {
+			case ch <- *mi:
+				current = mi.ID
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// ParseMasterInfo decodes a leading master that's been recorded in some external store (for example, a
+// KV store): either a JSON-encoded MasterInfo, or a "host:port" address.
+func ParseMasterInfo(data []byte) (*mesos.MasterInfo, error) {
+	s := strings.TrimSpace(string(data))
+	if strings.HasPrefix(s, "{") {
+		mi := new(mesos.MasterInfo)
+		if err := json.Unmarshal([]byte(s), mi); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal json MasterInfo: %v", err)
+		}
+		if mi.ID == "" {
+			mi.ID = mi.HostPort()
+		}
+		return mi, nil
+	}
+	return MasterInfo(s)
+}
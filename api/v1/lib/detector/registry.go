@@ -0,0 +1,93 @@
+package detector
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type (
+	// Factory returns a Master detector for the given master specification, for example a URL.
+	Factory func(spec string) (Master, error)
+
+	// Registry is a concurrency-safe collection of detector Factory funcs, keyed by URL scheme.
+	Registry struct {
+		mu             sync.RWMutex
+		factories      map[string]Factory
+		standaloneOpts []StandaloneOpt
+	}
+)
+
+// DefaultRegistry supports the "file" scheme and lists of master addresses. Other detectors (for example
+// ZooKeeper) register their Factory w/ this registry, or w/ some other Registry, as needed.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns a Registry that supports the "file" scheme and lists of master addresses; the
+// options configure the Standalone detectors of lists that consist of more than one master.
+func NewRegistry(opts ...StandaloneOpt) *Registry {
+	r := &Registry{factories: make(map[string]Factory), standaloneOpts: opts}
+	r.Register("file", r.fromFile)
+	return r
+}
+
+// Register adds a Factory to the registry for the given URL scheme (e.g. "zk"), replacing any Factory
+// that was previously registered for the scheme.
+func (r *Registry) Register(scheme string, f Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[strings.ToLower(scheme)] = f
+}
+
+// New returns a Master detector for the given specification. A specification that's a URL is handled by
+// the Factory registered for the URL's scheme; otherwise the specification is interpreted as a comma-
+// separated list of master addresses. The leader among multiple masters is discovered by probing them (see
+// Standalone), while a single master is reported as is (see Static).
+func (r *Registry) New(spec string) (Master, error) {
+	spec = strings.TrimSpace(spec)
+	if i := strings.Index(spec, "://"); i >= 0 {
+		scheme := strings.ToLower(spec[:i])
+		r.mu.RLock()
+		f, ok := r.factories[scheme]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("no master detector registered for scheme %q", scheme)
+		}
+		return f(spec)
+	}
+	var hostports []string
+	for _, hp := range strings.Split(spec, ",") {
+		if hp = strings.TrimSpace(hp); hp != "" {
+			mi, err := MasterInfo(hp)
+			if err != nil {
+				return nil, err
+			}
+			hostports = append(hostports, mi.HostPort())
+		}
+	}
+	if len(hostports) > 1 {
+		return Standalone(hostports, r.standaloneOpts...)
+	}
+	return Static(hostports...)
+}
+
+// fromFile reads a master specification from the file named by a "file://" URL.
+func (r *Registry) fromFile(spec string) (Master, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(u.Path)
+	if err != nil {
+		return nil, err
+	}
+	nested := strings.TrimSpace(string(data))
+	if strings.HasPrefix(strings.ToLower(nested), "file://") {
+		return nil, fmt.Errorf("master specification in %q refers to another file", u.Path)
+	}
+	return r.New(nested)
+}
+
+// New returns a Master detector for the given specification, as resolved by DefaultRegistry.
+func New(spec string) (Master, error) { return DefaultRegistry.New(spec) }
@@ -0,0 +1,108 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// DefaultProbeInterval is the default period between probes of a Standalone detector.
+const DefaultProbeInterval = 10 * time.Second
+
+type (
+	// Prober probes the master at the given "host:port" address and returns the address of the leading
+	// master, as known by the probed master.
+	Prober func(ctx context.Context, hostport string) (leader string, err error)
+
+	// StandaloneDetector discovers the leading master among a static list of masters by probing them.
+	StandaloneDetector struct {
+		hostports []string
+		probe     Prober
+		interval  time.Duration
+	}
+
+	// StandaloneOpt is a functional option for a StandaloneDetector.
+	StandaloneOpt func(*StandaloneDetector)
+)
+
+// ProbeInterval returns a StandaloneOpt that sets the period between probes. Masters are re-probed upon
+// every interval so that leadership changes are detected.
+func ProbeInterval(d time.Duration) StandaloneOpt {
+	return func(s *StandaloneDetector) { s.interval = d }
+}
+
+// WithProber returns a StandaloneOpt that sets the Prober of a StandaloneDetector.
+func WithProber(p Prober) StandaloneOpt {
+	return func(s *StandaloneDetector) { s.probe = p }
+}
+
+// Standalone returns a detector that probes the masters at the given "host:port" addresses, in order,
+// until one of them reports the leader. Masters that fail to respond are skipped. By default masters
+// are probed w/ HTTPProber(http.DefaultTransport).
+func Standalone(hostports []string, opts ...StandaloneOpt) (*StandaloneDetector, error) {
+	if len(hostports) == 0 {
+		return nil, fmt.Errorf("no master addresses specified")
+	}
+	s := &StandaloneDetector{
+		hostports: hostports,
+		probe:     HTTPProber(http.DefaultTransport),
+		interval:  DefaultProbeInterval,
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(s)
+		}
+	}
+	return s, nil
+}
+
+// HTTPProber returns a Prober that issues a GET request for the "/master/redirect" endpoint of the
+// probed master, which responds w/ a redirect to the leading master; a successful response indicates
+// that the probed master is both healthy and aware of the leader.
+func HTTPProber(rt http.RoundTripper) Prober {
+	return func(ctx context.Context, hostport string) (string, error) {
+		req, err := http.NewRequest("GET", "http://"+hostport+"/master/redirect", nil)
+		if err != nil {
+			return "", err
+		}
+		res, err := rt.RoundTrip(req.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusTemporaryRedirect {
+			return "", fmt.Errorf("unexpected response status %q from master %q", res.Status, hostport)
+		}
+		// current format appears to be //x.y.z.w:port
+		u, err := url.Parse(res.Header.Get("Location"))
+		if err != nil || u.Host == "" {
+			return "", fmt.Errorf("master %q failed to report a leader", hostport)
+		}
+		return u.Host, nil
+	}
+}
+
+// Leader probes the masters and returns the leader, as reported by the first master that responds.
+func (s *StandaloneDetector) Leader(ctx context.Context) (*mesos.MasterInfo, error) {
+	var err error
+	for _, hp := range s.hostports {
+		var leader string
+		if leader, err = s.probe(ctx, hp); err == nil {
+			return MasterInfo(leader)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, fmt.Errorf("failed to probe masters: %v", err)
+}
+
+// Detect implements Master. It returns an error if none of the masters respond initially; subsequent
+// probe failures are retried upon the next interval.
+func (s *StandaloneDetector) Detect(ctx context.Context) (<-chan mesos.MasterInfo, error) {
+	return Poll(ctx, s.Leader, func(error) time.Duration { return s.interval })
+}
@@ -0,0 +1,74 @@
+package detector_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+func TestStandalone(t *testing.T) {
+	var leader atomic.Value
+	leader.Store("m1:5050")
+	probe := func(_ context.Context, hostport string) (string, error) {
+		if hostport == "down:5050" {
+			return "", errors.New("connection refused")
+		}
+		return leader.Load().(string), nil
+	}
+	s, err := detector.Standalone([]string{"down:5050", "m2:5050"},
+		detector.WithProber(probe), detector.ProbeInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := s.Detect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mi := <-ch; mi.HostPort() != "m1:5050" {
+		t.Fatalf("unexpected leader %v", mi)
+	}
+	leader.Store("m2:5050")
+	select {
+	case mi := <-ch:
+		if mi.HostPort() != "m2:5050" {
+			t.Fatalf("unexpected leader %v", mi)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a leadership change")
+	}
+
+	if _, err = detector.Standalone(nil); err == nil {
+		t.Fatal("expected an error for an empty list of masters")
+	}
+	s, _ = detector.Standalone([]string{"down:5050"}, detector.WithProber(probe))
+	if _, err = s.Detect(ctx); err == nil {
+		t.Fatal("expected an error when no master responds")
+	}
+}
+
+func TestHTTPProber(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/master/redirect" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Location", "//10.0.0.1:5050")
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	probe := detector.HTTPProber(http.DefaultTransport)
+	leader, err := probe(context.Background(), strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil || leader != "10.0.0.1:5050" {
+		t.Fatalf("unexpected leader %q: %v", leader, err)
+	}
+}
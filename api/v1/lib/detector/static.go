@@ -0,0 +1,72 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// DefaultPort is the port assumed for a master address that doesn't specify one.
+const DefaultPort = 5050
+
+// Static returns a Master detector that reports a fixed master, the first of the given "host:port"
+// addresses; the port is optional. Non-leading masters redirect API calls to the leader, so a static
+// detector is sufficient for clients that follow redirects (e.g. httpsched).
+func Static(hostports ...string) (Master, error) {
+	if len(hostports) == 0 {
+		return nil, fmt.Errorf("no master addresses specified")
+	}
+	infos := make([]mesos.MasterInfo, 0, len(hostports))
+	for _, hp := range hostports {
+		mi, err := MasterInfo(hp)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, *mi)
+	}
+	return MasterFunc(func(ctx context.Context) (<-chan mesos.MasterInfo, error) {
+		ch := make(chan mesos.MasterInfo, 1)
+		ch <- infos[0]
+		go func() {
+			<-ctx.Done()
+			close(ch)
+		}()
+		return ch, nil
+	}), nil
+}
+
+// MasterInfo returns a MasterInfo for the master at the given "host:port" address; the port is optional
+// and defaults to DefaultPort. The ID of the returned MasterInfo is the address itself.
+func MasterInfo(hostport string) (*mesos.MasterInfo, error) {
+	hostport = strings.TrimSpace(hostport)
+	host, port := hostport, DefaultPort
+	if h, p, err := net.SplitHostPort(hostport); err == nil {
+		n, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("illegal port in master address %q", hostport)
+		}
+		host, port = h, int(n)
+	}
+	if host == "" {
+		return nil, fmt.Errorf("illegal master address %q", hostport)
+	}
+	var (
+		address = &mesos.Address{Port: int32(port)}
+		p       = uint32(port)
+	)
+	if net.ParseIP(host) != nil {
+		address.IP = &host
+	} else {
+		address.Hostname = &host
+	}
+	return &mesos.MasterInfo{
+		ID:       net.JoinHostPort(host, strconv.Itoa(port)),
+		Port:     &p,
+		Hostname: address.Hostname,
+		Address:  address,
+	}, nil
+}
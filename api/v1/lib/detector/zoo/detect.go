@@ -0,0 +1,234 @@
+package zoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+const (
+	nodePrefix     = "info_"
+	nodeJSONPrefix = "json.info_"
+
+	// DefaultRetryPeriod is the default amount of time that a Detector waits before retrying a failed
+	// ZooKeeper operation.
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+var (
+	// ErrNoLeader is returned by Leader when there are no participants in the master group.
+	ErrNoLeader = errors.New("no leading master")
+)
+
+type (
+	// Conn is the subset of a ZooKeeper client connection that's required by a Detector.
+	Conn interface {
+		// ChildrenW returns the names of the children of the znode at the given path, along with a
+		// watch chan that's closed (or yields) once the children of the znode change.
+		ChildrenW(ctx context.Context, path string) ([]string, <-chan struct{}, error)
+
+		// Get returns the data of the znode at the given path.
+		Get(ctx context.Context, path string) ([]byte, error)
+	}
+
+	// Detector watches a group of Mesos masters in ZooKeeper and reports leadership changes.
+	Detector struct {
+		conn           Conn
+		path           string
+		retryPeriod    time.Duration
+		minCyclePeriod time.Duration
+		dialConfig     DialConfig // see Factory
+	}
+
+	// Opt is a functional option for a Detector.
+	Opt func(*Detector)
+)
+
+// RetryPeriod returns an Opt that sets the amount of time to wait before retrying a failed ZooKeeper
+// operation.
+func RetryPeriod(d time.Duration) Opt {
+	return func(d2 *Detector) { d2.retryPeriod = d }
+}
+
+// MinCyclePeriod returns an Opt that limits the frequency of leadership change notifications; useful to
+// dampen the effects of a flapping master group.
+func MinCyclePeriod(d time.Duration) Opt {
+	return func(d2 *Detector) { d2.minCyclePeriod = d }
+}
+
+// NewDetector returns a Detector that watches the master group at the given path (e.g. "/mesos").
+func NewDetector(conn Conn, path string, opts ...Opt) *Detector {
+	d := &Detector{
+		conn:        conn,
+		path:        path,
+		retryPeriod: DefaultRetryPeriod,
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(d)
+		}
+	}
+	return d
+}
+
+// ParseURL parses a ZooKeeper URL of the form "zk://host1:port1,host2:port2/path" and returns the
+// ZooKeeper servers and the path of the master group.
+func ParseURL(zkurl string) (servers []string, group string, err error) {
+	u, err := url.Parse(zkurl)
+	if err != nil {
+		return nil, "", err
+	}
+	if u.Scheme != "zk" {
+		return nil, "", fmt.Errorf("invalid url scheme for zk url: %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, "", fmt.Errorf("missing zk servers in url %q", zkurl)
+	}
+	if u.Path == "" || u.Path == "/" {
+		return nil, "", fmt.Errorf("missing zk path in url %q", zkurl)
+	}
+	return strings.Split(u.Host, ","), u.Path, nil
+}
+
+// Leader returns the MasterInfo of the leading master, as currently recorded in ZooKeeper.
+func (d *Detector) Leader(ctx context.Context) (*mesos.MasterInfo, error) {
+	children, _, err := d.conn.ChildrenW(ctx, d.path)
+	if err != nil {
+		return nil, err
+	}
+	return d.leader(ctx, children)
+}
+
+// Detect watches the master group and returns a chan that yields the MasterInfo of the leading master
+// upon every leadership change, starting w/ the current leader (if any). Errors encountered while
+// watching the group are retried; the returned chan is closed once the context is canceled. An error is
+// returned if the master group can't be read initially.
+func (d *Detector) Detect(ctx context.Context) (<-chan mesos.MasterInfo, error) {
+	children, watch, err := d.conn.ChildrenW(ctx, d.path)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan mesos.MasterInfo)
+	go d.detect(ctx, ch, children, watch)
+	return ch, nil
+}
+
+func (d *Detector) detect(ctx context.Context, ch chan<- mesos.MasterInfo, children []string, watch <-chan struct{}) {
+	defer close(ch)
+	var (
+		current  string // ID of the most recently reported leader
+		lastSent time.Time
+		err      error
+	)
+	for {
+		if watch != nil {
+			mi, err := d.leader(ctx, children)
+			if err == nil && mi.ID != current {
+				if wait := d.minCyclePeriod - time.Since(lastSent); !lastSent.IsZero() && wait > 0 {
+					if !sleep(ctx, wait) {
+						return
+					}
+				}
+				select {
+				case ch <- *mi:
+					current, lastSent = mi.ID, time.Now()
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil && err != ErrNoLeader {
+				// the leader's znode may have vanished in the meantime; re-read the group
+				watch = nil
+			}
+		}
+		if watch != nil {
+			select {
+			case <-watch:
+			case <-ctx.Done():
+				return
+			}
+		} else if !sleep(ctx, d.retryPeriod) {
+			return
+		}
+		children, watch, err = d.conn.ChildrenW(ctx, d.path)
+		if err != nil {
+			watch = nil
+		}
+	}
+}
+
+func (d *Detector) leader(ctx context.Context, children []string) (*mesos.MasterInfo, error) {
+	// mesos v0.24 writes JSON only, v0.23 writes json and protobuf, v0.22 and prior only write protobuf
+	if node := selectTopNode(children, nodeJSONPrefix); node != "" {
+		data, err := d.conn.Get(ctx, path.Join(d.path, node))
+		if err != nil {
+			return nil, err
+		}
+		return DecodeJSON(data)
+	}
+	if node := selectTopNode(children, nodePrefix); node != "" {
+		data, err := d.conn.Get(ctx, path.Join(d.path, node))
+		if err != nil {
+			return nil, err
+		}
+		return DecodeProtobuf(data)
+	}
+	return nil, ErrNoLeader
+}
+
+// DecodeJSON decodes a MasterInfo that was written to ZooKeeper by Mesos 0.23 or later.
+func DecodeJSON(data []byte) (*mesos.MasterInfo, error) {
+	mi := new(mesos.MasterInfo)
+	if err := json.Unmarshal(data, mi); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal json MasterInfo data from zookeeper: %v", err)
+	}
+	return mi, nil
+}
+
+// DecodeProtobuf decodes a MasterInfo that was written to ZooKeeper by Mesos 0.23 or earlier.
+func DecodeProtobuf(data []byte) (*mesos.MasterInfo, error) {
+	mi := new(mesos.MasterInfo)
+	if err := mi.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal protobuf MasterInfo data from zookeeper: %v", err)
+	}
+	return mi, nil
+}
+
+// selectTopNode returns the name of the node w/ the given prefix that has the lowest sequence number.
+func selectTopNode(children []string, prefix string) (node string) {
+	var leaderSeq uint64 = math.MaxUint64
+	for _, v := range children {
+		if !strings.HasPrefix(v, prefix) {
+			continue // only care about participants
+		}
+		seq, err := strconv.ParseUint(strings.TrimPrefix(v, prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		if seq < leaderSeq {
+			leaderSeq = seq
+			node = v
+		}
+	}
+	return
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+package zoo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type fakeConn struct {
+	sync.Mutex
+	nodes map[string][]byte // keyed by child name
+	watch chan struct{}
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{nodes: make(map[string][]byte), watch: make(chan struct{})}
+}
+
+func (c *fakeConn) ChildrenW(_ context.Context, path string) ([]string, <-chan struct{}, error) {
+	c.Lock()
+	defer c.Unlock()
+	if path != "/mesos" {
+		return nil, nil, errors.New("no node")
+	}
+	var children []string
+	for k := range c.nodes {
+		children = append(children, k)
+	}
+	return children, c.watch, nil
+}
+
+func (c *fakeConn) Get(_ context.Context, path string) ([]byte, error) {
+	c.Lock()
+	defer c.Unlock()
+	data, ok := c.nodes[path[len("/mesos/"):]]
+	if !ok {
+		return nil, errors.New("no node")
+	}
+	return data, nil
+}
+
+func (c *fakeConn) set(name string, data []byte) {
+	c.Lock()
+	defer c.Unlock()
+	if data == nil {
+		delete(c.nodes, name)
+	} else {
+		c.nodes[name] = data
+	}
+	close(c.watch)
+	c.watch = make(chan struct{})
+}
+
+func TestParseURL(t *testing.T) {
+	servers, group, err := ParseURL("zk://a:2181,b:2181/mesos")
+	if err != nil || len(servers) != 2 || servers[1] != "b:2181" || group != "/mesos" {
+		t.Fatalf("unexpected result %v %q %v", servers, group, err)
+	}
+	for _, u := range []string{"http://a/mesos", "zk:///mesos", "zk://a:2181"} {
+		if _, _, err := ParseURL(u); err == nil {
+			t.Errorf("expected an error for %q", u)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	conn := newFakeConn()
+	conn.set("json.info_0000000002", []byte(`{"id":"m2","ip":0,"port":5050,"address":{"hostname":"m2.local","port":5050}}`))
+	conn.set("json.info_0000000003", []byte(`{"id":"m3","ip":0,"port":5050}`))
+	conn.set("log_replicas", []byte("x"))
+
+	pb, err := (&mesos.MasterInfo{ID: "m1", IP: 0x7f000001, Port: proto32(5051)}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.set("info_0000000001", pb)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewDetector(conn, "/mesos", RetryPeriod(time.Millisecond))
+	if _, err := NewDetector(conn, "/missing").Detect(ctx); err == nil {
+		t.Fatal("expected an error for a missing group")
+	}
+	ch, err := d.Detect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := func(id, hostport string) {
+		select {
+		case mi := <-ch:
+			if mi.ID != id || mi.HostPort() != hostport {
+				t.Fatalf("expected leader %q (%s) instead of %q (%s)", id, hostport, mi.ID, mi.HostPort())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for leader %q", id)
+		}
+	}
+	// JSON participants take precedence over protobuf participants
+	expect("m2", "m2.local:5050")
+
+	conn.set("json.info_0000000002", nil)
+	expect("m3", "")
+
+	conn.set("json.info_0000000003", nil)
+	expect("m1", "127.0.0.1:5051")
+
+	if mi, err := d.Leader(ctx); err != nil || mi.ID != "m1" {
+		t.Fatalf("unexpected leader %v: %v", mi, err)
+	}
+	conn.set("info_0000000001", nil)
+	if _, err := d.Leader(ctx); err != ErrNoLeader {
+		t.Fatalf("expected ErrNoLeader instead of %v", err)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func proto32(x uint32) *uint32 { return &x }
+
+func TestFactory(t *testing.T) {
+	var (
+		dialed []string
+		config DialConfig
+		tc     = &tls.Config{ServerName: "zk"}
+	)
+	f := Factory(func(servers []string, c DialConfig) (Conn, error) {
+		dialed, config = servers, c
+		return newFakeConn(), nil
+	}, DigestAuth("u", "p"), TLS(tc))
+	m, err := f("zk://a:2181,b:2181/mesos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := m.(*Detector); d.path != "/mesos" || len(dialed) != 2 {
+		t.Fatalf("unexpected detector %v, dialed %v", d, dialed)
+	}
+	if len(config.Credentials) != 1 || config.Credentials[0].Scheme != "digest" ||
+		string(config.Credentials[0].Auth) != "u:p" || config.TLS != tc {
+		t.Fatalf("unexpected dial config %v", config)
+	}
+	if _, err = f("zk://a:2181"); err == nil {
+		t.Fatal("expected an error for a url w/o a path")
+	}
+}
@@ -0,0 +1,11 @@
+// Package zoo implements a master detector that tracks the leading Mesos master via ZooKeeper.
+//
+// Mesos masters participate in a leader election by creating ephemeral, sequential znodes beneath a
+// common group path (e.g. "/mesos"); the participant w/ the lowest sequence number is the leader. The
+// MasterInfo of each participant is stored as the data of its znode: Mesos 0.24 and later write JSON
+// ("json.info_" nodes), earlier versions write protobuf ("info_" nodes).
+//
+// This package doesn't depend upon a particular ZooKeeper client library: a Conn adapter is expected
+// to bridge the Detector and the client of choice. Likewise, the DialFunc of Factory establishes the
+// connection and is responsible for securing it as requested by its DialConfig.
+package zoo
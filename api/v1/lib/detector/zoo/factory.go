@@ -0,0 +1,74 @@
+package zoo
+
+import (
+	"crypto/tls"
+
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+type (
+	// DialFunc returns a connection to the given ZooKeeper servers. It's implemented by the adapter of
+	// the ZooKeeper client of choice, and is responsible for securing the connection as requested by the
+	// DialConfig; this package can't do so on its own.
+	DialFunc func(servers []string, config DialConfig) (Conn, error)
+
+	// DialConfig requests that a connection to ZooKeeper be secured; it's only a description, which the
+	// DialFunc of Factory is expected to apply.
+	DialConfig struct {
+		// Credentials should be added to the ZooKeeper session once it's established.
+		Credentials []Credential
+
+		// TLS, if not nil, is the configuration that TLS connections to the ZooKeeper servers should use.
+		TLS *tls.Config
+	}
+
+	// Credential is an authentication scheme along w/ the scheme-specific authentication data; it
+	// corresponds to the arguments of the ZooKeeper "addauth" command.
+	Credential struct {
+		Scheme string
+		Auth   []byte
+	}
+)
+
+// DigestAuth returns an Opt that adds a credential of the "digest" scheme, w/ the given user and password,
+// to the DialConfig that Factory passes to its DialFunc. The session is only authenticated if the DialFunc
+// adds the credential to it (e.g. via the "addauth" call of the client library); the Opt has no effect
+// upon a Detector created by NewDetector.
+func DigestAuth(user, password string) Opt {
+	return func(d *Detector) {
+		d.dialConfig.Credentials = append(d.dialConfig.Credentials, Credential{
+			Scheme: "digest",
+			Auth:   []byte(user + ":" + password),
+		})
+	}
+}
+
+// TLS returns an Opt that sets the TLS configuration (see httpcli.NewTLSConfig) of the DialConfig that
+// Factory passes to its DialFunc. Connections are only secured if the DialFunc dials the servers w/ it; the
+// Opt has no effect upon a Detector created by NewDetector.
+func TLS(tc *tls.Config) Opt {
+	return func(d *Detector) { d.dialConfig.TLS = tc }
+}
+
+var _ = detector.Master(&Detector{}) // sanity check
+
+// Factory returns a detector.Factory for "zk://" URLs that connects to ZooKeeper via the given DialFunc.
+// The DialFunc is given the DialConfig that's specified by the options (see DigestAuth and TLS), and must
+// apply it; DialFuncs that don't support authentication or TLS should fail when it's requested, rather
+// than connect insecurely.
+// For example:
+//
+//	detector.DefaultRegistry.Register("zk", zoo.Factory(dial))
+func Factory(dial DialFunc, opts ...Opt) detector.Factory {
+	return func(spec string) (detector.Master, error) {
+		servers, group, err := ParseURL(spec)
+		if err != nil {
+			return nil, err
+		}
+		d := NewDetector(nil, group, opts...)
+		if d.conn, err = dial(servers, d.dialConfig); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+}
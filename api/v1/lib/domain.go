@@ -0,0 +1,40 @@
+package mesos
+
+// NewDomainInfo returns a DomainInfo for the given fault domain.
+func NewDomainInfo(region, zone string) *DomainInfo {
+	return &DomainInfo{FaultDomain: &DomainInfo_FaultDomain{
+		Region: DomainInfo_FaultDomain_RegionInfo{Name: region},
+		Zone:   DomainInfo_FaultDomain_ZoneInfo{Name: zone},
+	}}
+}
+
+// Region returns the name of the fault domain region; empty if no fault domain is specified.
+func (d *DomainInfo) Region() string {
+	if fd := d.GetFaultDomain(); fd != nil {
+		return fd.Region.Name
+	}
+	return ""
+}
+
+// Zone returns the name of the fault domain zone; empty if no fault domain is specified.
+func (d *DomainInfo) Zone() string {
+	if fd := d.GetFaultDomain(); fd != nil {
+		return fd.Zone.Name
+	}
+	return ""
+}
+
+// IsRemote returns true if the domain is in a different region than the local domain, usually that of
+// the master. As in Mesos, if either domain is unspecified then the domain is considered local.
+func (d *DomainInfo) IsRemote(local *DomainInfo) bool {
+	r, l := d.Region(), local.Region()
+	return r != "" && l != "" && r != l
+}
+
+// AddCapability declares the given capability for the framework, unless it's already declared.
+// For example, frameworks that accept offers from remote regions must declare REGION_AWARE.
+func (fi *FrameworkInfo) AddCapability(t FrameworkInfo_Capability_Type) {
+	if !fi.HasCapability(t) {
+		fi.Capabilities = append(fi.Capabilities, FrameworkInfo_Capability{Type: t})
+	}
+}
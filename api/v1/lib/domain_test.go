@@ -0,0 +1,39 @@
+package mesos_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestDomainInfo(t *testing.T) {
+	var (
+		east  = mesos.NewDomainInfo("east", "a")
+		east2 = mesos.NewDomainInfo("east", "b")
+		west  = mesos.NewDomainInfo("west", "a")
+		none  *mesos.DomainInfo
+	)
+	if east.Region() != "east" || east.Zone() != "a" || none.Region() != "" || none.Zone() != "" {
+		t.Fatal("unexpected region or zone")
+	}
+	for i, tc := range []struct {
+		d, local *mesos.DomainInfo
+		remote   bool
+	}{
+		{east, east2, false},
+		{east, west, true},
+		{none, west, false},
+		{east, none, false},
+	} {
+		if remote := tc.d.IsRemote(tc.local); remote != tc.remote {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.remote, remote)
+		}
+	}
+
+	var fi mesos.FrameworkInfo
+	fi.AddCapability(mesos.FrameworkInfo_Capability_REGION_AWARE)
+	fi.AddCapability(mesos.FrameworkInfo_Capability_REGION_AWARE)
+	if len(fi.Capabilities) != 1 || !fi.HasCapability(mesos.FrameworkInfo_Capability_REGION_AWARE) {
+		t.Fatalf("unexpected capabilities %v", fi.Capabilities)
+	}
+}
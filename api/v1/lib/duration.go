@@ -0,0 +1,18 @@
+package mesos
+
+import "time"
+
+// NewDurationInfo returns a DurationInfo that represents the given duration.
+func NewDurationInfo(d time.Duration) *DurationInfo {
+	return &DurationInfo{Nanoseconds: int64(d)}
+}
+
+// Duration returns the duration represented by the DurationInfo; zero if d is nil.
+func (d *DurationInfo) Duration() time.Duration {
+	return time.Duration(d.GetNanoseconds())
+}
+
+// NewKillPolicy returns a KillPolicy w/ the given grace period.
+func NewKillPolicy(gracePeriod time.Duration) *KillPolicy {
+	return &KillPolicy{GracePeriod: NewDurationInfo(gracePeriod)}
+}
@@ -31,3 +31,7 @@ var ByMediaType = map[encoding.MediaType]encoding.Codec{
 		NewDecoder: json.NewDecoder,
 	},
 }
+
+// DefaultRegistry contains the pre-configured default Codecs. Clients consult it in order to decode
+// responses w/ a media type that differs from the one that was requested.
+var DefaultRegistry = encoding.NewRegistry(ByMediaType[MediaTypeProtobuf], ByMediaType[MediaTypeJSON])
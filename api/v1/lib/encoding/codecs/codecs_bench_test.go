@@ -0,0 +1,168 @@
+package codecs_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
+	"github.com/mesos/mesos-go/api/v1/lib/internal/race"
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+var mediaTypes = []encoding.MediaType{codecs.MediaTypeProtobuf, codecs.MediaTypeJSON}
+
+func offersEvent(n int) *scheduler.Event {
+	e := &scheduler.Event{Type: scheduler.Event_OFFERS, Offers: &scheduler.Event_Offers{}}
+	for i := 0; i < n; i++ {
+		s := strconv.Itoa(i)
+		e.Offers.Offers = append(e.Offers.Offers, mesos.Offer{
+			ID:          mesos.OfferID{Value: "o" + s},
+			FrameworkID: mesos.FrameworkID{Value: "f"},
+			AgentID:     mesos.AgentID{Value: "a" + s},
+			Hostname:    "h" + s,
+			Resources: mesos.Resources{
+				resources.NewCPUs(4).Resource,
+				resources.NewMemory(1024).Resource,
+				resources.NewDisk(4096).Resource,
+			},
+		})
+	}
+	return e
+}
+
+func acceptCall(n int) *scheduler.Call {
+	var tasks []mesos.TaskInfo
+	for i := 0; i < n; i++ {
+		s := strconv.Itoa(i)
+		tasks = append(tasks, mesos.TaskInfo{
+			Name:      "task-" + s,
+			TaskID:    mesos.TaskID{Value: "t" + s},
+			AgentID:   mesos.AgentID{Value: "a1"},
+			Command:   &mesos.CommandInfo{Value: str("sleep 100")},
+			Resources: mesos.Resources{resources.NewCPUs(0.1).Resource, resources.NewMemory(32).Resource},
+		})
+	}
+	return calls.Accept(
+		calls.OfferOperations{calls.OpLaunch(tasks...)}.WithOffers(mesos.OfferID{Value: "o1"}),
+	).With(calls.Framework("f"))
+}
+
+func str(s string) *string { return &s }
+
+// stream returns a recordio stream of n events, encoded w/ the given codec.
+func stream(tb testing.TB, codec encoding.Codec, n int, e *scheduler.Event) []byte {
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(func() framing.Writer { return recordio.NewWriter(&buf) })
+	for i := 0; i < n; i++ {
+		if err := enc.Encode(e); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func decodeAll(codec encoding.Codec, r io.Reader) (n int, err error) {
+	dec := codec.NewDecoder(func() framing.Reader { return recordio.NewReader(r) })
+	for ; ; n++ {
+		var e scheduler.Event
+		if err = dec.Decode(&e); err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return
+		}
+	}
+}
+
+func BenchmarkDecodeEvents(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		event *scheduler.Event
+	}{
+		{"heartbeat", &scheduler.Event{Type: scheduler.Event_HEARTBEAT}},
+		{"offers", offersEvent(10)},
+	} {
+		for _, mt := range mediaTypes {
+			codec := codecs.ByMediaType[mt]
+			data := stream(b, codec, 100, bc.event)
+			b.Run(bc.name+"/"+codec.Name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(data)))
+				for i := 0; i < b.N; i++ {
+					if _, err := decodeAll(codec, bytes.NewReader(data)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkEncodeCall(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		call *scheduler.Call
+	}{
+		{"decline", calls.Decline(mesos.OfferID{Value: "o1"}).With(calls.Framework("f"))},
+		{"accept", acceptCall(10)},
+	} {
+		for _, mt := range mediaTypes {
+			codec := codecs.ByMediaType[mt]
+			b.Run(bc.name+"/"+codec.Name, func(b *testing.B) {
+				b.ReportAllocs()
+				enc := codec.NewEncoder(encoding.SinkWriter(ioutil.Discard))
+				for i := 0; i < b.N; i++ {
+					if err := enc.Encode(bc.call); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestAllocs guards against regressions of the allocations of the hot paths of the codecs; the limits
+// leave some headroom over what's currently observed.
+func TestAllocs(t *testing.T) {
+	if race.Enabled {
+		t.Skip("allocation counts are skewed by the race detector")
+	}
+	var (
+		protobuf  = codecs.ByMediaType[codecs.MediaTypeProtobuf]
+		heartbeat = stream(t, protobuf, 1, &scheduler.Event{Type: scheduler.Event_HEARTBEAT})
+		decline   = calls.Decline(mesos.OfferID{Value: "o1"}).With(calls.Framework("f"))
+		enc       = protobuf.NewEncoder(encoding.SinkWriter(ioutil.Discard))
+		r         = bytes.NewReader(heartbeat)
+		dec       = protobuf.NewDecoder(func() framing.Reader { return recordio.NewReader(r) })
+	)
+	for _, tc := range []struct {
+		name string
+		max  float64
+		f    func()
+	}{
+		{"encode decline", 2, func() {
+			if err := enc.Encode(decline); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"decode heartbeat", 5, func() {
+			r.Reset(heartbeat)
+			var e scheduler.Event
+			if err := dec.Decode(&e); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	} {
+		if n := testing.AllocsPerRun(100, tc.f); n > tc.max {
+			t.Errorf("%s: %v allocations exceed the limit of %v", tc.name, n, tc.max)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package compressed wraps codecs so that every encoded frame is compressed. Compressed codecs aren't
+// understood by Mesos; they're intended for deployments where both ends are mesos-go (e.g. test
+// harnesses and proxies) and must be registered w/ an encoding.Registry in order to be negotiated.
+//
+// Only DEFLATE is provided because snappy and zstd aren't implemented by the standard library, and
+// mesos-go doesn't take on compression dependencies on behalf of its users; a snappy or zstd Compressor
+// is a few lines on top of the package of the caller's choosing, for example:
+//
+//	var Snappy = compressed.Compressor{
+//		Name:      "snappy",
+//		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return snappy.NewBufferedWriter(w), nil },
+//		NewReader: func(r io.Reader) (io.Reader, error) { return snappy.NewReader(r), nil },
+//	}
+package compressed
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"io/ioutil"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
+)
+
+// MaxFrameSize is the max size of a decompressed frame, the max size of a protobuf message.
+const MaxFrameSize = 1 << 22
+
+// Compressor compresses and decompresses frames. Implementations for algorithms outside of the standard
+// library (e.g. snappy or zstd) may be provided by the caller.
+type Compressor struct {
+	// Name identifies the compression algorithm; it's used as a suffix for codec names and media types.
+	Name string
+	// NewWriter returns a writer that compresses to w; it's closed once a frame has been written.
+	NewWriter func(w io.Writer) (io.WriteCloser, error)
+	// NewReader returns a reader that decompresses from r.
+	NewReader func(r io.Reader) (io.Reader, error)
+}
+
+// Deflate is a Compressor that uses the DEFLATE algorithm, as implemented by the standard library.
+var Deflate = Compressor{
+	Name:      "deflate",
+	NewWriter: func(w io.Writer) (io.WriteCloser, error) { return flate.NewWriter(w, flate.DefaultCompression) },
+	NewReader: func(r io.Reader) (io.Reader, error) { return flate.NewReader(r), nil },
+}
+
+// Codec returns a codec that compresses the frames that are generated by the given codec. The name of the
+// returned codec is "<codec>+<compressor>", and likewise for its media type.
+func Codec(c encoding.Codec, z Compressor) encoding.Codec {
+	return encoding.Codec{
+		Name: c.Name + "+" + z.Name,
+		Type: encoding.MediaType(c.Type.ContentType() + "+" + z.Name),
+		NewEncoder: func(s encoding.Sink) encoding.Encoder {
+			return c.NewEncoder(func() framing.Writer { return z.writer(s()) })
+		},
+		NewDecoder: func(s encoding.Source) encoding.Decoder {
+			return c.NewDecoder(func() framing.Reader { return z.reader(s()) })
+		},
+	}
+}
+
+func (z Compressor) writer(w framing.Writer) framing.WriterFunc {
+	return func(frame []byte) error {
+		var buf bytes.Buffer
+		zw, err := z.NewWriter(&buf)
+		if err != nil {
+			return err
+		}
+		if _, err = zw.Write(frame); err != nil {
+			return err
+		}
+		if err = zw.Close(); err != nil {
+			return err
+		}
+		return w.WriteFrame(buf.Bytes())
+	}
+}
+
+func (z Compressor) reader(r framing.Reader) framing.ReaderFunc {
+	return func() ([]byte, error) {
+		frame, err := r.ReadFrame()
+		if err != nil {
+			return nil, err
+		}
+		zr, err := z.NewReader(bytes.NewReader(frame))
+		if err != nil {
+			return nil, err
+		}
+		// guard against decompression bombs
+		b, err := ioutil.ReadAll(io.LimitReader(zr, MaxFrameSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > MaxFrameSize {
+			return nil, framing.ErrorOversizedFrame
+		}
+		return b, nil
+	}
+}
@@ -0,0 +1,44 @@
+package compressed_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/compressed"
+)
+
+func TestCodec(t *testing.T) {
+	c := compressed.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf], compressed.Deflate)
+	if c.Name != "protobuf+deflate" || c.Type != "application/x-protobuf+deflate" {
+		t.Fatalf("unexpected codec %q (%q)", c.Name, c.Type)
+	}
+
+	var (
+		buf bytes.Buffer
+		id  = mesos.FrameworkID{Value: strings.Repeat("x", 1024)}
+	)
+	if err := c.NewEncoder(encoding.SinkWriter(&buf)).Encode(&id); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() >= 1024 {
+		t.Fatalf("expected compressed output instead of %d bytes", buf.Len())
+	}
+
+	// negotiated via a registry
+	r := encoding.NewRegistry(c)
+	dc, ok := r.Lookup("application/x-protobuf+deflate")
+	if !ok {
+		t.Fatal("expected registered codec")
+	}
+	var got mesos.FrameworkID
+	if err := dc.NewDecoder(encoding.SourceReader(&buf)).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Value != id.Value {
+		t.Fatalf("expected %q instead of %q", id.Value, got.Value)
+	}
+}
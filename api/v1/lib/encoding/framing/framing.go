@@ -57,6 +57,25 @@ func ReadAll(r io.Reader) ReaderFunc {
 	}
 }
 
+// ReadAllLimit is like ReadAll, but returns ErrorOversizedFrame (w/o buffering the remaining input) if `r`
+// yields more than max bytes. A non-positive max disables the limit.
+func ReadAllLimit(r io.Reader, max int64) ReaderFunc {
+	if max <= 0 {
+		return ReadAll(r)
+	}
+	return func() (b []byte, err error) {
+		b, err = ioutil.ReadAll(io.LimitReader(r, max+1))
+		switch {
+		case err != nil:
+		case int64(len(b)) > max:
+			b, err = nil, ErrorOversizedFrame
+		case len(b) == 0:
+			err = io.EOF
+		}
+		return
+	}
+}
+
 // WriterFor adapts an io.Writer to the Writer interface. All buffers are written to `w` without decoration or
 // modification.
 func WriterFor(w io.Writer) WriterFunc {
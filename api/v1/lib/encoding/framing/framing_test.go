@@ -101,3 +101,25 @@ func (s *shortWriter) Write(b []byte) (n int, err error) {
 	s.n -= n
 	return
 }
+
+func TestReadAllLimit(t *testing.T) {
+	for i, tc := range []struct {
+		in    string
+		max   int64
+		frame string
+		err   error
+	}{
+		{"", 3, "", io.EOF},
+		{"foo", 3, "foo", nil},
+		{"food", 3, "", ErrorOversizedFrame},
+		{"food", 0, "food", nil},
+	} {
+		buf, err := ReadAllLimit(bytes.NewBufferString(tc.in), tc.max).ReadFrame()
+		if err != tc.err {
+			t.Errorf("test case %d failed: expected error %v instead of %v", i, tc.err, err)
+		}
+		if string(buf) != tc.frame {
+			t.Errorf("test case %d failed: expected frame %q instead of %q", i, tc.frame, string(buf))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package fuzz provides fuzz targets for the decoders of Mesos API streams: the recordio framing reader
+// and the JSON and protobuf decoders of scheduler and executor events. These parsers consume untrusted
+// network input, so they must reject malformed input w/ an error rather than crash.
+//
+// The targets follow the go-fuzz conventions (a non-zero result marks an input as interesting), e.g.:
+//
+//	go-fuzz-build -func RecordIO github.com/mesos/mesos-go/api/v1/lib/encoding/fuzz
+//	go-fuzz -bin fuzz-fuzz.zip -workdir corpus
+//
+// Native fuzz tests (Go 1.18+) wrap the same targets: go test -fuzz FuzzRecordIO.
+package fuzz
+
+import (
+	"bytes"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+// maxMessageSize keeps the memory usage of the targets in check.
+const maxMessageSize = 1 << 16
+
+// RecordIO reads all frames of a recordio stream.
+func RecordIO(data []byte) int {
+	r := recordio.NewReader(bytes.NewReader(data), recordio.MaxMessageSize(maxMessageSize))
+	n := 0
+	for ; ; n++ {
+		if _, err := r.ReadFrame(); err != nil {
+			break
+		}
+	}
+	if n > 0 {
+		return 1
+	}
+	return 0
+}
+
+// EventJSON decodes a recordio stream of JSON scheduler and executor events.
+func EventJSON(data []byte) int { return decodeEvents(codecs.ByMediaType[codecs.MediaTypeJSON], data) }
+
+// EventProtobuf decodes a recordio stream of protobuf scheduler and executor events.
+func EventProtobuf(data []byte) int {
+	return decodeEvents(codecs.ByMediaType[codecs.MediaTypeProtobuf], data)
+}
+
+func decodeEvents(codec encoding.Codec, data []byte) (result int) {
+	for _, newEvent := range []func() encoding.Unmarshaler{
+		func() encoding.Unmarshaler { return new(scheduler.Event) },
+		func() encoding.Unmarshaler { return new(executor.Event) },
+	} {
+		dec := codec.NewDecoder(func() framing.Reader {
+			return recordio.NewReader(bytes.NewReader(data), recordio.MaxMessageSize(maxMessageSize))
+		})
+		for {
+			if err := dec.Decode(newEvent()); err != nil {
+				break
+			}
+			result = 1
+		}
+	}
+	return
+}
@@ -0,0 +1,39 @@
+// +build go1.18
+
+package fuzz
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// seed adds the files of the corpus directory to the seed corpus of a native fuzz test.
+func seed(f *testing.F) {
+	files, err := filepath.Glob(filepath.Join("corpus", "*"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, name := range files {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+}
+
+func FuzzRecordIO(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(_ *testing.T, data []byte) { RecordIO(data) })
+}
+
+func FuzzEventJSON(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(_ *testing.T, data []byte) { EventJSON(data) })
+}
+
+func FuzzEventProtobuf(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(_ *testing.T, data []byte) { EventProtobuf(data) })
+}
@@ -0,0 +1,9 @@
+// Package golden checks in the wire encodings, protobuf and JSON, of every scheduler, executor and
+// operator (master and agent) call, event and response type. Its tests fail whenever a codec change or
+// a regeneration of the protos alters the bytes that are exchanged w/ Mesos.
+//
+// Fixtures live under testdata/<api>/<Message>_<TYPE>.{pb,json}. After an intentional change to the
+// wire format, regenerate them and review the diff:
+//
+//	go test ./api/v1/lib/encoding/golden -update
+package golden
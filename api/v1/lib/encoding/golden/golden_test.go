@@ -0,0 +1,221 @@
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden fixtures")
+
+// maxDepth bounds the nesting of generated messages; deeper messages only carry their required fields.
+const maxDepth = 4
+
+type message interface {
+	encoding.Marshaler
+	encoding.Unmarshaler
+	Equal(interface{}) bool
+}
+
+type fixture struct {
+	api   string
+	name  string
+	types map[int32]string
+	new   func() message
+}
+
+var fixtures = []fixture{
+	{"scheduler", "Call", scheduler.Call_Type_name, func() message { return new(scheduler.Call) }},
+	{"scheduler", "Event", scheduler.Event_Type_name, func() message { return new(scheduler.Event) }},
+	{"scheduler", "Response", scheduler.Response_Type_name, func() message { return new(scheduler.Response) }},
+	{"executor", "Call", executor.Call_Type_name, func() message { return new(executor.Call) }},
+	{"executor", "Event", executor.Event_Type_name, func() message { return new(executor.Event) }},
+	{"master", "Call", master.Call_Type_name, func() message { return new(master.Call) }},
+	{"master", "Event", master.Event_Type_name, func() message { return new(master.Event) }},
+	{"master", "Response", master.Response_Type_name, func() message { return new(master.Response) }},
+	{"agent", "Call", agent.Call_Type_name, func() message { return new(agent.Call) }},
+	{"agent", "Response", agent.Response_Type_name, func() message { return new(agent.Response) }},
+}
+
+var extensions = map[string]encoding.Codec{
+	".pb":   codecs.ByMediaType[codecs.MediaTypeProtobuf],
+	".json": codecs.ByMediaType[codecs.MediaTypeJSON],
+}
+
+func TestGolden(t *testing.T) {
+	for _, f := range fixtures {
+		for _, typ := range sortedTypes(f.types) {
+			want := f.new()
+			populate(want, typ, f.types)
+			for ext, codec := range extensions {
+				path := filepath.Join("testdata", f.api, f.name+"_"+f.types[typ]+ext)
+				t.Run(path, func(t *testing.T) {
+					var buf bytes.Buffer
+					if err := codec.NewEncoder(encoding.SinkWriter(&buf)).Encode(want); err != nil {
+						t.Fatal(err)
+					}
+					if *update {
+						if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+							t.Fatal(err)
+						}
+						if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+							t.Fatal(err)
+						}
+						return
+					}
+					golden, err := ioutil.ReadFile(path)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !bytes.Equal(buf.Bytes(), golden) {
+						t.Errorf("encoding differs from %s; got:\n%q", path, buf.Bytes())
+					}
+					if len(golden) == 0 {
+						return // an empty message has no protobuf encoding to decode
+					}
+					got := f.new()
+					if err := codec.NewDecoder(encoding.SourceReader(bytes.NewReader(golden))).Decode(got); err != nil {
+						t.Fatal(err)
+					}
+					if !want.Equal(got) {
+						t.Errorf("decoding %s yields %+v instead of %+v", path, got, want)
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestFixturesComplete flags fixtures whose type has been removed from the protos.
+func TestFixturesComplete(t *testing.T) {
+	if *update {
+		t.Skip("updating fixtures")
+	}
+	known := map[string]bool{}
+	for _, f := range fixtures {
+		for _, name := range f.types {
+			for ext := range extensions {
+				known[filepath.Join("testdata", f.api, f.name+"_"+name+ext)] = true
+			}
+		}
+	}
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		if !known[path] {
+			t.Errorf("stale fixture %s", path)
+		}
+	}
+}
+
+func sortedTypes(names map[int32]string) []int32 {
+	result := make([]int32, 0, len(names))
+	for k := range names {
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// populate fills m deterministically: Type is set to typ and, of the type-specific fields, only the one
+// that corresponds to typ is populated. Messages that lack a Type field (scheduler.Response) are told apart
+// by the type-specific field alone.
+func populate(m message, typ int32, types map[int32]string) {
+	v := reflect.ValueOf(m).Elem()
+	if f := v.FieldByName("Type"); f.IsValid() {
+		f.SetInt(int64(typ))
+	}
+
+	specific := map[string]bool{}
+	for _, name := range types {
+		specific[strings.ToLower(strings.Replace(name, "_", "", -1))] = true
+	}
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if sf.Name == "Type" {
+			continue
+		}
+		key := strings.ToLower(sf.Name)
+		if specific[key] && key != strings.ToLower(strings.Replace(types[typ], "_", "", -1)) {
+			continue
+		}
+		fill(v.Field(i), sf.Name, 1)
+	}
+}
+
+func fill(v reflect.Value, name string, depth int) {
+	if isEnum(v.Type()) {
+		v.SetInt(enumValue(v.Type()))
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem(), name, depth)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			if depth >= maxDepth && !required(sf) && sf.Type.Kind() != reflect.Struct {
+				continue
+			}
+			fill(v.Field(i), sf.Name, depth+1)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(name))
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0), name, depth)
+	case reflect.String:
+		v.SetString(name)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	}
+}
+
+func required(sf reflect.StructField) bool {
+	return strings.Contains(sf.Tag.Get("protobuf"), ",req,")
+}
+
+var descriptorType = reflect.TypeOf((*interface {
+	EnumDescriptor() ([]byte, []int)
+})(nil)).Elem()
+
+func isEnum(t reflect.Type) bool { return t.Kind() == reflect.Int32 && t.Implements(descriptorType) }
+
+// enumValue returns the lowest non-zero value of the enum, falling back to zero.
+func enumValue(t reflect.Type) int64 {
+	v := reflect.New(t).Elem()
+	for i := int64(1); i < 64; i++ {
+		v.SetInt(i)
+		if s := v.Interface().(fmt.Stringer).String(); !isNumeric(s) {
+			return i
+		}
+	}
+	return 0
+}
+
+func isNumeric(s string) bool { return strings.Trim(s, "-0123456789") == "" }
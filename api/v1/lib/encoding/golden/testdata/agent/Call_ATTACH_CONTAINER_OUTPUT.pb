@@ -0,0 +1,5 @@
+Z
+
+Value
+Value
+Value
\ No newline at end of file
@@ -0,0 +1,5 @@
+z
+
+Value
+Value
+Value
\ No newline at end of file
@@ -0,0 +1,5 @@
+B
+
+Value
+Value
+Value
\ No newline at end of file
@@ -0,0 +1,5 @@
+b
+
+Value
+Value
+Value
\ No newline at end of file
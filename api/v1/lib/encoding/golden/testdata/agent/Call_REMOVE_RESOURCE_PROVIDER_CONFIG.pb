@@ -0,0 +1,2 @@
+
+TypeName
\ No newline at end of file
@@ -0,0 +1,5 @@
+r
+
+Value
+Value
+Value
\ No newline at end of file
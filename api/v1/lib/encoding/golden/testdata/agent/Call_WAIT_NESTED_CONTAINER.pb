@@ -0,0 +1,5 @@
+:
+
+Value
+Value
+Value
\ No newline at end of file
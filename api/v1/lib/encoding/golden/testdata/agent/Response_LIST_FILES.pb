@@ -0,0 +1,3 @@
+:
+
+Path"(2UID:GID
\ No newline at end of file
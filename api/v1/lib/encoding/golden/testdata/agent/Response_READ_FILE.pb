@@ -0,0 +1 @@
+BData
\ No newline at end of file
@@ -0,0 +1,4 @@
+
+
+Value
+Value2Data
\ No newline at end of file
@@ -0,0 +1,3 @@
+
+
+ValueUUID
\ No newline at end of file
@@ -0,0 +1,2 @@
+:	
+Message
\ No newline at end of file
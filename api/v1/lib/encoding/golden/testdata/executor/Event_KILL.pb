@@ -0,0 +1,4 @@
+*
+
+Value
+
\ No newline at end of file
@@ -0,0 +1,2 @@
+2
+Data
\ No newline at end of file
@@ -0,0 +1,2 @@
+"
+Path
\ No newline at end of file
@@ -0,0 +1,3 @@
+ 	
+
+Value
\ No newline at end of file
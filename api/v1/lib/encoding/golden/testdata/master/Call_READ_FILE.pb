@@ -0,0 +1,3 @@
+*
+
+Path
\ No newline at end of file
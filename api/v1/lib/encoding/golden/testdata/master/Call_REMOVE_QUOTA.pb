@@ -0,0 +1,2 @@
+z
+Role
\ No newline at end of file
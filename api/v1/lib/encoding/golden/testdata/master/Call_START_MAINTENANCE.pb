@@ -0,0 +1,3 @@
+b
+
+HostnameIP
\ No newline at end of file
@@ -0,0 +1,3 @@
+j
+
+HostnameIP
\ No newline at end of file
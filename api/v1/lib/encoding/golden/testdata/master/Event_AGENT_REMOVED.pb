@@ -0,0 +1,3 @@
+2	
+
+Value
\ No newline at end of file
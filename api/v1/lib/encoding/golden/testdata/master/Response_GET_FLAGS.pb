@@ -0,0 +1,3 @@
+
+
+NameValue
\ No newline at end of file
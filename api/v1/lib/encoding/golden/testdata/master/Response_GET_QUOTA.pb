@@ -0,0 +1,5 @@
+
+
+
+Role	Principal
+Name
\ No newline at end of file
@@ -0,0 +1,6 @@
+
+
+ValueB
+
+Value
+ValueUUID
\ No newline at end of file
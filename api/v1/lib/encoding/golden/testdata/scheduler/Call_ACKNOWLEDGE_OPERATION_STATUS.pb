@@ -0,0 +1,7 @@
+
+
+Value!
+
+Value
+ValueUUID"
+Value
\ No newline at end of file
@@ -0,0 +1,7 @@
+
+
+Value2
+
+Value
+Value
+
\ No newline at end of file
@@ -0,0 +1,7 @@
+
+
+Value
+R
+
+Value
+ValueData
\ No newline at end of file
@@ -0,0 +1,7 @@
+
+
+Value	J
+
+
+Value
+Value
\ No newline at end of file
@@ -0,0 +1,8 @@
+
+
+Value
+
+
+Value
+Value
+Value
\ No newline at end of file
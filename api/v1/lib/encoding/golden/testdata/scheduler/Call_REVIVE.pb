@@ -0,0 +1,4 @@
+
+
+Valuez
+Roles
\ No newline at end of file
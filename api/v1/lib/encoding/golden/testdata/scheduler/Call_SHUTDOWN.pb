@@ -0,0 +1,6 @@
+
+
+Value:
+
+Value
+Value
\ No newline at end of file
@@ -0,0 +1,4 @@
+
+
+Value
+Roles
\ No newline at end of file
@@ -0,0 +1,2 @@
+B	
+Message
\ No newline at end of file
@@ -0,0 +1,4 @@
+:
+
+Value
+Value
\ No newline at end of file
@@ -0,0 +1,4 @@
+2
+
+Value
+ValueData
\ No newline at end of file
@@ -0,0 +1,4 @@
+
+R	
+
+Value
\ No newline at end of file
@@ -0,0 +1,20 @@
+package encoding
+
+import (
+	"bytes"
+)
+
+// Marshal returns the encoding of m, using the given Codec, as a single frame. Useful for storing
+// messages in opaque byte fields such as TaskInfo.Data and TaskStatus.Data.
+func Marshal(c Codec, m Marshaler) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.NewEncoder(SinkWriter(&buf)).Encode(m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes data, as produced by Marshal w/ the same Codec, into u.
+func Unmarshal(c Codec, data []byte, u Unmarshaler) error {
+	return c.NewDecoder(SourceReader(bytes.NewReader(data))).Decode(u)
+}
@@ -0,0 +1,26 @@
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+)
+
+func TestMarshal(t *testing.T) {
+	for _, c := range codecs.DefaultRegistry.Codecs() {
+		in := mesos.Labels{Labels: []mesos.Label{{Key: "a"}}}
+		data, err := encoding.Marshal(c, &in)
+		if err != nil {
+			t.Fatalf("%v: %v", c.Name, err)
+		}
+		var out mesos.Labels
+		if err = encoding.Unmarshal(c, data, &out); err != nil {
+			t.Fatalf("%v: %v", c.Name, err)
+		}
+		if !in.Equivalent(&out) {
+			t.Fatalf("%v: expected %v instead of %v", c.Name, in, out)
+		}
+	}
+}
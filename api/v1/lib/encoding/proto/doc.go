@@ -1,4 +1,9 @@
 // Package proto implements protobuf utilities such as functional options to
 // construct complex structs and encoders and decoders composable with
 // io.ReadWriters.
+//
+// Encoding is deterministic: the Mesos v1 API protos don't declare map fields,
+// and the generated marshalers write fields in field-number order, so equal
+// messages always yield byte-identical output. Golden-file tests and
+// content-addressed caches may rely on this; no special mode is required.
 package proto
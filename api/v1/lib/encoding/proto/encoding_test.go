@@ -7,6 +7,7 @@ import (
 	"github.com/mesos/mesos-go/api/v1/lib"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding"
 	. "github.com/mesos/mesos-go/api/v1/lib/encoding/proto"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
 )
 
 type FakeMessage string
@@ -47,3 +48,38 @@ func TestEncoder(t *testing.T) {
 		t.Fatal("Encode failed to complete normally, but we didn't see a panic? should never happen")
 	}
 }
+
+func TestEncoder_Deterministic(t *testing.T) {
+	call := func() *scheduler.Call {
+		return &scheduler.Call{
+			Type:        scheduler.Call_ACCEPT,
+			FrameworkID: &mesos.FrameworkID{Value: "f"},
+			Accept: &scheduler.Call_Accept{
+				OfferIDs: []mesos.OfferID{{Value: "o1"}, {Value: "o2"}},
+				Operations: []mesos.Offer_Operation{{
+					Type: mesos.Offer_Operation_LAUNCH,
+					Launch: &mesos.Offer_Operation_Launch{TaskInfos: []mesos.TaskInfo{{
+						Name:    "t",
+						TaskID:  mesos.TaskID{Value: "t1"},
+						AgentID: mesos.AgentID{Value: "a"},
+						Labels: &mesos.Labels{Labels: []mesos.Label{
+							{Key: "b"}, {Key: "a"}, {Key: "c"},
+						}},
+					}}},
+				}},
+			},
+		}
+	}
+	var golden []byte
+	for i := 0; i < 10; i++ {
+		var buf bytes.Buffer
+		if err := NewEncoder(encoding.SinkWriter(&buf)).Encode(call()); err != nil {
+			t.Fatal(err)
+		}
+		if golden == nil {
+			golden = buf.Bytes()
+		} else if !bytes.Equal(golden, buf.Bytes()) {
+			t.Fatalf("expected %x instead of %x", golden, buf.Bytes())
+		}
+	}
+}
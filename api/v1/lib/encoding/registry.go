@@ -0,0 +1,67 @@
+package encoding
+
+import (
+	"mime"
+	"strings"
+	"sync"
+)
+
+// Registry is a concurrency-safe collection of Codecs, keyed by media type.
+type Registry struct {
+	mu     sync.RWMutex
+	codecs map[MediaType]Codec
+}
+
+// NewRegistry returns a Registry w/ the given Codecs registered.
+func NewRegistry(codecs ...Codec) *Registry {
+	r := &Registry{codecs: make(map[MediaType]Codec, len(codecs))}
+	for _, c := range codecs {
+		r.Register(c)
+	}
+	return r
+}
+
+// Register adds a Codec to the registry, replacing any Codec previously registered for the same media type.
+func (r *Registry) Register(c Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codecs[normalize(string(c.Type))] = c
+}
+
+// Lookup returns the Codec registered for the media type of the given Content-Type header value; media
+// type parameters (e.g. "charset=utf-8") are ignored.
+func (r *Registry) Lookup(contentType string) (Codec, bool) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return Codec{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.codecs[normalize(mt)]
+	return c, ok
+}
+
+// ByName returns the registered Codec w/ the given name.
+func (r *Registry) ByName(name string) (Codec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.codecs {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Codec{}, false
+}
+
+// Codecs returns the registered Codecs, in no particular order.
+func (r *Registry) Codecs() []Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]Codec, 0, len(r.codecs))
+	for _, c := range r.codecs {
+		result = append(result, c)
+	}
+	return result
+}
+
+func normalize(mt string) MediaType { return MediaType(strings.ToLower(mt)) }
@@ -0,0 +1,40 @@
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+)
+
+func TestRegistry(t *testing.T) {
+	var (
+		foo = encoding.Codec{Name: "foo", Type: encoding.MediaType("application/x-foo")}
+		bar = encoding.Codec{Name: "bar", Type: encoding.MediaType("application/x-bar")}
+		r   = encoding.NewRegistry(foo)
+	)
+	r.Register(bar)
+	for ti, tc := range []struct {
+		ct   string
+		want string
+	}{
+		{"application/x-foo", "foo"},
+		{"application/x-bar; charset=utf-8", "bar"},
+		{"Application/X-Foo", "foo"},
+		{"application/json", ""},
+		{"", ""},
+	} {
+		c, ok := r.Lookup(tc.ct)
+		if ok != (tc.want != "") || c.Name != tc.want {
+			t.Errorf("test case %d failed: expected codec %q instead of %q (ok=%v)", ti, tc.want, c.Name, ok)
+		}
+	}
+	if c, ok := r.ByName("bar"); !ok || c.Type != bar.Type {
+		t.Errorf("expected codec %v instead of %v", bar, c)
+	}
+	if _, ok := r.ByName("baz"); ok {
+		t.Errorf("unexpected codec baz")
+	}
+	if n := len(r.Codecs()); n != 2 {
+		t.Errorf("expected 2 codecs instead of %d", n)
+	}
+}
@@ -47,15 +47,22 @@ var (
 
 // SourceReader returns a Source that buffers all input from the given io.Reader
 // and returns the contents in a single frame.
-func SourceReader(r io.Reader) Source {
-	ch := make(chan framing.ReaderFunc, 1)
-	ch <- framing.ReadAll(r)
-	return func() framing.Reader {
-		select {
-		case f := <-ch:
-			return f
-		default:
-			return framing.ReaderFunc(framing.EOFReaderFunc)
+func SourceReader(r io.Reader) Source { return SourceReaderLimit(0)(r) }
+
+// SourceReaderLimit returns a SourceFactory that behaves like SourceReader, except that its single frame
+// is limited to max bytes: larger inputs yield framing.ErrorOversizedFrame. A non-positive max disables
+// the limit.
+func SourceReaderLimit(max int64) SourceFactoryFunc {
+	return func(r io.Reader) Source {
+		ch := make(chan framing.ReaderFunc, 1)
+		ch <- framing.ReadAllLimit(r, max)
+		return func() framing.Reader {
+			select {
+			case f := <-ch:
+				return f
+			default:
+				return framing.ReaderFunc(framing.EOFReaderFunc)
+			}
 		}
 	}
 }
@@ -0,0 +1,39 @@
+package mesos
+
+// Get returns the value of the (VALUE type) variable w/ the given name; false if there's no such variable.
+func (e *Environment) Get(name string) (string, bool) {
+	for _, v := range e.GetVariables() {
+		if v.Name == name && v.GetType() == Environment_Variable_VALUE {
+			return v.GetValue(), true
+		}
+	}
+	return "", false
+}
+
+// Set sets the value of the variable w/ the given name, replacing an existing variable (if any).
+func (e *Environment) Set(name, value string) {
+	e.set(Environment_Variable{Name: name, Value: &value})
+}
+
+// SetSecret sets the variable w/ the given name to the value of the secret, replacing an existing variable
+// (if any).
+func (e *Environment) SetSecret(name string, s *Secret) {
+	e.set(Environment_Variable{Name: name, Type: Environment_Variable_SECRET.Enum(), Secret: s})
+}
+
+// Merge sets all of the variables of right into e; variables of right take precedence.
+func (e *Environment) Merge(right *Environment) {
+	for _, v := range right.GetVariables() {
+		e.set(v)
+	}
+}
+
+func (e *Environment) set(v Environment_Variable) {
+	for i := range e.Variables {
+		if e.Variables[i].Name == v.Name {
+			e.Variables[i] = v
+			return
+		}
+	}
+	e.Variables = append(e.Variables, v)
+}
@@ -38,6 +38,14 @@ type Config struct {
 	// The maximum backoff duration to be used by the executor between two retries when
 	// disconnected (e.g., 250ms, 1mins etc.)
 	SubscriptionBackoffMax time.Duration
+
+	// AuthenticationToken is the token that the executor should use to authenticate with the agent.
+	// Only set when executor authentication is enabled on the agent.
+	AuthenticationToken string
+	// AuthenticationTokenFile is the path of a file from which a rotated AuthenticationToken is read once
+	// the agent rejects the current one. It's not set by Mesos, but via MESOS_EXECUTOR_AUTHENTICATION_TOKEN_FILE
+	// in deployments that rotate executor tokens on disk.
+	AuthenticationTokenFile string
 }
 
 type EnvError struct {
@@ -78,6 +86,8 @@ func fromEnv(getter func(string) string) (Config, error) {
 		Sandbox:                     required("MESOS_SANDBOX"),
 		AgentEndpoint:               required("MESOS_AGENT_ENDPOINT"),
 		ExecutorShutdownGracePeriod: requiredDuration("MESOS_EXECUTOR_SHUTDOWN_GRACE_PERIOD"),
+		AuthenticationToken:         getter("MESOS_EXECUTOR_AUTHENTICATION_TOKEN"),
+		AuthenticationTokenFile:     getter("MESOS_EXECUTOR_AUTHENTICATION_TOKEN_FILE"),
 	}
 	checkpoint, err := envBool("MESOS_CHECKPOINT", getter)
 	if err != nil {
@@ -0,0 +1,231 @@
+// Package advertise determines the hostname and IP address that a framework advertises to Mesos, for
+// example via the hostname and webui_url of its FrameworkInfo, in the way that libprocess-based frameworks
+// do: environment overrides take precedence over the network configuration of the host, and addresses
+// that are meaningless to other hosts (such as loopback addresses, or the hostname of a container) are
+// avoided.
+package advertise
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+const (
+	// EnvAdvertiseIP overrides the IP address that's advertised, for example when the framework runs
+	// behind NAT or in a container w/ bridge networking; see Discover.
+	EnvAdvertiseIP = "LIBPROCESS_ADVERTISE_IP"
+
+	// EnvIP is the IP address that a libprocess-based framework binds to; it's advertised unless it's
+	// the wildcard address.
+	EnvIP = "LIBPROCESS_IP"
+
+	// EnvHost is the hostname of the agent that runs a framework that's launched as a Mesos task (e.g. by
+	// Marathon or Aurora); it's preferred to the hostname of the container.
+	EnvHost = "HOST"
+)
+
+// ErrNoAddress is returned by Discover when no address that's reachable from other hosts is found.
+var ErrNoAddress = errors.New("no non-loopback IP address found")
+
+type (
+	// Address is the hostname and IP address that a framework advertises.
+	Address struct {
+		Hostname string
+		IP       net.IP
+	}
+
+	config struct {
+		hostname  string
+		iface     string
+		lookupEnv func(string) (string, bool)
+		osHost    func() (string, error)
+		lookupIP  func(string) ([]net.IP, error)
+		addrs     func(iface string) ([]net.Addr, error)
+	}
+
+	// Opt is a functional option type for Discover.
+	Opt func(*config)
+)
+
+// Hostname overrides the hostname that's advertised, for example as specified by a command line flag.
+func Hostname(h string) Opt { return func(c *config) { c.hostname = h } }
+
+// Interface restricts the IP addresses that are considered to those of the named network interface, for
+// example "eth0" of a host that has multiple networks.
+func Interface(name string) Opt { return func(c *config) { c.iface = name } }
+
+// LookupEnv overrides os.LookupEnv, which yields the environment overrides.
+func LookupEnv(f func(string) (string, bool)) Opt { return func(c *config) { c.lookupEnv = f } }
+
+// Discover determines the address that a framework advertises. The IP address is, in order of precedence:
+//   - the value of EnvAdvertiseIP;
+//   - the value of EnvIP, unless it's the wildcard address;
+//   - the first non-loopback IPv4 (or else IPv6) address of the network interface given by Interface;
+//   - the first non-loopback address that the hostname resolves to (some distributions map the hostname
+//     to 127.0.1.1, for example);
+//   - the first non-loopback IPv4 (or else IPv6) address of the network interfaces of the host.
+//
+// The hostname is, in order of precedence: the one given by Hostname, the value of EnvHost, or the
+// hostname of the host provided that it resolves (the hostname of a container often doesn't resolve
+// elsewhere); the IP address is advertised as the hostname otherwise. The hostname of the host is never
+// advertised w/ EnvAdvertiseIP: Docker maps the hostname of a container (its ID) in its /etc/hosts, so
+// that it resolves within the container only.
+func Discover(opts ...Opt) (Address, error) {
+	c := config{
+		lookupEnv: os.LookupEnv,
+		osHost:    os.Hostname,
+		lookupIP:  net.LookupIP,
+		addrs:     interfaceAddrs,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	ip, err := c.ip()
+	if err != nil {
+		return Address{}, err
+	}
+	return Address{Hostname: c.host(ip), IP: ip}, nil
+}
+
+func (c *config) env(key string) string {
+	v, _ := c.lookupEnv(key)
+	return v
+}
+
+func (c *config) ip() (net.IP, error) {
+	for _, key := range []string{EnvAdvertiseIP, EnvIP} {
+		v := c.env(key)
+		if v == "" {
+			continue
+		}
+		ip := net.ParseIP(v)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q in %s", v, key)
+		}
+		if !ip.IsUnspecified() {
+			return ip, nil
+		}
+	}
+	if c.iface != "" {
+		addrs, err := c.addrs(c.iface)
+		if err != nil {
+			return nil, err
+		}
+		if ip := firstReachable(addrs); ip != nil {
+			return ip, nil
+		}
+		return nil, fmt.Errorf("no non-loopback IP address found for interface %q", c.iface)
+	}
+	if h, err := c.osHost(); err == nil {
+		if ips, err := c.lookupIP(h); err == nil {
+			for _, ip := range ips {
+				if !ip.IsLoopback() && !ip.IsUnspecified() {
+					return ip, nil
+				}
+			}
+		}
+	}
+	addrs, err := c.addrs("")
+	if err != nil {
+		return nil, err
+	}
+	if ip := firstReachable(addrs); ip != nil {
+		return ip, nil
+	}
+	return nil, ErrNoAddress
+}
+
+func (c *config) host(ip net.IP) string {
+	if c.hostname != "" {
+		return c.hostname
+	}
+	if h := c.env(EnvHost); h != "" {
+		return h
+	}
+	if c.env(EnvAdvertiseIP) != "" {
+		return ip.String()
+	}
+	if h, err := c.osHost(); err == nil && h != "" {
+		if _, err := c.lookupIP(h); err == nil {
+			return h
+		}
+	}
+	return ip.String()
+}
+
+// firstReachable returns the first IPv4 address that's neither loopback nor link-local, or else the first
+// such IPv6 address.
+func firstReachable(addrs []net.Addr) (ip6 net.IP) {
+	for _, addr := range addrs {
+		var ip net.IP
+		switch a := addr.(type) {
+		case *net.IPNet:
+			ip = a.IP
+		case *net.IPAddr:
+			ip = a.IP
+		}
+		if ip == nil || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		if ip.To4() != nil {
+			return ip
+		}
+		if ip6 == nil {
+			ip6 = ip
+		}
+	}
+	return
+}
+
+// interfaceAddrs returns the addresses of the named network interface, or of the network interfaces of
+// the host that are up if the name is empty.
+func interfaceAddrs(name string) ([]net.Addr, error) {
+	if name != "" {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return nil, err
+		}
+		return iface.Addrs()
+	}
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var result []net.Addr
+	for i := range ifaces {
+		if ifaces[i].Flags&net.FlagUp == 0 {
+			continue
+		}
+		addrs, err := ifaces[i].Addrs()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, addrs...)
+	}
+	return result, nil
+}
+
+// URL returns a URL of the given scheme for the address and port, suitable for the webui_url of a
+// FrameworkInfo; the hostname of the address is preferred to its IP address.
+func (a Address) URL(scheme string, port int) string {
+	host := a.Hostname
+	if host == "" {
+		host = a.IP.String()
+	}
+	return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// Apply sets the hostname of the FrameworkInfo to that of the address, unless it's already set.
+func (a Address) Apply(fi *mesos.FrameworkInfo) {
+	if fi.GetHostname() == "" && a.Hostname != "" {
+		h := a.Hostname
+		fi.Hostname = &h
+	}
+}
@@ -0,0 +1,99 @@
+package advertise
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestDiscover(t *testing.T) {
+	var (
+		ipnet = func(s string) net.Addr { return &net.IPNet{IP: net.ParseIP(s), Mask: net.CIDRMask(24, 32)} }
+		hosts = map[string][]net.IP{
+			"agent1.example.com": {net.ParseIP("10.0.0.1")},
+			"debian":             {net.ParseIP("127.0.1.1")},
+		}
+		ifaces = map[string][]net.Addr{
+			"lo":   {ipnet("127.0.0.1")},
+			"eth0": {ipnet("fe80::1"), ipnet("2001:db8::2"), ipnet("192.168.1.2")},
+			"eth1": {ipnet("2001:db8::3")},
+			"":     {ipnet("127.0.0.1"), ipnet("fe80::1"), ipnet("172.17.0.2")},
+		}
+	)
+	for i, tc := range []struct {
+		env      map[string]string
+		hostname string
+		opts     []Opt
+		want     Address
+		wantErr  bool
+	}{
+		// the hostname resolves to a reachable address
+		{nil, "agent1.example.com", nil, Address{"agent1.example.com", net.ParseIP("10.0.0.1")}, false},
+		// the hostname resolves to loopback, fall back to the interfaces of the host
+		{nil, "debian", nil, Address{"debian", net.ParseIP("172.17.0.2")}, false},
+		// the hostname of a container doesn't resolve
+		{nil, "3f4e2a1b", nil, Address{"172.17.0.2", net.ParseIP("172.17.0.2")}, false},
+		{map[string]string{EnvHost: "agent2.example.com"}, "3f4e2a1b", nil, Address{"agent2.example.com", net.ParseIP("172.17.0.2")}, false},
+		{nil, "3f4e2a1b", []Opt{Hostname("flag.example.com")}, Address{"flag.example.com", net.ParseIP("172.17.0.2")}, false},
+		{nil, "debian", []Opt{Interface("eth0")}, Address{"debian", net.ParseIP("192.168.1.2")}, false},
+		{nil, "debian", []Opt{Interface("eth1")}, Address{"debian", net.ParseIP("2001:db8::3")}, false},
+		{nil, "debian", []Opt{Interface("lo")}, Address{}, true},
+		{nil, "debian", []Opt{Interface("eth2")}, Address{}, true},
+		// environment overrides
+		{map[string]string{EnvIP: "10.1.1.1"}, "debian", nil, Address{"debian", net.ParseIP("10.1.1.1")}, false},
+		{map[string]string{EnvIP: "0.0.0.0"}, "debian", nil, Address{"debian", net.ParseIP("172.17.0.2")}, false},
+		{map[string]string{EnvIP: "0.0.0.0", EnvAdvertiseIP: "203.0.113.7"}, "debian", nil, Address{"203.0.113.7", net.ParseIP("203.0.113.7")}, false},
+		// the hostname of a container resolves locally, but not to the advertised IP
+		{map[string]string{EnvAdvertiseIP: "203.0.113.7"}, "agent1.example.com", nil, Address{"203.0.113.7", net.ParseIP("203.0.113.7")}, false},
+		{map[string]string{EnvAdvertiseIP: "203.0.113.7", EnvHost: "agent2.example.com"}, "agent1.example.com", nil, Address{"agent2.example.com", net.ParseIP("203.0.113.7")}, false},
+		{map[string]string{EnvAdvertiseIP: "bogus"}, "debian", nil, Address{}, true},
+	} {
+		env := tc.env
+		opts := append([]Opt{LookupEnv(func(k string) (string, bool) { v, ok := env[k]; return v, ok })}, tc.opts...)
+		opts = append(opts, func(c *config) {
+			c.osHost = func() (string, error) { return tc.hostname, nil }
+			c.lookupIP = func(h string) ([]net.IP, error) {
+				if ips, ok := hosts[h]; ok {
+					return ips, nil
+				}
+				return nil, errors.New("no such host")
+			}
+			c.addrs = func(name string) ([]net.Addr, error) {
+				if addrs, ok := ifaces[name]; ok {
+					return addrs, nil
+				}
+				return nil, errors.New("no such interface")
+			}
+		})
+		got, err := Discover(opts...)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("test case %d: unexpected error %v", i, err)
+			continue
+		}
+		if got.Hostname != tc.want.Hostname || !got.IP.Equal(tc.want.IP) {
+			t.Errorf("test case %d: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+}
+
+func TestAddress(t *testing.T) {
+	a := Address{Hostname: "agent1.example.com", IP: net.ParseIP("10.0.0.1")}
+	if u := a.URL("http", 8080); u != "http://agent1.example.com:8080" {
+		t.Fatalf("unexpected URL %q", u)
+	}
+	if u := (Address{IP: net.ParseIP("2001:db8::1")}).URL("https", 443); u != "https://[2001:db8::1]:443" {
+		t.Fatalf("unexpected URL %q", u)
+	}
+
+	var fi mesos.FrameworkInfo
+	a.Apply(&fi)
+	if fi.GetHostname() != a.Hostname {
+		t.Fatalf("expected hostname %q instead of %q", a.Hostname, fi.GetHostname())
+	}
+	Address{Hostname: "other"}.Apply(&fi)
+	if fi.GetHostname() != a.Hostname {
+		t.Fatal("expected the hostname to be retained")
+	}
+}
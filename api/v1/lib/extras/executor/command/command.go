@@ -0,0 +1,249 @@
+// Package command implements a reusable executor that runs CommandInfo-based tasks as OS processes.
+// It's intended for framework authors that need a slightly customized command executor: process
+// environment, URI setup, and the executor event handlers may all be extended via functional options.
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/tasks"
+)
+
+type (
+	// Hook customizes the command of a task prior to the command being started. Hooks may, for example,
+	// modify the process environment or fetch the URIs of the task into the working directory of the
+	// command. An error returned by a hook aborts the launch of the task.
+	Hook func(ctx context.Context, task mesos.TaskInfo, cmd *exec.Cmd) error
+
+	// Option modifies an Executor, returns an Option that acts as an "undo"
+	Option func(*Executor) Option
+
+	// Executor launches the tasks of a framework as OS processes.
+	Executor struct {
+		sandbox      string
+		shell        []string
+		environ      func() []string
+		hooks        []Hook
+		healthChecks bool
+		taskOptions  []tasks.Option
+		rules        []eventrules.Rule
+	}
+)
+
+var (
+	// ErrNoCommand is returned when attempting to launch a task that does not specify a command.
+	ErrNoCommand = errors.New("task does not specify a command")
+
+	// ErrSecretReference is returned when a task environment includes a secret reference; such secrets
+	// must be resolved by a Hook.
+	ErrSecretReference = errors.New("unable to resolve secret reference of environment variable")
+)
+
+// DefaultShell is used to execute shell commands.
+var DefaultShell = []string{"/bin/sh", "-c"}
+
+// WithSandbox sets the working directory of launched tasks; defaults to the working directory of the
+// executor.
+func WithSandbox(dir string) Option {
+	return func(x *Executor) Option {
+		old := x.sandbox
+		x.sandbox = dir
+		return WithSandbox(old)
+	}
+}
+
+// WithShell overrides DefaultShell; the command value of a task is appended to the given args.
+func WithShell(args ...string) Option {
+	return func(x *Executor) Option {
+		old := x.shell
+		x.shell = args
+		return WithShell(old...)
+	}
+}
+
+// WithEnviron sets the func that generates the base environment of launched tasks, to which the variables
+// of the task environment are appended; defaults to os.Environ.
+func WithEnviron(f func() []string) Option {
+	return func(x *Executor) Option {
+		old := x.environ
+		x.environ = f
+		return WithEnviron(old)
+	}
+}
+
+// WithHooks sets the hooks that are invoked, in order, prior to starting the command of a task.
+func WithHooks(hooks ...Hook) Option {
+	return func(x *Executor) Option {
+		old := x.hooks
+		x.hooks = hooks
+		return WithHooks(old...)
+	}
+}
+
+// WithHealthChecks toggles the execution of task health checks; enabled by default.
+func WithHealthChecks(enabled bool) Option {
+	return func(x *Executor) Option {
+		old := x.healthChecks
+		x.healthChecks = enabled
+		return WithHealthChecks(old)
+	}
+}
+
+// WithTaskOptions sets additional options for the task manager that's created by Run. For example,
+// frameworks that declare the TASK_KILLING_STATE capability may specify tasks.WithKillingState(true).
+func WithTaskOptions(opts ...tasks.Option) Option {
+	return func(x *Executor) Option {
+		old := x.taskOptions
+		x.taskOptions = opts
+		return WithTaskOptions(old...)
+	}
+}
+
+// WithEventRules sets rules that are evaluated by Run for each event, prior to the event being processed
+// by the default event handlers. Rules may intercept events by not invoking the rest of the chain.
+func WithEventRules(rules ...eventrules.Rule) Option {
+	return func(x *Executor) Option {
+		old := x.rules
+		x.rules = rules
+		return WithEventRules(old...)
+	}
+}
+
+// New returns an Executor configured with the given options.
+func New(opts ...Option) *Executor {
+	x := &Executor{
+		shell:        DefaultShell,
+		environ:      os.Environ,
+		healthChecks: true,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(x)
+		}
+	}
+	return x
+}
+
+// Command generates the OS command for the given CommandInfo. Shell commands are executed via the
+// configured shell; otherwise the value of the command is executed with the given arguments (the first
+// of which, by convention, is the name of the program).
+func (x *Executor) Command(ci *mesos.CommandInfo) (*exec.Cmd, error) {
+	if ci == nil || ci.GetValue() == "" {
+		return nil, ErrNoCommand
+	}
+	var cmd *exec.Cmd
+	if ci.GetShell() {
+		shell := x.shell
+		if len(shell) == 0 {
+			shell = DefaultShell
+		}
+		args := append(append(make([]string, 0, len(shell)), shell[1:]...), ci.GetValue())
+		cmd = exec.Command(shell[0], args...)
+	} else {
+		cmd = exec.Command(ci.GetValue())
+		if args := ci.GetArguments(); len(args) > 0 {
+			cmd.Args = append([]string(nil), args...)
+		}
+	}
+	env, err := Environment(ci.GetEnvironment())
+	if err != nil {
+		return nil, err
+	}
+	if x.environ != nil {
+		env = append(x.environ(), env...)
+	}
+	cmd.Env = env
+	cmd.Dir = x.sandbox
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+// Environment returns the variables of the given environment in "key=value" form. Secrets are only
+// supported if they're specified by value.
+func Environment(env *mesos.Environment) ([]string, error) {
+	vars := env.GetVariables()
+	if len(vars) == 0 {
+		return nil, nil
+	}
+	result := make([]string, 0, len(vars))
+	for i := range vars {
+		v := &vars[i]
+		switch v.GetType() {
+		case mesos.Environment_Variable_SECRET:
+			s := v.GetSecret()
+			if s.GetType() != mesos.Secret_VALUE {
+				return nil, ErrSecretReference
+			}
+			result = append(result, v.Name+"="+string(s.GetValue().GetData()))
+		default:
+			result = append(result, v.Name+"="+v.GetValue())
+		}
+	}
+	return result, nil
+}
+
+// Launcher returns a tasks.Launcher that starts the command of a task after invoking the configured hooks.
+// If health checks are enabled then, once the task has been launched by the given Manager, the health of the
+// task is reported via the Manager, and the task is killed once it has been unhealthy for the configured
+// number of consecutive checks.
+func (x *Executor) Launcher(m *tasks.Manager) tasks.Launcher {
+	return func(ctx context.Context, task mesos.TaskInfo) (tasks.Process, error) {
+		cmd, err := x.Command(task.Command)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range x.hooks {
+			if err := h(ctx, task, cmd); err != nil {
+				return nil, err
+			}
+		}
+		proc, err := Start(cmd)
+		if err != nil {
+			return nil, err
+		}
+		if hc := task.GetHealthCheck(); hc != nil && x.healthChecks && m != nil {
+			id := task.TaskID
+			return &monitoredProcess{
+				Process: proc,
+				monitor: func(ctx context.Context) { x.watchHealth(ctx, m, id, hc, proc) },
+			}, nil
+		}
+		return proc, nil
+	}
+}
+
+// monitoredProcess implements tasks.Monitor so that health checks begin once the task is being tracked by
+// the Manager, and has been reported as running.
+type monitoredProcess struct {
+	tasks.Process
+	monitor func(context.Context)
+}
+
+func (p *monitoredProcess) Monitor(ctx context.Context) { p.monitor(ctx) }
+
+// EnvHook returns a Hook that appends the variables generated by f to the environment of a task.
+func EnvHook(f func(mesos.TaskInfo) []string) Hook {
+	return func(_ context.Context, task mesos.TaskInfo, cmd *exec.Cmd) error {
+		cmd.Env = append(cmd.Env, f(task)...)
+		return nil
+	}
+}
+
+// URIHook returns a Hook that invokes fetch for each URI of a task; dir is the working directory of the
+// command. URIs are processed in order and the first error aborts the launch of the task.
+func URIHook(fetch func(ctx context.Context, dir string, uri mesos.CommandInfo_URI) error) Hook {
+	return func(ctx context.Context, task mesos.TaskInfo, cmd *exec.Cmd) error {
+		for _, uri := range task.GetCommand().GetURIs() {
+			if err := fetch(ctx, cmd.Dir, uri); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
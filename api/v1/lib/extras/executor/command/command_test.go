@@ -0,0 +1,209 @@
+package command
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/tasks"
+)
+
+type updateRecorder struct {
+	mu      sync.Mutex
+	updates []mesos.TaskStatus
+}
+
+func (r *updateRecorder) update(_ context.Context, s mesos.TaskStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, s)
+	return nil
+}
+
+func (r *updateRecorder) find(f func(mesos.TaskStatus) bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.updates {
+		if f(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func waitFor(t *testing.T, what string, f func() bool) {
+	deadline := time.After(5 * time.Second)
+	for !f() {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for %s", what)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func shellTask(id, value string) mesos.TaskInfo {
+	return mesos.TaskInfo{TaskID: mesos.TaskID{Value: id}, Command: &mesos.CommandInfo{Value: &value}}
+}
+
+func launchEvent(task mesos.TaskInfo) *executor.Event {
+	return &executor.Event{
+		Type:   executor.Event_LAUNCH,
+		Launch: &executor.Event_Launch{Task: task},
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var (
+		x        = New(WithEnviron(nil), WithSandbox("/tmp"))
+		value    = "/bin/echo"
+		shell    = false
+		varValue = "bar"
+	)
+	cmd, err := x.Command(&mesos.CommandInfo{
+		Value:     &value,
+		Shell:     &shell,
+		Arguments: []string{"echo", "hello"},
+		Environment: &mesos.Environment{Variables: []mesos.Environment_Variable{
+			{Name: "FOO", Value: &varValue},
+			{
+				Name:   "SECRET",
+				Type:   mesos.Environment_Variable_SECRET.Enum(),
+				Secret: &mesos.Secret{Type: mesos.Secret_VALUE, Value: &mesos.Secret_Value{Data: []byte("s3cr3t")}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"echo", "hello"}; !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("expected args %v instead of %v", expected, cmd.Args)
+	}
+	if expected := []string{"FOO=bar", "SECRET=s3cr3t"}; !reflect.DeepEqual(cmd.Env, expected) {
+		t.Errorf("expected env %v instead of %v", expected, cmd.Env)
+	}
+	if cmd.Dir != "/tmp" {
+		t.Errorf("expected working dir /tmp instead of %q", cmd.Dir)
+	}
+
+	cmd, err = x.Command(shellTask("a", "exit 0").Command)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"/bin/sh", "-c", "exit 0"}; !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("expected args %v instead of %v", expected, cmd.Args)
+	}
+
+	if _, err = x.Command(nil); err != ErrNoCommand {
+		t.Errorf("expected ErrNoCommand instead of %v", err)
+	}
+}
+
+func TestLauncher(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		rec    = &updateRecorder{}
+		m      = tasks.NewManager(tasks.WithUpdater(rec.update), tasks.WithGracePeriod(5*time.Second))
+		hooked bool
+		x      = New(WithHooks(EnvHook(func(mesos.TaskInfo) []string {
+			hooked = true
+			return []string{"EXIT_CODE=3"}
+		})))
+		launch = m.HandleLaunch(ctx, x.Launcher(m), nil)
+		state  = func(id string, s mesos.TaskState) func() bool {
+			return func() bool {
+				current, _ := m.State(mesos.TaskID{Value: id})
+				return current == s
+			}
+		}
+	)
+	for _, task := range []mesos.TaskInfo{
+		shellTask("finished", "exit 0"),
+		shellTask("failed", "exit $EXIT_CODE"),
+		shellTask("running", "exec sleep 30"),
+	} {
+		launch(ctx, launchEvent(task))
+	}
+	if !hooked {
+		t.Fatalf("expected hook to be invoked")
+	}
+	waitFor(t, "TASK_FINISHED", state("finished", mesos.TASK_FINISHED))
+	waitFor(t, "TASK_FAILED", state("failed", mesos.TASK_FAILED))
+
+	if err := m.Kill(ctx, mesos.TaskID{Value: "running"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, _ := m.State(mesos.TaskID{Value: "running"}); s != mesos.TASK_KILLED {
+		t.Errorf("expected TASK_KILLED instead of %v", s)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	var (
+		ctx      = context.Background()
+		rec      = &updateRecorder{}
+		m        = tasks.NewManager(tasks.WithUpdater(rec.update), tasks.WithGracePeriod(5*time.Second))
+		x        = New()
+		launch   = m.HandleLaunch(ctx, x.Launcher(m), nil)
+		zero     = float64(0)
+		interval = 0.01
+		failures = uint32(2)
+		healthy  = shellTask("healthy", "exec sleep 30")
+		sick     = shellTask("sick", "exec sleep 30")
+		failing  = "exit 1"
+	)
+	healthy.HealthCheck = &mesos.HealthCheck{
+		Type:            mesos.HealthCheck_TCP,
+		TCP:             &mesos.HealthCheck_TCPCheckInfo{Port: uint32(port)},
+		DelaySeconds:    &zero,
+		IntervalSeconds: &interval,
+	}
+	sick.HealthCheck = &mesos.HealthCheck{
+		Type:                mesos.HealthCheck_COMMAND,
+		Command:             &mesos.CommandInfo{Value: &failing},
+		DelaySeconds:        &zero,
+		IntervalSeconds:     &interval,
+		GracePeriodSeconds:  &zero,
+		ConsecutiveFailures: &failures,
+	}
+	launch(ctx, launchEvent(healthy))
+	launch(ctx, launchEvent(sick))
+
+	reported := func(id string, h bool) func() bool {
+		return func() bool {
+			return rec.find(func(s mesos.TaskStatus) bool {
+				return s.TaskID.Value == id && s.GetState() == mesos.TASK_RUNNING && s.Healthy != nil && *s.Healthy == h
+			})
+		}
+	}
+	waitFor(t, "healthy report", reported("healthy", true))
+	waitFor(t, "unhealthy report", reported("sick", false))
+	waitFor(t, "unhealthy task to be killed", func() bool {
+		s, _ := m.State(mesos.TaskID{Value: "sick"})
+		return s == mesos.TASK_KILLED
+	})
+	if err := m.Shutdown(ctx, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
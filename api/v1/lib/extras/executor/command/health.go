@@ -0,0 +1,168 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/tasks"
+)
+
+// healthCheck returns nil if the task is healthy.
+type healthCheck func(context.Context) error
+
+// ErrUnsupportedHealthCheck is returned for health checks of an unknown type.
+var ErrUnsupportedHealthCheck = errors.New("unsupported health check type")
+
+func (x *Executor) healthCheckFor(hc *mesos.HealthCheck) (healthCheck, error) {
+	switch hc.GetType() {
+	case mesos.HealthCheck_COMMAND:
+		if _, err := x.Command(hc.GetCommand()); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) error {
+			cmd, _ := x.Command(hc.GetCommand())
+			cmd.Stdout, cmd.Stderr = nil, nil
+			proc, err := Start(cmd)
+			if err != nil {
+				return err
+			}
+			select {
+			case <-proc.Done():
+				return proc.Err()
+			case <-ctx.Done():
+				proc.Kill()
+				<-proc.Done()
+				return ctx.Err()
+			}
+		}, nil
+
+	case mesos.HealthCheck_HTTP:
+		info := hc.GetHTTP()
+		scheme := info.GetScheme()
+		if scheme == "" {
+			scheme = "http"
+		}
+		u := fmt.Sprintf("%s://%s%s", scheme, hostPort(info.GetProtocol(), info.GetPort()), info.GetPath())
+		return func(ctx context.Context) error {
+			req, err := http.NewRequest("GET", u, nil)
+			if err != nil {
+				return err
+			}
+			res, err := http.DefaultClient.Do(req.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			res.Body.Close()
+			// consistent w/ Mesos, statuses in [200, 400) are considered healthy
+			if res.StatusCode < 200 || res.StatusCode >= 400 {
+				return fmt.Errorf("unexpected HTTP status code %d from %s", res.StatusCode, u)
+			}
+			return nil
+		}, nil
+
+	case mesos.HealthCheck_TCP:
+		info := hc.GetTCP()
+		addr := hostPort(info.GetProtocol(), info.GetPort())
+		return func(ctx context.Context) error {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		}, nil
+
+	default:
+		return nil, ErrUnsupportedHealthCheck
+	}
+}
+
+func hostPort(p mesos.NetworkInfo_Protocol, port uint32) string {
+	host := "127.0.0.1"
+	if p == mesos.IPv6 {
+		host = "::1"
+	}
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+}
+
+func seconds(s float64) time.Duration { return time.Duration(s * float64(time.Second)) }
+
+// watchHealth periodically checks the health of a task until the process of the task exits. Health is
+// reported upon the first check result and upon each transition between healthy and unhealthy; a result
+// that fails to be reported is reported again upon the next check. Failures
+// that occur within the grace period are ignored until the task has been healthy at least once. Once the
+// consecutive failure limit is reached the task is killed.
+func (x *Executor) watchHealth(ctx context.Context, m *tasks.Manager, id mesos.TaskID, hc *mesos.HealthCheck, proc tasks.Process) {
+	check, err := x.healthCheckFor(hc)
+	if err != nil {
+		log.Printf("task %s: invalid health check: %v", id.Value, err)
+		return
+	}
+	var (
+		started     = time.Now()
+		grace       = seconds(hc.GetGracePeriodSeconds())
+		interval    = seconds(hc.GetIntervalSeconds())
+		timeout     = seconds(hc.GetTimeoutSeconds())
+		maxFailures = hc.GetConsecutiveFailures()
+		timer       = time.NewTimer(seconds(hc.GetDelaySeconds()))
+
+		failures                       uint32
+		succeeded, reported, isHealthy bool
+	)
+	defer timer.Stop()
+	report := func(healthy bool, message string) {
+		if reported && healthy == isHealthy {
+			return
+		}
+		if err := m.ReportHealth(ctx, id, healthy, message); err != nil {
+			// try again upon the next result
+			if err != tasks.ErrUnknownTask {
+				log.Printf("task %s: failed to report health: %v", id.Value, err)
+			}
+			return
+		}
+		reported, isHealthy = true, healthy
+	}
+	for {
+		select {
+		case <-proc.Done():
+			return
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		err := runHealthCheck(ctx, check, timeout)
+		switch {
+		case err == nil:
+			failures, succeeded = 0, true
+			report(true, "")
+		case succeeded || time.Since(started) >= grace:
+			failures++
+			report(false, err.Error())
+			if maxFailures > 0 && failures >= maxFailures {
+				log.Printf("task %s: killing unhealthy task after %d consecutive failed health checks", id.Value, failures)
+				if err := m.Kill(ctx, id, nil); err != nil && err != tasks.ErrUnknownTask {
+					log.Printf("task %s: failed to kill unhealthy task: %v", id.Value, err)
+				}
+				return
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+func runHealthCheck(ctx context.Context, check healthCheck, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return check(ctx)
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// Process is a tasks.Process that's backed by an OS process.
+type Process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+// Start starts the given command and returns a Process that tracks it.
+func Start(cmd *exec.Cmd) (*Process, error) {
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	p := &Process{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		p.err = cmd.Wait()
+	}()
+	return p, nil
+}
+
+// Terminate sends SIGTERM to the process. On platforms that don't support SIGTERM an error is returned,
+// and callers are expected to escalate to Kill.
+func (p *Process) Terminate() error { return p.cmd.Process.Signal(syscall.SIGTERM) }
+
+// Kill forcibly terminates the process.
+func (p *Process) Kill() error { return p.cmd.Process.Kill() }
+
+// Done returns a chan that's closed once the process has exited.
+func (p *Process) Done() <-chan struct{} { return p.done }
+
+// Err returns the error that was reported upon waiting for the process to exit; nil if the process
+// exited with a zero status.
+func (p *Process) Err() error { return p.err }
+
+// Pid returns the ID of the process.
+func (p *Process) Pid() int { return p.cmd.Process.Pid }
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/config"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/events"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/controller"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/executor/tasks"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpexec"
+)
+
+const (
+	apiPath     = "/api/v1/executor"
+	httpTimeout = 10 * time.Second
+)
+
+// state tracks the updates that have not yet been acknowledged by the agent so that they may be
+// retransmitted upon resubscription.
+type state struct {
+	mu             sync.Mutex
+	unackedTasks   map[mesos.TaskID]mesos.TaskInfo
+	unackedUpdates map[string]executor.Call_Update
+	shouldQuit     bool
+}
+
+// Run subscribes to the agent specified by cfg and launches the tasks sent by the agent until the executor
+// is told to shut down, the context is canceled, or else the executor fails to (re)subscribe. Upon SHUTDOWN
+// the tasks are killed, and their TASK_KILLED updates sent, before Run returns nil. Otherwise, tasks that are
+// still running are killed before Run returns, but the subscription has ended by then: the TASK_KILLED
+// updates are sent on a best-effort basis and are never retransmitted.
+func (x *Executor) Run(ctx context.Context, cfg config.Config) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		apiURL = url.URL{
+			Scheme: "http",
+			Host:   cfg.AgentEndpoint,
+			Path:   apiPath,
+		}
+		http = httpcli.New(
+			httpcli.Endpoint(apiURL.String()),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeProtobuf]),
+			httpcli.Do(httpcli.With(httpcli.Timeout(httpTimeout), httpexec.AuthConfigOpt(cfg))),
+		)
+		callOptions = executor.CallOptions{
+			calls.Framework(cfg.FrameworkID),
+			calls.Executor(cfg.ExecutorID),
+		}
+		cli = calls.SenderWith(
+			httpexec.NewSender(http.Send),
+			callOptions...,
+		)
+		subscriber = calls.SenderWith(
+			httpexec.NewSender(http.Send, httpcli.Close(true)),
+			callOptions...,
+		)
+		s = &state{
+			unackedTasks:   make(map[mesos.TaskID]mesos.TaskInfo),
+			unackedUpdates: make(map[string]executor.Call_Update),
+		}
+		grace = cfg.ExecutorShutdownGracePeriod * 3 / 4 // reserve some time for sending status updates
+		m     = tasks.NewManager(append([]tasks.Option{
+			tasks.WithUpdater(s.updater(cli)),
+			tasks.WithExecutorID(&mesos.ExecutorID{Value: cfg.ExecutorID}),
+		}, x.taskOptions...)...)
+	)
+	err := controller.Run(
+		ctx,
+		subscriber,
+		controller.WithEventHandler(x.buildEventHandler(ctx, s, m, grace, cancel)),
+		controller.WithSubscribe(s.subscribe),
+		controller.WithRegistrationTokens(controller.RegistrationTokens(cfg, ctx.Done())),
+		controller.WithRecoveryTimeout(cfg.RecoveryTimeout),
+		controller.WithSubscriptionTerminated(func(err error) {
+			if err != nil && err != io.EOF {
+				log.Println(err)
+			}
+		}),
+	)
+
+	s.mu.Lock()
+	shouldQuit := s.shouldQuit
+	s.mu.Unlock()
+	if shouldQuit {
+		return nil
+	}
+	// don't orphan tasks. the subscription context has been canceled, so the updates are sent w/ a context
+	// of their own; they're lost if the agent is unreachable.
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ExecutorShutdownGracePeriod)
+	defer cancelShutdown()
+	m.Shutdown(shutdownCtx, grace)
+	if err == context.Canceled {
+		err = nil
+	}
+	return err
+}
+
+func (x *Executor) buildEventHandler(ctx context.Context, s *state, m *tasks.Manager, grace time.Duration, cancel context.CancelFunc) events.Handler {
+	var (
+		launcher = x.Launcher(m)
+		logError = func(err error) { log.Println(err) }
+		rules    = append(append([]eventrules.Rule(nil), x.rules...), controller.LiftErrors())
+		launch   = m.HandleLaunch(ctx, launcher, logError)
+		group    = m.HandleLaunchGroup(ctx, launcher, logError)
+	)
+	return eventrules.New(rules...).Handle(events.HandlerFuncs{
+		executor.Event_LAUNCH: func(ctx context.Context, e *executor.Event) error {
+			s.mu.Lock()
+			s.unackedTasks[e.GetLaunch().Task.TaskID] = e.GetLaunch().Task
+			s.mu.Unlock()
+			return launch(ctx, e)
+		},
+		executor.Event_LAUNCH_GROUP: func(ctx context.Context, e *executor.Event) error {
+			s.mu.Lock()
+			for _, t := range e.GetLaunchGroup().TaskGroup.Tasks {
+				s.unackedTasks[t.TaskID] = t
+			}
+			s.mu.Unlock()
+			return group(ctx, e)
+		},
+		executor.Event_KILL: m.HandleKill(ctx, logError),
+		executor.Event_ACKNOWLEDGED: func(_ context.Context, e *executor.Event) error {
+			s.mu.Lock()
+			delete(s.unackedTasks, e.Acknowledged.TaskID)
+			delete(s.unackedUpdates, string(e.Acknowledged.UUID))
+			s.mu.Unlock()
+			m.Acknowledged(e.Acknowledged.TaskID, e.Acknowledged.UUID)
+			return nil
+		},
+		executor.Event_SHUTDOWN: m.HandleShutdown(ctx, grace, func(err error) {
+			if err != nil {
+				log.Println(err)
+			}
+			s.mu.Lock()
+			s.shouldQuit = true
+			s.mu.Unlock()
+			cancel()
+		}),
+	})
+}
+
+// updater returns a tasks.Updater that sends status updates to the agent. Updates are tracked until they
+// have been acknowledged, even if they fail to send, so that they're retransmitted upon resubscription.
+func (s *state) updater(cli calls.Sender) tasks.Updater {
+	return func(ctx context.Context, status mesos.TaskStatus) error {
+		upd := calls.Update(status)
+		s.mu.Lock()
+		s.unackedUpdates[string(status.UUID)] = *upd.Update
+		s.mu.Unlock()
+
+		resp, err := cli.Send(ctx, calls.NonStreaming(upd))
+		if resp != nil {
+			resp.Close()
+		}
+		return err
+	}
+}
+
+func (s *state) subscribe() *executor.Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		unackedTasks   = make([]mesos.TaskInfo, 0, len(s.unackedTasks))
+		unackedUpdates = make([]executor.Call_Update, 0, len(s.unackedUpdates))
+	)
+	for _, t := range s.unackedTasks {
+		unackedTasks = append(unackedTasks, t)
+	}
+	for _, u := range s.unackedUpdates {
+		unackedUpdates = append(unackedUpdates, u)
+	}
+	return calls.Subscribe(unackedTasks, unackedUpdates)
+}
@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	"github.com/mesos/mesos-go/api/v1/lib/client"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/config"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/events"
+)
+
+type (
+	// Option modifies a Config, returns an Option that acts as an "undo"
+	Option func(*Config) Option
+
+	// Config is an opaque controller configuration. Properties are configured by applying Option funcs.
+	Config struct {
+		handler                events.Handler
+		subscribe              func() *executor.Call
+		registrationTokens     <-chan struct{}
+		subscriptionTerminated func(error)
+		recoveryTimeout        time.Duration
+		retryBudget            *backoff.Budget
+		listener               client.LifecycleListener
+	}
+
+	// StateError is returned by Run when the control loop aborts because of an unresolvable state.
+	StateError string
+)
+
+func (err StateError) Error() string { return string(err) }
+
+// ErrRecoveryTimeout is returned by Run when the executor fails to re-establish a subscription with the
+// agent within the configured recovery timeout.
+const ErrRecoveryTimeout = StateError("failed to re-establish subscription with agent within the recovery timeout")
+
+// WithEventHandler sets the consumer of executor events. The controller's internal event processing
+// loop is aborted if a Handler returns a non-nil error, after which the controller may attempt
+// to re-subscribe with the agent.
+func WithEventHandler(handler events.Handler) Option {
+	return func(c *Config) Option {
+		old := c.handler
+		c.handler = handler
+		return WithEventHandler(old)
+	}
+}
+
+// WithSubscribe sets a generator of SUBSCRIBE calls; the generator is invoked for each subscription attempt
+// so that it may report the most recent set of unacknowledged tasks and updates. When nil, the controller
+// subscribes without reporting any unacknowledged tasks or updates.
+func WithSubscribe(f func() *executor.Call) Option {
+	return func(c *Config) Option {
+		old := c.subscribe
+		c.subscribe = f
+		return WithSubscribe(old)
+	}
+}
+
+// WithSubscriptionTerminated sets a handler that is invoked at the end of every subscription cycle; the
+// given error may be nil if no error occurred. subscriptionTerminated is optional; if nil then errors are
+// swallowed.
+func WithSubscriptionTerminated(handler func(error)) Option {
+	return func(c *Config) Option {
+		old := c.subscriptionTerminated
+		c.subscriptionTerminated = handler
+		return WithSubscriptionTerminated(old)
+	}
+}
+
+// WithRegistrationTokens limits the rate at which an executor (re)subscribes with the agent.
+// A non-nil chan should yield a struct{} in order to allow the subscription process to continue.
+// When nil, there is no backoff delay between re-subscription attempts.
+// A closed chan disables re-subscription and terminates the Run control loop.
+func WithRegistrationTokens(registrationTokens <-chan struct{}) Option {
+	return func(c *Config) Option {
+		old := c.registrationTokens
+		c.registrationTokens = registrationTokens
+		return WithRegistrationTokens(old)
+	}
+}
+
+// WithRecoveryTimeout sets the maximum amount of time that the controller will spend attempting to
+// re-subscribe with the agent after a disconnection. Once exceeded, Run returns ErrRecoveryTimeout.
+// A zero duration disables the timeout.
+func WithRecoveryTimeout(d time.Duration) Option {
+	return func(c *Config) Option {
+		old := c.recoveryTimeout
+		c.recoveryTimeout = d
+		return WithRecoveryTimeout(old)
+	}
+}
+
+// WithRetryBudget limits re-subscription attempts w/ the given retry Budget, which may be shared w/ other
+// subsystems. Run returns backoff.ErrBudgetExceeded once a re-subscription attempt is denied by the budget.
+func WithRetryBudget(b *backoff.Budget) Option {
+	return func(c *Config) Option {
+		old := c.retryBudget
+		c.retryBudget = b
+		return WithRetryBudget(old)
+	}
+}
+
+// WithLifecycleListener notifies the given listener of the lifecycle transitions of the executor's
+// subscription: the establishment of a subscription stream (Connected), receipt of the SUBSCRIBED event
+// (Subscribed) and termination of the stream (Disconnected), along w/ the error that terminated it.
+func WithLifecycleListener(l client.LifecycleListener) Option {
+	return func(c *Config) Option {
+		old := c.listener
+		c.listener = l
+		return WithLifecycleListener(old)
+	}
+}
+
+// SubscribeOnce returns a registration token chan that allows for exactly one subscription attempt;
+// useful for executors of frameworks that have not enabled checkpointing, and therefore should not
+// attempt to reconnect to an agent.
+func SubscribeOnce() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	close(ch)
+	return ch
+}
+
+// RegistrationTokens returns a registration token chan that's appropriate for the given executor
+// configuration: executors of frameworks that have enabled checkpointing reattempt subscription w/ backoff
+// (until the given chan is closed) so that they may reconnect to a restarted agent, other executors are
+// allowed exactly one subscription attempt; see SubscribeOnce.
+func RegistrationTokens(cfg config.Config, until <-chan struct{}) <-chan struct{} {
+	if cfg.Checkpoint {
+		return backoff.Notifier(1*time.Second, cfg.SubscriptionBackoffMax*3/4, until)
+	}
+	return SubscribeOnce()
+}
+
+func (c *Config) subscribeCall() *executor.Call {
+	if c.subscribe != nil {
+		if call := c.subscribe(); call != nil {
+			return call
+		}
+	}
+	return calls.Subscribe(nil, nil)
+}
+
+func isDone(ctx context.Context) (result bool) {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// Run executes a control loop that subscribes an executor to its agent and processes the executor events
+// that flow through the subscription. Events are dispatched to the configured handler, which is typically
+// an events.Handlers map keyed by executor.Event_Type. Upon disconnection, if the context is not yet done
+// then the controller will attempt to re-subscribe and continue processing events, subject to the
+// configured registration tokens, retry budget and recovery timeout. The recovery timeout applies only
+// once an established subscription is lost; it doesn't bound the attempts to subscribe for the first time.
+//
+// Run returns ctx.Err() once the context is done, ErrRecoveryTimeout or backoff.ErrBudgetExceeded if
+// re-subscription is abandoned, and otherwise (when the registration tokens are closed) the error that
+// terminated the last subscription attempt.
+func Run(ctx context.Context, subscriber calls.Sender, options ...Option) error {
+	var config Config
+	for _, opt := range options {
+		if opt != nil {
+			opt(&config)
+		}
+	}
+	if config.handler == nil {
+		config.handler = DefaultHandler
+	}
+	var (
+		lastErr      error
+		disconnected time.Time // zero until a subscription is lost
+	)
+	for attempt := 0; ; attempt++ {
+		if isDone(ctx) {
+			return ctx.Err()
+		}
+		if config.registrationTokens != nil {
+			select {
+			case _, ok := <-config.registrationTokens:
+				if !ok {
+					// re-subscription canceled, exit Run loop
+					return lastErr
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if config.recoveryTimeout > 0 && !disconnected.IsZero() && time.Since(disconnected) > config.recoveryTimeout {
+			return ErrRecoveryTimeout
+		}
+		if attempt > 0 && !config.retryBudget.Allow() {
+			return backoff.ErrBudgetExceeded
+		}
+		resp, err := subscriber.Send(ctx, calls.NonStreaming(config.subscribeCall()))
+		var connected bool
+		connected, lastErr = processSubscription(ctx, config, resp, err)
+		if connected {
+			disconnected = time.Now()
+			client.Notify(config.listener, client.LifecycleEvent{Transition: client.Disconnected, Err: lastErr})
+		}
+		if config.subscriptionTerminated != nil {
+			config.subscriptionTerminated(lastErr)
+		}
+	}
+}
+
+func processSubscription(ctx context.Context, config Config, resp mesos.Response, err error) (bool, error) {
+	if resp != nil {
+		defer resp.Close()
+	}
+	if err != nil {
+		return false, err
+	}
+	client.Notify(config.listener, client.LifecycleEvent{Transition: client.Connected})
+	return true, eventLoop(ctx, config, resp)
+}
+
+func eventLoop(ctx context.Context, config Config, eventDecoder encoding.Decoder) (err error) {
+	for err == nil && !isDone(ctx) {
+		var e executor.Event
+		if err = eventDecoder.Decode(&e); err == nil {
+			if e.GetType() == executor.Event_SUBSCRIBED {
+				client.Notify(config.listener, client.LifecycleEvent{Transition: client.Subscribed})
+			}
+			err = config.handler.HandleEvent(ctx, &e)
+		}
+	}
+	return err
+}
+
+// DefaultHandler is invoked when no other handlers have been defined for the controller.
+// The current implementation does nothing.
+const DefaultHandler = events.NoopHandler
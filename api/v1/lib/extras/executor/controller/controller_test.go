@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/client"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/events"
+)
+
+// eventResponse returns a mesos.Response that yields the given events, followed by io.EOF.
+func eventResponse(es ...executor.Event) mesos.Response {
+	return &mesos.ResponseWrapper{
+		Closer: mesos.CloseFunc(func() error { return nil }),
+		Decoder: encoding.DecoderFunc(func(u encoding.Unmarshaler) error {
+			if len(es) == 0 {
+				return io.EOF
+			}
+			*(u.(*executor.Event)) = es[0]
+			es = es[1:]
+			return nil
+		}),
+	}
+}
+
+func TestRun(t *testing.T) {
+	var (
+		subscriptions int
+		sender        = calls.SenderFunc(func(_ context.Context, r calls.Request) (mesos.Response, error) {
+			if r.Call().GetType() != executor.Call_SUBSCRIBE {
+				t.Fatalf("unexpected call %v", r.Call())
+			}
+			subscriptions++
+			return eventResponse(
+				executor.Event{Type: executor.Event_SUBSCRIBED},
+				executor.Event{Type: executor.Event_MESSAGE, Message: &executor.Event_Message{Data: []byte("hello")}},
+			), nil
+		})
+		received   []executor.Event_Type
+		terminated []error
+		tokens     = make(chan struct{}, 2)
+	)
+	tokens <- struct{}{}
+	tokens <- struct{}{}
+	close(tokens)
+
+	err := Run(
+		context.Background(),
+		sender,
+		WithEventHandler(events.HandlerFunc(func(_ context.Context, e *executor.Event) error {
+			received = append(received, e.GetType())
+			return nil
+		})),
+		WithRegistrationTokens(tokens),
+		WithSubscriptionTerminated(func(err error) { terminated = append(terminated, err) }),
+	)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF instead of %v", err)
+	}
+	if subscriptions != 2 {
+		t.Fatalf("expected 2 subscription attempts instead of %d", subscriptions)
+	}
+	if len(received) != 4 {
+		t.Fatalf("expected 4 events instead of %v", received)
+	}
+	if len(terminated) != 2 {
+		t.Fatalf("expected 2 terminated subscriptions instead of %v", terminated)
+	}
+}
+
+func TestRun_SubscribeOnce(t *testing.T) {
+	var (
+		fakeErr       = errors.New("connection refused")
+		subscriptions int
+		sender        = calls.SenderFunc(func(_ context.Context, _ calls.Request) (mesos.Response, error) {
+			subscriptions++
+			return nil, fakeErr
+		})
+	)
+	err := Run(context.Background(), sender, WithRegistrationTokens(SubscribeOnce()))
+	if err != fakeErr {
+		t.Fatalf("expected %v instead of %v", fakeErr, err)
+	}
+	if subscriptions != 1 {
+		t.Fatalf("expected a single subscription attempt instead of %d", subscriptions)
+	}
+}
+
+func TestRun_LifecycleListener(t *testing.T) {
+	var (
+		transitions []client.Transition
+		sender      = calls.SenderFunc(func(_ context.Context, _ calls.Request) (mesos.Response, error) {
+			return eventResponse(executor.Event{Type: executor.Event_SUBSCRIBED}), nil
+		})
+	)
+	err := Run(context.Background(), sender,
+		WithRegistrationTokens(SubscribeOnce()),
+		WithLifecycleListener(client.LifecycleListenerFunc(func(e client.LifecycleEvent) {
+			transitions = append(transitions, e.Transition)
+			if e.Transition == client.Disconnected && e.Err != io.EOF {
+				t.Errorf("expected io.EOF instead of %v", e.Err)
+			}
+		})),
+	)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF instead of %v", err)
+	}
+	if !reflect.DeepEqual(transitions, []client.Transition{client.Connected, client.Subscribed, client.Disconnected}) {
+		t.Fatalf("unexpected transitions %v", transitions)
+	}
+}
+
+func TestRun_RecoveryTimeout(t *testing.T) {
+	var (
+		subscriptions int
+		sender        = calls.SenderFunc(func(_ context.Context, _ calls.Request) (mesos.Response, error) {
+			subscriptions++
+			if subscriptions == 3 {
+				return eventResponse(executor.Event{Type: executor.Event_SUBSCRIBED}), nil
+			}
+			return nil, errors.New("connection refused")
+		})
+	)
+	// the timeout doesn't bound the attempts to subscribe for the first time
+	err := Run(context.Background(), sender, WithRecoveryTimeout(1))
+	if err != ErrRecoveryTimeout {
+		t.Fatalf("expected %v instead of %v", ErrRecoveryTimeout, err)
+	}
+	if subscriptions != 3 {
+		t.Fatalf("expected 3 subscription attempts instead of %d", subscriptions)
+	}
+}
+
+func TestRun_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sender := calls.SenderFunc(func(_ context.Context, _ calls.Request) (mesos.Response, error) {
+		cancel()
+		return nil, errors.New("connection refused")
+	})
+	err := Run(ctx, sender, WithRecoveryTimeout(1))
+	if err != context.Canceled {
+		t.Fatalf("expected %v instead of %v", context.Canceled, err)
+	}
+}
+
+func TestLiftErrors(t *testing.T) {
+	e := &executor.Event{Type: executor.Event_ERROR, Error: &executor.Event_Error{Message: "bad call"}}
+	err := LiftErrors().HandleEvent(context.Background(), e)
+	if err != ErrEvent("bad call") {
+		t.Fatalf("expected ErrEvent instead of %v", err)
+	}
+	err = LiftErrors().HandleEvent(context.Background(), &executor.Event{Type: executor.Event_MESSAGE})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"log"
+
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	. "github.com/mesos/mesos-go/api/v1/lib/extras/executor/eventrules"
+)
+
+// ErrEvent errors are generated by LiftErrors upon receiving an ERROR event from the agent.
+type ErrEvent string
+
+func (e ErrEvent) Error() string {
+	return string(e)
+}
+
+// LiftErrors extract the error message from an executor error event and returns it as an ErrEvent
+// so that downstream rules/handlers may continue processing.
+func LiftErrors() Rule {
+	return func(ctx context.Context, e *executor.Event, err error, chain Chain) (context.Context, *executor.Event, error) {
+		if err != nil {
+			return chain(ctx, e, err)
+		}
+		if e.GetType() == executor.Event_ERROR {
+			// it's recommended that we abort and re-try subscribing; returning an
+			// error here will cause the event loop to terminate and the connection
+			// will be reset.
+			return chain(ctx, e, ErrEvent(e.GetError().GetMessage()))
+		}
+		return chain(ctx, e, nil)
+	}
+}
+
+// DefaultEventLabel is, by default, logged as the first argument by DefaultEventLogger
+const DefaultEventLabel = "event"
+
+// DefaultEventLogger logs the event via the `log` package.
+func DefaultEventLogger(eventLabel string) func(*executor.Event) {
+	if eventLabel == "" {
+		return func(e *executor.Event) { log.Println(e) }
+	}
+	return func(e *executor.Event) { log.Println(eventLabel, e) }
+}
+
+// LogEvents returns a rule that logs executor events to the EventLogger
+func LogEvents(f func(*executor.Event)) Rule {
+	if f == nil {
+		f = DefaultEventLogger(DefaultEventLabel)
+	}
+	return Rule(func(ctx context.Context, e *executor.Event, err error, chain Chain) (context.Context, *executor.Event, error) {
+		f(e)
+		return chain(ctx, e, err)
+	})
+}
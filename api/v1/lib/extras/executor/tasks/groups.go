@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type (
+	// Launcher starts the process of a task.
+	Launcher func(context.Context, mesos.TaskInfo) (Process, error)
+
+	// GroupPolicy determines how the termination of a task affects the other tasks of its task group.
+	GroupPolicy int
+
+	// group tracks the members of a task group.
+	group struct {
+		ids []mesos.TaskID
+	}
+)
+
+const (
+	// GroupPolicyNone allows the tasks of a group to terminate independently of each other.
+	GroupPolicyNone GroupPolicy = iota
+	// GroupPolicyKillOnFailure kills all remaining tasks of a group once any task of the group fails.
+	GroupPolicyKillOnFailure
+	// GroupPolicyKillOnExit kills all remaining tasks of a group once any task of the group terminates,
+	// successfully or otherwise.
+	GroupPolicyKillOnExit
+)
+
+func (p GroupPolicy) terminatesGroup(exitErr error) bool {
+	switch p {
+	case GroupPolicyKillOnFailure:
+		return exitErr != nil
+	case GroupPolicyKillOnExit:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithGroupPolicy sets the policy applied to tasks launched via LaunchGroup; defaults to GroupPolicyNone.
+func WithGroupPolicy(p GroupPolicy) Option {
+	return func(m *Manager) Option {
+		old := m.groupPolicy
+		m.groupPolicy = p
+		return WithGroupPolicy(old)
+	}
+}
+
+// LaunchGroup launches the tasks of a task group, all of which share the executor. Launching is atomic:
+// if any task fails to launch then the tasks that were launched are killed, and TASK_FAILED updates are
+// sent for the tasks that were not launched. Once launched, the termination of a task affects the other
+// tasks of the group according to the Manager's GroupPolicy.
+func (m *Manager) LaunchGroup(ctx context.Context, tg mesos.TaskGroupInfo, launch Launcher) error {
+	var (
+		g     = &group{ids: make([]mesos.TaskID, 0, len(tg.Tasks))}
+		procs = make([]Process, 0, len(tg.Tasks))
+	)
+	for i := range tg.Tasks {
+		g.ids = append(g.ids, tg.Tasks[i].TaskID)
+	}
+	for i := range tg.Tasks {
+		proc, err := launch(ctx, tg.Tasks[i])
+		if err != nil {
+			m.abortGroup(ctx, tg, procs, err)
+			return err
+		}
+		procs = append(procs, proc)
+	}
+	// register the whole group before any task is reported as running (or watched) so that the early
+	// termination of a task affects all of its siblings
+	ts := make([]*task, len(tg.Tasks))
+	m.mu.Lock()
+	for i := range tg.Tasks {
+		ts[i] = newTask(tg.Tasks[i], procs[i], g)
+		m.tasks[tg.Tasks[i].TaskID] = ts[i]
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, t := range ts {
+		if err := m.sendStatus(ctx, t.info.TaskID, mesos.TASK_RUNNING, ""); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, t := range ts {
+		m.supervise(ctx, t)
+	}
+	return firstErr
+}
+
+// abortGroup forcibly kills the processes that were launched for a task group and reports all tasks of
+// the group as failed.
+func (m *Manager) abortGroup(ctx context.Context, tg mesos.TaskGroupInfo, procs []Process, cause error) {
+	for _, proc := range procs {
+		proc.Kill() // ignore error; best effort
+	}
+	for i := range tg.Tasks {
+		m.sendStatus(ctx, tg.Tasks[i].TaskID, mesos.TASK_FAILED, "task group launch failed: "+cause.Error())
+	}
+}
+
+// killGroup concurrently kills the tasks of a group that are still tracked by the Manager.
+func (m *Manager) killGroup(ctx context.Context, g *group) {
+	var wg sync.WaitGroup
+	wg.Add(len(g.ids))
+	for _, id := range g.ids {
+		go func(id mesos.TaskID) {
+			defer wg.Done()
+			m.kill(ctx, id, nil, 0) // ignore error; ErrUnknownTask is expected for tasks that have terminated
+		}(id)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/events"
+)
+
+// HandleLaunch returns a handler for LAUNCH events that starts the task via the given Launcher. A task
+// that fails to launch is reported as TASK_FAILED; the error is also reported to the optional errorHandler.
+func (m *Manager) HandleLaunch(ctx context.Context, launch Launcher, errorHandler func(error)) events.HandlerFunc {
+	return func(_ context.Context, e *executor.Event) error {
+		l := e.GetLaunch()
+		if l == nil {
+			return nil
+		}
+		proc, err := launch(ctx, l.Task)
+		if err != nil {
+			m.sendStatus(ctx, l.Task.TaskID, mesos.TASK_FAILED, err.Error()) // report the launch error instead
+		} else {
+			err = m.Launch(ctx, l.Task, proc)
+		}
+		if err != nil && errorHandler != nil {
+			errorHandler(err)
+		}
+		return nil
+	}
+}
+
+// HandleLaunchGroup returns a handler for LAUNCH_GROUP events that atomically starts the tasks of the group
+// via the given Launcher. Errors are reported to the optional errorHandler.
+func (m *Manager) HandleLaunchGroup(ctx context.Context, launch Launcher, errorHandler func(error)) events.HandlerFunc {
+	return func(_ context.Context, e *executor.Event) error {
+		lg := e.GetLaunchGroup()
+		if lg == nil {
+			return nil
+		}
+		err := m.LaunchGroup(ctx, lg.TaskGroup, launch)
+		if err != nil && errorHandler != nil {
+			errorHandler(err)
+		}
+		return nil
+	}
+}
+
+// HandleKill returns a handler for KILL events that kills the identified task in the background so
+// that the event loop may continue to process, for example, ACKNOWLEDGED events. Errors that occur
+// while killing the task are reported to the optional errorHandler.
+func (m *Manager) HandleKill(ctx context.Context, errorHandler func(error)) events.HandlerFunc {
+	return func(_ context.Context, e *executor.Event) error {
+		k := e.GetKill()
+		if k == nil {
+			return nil
+		}
+		go func() {
+			err := m.Kill(ctx, k.TaskID, k.KillPolicy)
+			if err != nil && errorHandler != nil {
+				errorHandler(err)
+			}
+		}()
+		return nil
+	}
+}
+
+// HandleShutdown returns a handler for SHUTDOWN events that kills all tasks in the background, capping
+// the grace period of each task to the given gracePeriod. Once all tasks have terminated and their terminal
+// updates have been sent, done is invoked with the result of Shutdown; executors should exit at that point.
+func (m *Manager) HandleShutdown(ctx context.Context, gracePeriod time.Duration, done func(error)) events.HandlerFunc {
+	return func(_ context.Context, _ *executor.Event) error {
+		go func() {
+			err := m.Shutdown(ctx, gracePeriod)
+			if done != nil {
+				done(err)
+			}
+		}()
+		return nil
+	}
+}
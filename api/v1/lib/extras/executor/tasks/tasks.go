@@ -0,0 +1,442 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type (
+	// Process is the runtime representation of a launched task. Implementations are expected to be
+	// safe for concurrent use.
+	Process interface {
+		// Terminate requests that the process gracefully shut down; it's the SIGTERM-equivalent.
+		Terminate() error
+		// Kill forcibly terminates the process; it's the SIGKILL-equivalent.
+		Kill() error
+		// Done returns a chan that's closed once the process has exited.
+		Done() <-chan struct{}
+		// Err returns the reason that the process exited; nil indicates a successful exit.
+		// Only meaningful once Done has been closed.
+		Err() error
+	}
+
+	// Monitor is optionally implemented by a Process that watches over its task once the task has been
+	// reported as running; for example, by checking the health of the task.
+	Monitor interface {
+		// Monitor is invoked in a goroutine of its own after the TASK_RUNNING update has been sent. It
+		// should return once the process has exited or else the context is canceled.
+		Monitor(context.Context)
+	}
+
+	// Updater sends a task status update to the agent.
+	Updater func(context.Context, mesos.TaskStatus) error
+
+	// Option modifies a Manager, returns an Option that acts as an "undo"
+	Option func(*Manager) Option
+
+	// Manager tracks the processes of the tasks launched by an executor and orchestrates their
+	// termination. All Manager funcs are safe to invoke concurrently.
+	Manager struct {
+		mu           sync.Mutex
+		tasks        map[mesos.TaskID]*task
+		states       map[mesos.TaskID]reported // states are the most recently reported task states
+		update       Updater
+		executorID   *mesos.ExecutorID
+		gracePeriod  time.Duration
+		killingState bool
+		groupPolicy  GroupPolicy
+	}
+
+	// reported is the most recent status update of a task.
+	reported struct {
+		state mesos.TaskState
+		uuid  []byte
+	}
+
+	task struct {
+		info     mesos.TaskInfo
+		proc     Process
+		group    *group // group is nil for tasks that were not launched as part of a task group
+		kill     *kill  // kill is the kill that's underway, if any
+		escalate chan time.Duration // escalate yields revised grace periods for a task that's being killed
+	}
+
+	// kill is an attempt to kill a task.
+	kill struct {
+		done chan struct{} // done is closed once the kill has completed
+		err  error         // err is the result of the kill; only meaningful once done is closed
+	}
+)
+
+// ErrUnknownTask is returned when attempting to operate upon a task that the Manager is not tracking.
+var ErrUnknownTask = errors.New("unknown task")
+
+// DefaultGracePeriod is the amount of time allotted for a task to gracefully terminate when neither
+// the KILL event nor the TaskInfo specify a kill policy.
+const DefaultGracePeriod = 3 * time.Second
+
+// WithUpdater sets the func used to send task status updates; required.
+func WithUpdater(u Updater) Option {
+	return func(m *Manager) Option {
+		old := m.update
+		m.update = u
+		return WithUpdater(old)
+	}
+}
+
+// WithExecutorID sets the ExecutorID reported in generated task status updates.
+func WithExecutorID(id *mesos.ExecutorID) Option {
+	return func(m *Manager) Option {
+		old := m.executorID
+		m.executorID = id
+		return WithExecutorID(old)
+	}
+}
+
+// WithGracePeriod overrides DefaultGracePeriod.
+func WithGracePeriod(d time.Duration) Option {
+	return func(m *Manager) Option {
+		old := m.gracePeriod
+		m.gracePeriod = d
+		return WithGracePeriod(old)
+	}
+}
+
+// WithKillingState enables TASK_KILLING status updates for tasks that are being gracefully killed; it
+// should only be enabled for frameworks that have declared the TASK_KILLING_STATE capability.
+func WithKillingState(enabled bool) Option {
+	return func(m *Manager) Option {
+		old := m.killingState
+		m.killingState = enabled
+		return WithKillingState(old)
+	}
+}
+
+// NewManager returns a Manager configured with the given options.
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		tasks:       make(map[mesos.TaskID]*task),
+		states:      make(map[mesos.TaskID]reported),
+		gracePeriod: DefaultGracePeriod,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	return m
+}
+
+// KillingStateCapable returns true if the framework has declared the TASK_KILLING_STATE capability.
+func KillingStateCapable(fi *mesos.FrameworkInfo) bool {
+	return fi.HasCapability(mesos.FrameworkInfo_Capability_TASK_KILLING_STATE)
+}
+
+// Len returns the number of tasks currently tracked by the Manager.
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.tasks)
+}
+
+// State returns the most recently reported state of the identified task; false if no status update has
+// been sent for the task, or once the terminal update of the task has been acknowledged.
+func (m *Manager) State(id mesos.TaskID) (state mesos.TaskState, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.states[id]
+	return r.state, ok
+}
+
+// Acknowledged informs the Manager that the agent has acknowledged the identified status update of a task,
+// for example upon an ACKNOWLEDGED event. The state of a task is forgotten once its terminal update has
+// been acknowledged.
+func (m *Manager) Acknowledged(id mesos.TaskID, uuid []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.states[id]; ok && r.state.IsTerminal() && bytes.Equal(r.uuid, uuid) {
+		delete(m.states, id)
+	}
+}
+
+// Launch begins tracking the process of a task that has been started by the executor and sends a
+// TASK_RUNNING update on its behalf. Once the process exits, of its own accord, a TASK_FINISHED or
+// TASK_FAILED update is sent depending upon Process.Err; such updates always follow the TASK_RUNNING
+// update, even for processes that exit immediately.
+func (m *Manager) Launch(ctx context.Context, info mesos.TaskInfo, proc Process) error {
+	t := newTask(info, proc, nil)
+	m.mu.Lock()
+	m.tasks[info.TaskID] = t
+	m.mu.Unlock()
+
+	err := m.sendStatus(ctx, info.TaskID, mesos.TASK_RUNNING, "")
+	m.supervise(ctx, t) // even if the update can't be sent
+	return err
+}
+
+func newTask(info mesos.TaskInfo, proc Process, g *group) *task {
+	return &task{
+		info:     info,
+		proc:     proc,
+		group:    g,
+		escalate: make(chan time.Duration, 1),
+	}
+}
+
+// supervise watches the process of a task that's been reported as running, and starts its Monitor, if any.
+func (m *Manager) supervise(ctx context.Context, t *task) {
+	go m.watch(ctx, t)
+	if mon, ok := t.proc.(Monitor); ok {
+		go mon.Monitor(ctx)
+	}
+}
+
+func (m *Manager) watch(ctx context.Context, t *task) {
+	<-t.proc.Done()
+
+	m.mu.Lock()
+	killing := t.kill != nil
+	if !killing {
+		delete(m.tasks, t.info.TaskID)
+	}
+	m.mu.Unlock()
+
+	if killing {
+		// the goroutine that's killing the task reports the terminal state
+		return
+	}
+	err := t.proc.Err()
+	if err != nil {
+		m.sendStatus(ctx, t.info.TaskID, mesos.TASK_FAILED, err.Error())
+	} else {
+		m.sendStatus(ctx, t.info.TaskID, mesos.TASK_FINISHED, "")
+	}
+	if t.group != nil && m.groupPolicy.terminatesGroup(err) {
+		m.killGroup(ctx, t.group)
+	}
+}
+
+// Kill attempts to gracefully terminate the identified task, escalating to a forced termination if the
+// task has not terminated once the grace period expires. The grace period is determined by, in order of
+// precedence: the given kill policy, the kill policy of the task, the Manager's default grace period.
+// A TASK_KILLED update is sent once the task has terminated. Kill blocks until the task has terminated
+// or else the context is canceled, in which case the task remains tracked and may be killed anew; should
+// its process exit meanwhile, a TASK_FINISHED or TASK_FAILED update is sent as per Launch. A subsequent call to Kill for a task that's already being killed may
+// shorten, but not extend, the grace period; it also blocks until the task has terminated, and returns
+// the result of the kill that's already underway.
+func (m *Manager) Kill(ctx context.Context, id mesos.TaskID, policy *mesos.KillPolicy) error {
+	return m.kill(ctx, id, policy, 0)
+}
+
+// Shutdown concurrently kills all tasks that are tracked by the Manager, blocking until each has
+// terminated and a TASK_KILLED update has been sent (or else the context is canceled). The grace period of
+// each task is capped by the given gracePeriod; a zero gracePeriod imposes no limit. Callers should allot
+// some portion of the executor shutdown grace period for sending status updates.
+func (m *Manager) Shutdown(ctx context.Context, gracePeriod time.Duration) error {
+	m.mu.Lock()
+	ids := make([]mesos.TaskID, 0, len(m.tasks))
+	for id := range m.tasks {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, len(ids))
+	)
+	wg.Add(len(ids))
+	for _, id := range ids {
+		go func(id mesos.TaskID) {
+			defer wg.Done()
+			if err := m.kill(ctx, id, nil, gracePeriod); err != nil && err != ErrUnknownTask {
+				errs <- err
+			}
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs // nil if there were no errors
+}
+
+func (m *Manager) kill(ctx context.Context, id mesos.TaskID, policy *mesos.KillPolicy, max time.Duration) error {
+	m.mu.Lock()
+	t, ok := m.tasks[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrUnknownTask
+	}
+	grace := m.gracePeriodFor(t, policy)
+	if max > 0 && grace > max {
+		grace = max
+	}
+	if k := t.kill; k != nil {
+		// already being killed; possibly shorten the grace period, then wait for the kill to complete
+		m.mu.Unlock()
+		if policy != nil || max > 0 {
+			select {
+			case <-t.escalate:
+			default:
+			}
+			select {
+			case t.escalate <- grace:
+			default:
+				// lost the race with a concurrent escalation; that's ok
+			}
+		}
+		select {
+		case <-k.done:
+			return k.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	k := &kill{done: make(chan struct{})}
+	t.kill = k
+	select {
+	case <-t.escalate: // left over from an abandoned kill
+	default:
+	}
+	m.mu.Unlock()
+
+	k.err = m.killTask(ctx, t, grace)
+	close(k.done)
+	return k.err
+}
+
+func (m *Manager) killTask(ctx context.Context, t *task, grace time.Duration) error {
+	id := t.info.TaskID
+	var killingErr error
+	if m.killingState {
+		// failure to report TASK_KILLING shouldn't prevent the task from being killed
+		killingErr = m.sendStatus(ctx, id, mesos.TASK_KILLING, "")
+	}
+	err := terminate(ctx, t, grace)
+
+	m.mu.Lock()
+	select {
+	case <-t.proc.Done():
+		delete(m.tasks, id)
+		err = nil
+	default:
+		// the context was canceled before the process terminated: the task remains tracked so that it may
+		// be killed anew, and its watcher reports the terminal state should the process exit meanwhile
+		t.kill = nil
+	}
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := m.sendStatus(ctx, id, mesos.TASK_KILLED, ""); err != nil {
+		return err
+	}
+	return killingErr
+}
+
+// terminate sends a termination request to the process of the task and escalates to a forced kill
+// once the grace period expires.
+func terminate(ctx context.Context, t *task, grace time.Duration) error {
+	if err := t.proc.Terminate(); err != nil {
+		grace = 0 // escalate immediately
+	}
+	var (
+		deadline = time.Now().Add(grace)
+		timer    = time.NewTimer(grace)
+	)
+	defer func() { timer.Stop() }() // the timer is replaced upon escalation
+	for {
+		select {
+		case <-t.proc.Done():
+			return nil
+		case d := <-t.escalate:
+			if revised := time.Now().Add(d); revised.Before(deadline) {
+				deadline = revised
+				timer.Stop()
+				timer = time.NewTimer(d)
+			}
+		case <-timer.C:
+			t.proc.Kill() // ignore error; wait for the process to exit
+			select {
+			case <-t.proc.Done():
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *Manager) gracePeriodFor(t *task, policy *mesos.KillPolicy) time.Duration {
+	if gp := policy.GetGracePeriod(); gp != nil {
+		return gp.Duration()
+	}
+	if gp := t.info.GetKillPolicy().GetGracePeriod(); gp != nil {
+		return gp.Duration()
+	}
+	return m.gracePeriod
+}
+
+// ReportHealth sends a TASK_RUNNING update that reflects the result of a health check of the identified
+// task. Health is not reported for tasks that the Manager is not tracking, or that are being killed.
+func (m *Manager) ReportHealth(ctx context.Context, id mesos.TaskID, healthy bool, message string) error {
+	m.mu.Lock()
+	t, ok := m.tasks[id]
+	if ok && t.kill != nil {
+		ok = false
+	}
+	m.mu.Unlock()
+	if !ok {
+		return ErrUnknownTask
+	}
+	status, err := m.newStatus(id, mesos.TASK_RUNNING, message)
+	if err != nil {
+		return err
+	}
+	status.Healthy = &healthy
+	status.Reason = mesos.REASON_TASK_HEALTH_CHECK_STATUS_UPDATED.Enum()
+	return m.send(ctx, status)
+}
+
+func (m *Manager) sendStatus(ctx context.Context, id mesos.TaskID, state mesos.TaskState, message string) error {
+	status, err := m.newStatus(id, state, message)
+	if err != nil {
+		return err
+	}
+	return m.send(ctx, status)
+}
+
+func (m *Manager) send(ctx context.Context, status mesos.TaskStatus) error {
+	m.mu.Lock()
+	m.states[status.TaskID] = reported{state: status.GetState(), uuid: status.UUID}
+	m.mu.Unlock()
+
+	if m.update == nil {
+		return nil
+	}
+	return m.update(ctx, status)
+}
+
+func (m *Manager) newStatus(id mesos.TaskID, state mesos.TaskState, message string) (mesos.TaskStatus, error) {
+	uuid, err := mesos.NewUUID()
+	if err != nil {
+		return mesos.TaskStatus{}, err
+	}
+	status := mesos.TaskStatus{
+		TaskID:     id,
+		State:      state.Enum(),
+		Source:     mesos.SOURCE_EXECUTOR.Enum(),
+		ExecutorID: m.executorID,
+		UUID:       uuid,
+	}
+	if message != "" {
+		status.Message = &message
+	}
+	return status, nil
+}
@@ -0,0 +1,468 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// fakeProcess ignores termination requests when stubborn, otherwise it exits upon Terminate.
+type fakeProcess struct {
+	once     sync.Once
+	done     chan struct{}
+	err      error
+	stubborn bool
+
+	mu     sync.Mutex
+	killed bool
+}
+
+func newFakeProcess(stubborn bool) *fakeProcess {
+	return &fakeProcess{done: make(chan struct{}), stubborn: stubborn}
+}
+
+func (p *fakeProcess) exit(err error) {
+	p.once.Do(func() {
+		p.err = err
+		close(p.done)
+	})
+}
+
+func (p *fakeProcess) Terminate() error {
+	if !p.stubborn {
+		p.exit(errors.New("terminated"))
+	}
+	return nil
+}
+
+func (p *fakeProcess) Kill() error {
+	p.mu.Lock()
+	p.killed = true
+	p.mu.Unlock()
+	p.exit(errors.New("killed"))
+	return nil
+}
+
+func (p *fakeProcess) wasKilled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.killed
+}
+
+func (p *fakeProcess) Done() <-chan struct{} { return p.done }
+func (p *fakeProcess) Err() error            { return p.err }
+
+type updateRecorder struct {
+	mu      sync.Mutex
+	updates []mesos.TaskStatus
+}
+
+func (r *updateRecorder) update(_ context.Context, s mesos.TaskStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, s)
+	return nil
+}
+
+func (r *updateRecorder) last() mesos.TaskStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.updates[len(r.updates)-1]
+}
+
+func (r *updateRecorder) states(id string) (result []mesos.TaskState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.updates {
+		if s.TaskID.Value == id {
+			result = append(result, s.GetState())
+		}
+	}
+	return
+}
+
+func TestManager_Kill(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		rec     = &updateRecorder{}
+		m       = NewManager(WithUpdater(rec.update), WithKillingState(true), WithGracePeriod(time.Hour))
+		polite  = newFakeProcess(false)
+		stubby  = newFakeProcess(true)
+		shortKP = &mesos.KillPolicy{GracePeriod: &mesos.DurationInfo{Nanoseconds: int64(time.Millisecond)}}
+	)
+	m.Launch(ctx, mesos.TaskInfo{TaskID: mesos.TaskID{Value: "polite"}}, polite)
+	m.Launch(ctx, mesos.TaskInfo{TaskID: mesos.TaskID{Value: "stubby"}, KillPolicy: shortKP}, stubby)
+
+	if err := m.Kill(ctx, mesos.TaskID{Value: "polite"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polite.wasKilled() {
+		t.Errorf("expected polite process to terminate gracefully")
+	}
+	if err := m.Kill(ctx, mesos.TaskID{Value: "stubby"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stubby.wasKilled() {
+		t.Errorf("expected stubborn process to be forcibly killed")
+	}
+	expected := []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_KILLING, mesos.TASK_KILLED}
+	for _, id := range []string{"polite", "stubby"} {
+		if states := rec.states(id); !reflect.DeepEqual(states, expected) {
+			t.Errorf("expected %v for task %q instead of %v", expected, id, states)
+		}
+	}
+	if n := m.Len(); n != 0 {
+		t.Errorf("expected zero tracked tasks instead of %d", n)
+	}
+	if err := m.Kill(ctx, mesos.TaskID{Value: "polite"}, nil); err != ErrUnknownTask {
+		t.Errorf("expected ErrUnknownTask instead of %v", err)
+	}
+}
+
+func TestManager_KillCanceled(t *testing.T) {
+	var (
+		rec         = &updateRecorder{}
+		m           = NewManager(WithUpdater(rec.update), WithGracePeriod(time.Hour))
+		ctx, cancel = context.WithCancel(context.Background())
+		first       = newFakeProcess(true)
+		second      = newFakeProcess(true)
+		shortKP     = &mesos.KillPolicy{GracePeriod: &mesos.DurationInfo{Nanoseconds: int64(time.Millisecond)}}
+	)
+	m.Launch(context.Background(), mesos.TaskInfo{TaskID: mesos.TaskID{Value: "first"}}, first)
+	m.Launch(context.Background(), mesos.TaskInfo{TaskID: mesos.TaskID{Value: "second"}}, second)
+
+	// a canceled kill leaves the task tracked, and its watcher reports the exit of the process
+	cancel()
+	if err := m.Kill(ctx, mesos.TaskID{Value: "first"}, nil); err != context.Canceled {
+		t.Fatalf("expected context.Canceled instead of %v", err)
+	}
+	if n := m.Len(); n != 2 {
+		t.Fatalf("expected 2 tracked tasks instead of %d", n)
+	}
+	first.exit(errors.New("crashed"))
+	deadline := time.After(5 * time.Second)
+	for m.Len() > 1 || len(rec.states("first")) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for terminal update")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if states := rec.states("first"); !reflect.DeepEqual(states, []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_FAILED}) {
+		t.Errorf("unexpected states %v", states)
+	}
+
+	// a task may be killed anew once a kill was canceled
+	if err := m.Kill(ctx, mesos.TaskID{Value: "second"}, nil); err != context.Canceled {
+		t.Fatalf("expected context.Canceled instead of %v", err)
+	}
+	if err := m.Kill(context.Background(), mesos.TaskID{Value: "second"}, shortKP); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if states := rec.states("second"); !reflect.DeepEqual(states, []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_KILLED}) {
+		t.Errorf("unexpected states %v", states)
+	}
+	if n := m.Len(); n != 0 {
+		t.Errorf("expected zero tracked tasks instead of %d", n)
+	}
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		rec   = &updateRecorder{}
+		m     = NewManager(WithUpdater(rec.update), WithGracePeriod(time.Hour))
+		procs = []*fakeProcess{newFakeProcess(true), newFakeProcess(false), newFakeProcess(true)}
+		ids   = []string{"a", "b", "c"}
+	)
+	for i := range procs {
+		m.Launch(ctx, mesos.TaskInfo{TaskID: mesos.TaskID{Value: ids[i]}}, procs[i])
+	}
+	if err := m.Shutdown(ctx, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_KILLED}
+	for i := range ids {
+		if states := rec.states(ids[i]); !reflect.DeepEqual(states, expected) {
+			t.Errorf("expected %v for task %q instead of %v", expected, ids[i], states)
+		}
+		if killed := procs[i].wasKilled(); killed != procs[i].stubborn {
+			t.Errorf("task %q: expected killed=%v", ids[i], procs[i].stubborn)
+		}
+	}
+}
+
+func TestManager_Exited(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		rec  = &updateRecorder{}
+		m    = NewManager(WithUpdater(rec.update))
+		proc = newFakeProcess(false)
+	)
+	m.Launch(ctx, mesos.TaskInfo{TaskID: mesos.TaskID{Value: "a"}}, proc)
+	proc.exit(nil)
+
+	deadline := time.After(5 * time.Second)
+	for m.Len() > 0 || len(rec.states("a")) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for terminal update")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	expected := []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_FINISHED}
+	if states := rec.states("a"); !reflect.DeepEqual(states, expected) {
+		t.Errorf("expected %v instead of %v", expected, states)
+	}
+}
+
+func TestManager_ExitedImmediately(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var (
+			ctx  = context.Background()
+			rec  = &updateRecorder{}
+			m    = NewManager(WithUpdater(rec.update))
+			proc = newFakeProcess(false)
+		)
+		proc.exit(errors.New("crashed"))
+		m.Launch(ctx, mesos.TaskInfo{TaskID: mesos.TaskID{Value: "a"}}, proc)
+
+		deadline := time.After(5 * time.Second)
+		for len(rec.states("a")) < 2 {
+			select {
+			case <-deadline:
+				t.Fatalf("timed out waiting for terminal update")
+			case <-time.After(time.Millisecond):
+			}
+		}
+		expected := []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_FAILED}
+		if states := rec.states("a"); !reflect.DeepEqual(states, expected) {
+			t.Fatalf("expected %v instead of %v", expected, states)
+		}
+	}
+}
+
+// monitoredProcess records the states that were reported for its task when it began monitoring.
+type monitoredProcess struct {
+	*fakeProcess
+	rec       *updateRecorder
+	monitored chan []mesos.TaskState
+}
+
+func (p *monitoredProcess) Monitor(context.Context) { p.monitored <- p.rec.states("a") }
+
+func TestManager_Monitor(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		rec  = &updateRecorder{}
+		m    = NewManager(WithUpdater(rec.update))
+		proc = &monitoredProcess{newFakeProcess(false), rec, make(chan []mesos.TaskState, 1)}
+	)
+	m.Launch(ctx, mesos.TaskInfo{TaskID: mesos.TaskID{Value: "a"}}, proc)
+	select {
+	case states := <-proc.monitored:
+		if expected := []mesos.TaskState{mesos.TASK_RUNNING}; !reflect.DeepEqual(states, expected) {
+			t.Fatalf("expected %v instead of %v", expected, states)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the process to be monitored")
+	}
+	if _, ok := m.State(mesos.TaskID{Value: "a"}); !ok {
+		t.Fatal("expected the task to be tracked")
+	}
+	proc.exit(nil)
+}
+
+func TestManager_KillTwice(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		rec     = &updateRecorder{}
+		m       = NewManager(WithUpdater(rec.update), WithKillingState(true), WithGracePeriod(time.Hour))
+		proc    = newFakeProcess(true)
+		id      = mesos.TaskID{Value: "a"}
+		first   = make(chan error, 1)
+		shortKP = &mesos.KillPolicy{GracePeriod: &mesos.DurationInfo{Nanoseconds: int64(time.Millisecond)}}
+	)
+	m.Launch(ctx, mesos.TaskInfo{TaskID: id}, proc)
+	go func() { first <- m.Kill(ctx, id, nil) }()
+	deadline := time.After(5 * time.Second)
+	for {
+		if state, _ := m.State(id); state == mesos.TASK_KILLING {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for TASK_KILLING")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	// a subsequent kill blocks until the task has terminated
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := m.Kill(timeout, id, nil); err != context.DeadlineExceeded {
+		t.Fatalf("expected the kill to block instead of %v", err)
+	}
+	if err := m.Kill(ctx, id, shortKP); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !proc.wasKilled() {
+		t.Fatal("expected the process to be killed once the grace period was shortened")
+	}
+	if err := <-first; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_KILLING, mesos.TASK_KILLED}
+	if states := rec.states("a"); !reflect.DeepEqual(states, expected) {
+		t.Fatalf("expected %v instead of %v", expected, states)
+	}
+}
+
+func TestManager_LaunchGroup(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		rec   = &updateRecorder{}
+		m     = NewManager(WithUpdater(rec.update), WithGroupPolicy(GroupPolicyKillOnFailure))
+		procs = map[string]*fakeProcess{"a": newFakeProcess(false), "b": newFakeProcess(false)}
+		tg    = mesos.TaskGroupInfo{Tasks: []mesos.TaskInfo{
+			{TaskID: mesos.TaskID{Value: "a"}},
+			{TaskID: mesos.TaskID{Value: "b"}},
+		}}
+		launcher = Launcher(func(_ context.Context, ti mesos.TaskInfo) (Process, error) {
+			return procs[ti.TaskID.Value], nil
+		})
+	)
+	if err := m.LaunchGroup(ctx, tg, launcher); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := m.Len(); n != 2 {
+		t.Fatalf("expected 2 tracked tasks instead of %d", n)
+	}
+
+	// the failure of one task should kill the others
+	procs["a"].exit(errors.New("crashed"))
+
+	deadline := time.After(5 * time.Second)
+	for {
+		state, _ := m.State(mesos.TaskID{Value: "b"})
+		if m.Len() == 0 && state == mesos.TASK_KILLED {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for task group to terminate")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if state, _ := m.State(mesos.TaskID{Value: "a"}); state != mesos.TASK_FAILED {
+		t.Errorf("expected TASK_FAILED instead of %v", state)
+	}
+}
+
+func TestManager_LaunchGroupExitedImmediately(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var (
+			ctx   = context.Background()
+			rec   = &updateRecorder{}
+			m     = NewManager(WithUpdater(rec.update), WithGroupPolicy(GroupPolicyKillOnFailure))
+			procs = map[string]*fakeProcess{"a": newFakeProcess(false), "b": newFakeProcess(false)}
+			tg    = mesos.TaskGroupInfo{Tasks: []mesos.TaskInfo{
+				{TaskID: mesos.TaskID{Value: "a"}},
+				{TaskID: mesos.TaskID{Value: "b"}},
+			}}
+			launcher = Launcher(func(_ context.Context, ti mesos.TaskInfo) (Process, error) {
+				return procs[ti.TaskID.Value], nil
+			})
+		)
+		procs["a"].exit(errors.New("crashed"))
+		if err := m.LaunchGroup(ctx, tg, launcher); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deadline := time.After(5 * time.Second)
+		for m.Len() > 0 || len(rec.states("b")) < 2 {
+			select {
+			case <-deadline:
+				t.Fatalf("timed out waiting for task group to terminate")
+			case <-time.After(time.Millisecond):
+			}
+		}
+		expected := []mesos.TaskState{mesos.TASK_RUNNING, mesos.TASK_KILLED}
+		if states := rec.states("b"); !reflect.DeepEqual(states, expected) {
+			t.Fatalf("expected %v instead of %v", expected, states)
+		}
+	}
+}
+
+func TestManager_Acknowledged(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		rec  = &updateRecorder{}
+		m    = NewManager(WithUpdater(rec.update))
+		proc = newFakeProcess(false)
+		id   = mesos.TaskID{Value: "a"}
+	)
+	m.Launch(ctx, mesos.TaskInfo{TaskID: id}, proc)
+	running := rec.last()
+	m.Acknowledged(id, running.UUID)
+	if state, ok := m.State(id); !ok || state != mesos.TASK_RUNNING {
+		t.Fatalf("expected TASK_RUNNING instead of %v, %v", state, ok)
+	}
+
+	proc.exit(nil)
+	deadline := time.After(5 * time.Second)
+	for len(rec.states("a")) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for terminal update")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	m.Acknowledged(id, running.UUID) // a stale acknowledgement doesn't prune the terminal state
+	if state, ok := m.State(id); !ok || state != mesos.TASK_FINISHED {
+		t.Fatalf("expected TASK_FINISHED instead of %v, %v", state, ok)
+	}
+	m.Acknowledged(id, rec.last().UUID)
+	if _, ok := m.State(id); ok {
+		t.Fatal("expected the state of the task to be forgotten")
+	}
+}
+
+func TestManager_LaunchGroupFailure(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		rec       = &updateRecorder{}
+		m         = NewManager(WithUpdater(rec.update))
+		launched  = newFakeProcess(true)
+		launchErr = errors.New("no such file")
+		tg        = mesos.TaskGroupInfo{Tasks: []mesos.TaskInfo{
+			{TaskID: mesos.TaskID{Value: "a"}},
+			{TaskID: mesos.TaskID{Value: "b"}},
+		}}
+		launcher = Launcher(func(_ context.Context, ti mesos.TaskInfo) (Process, error) {
+			if ti.TaskID.Value == "b" {
+				return nil, launchErr
+			}
+			return launched, nil
+		})
+	)
+	if err := m.LaunchGroup(ctx, tg, launcher); err != launchErr {
+		t.Fatalf("expected %v instead of %v", launchErr, err)
+	}
+	if !launched.wasKilled() {
+		t.Errorf("expected launched task to be killed")
+	}
+	for _, id := range []string{"a", "b"} {
+		expected := []mesos.TaskState{mesos.TASK_FAILED}
+		if states := rec.states(id); !reflect.DeepEqual(states, expected) {
+			t.Errorf("expected %v for task %q instead of %v", expected, id, states)
+		}
+	}
+	if n := m.Len(); n != 0 {
+		t.Errorf("expected zero tracked tasks instead of %d", n)
+	}
+}
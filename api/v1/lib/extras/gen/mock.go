@@ -0,0 +1,377 @@
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// mock generates a mock implementation of an interface: for every method the mock has a func field,
+// named after the method w/ a "Func" suffix, that the method delegates to. Methods whose func field is
+// nil return zero values. Every invocation is recorded by the (hand-written) recorder type that the
+// destination package is expected to provide.
+func main() {
+	var (
+		c      = NewConfig()
+		source string
+		iface  string
+		name   string
+		output string
+		fs     = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	)
+	fs.StringVar(&source, "source", "", "import path of the package that declares the interface")
+	fs.StringVar(&iface, "interface", "", "name of the interface to mock")
+	fs.StringVar(&name, "name", "", "name of the generated mock type; defaults to the interface name")
+	fs.StringVar(&output, "output", "", "path of the to-be-generated file")
+	c.AddFlags(fs)
+	fs.Parse(os.Args[1:])
+
+	if source == "" || iface == "" || output == "" {
+		fs.PrintDefaults()
+		os.Exit(2)
+	}
+	if name == "" {
+		name = iface
+	}
+	if c.Package == "" {
+		c.Package = "foo"
+	}
+	c.Args = strings.Join(os.Args[1:], " ")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	bp, err := build.Import(source, wd, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, bp.Dir, func(fi os.FileInfo) bool {
+		for _, f := range bp.GoFiles {
+			if f == fi.Name() {
+				return true
+			}
+		}
+		return false
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var (
+		it    *ast.InterfaceType
+		file  *ast.File
+		found = false
+	)
+	for _, p := range pkgs {
+		for _, f := range p.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == iface {
+					if it, found = ts.Type.(*ast.InterfaceType); found {
+						file = f
+					}
+				}
+				return !found
+			})
+		}
+	}
+	if !found {
+		log.Fatalf("interface %s not found in %s", iface, source)
+	}
+
+	q := &qualifier{pkg: bp.Name, source: source, srcDir: bp.Dir, file: file, imports: map[string]string{source: bp.Name}}
+	m := mock{Config: c, Name: name, Qualified: bp.Name + "." + iface}
+	for _, f := range it.Methods.List {
+		ft, ok := f.Type.(*ast.FuncType)
+		if !ok || len(f.Names) != 1 {
+			log.Fatalf("embedded interfaces are not supported: %s", iface)
+		}
+		m.Methods = append(m.Methods, q.method(f.Names[0].Name, q.qualify(ft).(*ast.FuncType)))
+	}
+	q.use(source) // for the compile-time interface check
+	for _, p := range c.Imports {
+		q.use(p)
+	}
+	c.Imports = q.sortedImports()
+
+	Generate(map[string]*template.Template{output: mockTemplate}, &m, func(err error) { log.Fatal(err) })
+	gofmt(output)
+}
+
+type (
+	// mock is the data of mockTemplate.
+	mock struct {
+		*Config
+		Name      string // Name of the mock type
+		Qualified string // Qualified name of the interface
+		Methods   []method
+	}
+
+	method struct {
+		Name     string
+		FuncType string // FuncType of the func field, w/o param names
+		Params   string
+		Results  string
+		Args     string // Args passed to the func field
+		Recorded string // Recorded args, each preceded by a comma
+	}
+)
+
+func (q *qualifier) method(name string, ft *ast.FuncType) method {
+	var params, args, results, recorded []string
+	for _, p := range ft.Params.List {
+		n := len(p.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			a := fmt.Sprintf("a%d", len(args))
+			params = append(params, a+" "+q.print(p.Type))
+			recorded = append(recorded, a)
+			if _, variadic := p.Type.(*ast.Ellipsis); variadic {
+				a += "..."
+			}
+			args = append(args, a)
+		}
+	}
+	if ft.Results != nil {
+		for _, r := range ft.Results.List {
+			n := len(r.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				results = append(results, fmt.Sprintf("r%d %s", len(results), q.print(r.Type)))
+			}
+		}
+	}
+	// func field types don't need param names
+	ft.Params = stripNames(ft.Params)
+	ft.Results = stripNames(ft.Results)
+	return method{
+		Name:     name,
+		FuncType: q.print(ft),
+		Params:   strings.Join(params, ", "),
+		Results:  strings.Join(results, ", "),
+		Args:     strings.Join(args, ", "),
+		Recorded: prefixAll(", ", recorded),
+	}
+}
+
+// StdImports returns the imports of the standard library, which precede the others.
+func (m *mock) StdImports() (result []string) {
+	for _, p := range m.Imports {
+		if !strings.Contains(p, ".") {
+			result = append(result, p)
+		}
+	}
+	return
+}
+
+// OtherImports returns the imports that aren't of the standard library.
+func (m *mock) OtherImports() (result []string) {
+	for _, p := range m.Imports {
+		if strings.Contains(p, ".") {
+			result = append(result, p)
+		}
+	}
+	return
+}
+
+// gofmt formats the generated file, which aligns the func fields of the mock.
+func gofmt(filename string) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := format.Source(b)
+	if err != nil {
+		log.Fatalf("%v\n%s", err, b)
+	}
+	if err = ioutil.WriteFile(filename, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var mockTemplate = template.Must(template.New("").Parse(`package {{.Package}}
+
+// go generate {{.Args}}
+// GENERATED CODE FOLLOWS; DO NOT EDIT.
+
+import (
+{{range .StdImports}}	{{printf "%q" .}}
+{{end}}{{if and .StdImports .OtherImports}}
+{{end}}{{range .OtherImports}}	{{printf "%q" .}}
+{{end}})
+
+// {{.Name}} is a mock implementation of {{.Qualified}}. Each method delegates to the func field of the same name
+// suffixed w/ "Func", returning zero values if that field is nil. Invocations are recorded.
+type {{.Name}} struct {
+{{range .Methods}}	{{.Name}}Func {{.FuncType}}
+{{end}}
+	recorder
+}
+
+var _ = {{.Qualified}}(&{{.Name}}{})
+{{range .Methods}}
+// {{.Name}} implements {{$.Qualified}}.
+func (m *{{$.Name}}) {{.Name}}({{.Params}}) ({{.Results}}) {
+	m.record({{printf "%q" .Name}}{{.Recorded}})
+	if m.{{.Name}}Func != nil {
+		{{if .Results}}return {{end}}m.{{.Name}}Func({{.Args}})
+	}
+	return
+}
+{{end}}`))
+
+func prefixAll(sep string, s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return sep + strings.Join(s, sep)
+}
+
+func stripNames(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	result := &ast.FieldList{}
+	for _, f := range fl.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			result.List = append(result.List, &ast.Field{Type: f.Type})
+		}
+	}
+	return result
+}
+
+// qualifier rewrites the types of a source package so that they may be referenced from another package,
+// keeping track of the imports that the rewritten types depend upon.
+type qualifier struct {
+	pkg     string // name of the source package
+	source  string // import path of the source package
+	srcDir  string
+	file    *ast.File
+	imports map[string]string // import path -> package name
+	used    map[string]bool
+}
+
+func (q *qualifier) use(p string) {
+	if q.used == nil {
+		q.used = make(map[string]bool)
+	}
+	q.used[p] = true
+}
+
+func (q *qualifier) sortedImports() []string {
+	var result []string
+	for p := range q.used {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		si, sj := strings.Contains(result[i], "."), strings.Contains(result[j], ".")
+		if si != sj {
+			return sj
+		}
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// resolve returns the import path of the package that's referred to as x in the source file.
+func (q *qualifier) resolve(x string) string {
+	for _, spec := range q.file.Imports {
+		p := strings.Trim(spec.Path.Value, `"`)
+		if spec.Name != nil {
+			if spec.Name.Name == x {
+				return p
+			}
+			continue
+		}
+		name, ok := q.imports[p]
+		if !ok {
+			name = path.Base(p)
+			if bp, err := build.Import(p, q.srcDir, 0); err == nil {
+				name = bp.Name
+			}
+			q.imports[p] = name
+		}
+		if name == x {
+			return p
+		}
+	}
+	log.Fatalf("unresolved package %q", x)
+	return ""
+}
+
+func (q *qualifier) qualify(e ast.Expr) ast.Expr {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(e.Name[0])) {
+			q.use(q.source)
+			return &ast.SelectorExpr{X: ast.NewIdent(q.pkg), Sel: e}
+		}
+		return e
+	case *ast.SelectorExpr:
+		q.use(q.resolve(e.X.(*ast.Ident).Name))
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: q.qualify(e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: q.qualify(e.Elt)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: q.qualify(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: q.qualify(e.Key), Value: q.qualify(e.Value)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: q.qualify(e.Value)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: q.qualifyFields(e.Params), Results: q.qualifyFields(e.Results)}
+	case *ast.InterfaceType:
+		if len(e.Methods.List) > 0 {
+			log.Fatal("anonymous interfaces w/ methods are not supported")
+		}
+		return e
+	}
+	log.Fatalf("unsupported type expression %T", e)
+	return nil
+}
+
+func (q *qualifier) qualifyFields(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	result := &ast.FieldList{}
+	for _, f := range fl.List {
+		result.List = append(result.List, &ast.Field{Names: f.Names, Type: q.qualify(f.Type)})
+	}
+	return result
+}
+
+func (q *qualifier) print(e ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), e); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
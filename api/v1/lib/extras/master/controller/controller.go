@@ -0,0 +1,235 @@
+// Package controller implements a control loop that subscribes to the event stream of the master operator
+// API, re-subscribing upon disconnection. It's intended for services that watch the state of a cluster.
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/master/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/master/events"
+)
+
+type (
+	// Option modifies a Config, returns an Option that acts as an "undo"
+	Option func(*Config) Option
+
+	// Config is an opaque controller configuration. Properties are configured by applying Option funcs.
+	Config struct {
+		handler                events.Handler
+		registrationTokens     <-chan struct{}
+		subscriptionTerminated func(error)
+		heartbeatMultiplier    float64
+		retryBudget            *backoff.Budget
+	}
+
+	// StateError is returned when the control loop aborts a subscription because of an unexpected state.
+	StateError string
+)
+
+func (err StateError) Error() string { return string(err) }
+
+// ErrMissedHeartbeats is reported to the subscriptionTerminated handler when a subscription is aborted
+// because the master failed to send heartbeats in a timely manner.
+const ErrMissedHeartbeats = StateError("missed heartbeats from master")
+
+// DefaultHeartbeatMultiplier is the default number of heartbeat intervals that may elapse without
+// receiving an event before the subscription is considered to be dead.
+const DefaultHeartbeatMultiplier = 3
+
+// WithEventHandler sets the consumer of master events. Upon every (re)subscription the first event is
+// a SUBSCRIBED event that contains a snapshot of the cluster state; handlers should use it to replace any
+// state that was derived from prior events. The controller's internal event processing loop is aborted if
+// a Handler returns a non-nil error, after which the controller may attempt to re-subscribe.
+func WithEventHandler(handler events.Handler) Option {
+	return func(c *Config) Option {
+		old := c.handler
+		c.handler = handler
+		return WithEventHandler(old)
+	}
+}
+
+// WithSubscriptionTerminated sets a handler that is invoked at the end of every subscription cycle; the
+// given error may be nil if no error occurred. subscriptionTerminated is optional; if nil then errors are
+// swallowed.
+func WithSubscriptionTerminated(handler func(error)) Option {
+	return func(c *Config) Option {
+		old := c.subscriptionTerminated
+		c.subscriptionTerminated = handler
+		return WithSubscriptionTerminated(old)
+	}
+}
+
+// WithRegistrationTokens limits the rate at which the controller (re)subscribes with the master.
+// A non-nil chan should yield a struct{} in order to allow the subscription process to continue.
+// When nil, there is no backoff delay between re-subscription attempts.
+// A closed chan disables re-subscription and terminates the Run control loop.
+func WithRegistrationTokens(registrationTokens <-chan struct{}) Option {
+	return func(c *Config) Option {
+		old := c.registrationTokens
+		c.registrationTokens = registrationTokens
+		return WithRegistrationTokens(old)
+	}
+}
+
+// WithHeartbeatMultiplier overrides DefaultHeartbeatMultiplier. A subscription is aborted if no events
+// are received within the heartbeat interval (as advertised by the master) times the multiplier.
+// A non-positive multiplier disables heartbeat monitoring.
+func WithHeartbeatMultiplier(m float64) Option {
+	return func(c *Config) Option {
+		old := c.heartbeatMultiplier
+		c.heartbeatMultiplier = m
+		return WithHeartbeatMultiplier(old)
+	}
+}
+
+// WithRetryBudget limits re-subscription attempts w/ the given retry Budget, which may be shared w/ other
+// subsystems. Run returns backoff.ErrBudgetExceeded once a re-subscription attempt is denied by the budget.
+func WithRetryBudget(b *backoff.Budget) Option {
+	return func(c *Config) Option {
+		old := c.retryBudget
+		c.retryBudget = b
+		return WithRetryBudget(old)
+	}
+}
+
+func isDone(ctx context.Context) (result bool) {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// Run executes a control loop that subscribes to the event stream of the master and processes the
+// master events that flow through the subscription. Events are dispatched to the configured handler,
+// which is typically an events.Handlers map keyed by master.Event_Type. Upon disconnection, if the
+// context is not yet done then the controller will attempt to re-subscribe and continue processing
+// events, subject to the configured registration tokens. The sender should be configured to send
+// requests to the leading master.
+func Run(ctx context.Context, subscriber calls.Sender, options ...Option) (lastErr error) {
+	config := Config{heartbeatMultiplier: DefaultHeartbeatMultiplier}
+	for _, opt := range options {
+		if opt != nil {
+			opt(&config)
+		}
+	}
+	if config.handler == nil {
+		config.handler = DefaultHandler
+	}
+	for attempt := 0; !isDone(ctx); attempt++ {
+		if config.registrationTokens != nil {
+			select {
+			case _, ok := <-config.registrationTokens:
+				if !ok {
+					// re-subscription canceled, exit Run loop
+					return
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if attempt > 0 && !config.retryBudget.Allow() {
+			return backoff.ErrBudgetExceeded
+		}
+		resp, err := subscriber.Send(ctx, calls.NonStreaming(calls.Subscribe()))
+		lastErr = processSubscription(ctx, config, resp, err)
+		if config.subscriptionTerminated != nil {
+			config.subscriptionTerminated(lastErr)
+		}
+	}
+	return
+}
+
+func processSubscription(ctx context.Context, config Config, resp mesos.Response, err error) error {
+	if err != nil {
+		if resp != nil {
+			resp.Close()
+		}
+		return err
+	}
+	w := &watchdog{resp: resp}
+	defer w.stop()
+	return eventLoop(ctx, config, w)
+}
+
+func eventLoop(ctx context.Context, config Config, w *watchdog) (err error) {
+	for err == nil && !isDone(ctx) {
+		var e master.Event
+		if err = w.resp.Decode(&e); err != nil {
+			if w.expired() {
+				err = ErrMissedHeartbeats
+			}
+			break
+		}
+		if e.GetType() == master.Event_SUBSCRIBED && config.heartbeatMultiplier > 0 {
+			if hb := e.GetSubscribed().GetHeartbeatIntervalSeconds(); hb > 0 {
+				w.timeout = time.Duration(hb * config.heartbeatMultiplier * float64(time.Second))
+			}
+		}
+		w.reset()
+		err = config.handler.HandleEvent(ctx, &e)
+	}
+	return err
+}
+
+// watchdog closes a subscription response if no events are received before the timeout expires,
+// which unblocks an event loop that's waiting to decode the next event.
+type watchdog struct {
+	resp    mesos.Response
+	timeout time.Duration
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	fired   bool
+	stopped bool
+}
+
+func (w *watchdog) reset() {
+	if w.timeout <= 0 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.timeout, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if !w.stopped {
+			w.fired, w.stopped = true, true
+			w.resp.Close()
+		}
+	})
+}
+
+func (w *watchdog) expired() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.fired
+}
+
+func (w *watchdog) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if !w.stopped {
+		w.stopped = true
+		w.resp.Close()
+	}
+}
+
+// DefaultHandler is invoked when no other handlers have been defined for the controller.
+// The current implementation does nothing.
+const DefaultHandler = events.NoopHandler
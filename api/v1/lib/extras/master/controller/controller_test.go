@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/master/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/master/events"
+)
+
+// eventResponse returns a mesos.Response that yields the given events. Once the events are exhausted
+// Decode blocks until the response is closed, and then returns io.EOF.
+func eventResponse(es ...master.Event) mesos.Response {
+	closed := make(chan struct{})
+	return &mesos.ResponseWrapper{
+		Closer: mesos.CloseFunc(func() error {
+			select {
+			case <-closed:
+			default:
+				close(closed)
+			}
+			return nil
+		}),
+		Decoder: encoding.DecoderFunc(func(u encoding.Unmarshaler) error {
+			if len(es) == 0 {
+				<-closed
+				return io.EOF
+			}
+			*(u.(*master.Event)) = es[0]
+			es = es[1:]
+			return nil
+		}),
+	}
+}
+
+func tokens(n int) <-chan struct{} {
+	ch := make(chan struct{}, n)
+	for i := 0; i < n; i++ {
+		ch <- struct{}{}
+	}
+	close(ch)
+	return ch
+}
+
+func TestRun(t *testing.T) {
+	var (
+		hb       = 0.001
+		received []master.Event_Type
+		errs     []error
+		sender   = calls.SenderFunc(func(_ context.Context, r calls.Request) (mesos.Response, error) {
+			if r.Call().GetType() != master.Call_SUBSCRIBE {
+				t.Fatalf("unexpected call %v", r.Call())
+			}
+			return eventResponse(
+				master.Event{
+					Type:       master.Event_SUBSCRIBED,
+					Subscribed: &master.Event_Subscribed{HeartbeatIntervalSeconds: &hb},
+				},
+				master.Event{Type: master.Event_TASK_ADDED},
+			), nil
+		})
+		handler = events.HandlerFunc(func(_ context.Context, e *master.Event) error {
+			received = append(received, e.GetType())
+			return nil
+		})
+	)
+	err := Run(
+		context.Background(),
+		sender,
+		WithEventHandler(handler),
+		WithRegistrationTokens(tokens(2)),
+		WithSubscriptionTerminated(func(err error) { errs = append(errs, err) }),
+	)
+	if err != ErrMissedHeartbeats {
+		t.Fatalf("expected ErrMissedHeartbeats instead of %v", err)
+	}
+	expected := []master.Event_Type{
+		master.Event_SUBSCRIBED, master.Event_TASK_ADDED,
+		master.Event_SUBSCRIBED, master.Event_TASK_ADDED,
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("expected events %v instead of %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Fatalf("expected events %v instead of %v", expected, received)
+		}
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 terminated subscriptions instead of %d", len(errs))
+	}
+}
+
+func TestRun_Canceled(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		sender      = calls.SenderFunc(func(_ context.Context, _ calls.Request) (mesos.Response, error) {
+			return eventResponse(master.Event{Type: master.Event_SUBSCRIBED}), nil
+		})
+		handler = events.HandlerFunc(func(_ context.Context, e *master.Event) error {
+			cancel()
+			return nil
+		})
+	)
+	defer cancel()
+	// heartbeats are disabled, so Run returns only because the context is canceled
+	if err := Run(ctx, sender, WithEventHandler(handler), WithHeartbeatMultiplier(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+// Package messages implements optional framing for the opaque data of framework messages that are
+// exchanged between schedulers and executors (MESSAGE calls and events). Framing supports splitting
+// large payloads into chunks, and the base64 encoding of binary payloads. Data that is not framed by
+// this package is passed through by Assembler as-is, so peers that don't frame their messages remain
+// interoperable.
+package messages
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sync"
+)
+
+type (
+	// Option modifies a Framer, returns an Option that acts as an "undo"
+	Option func(*Framer) Option
+
+	// Framer splits message data into frames.
+	Framer struct {
+		chunkSize int
+		base64    bool
+	}
+)
+
+// HeaderSize is the number of bytes of each frame that are consumed by the frame header: magic, version,
+// flags, message ID, chunk index, and chunk count.
+const HeaderSize = len(magic) + 1 + 1 + idSize + 2 + 2
+
+const (
+	version     = 1
+	flagBase64  = 1 << 0
+	maxChunks   = 1<<16 - 1
+	magic       = "\x00MGM"
+	idSize      = 8
+	offsetIndex = len(magic) + 2 + idSize
+)
+
+var (
+	// ErrChunkSize is returned when the chunk size is too small to hold any payload.
+	ErrChunkSize = errors.New("chunk size is too small")
+	// ErrTooManyChunks is returned when message data would be split into more chunks than a frame can index.
+	ErrTooManyChunks = errors.New("message requires too many chunks")
+	// ErrMalformedFrame is returned by Assembler for framed data with an invalid header.
+	ErrMalformedFrame = errors.New("malformed message frame")
+)
+
+// WithChunkSize limits the size of each frame, including the frame header; zero disables chunking.
+// Mesos doesn't impose a limit upon message size but large messages are relayed through the master and
+// may cause head-of-line blocking of other calls and events.
+func WithChunkSize(n int) Option {
+	return func(f *Framer) Option {
+		old := f.chunkSize
+		f.chunkSize = n
+		return WithChunkSize(old)
+	}
+}
+
+// WithBase64 enables the base64 encoding of message payloads.
+func WithBase64(enabled bool) Option {
+	return func(f *Framer) Option {
+		old := f.base64
+		f.base64 = enabled
+		return WithBase64(old)
+	}
+}
+
+// NewFramer returns a Framer configured with the given options. A Framer configured without options does
+// not frame data at all.
+func NewFramer(opts ...Option) *Framer {
+	f := &Framer{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(f)
+		}
+	}
+	return f
+}
+
+// Frames splits the given data into one or more frames, each of which should be sent as the data of a
+// separate MESSAGE call.
+func (f *Framer) Frames(data []byte) ([][]byte, error) {
+	if f.chunkSize <= 0 && !f.base64 {
+		return [][]byte{data}, nil
+	}
+	var flags byte
+	if f.base64 {
+		encoded := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+		base64.StdEncoding.Encode(encoded, data)
+		data = encoded
+		flags |= flagBase64
+	}
+	size := len(data)
+	if f.chunkSize > 0 {
+		size = f.chunkSize - HeaderSize
+		if size <= 0 {
+			return nil, ErrChunkSize
+		}
+	}
+	total := 1
+	if len(data) > size {
+		total = (len(data) + size - 1) / size
+	}
+	if total > maxChunks {
+		return nil, ErrTooManyChunks
+	}
+	id := make([]byte, idSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	frames := make([][]byte, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * size
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := data[i*size : end]
+		frame := make([]byte, HeaderSize, HeaderSize+len(chunk))
+		copy(frame, magic)
+		frame[len(magic)] = version
+		frame[len(magic)+1] = flags
+		copy(frame[len(magic)+2:], id)
+		binary.BigEndian.PutUint16(frame[offsetIndex:], uint16(i))
+		binary.BigEndian.PutUint16(frame[offsetIndex+2:], uint16(total))
+		frames = append(frames, append(frame, chunk...))
+	}
+	return frames, nil
+}
+
+// Framed returns true if the given data begins with a frame header generated by this package.
+func Framed(data []byte) bool {
+	return len(data) >= HeaderSize && bytes.HasPrefix(data, []byte(magic))
+}
+
+type partial struct {
+	chunks [][]byte
+	seen   int
+}
+
+// Assembler reassembles message data from frames. Assembler funcs are safe to invoke concurrently.
+type Assembler struct {
+	mu         sync.Mutex
+	maxPending int
+	pending    map[string]*partial
+	order      []string // order of pending messages, oldest first
+}
+
+// DefaultMaxPending is the default number of partially received messages that an Assembler retains.
+const DefaultMaxPending = 64
+
+// NewAssembler returns an Assembler that retains up to maxPending partially received messages; once that
+// limit is reached the oldest partial message is discarded. If maxPending is not positive then
+// DefaultMaxPending is used.
+func NewAssembler(maxPending int) *Assembler {
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPending
+	}
+	return &Assembler{maxPending: maxPending, pending: make(map[string]*partial)}
+}
+
+// Add processes the data of a received message. The source identifies the sender of the message, for
+// example an agent and executor ID pair; it may be empty for executors, which only receive messages from
+// their scheduler. Once all frames of a message have been received the reassembled (and decoded) message
+// data is returned with complete set to true. Unframed data is returned as-is.
+func (a *Assembler) Add(source string, data []byte) (msg []byte, complete bool, err error) {
+	if !Framed(data) {
+		return data, true, nil
+	}
+	if data[len(magic)] != version {
+		return nil, false, ErrMalformedFrame
+	}
+	var (
+		flags = data[len(magic)+1]
+		id    = data[len(magic)+2 : offsetIndex]
+		index = int(binary.BigEndian.Uint16(data[offsetIndex:]))
+		total = int(binary.BigEndian.Uint16(data[offsetIndex+2:]))
+		chunk = data[HeaderSize:]
+	)
+	if total == 0 || index >= total {
+		return nil, false, ErrMalformedFrame
+	}
+	if total > 1 {
+		chunk = a.add(source+"\x00"+string(id), index, total, chunk)
+		if chunk == nil {
+			return nil, false, nil
+		}
+	}
+	if flags&flagBase64 != 0 {
+		decoded := make([]byte, base64.StdEncoding.DecodedLen(len(chunk)))
+		n, err := base64.StdEncoding.Decode(decoded, chunk)
+		if err != nil {
+			return nil, false, err
+		}
+		chunk = decoded[:n]
+	}
+	return chunk, true, nil
+}
+
+// add records a chunk of a multi-chunk message and returns the message once all chunks have been received.
+func (a *Assembler) add(key string, index, total int, chunk []byte) []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	p, ok := a.pending[key]
+	if !ok {
+		if len(a.order) >= a.maxPending {
+			delete(a.pending, a.order[0])
+			a.order = a.order[1:]
+		}
+		p = &partial{chunks: make([][]byte, total)}
+		a.pending[key] = p
+		a.order = append(a.order, key)
+	}
+	if len(p.chunks) != total || p.chunks[index] != nil {
+		return nil // inconsistent or duplicate frame; ignore it
+	}
+	p.chunks[index] = append([]byte(nil), chunk...)
+	p.seen++
+	if p.seen < total {
+		return nil
+	}
+	delete(a.pending, key)
+	for i, k := range a.order {
+		if k == key {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+	return bytes.Join(p.chunks, nil)
+}
+
+// Pending returns the number of partially received messages.
+func (a *Assembler) Pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
@@ -0,0 +1,71 @@
+package messages
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFramesRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte{0, 1, 2, 3, 0xff}, 100)
+	for ti, tc := range []struct {
+		opts   []Option
+		frames int
+	}{
+		{nil, 1},
+		{[]Option{WithBase64(true)}, 1},
+		{[]Option{WithChunkSize(HeaderSize + 100)}, 5},
+		{[]Option{WithChunkSize(HeaderSize + 100), WithBase64(true)}, 7},
+	} {
+		frames, err := NewFramer(tc.opts...).Frames(data)
+		if err != nil {
+			t.Fatalf("test case %d: unexpected error: %v", ti, err)
+		}
+		if len(frames) != tc.frames {
+			t.Errorf("test case %d: expected %d frames instead of %d", ti, tc.frames, len(frames))
+		}
+		var (
+			a   = NewAssembler(0)
+			msg []byte
+		)
+		// deliver out of order
+		for i := len(frames) - 1; i >= 0; i-- {
+			m, complete, err := a.Add("src", frames[i])
+			if err != nil {
+				t.Fatalf("test case %d: unexpected error: %v", ti, err)
+			}
+			if complete != (i == 0) {
+				t.Fatalf("test case %d: unexpected completion at frame %d", ti, i)
+			}
+			msg = m
+		}
+		if !bytes.Equal(msg, data) {
+			t.Errorf("test case %d: reassembled message differs from original", ti)
+		}
+		if n := a.Pending(); n != 0 {
+			t.Errorf("test case %d: expected no pending messages instead of %d", ti, n)
+		}
+	}
+}
+
+func TestFramesChunkSize(t *testing.T) {
+	if _, err := NewFramer(WithChunkSize(HeaderSize)).Frames([]byte("x")); err != ErrChunkSize {
+		t.Errorf("expected ErrChunkSize instead of %v", err)
+	}
+}
+
+func TestAssemblerMaxPending(t *testing.T) {
+	var (
+		a = NewAssembler(1)
+		f = NewFramer(WithChunkSize(HeaderSize + 1))
+	)
+	first, _ := f.Frames([]byte("ab"))
+	second, _ := f.Frames([]byte("cd"))
+	a.Add("", first[0])
+	a.Add("", second[0]) // evicts the first message
+	if msg, complete, _ := a.Add("", second[1]); !complete || string(msg) != "cd" {
+		t.Errorf("expected complete message %q instead of %q", "cd", msg)
+	}
+	if _, complete, _ := a.Add("", first[1]); complete {
+		t.Errorf("expected evicted message to be incomplete")
+	}
+}
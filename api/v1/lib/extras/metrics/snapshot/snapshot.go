@@ -0,0 +1,165 @@
+// Package snapshot parses metrics snapshots of Mesos masters and agents, as served by the /metrics/snapshot
+// endpoint or returned by the GET_METRICS call of the operator API, into typed structures.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// Snapshot maps metric names to their values.
+type Snapshot map[string]float64
+
+type (
+	// Master holds well-known metrics of a Mesos master. Other holds the metrics that don't map to a field.
+	Master struct {
+		Elected              bool    `metric:"master/elected"`
+		UptimeSecs           float64 `metric:"master/uptime_secs"`
+		AgentsActive         float64 `metric:"master/slaves_active"`
+		AgentsConnected      float64 `metric:"master/slaves_connected"`
+		AgentsDisconnected   float64 `metric:"master/slaves_disconnected"`
+		AgentsInactive       float64 `metric:"master/slaves_inactive"`
+		AgentsUnreachable    float64 `metric:"master/slaves_unreachable"`
+		FrameworksActive     float64 `metric:"master/frameworks_active"`
+		FrameworksConnected  float64 `metric:"master/frameworks_connected"`
+		FrameworksInactive   float64 `metric:"master/frameworks_inactive"`
+		TasksStaging         float64 `metric:"master/tasks_staging"`
+		TasksStarting        float64 `metric:"master/tasks_starting"`
+		TasksRunning         float64 `metric:"master/tasks_running"`
+		TasksKilling         float64 `metric:"master/tasks_killing"`
+		TasksFinished        float64 `metric:"master/tasks_finished"`
+		TasksFailed          float64 `metric:"master/tasks_failed"`
+		TasksKilled          float64 `metric:"master/tasks_killed"`
+		TasksLost            float64 `metric:"master/tasks_lost"`
+		TasksError           float64 `metric:"master/tasks_error"`
+		TasksUnreachable     float64 `metric:"master/tasks_unreachable"`
+		CPUsTotal            float64 `metric:"master/cpus_total"`
+		CPUsUsed             float64 `metric:"master/cpus_used"`
+		MemTotal             float64 `metric:"master/mem_total"`
+		MemUsed              float64 `metric:"master/mem_used"`
+		DiskTotal            float64 `metric:"master/disk_total"`
+		DiskUsed             float64 `metric:"master/disk_used"`
+		GPUsTotal            float64 `metric:"master/gpus_total"`
+		GPUsUsed             float64 `metric:"master/gpus_used"`
+		EventQueueMessages   float64 `metric:"master/event_queue_messages"`
+		EventQueueDispatches float64 `metric:"master/event_queue_dispatches"`
+
+		Other Snapshot
+	}
+
+	// Agent holds well-known metrics of a Mesos agent. Other holds the metrics that don't map to a field.
+	Agent struct {
+		Registered           bool    `metric:"slave/registered"`
+		UptimeSecs           float64 `metric:"slave/uptime_secs"`
+		ExecutorsRegistering float64 `metric:"slave/executors_registering"`
+		ExecutorsRunning     float64 `metric:"slave/executors_running"`
+		ExecutorsTerminating float64 `metric:"slave/executors_terminating"`
+		ExecutorsTerminated  float64 `metric:"slave/executors_terminated"`
+		FrameworksActive     float64 `metric:"slave/frameworks_active"`
+		TasksStaging         float64 `metric:"slave/tasks_staging"`
+		TasksStarting        float64 `metric:"slave/tasks_starting"`
+		TasksRunning         float64 `metric:"slave/tasks_running"`
+		TasksKilling         float64 `metric:"slave/tasks_killing"`
+		TasksFinished        float64 `metric:"slave/tasks_finished"`
+		TasksFailed          float64 `metric:"slave/tasks_failed"`
+		TasksKilled          float64 `metric:"slave/tasks_killed"`
+		TasksLost            float64 `metric:"slave/tasks_lost"`
+		TasksGone            float64 `metric:"slave/tasks_gone"`
+		CPUsTotal            float64 `metric:"slave/cpus_total"`
+		CPUsUsed             float64 `metric:"slave/cpus_used"`
+		MemTotal             float64 `metric:"slave/mem_total"`
+		MemUsed              float64 `metric:"slave/mem_used"`
+		DiskTotal            float64 `metric:"slave/disk_total"`
+		DiskUsed             float64 `metric:"slave/disk_used"`
+		GPUsTotal            float64 `metric:"slave/gpus_total"`
+		GPUsUsed             float64 `metric:"slave/gpus_used"`
+
+		Other Snapshot
+	}
+)
+
+// FromMetrics returns a Snapshot of the given metrics, as returned by the GET_METRICS call of the operator
+// API; metrics without a value are omitted.
+func FromMetrics(metrics []mesos.Metric) Snapshot {
+	s := make(Snapshot, len(metrics))
+	for i := range metrics {
+		if v := metrics[i].Value; v != nil {
+			s[metrics[i].Name] = *v
+		}
+	}
+	return s
+}
+
+// Fetch retrieves a Snapshot from the /metrics/snapshot endpoint of the master or agent at the given
+// base URL, for example "http://localhost:5050". If client is nil then http.DefaultClient is used.
+func Fetch(ctx context.Context, client *http.Client, baseURL string) (Snapshot, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequest("GET", strings.TrimSuffix(baseURL, "/")+"/metrics/snapshot", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch metrics snapshot: %s", res.Status)
+	}
+	var s Snapshot
+	if err = json.NewDecoder(res.Body).Decode(&s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Master returns the master metrics of the Snapshot.
+func (s Snapshot) Master() (m Master) {
+	m.Other = s.decode(&m)
+	return
+}
+
+// Agent returns the agent metrics of the Snapshot.
+func (s Snapshot) Agent() (a Agent) {
+	a.Other = s.decode(&a)
+	return
+}
+
+// decode assigns metrics to the tagged fields of the struct that v points to, and returns the metrics
+// that weren't assigned.
+func (s Snapshot) decode(v interface{}) Snapshot {
+	var (
+		rv     = reflect.ValueOf(v).Elem()
+		rt     = rv.Type()
+		fields = make(map[string]reflect.Value, rt.NumField())
+		other  = make(Snapshot)
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		if name := rt.Field(i).Tag.Get("metric"); name != "" {
+			fields[name] = rv.Field(i)
+		}
+	}
+	for name, value := range s {
+		f, ok := fields[name]
+		if !ok {
+			other[name] = value
+			continue
+		}
+		switch f.Kind() {
+		case reflect.Bool:
+			f.SetBool(value != 0)
+		case reflect.Float64:
+			f.SetFloat(value)
+		}
+	}
+	return other
+}
@@ -0,0 +1,52 @@
+package snapshot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics/snapshot" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"master/elected":1,"master/tasks_running":3,"master/cpus_used":1.5,"allocator/event_queue_dispatches":0}`))
+	}))
+	defer srv.Close()
+
+	s, err := Fetch(context.Background(), nil, srv.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := s.Master()
+	if !m.Elected || m.TasksRunning != 3 || m.CPUsUsed != 1.5 {
+		t.Fatalf("unexpected master metrics %+v", m)
+	}
+	if _, ok := m.Other["allocator/event_queue_dispatches"]; !ok || len(m.Other) != 1 {
+		t.Fatalf("unexpected other metrics %v", m.Other)
+	}
+	if _, err = Fetch(context.Background(), nil, srv.URL+"/bogus"); err == nil {
+		t.Fatalf("expected error for missing endpoint")
+	}
+}
+
+func TestFromMetrics(t *testing.T) {
+	var (
+		one   = 1.0
+		seven = 7.0
+		a     = FromMetrics([]mesos.Metric{
+			{Name: "slave/registered", Value: &one},
+			{Name: "slave/executors_running", Value: &seven},
+			{Name: "slave/valueless"},
+		}).Agent()
+	)
+	if !a.Registered || a.ExecutorsRunning != 7 || len(a.Other) != 0 {
+		t.Fatalf("unexpected agent metrics %+v", a)
+	}
+}
@@ -0,0 +1,131 @@
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+)
+
+// Kind distinguishes the messages that are recorded.
+type Kind string
+
+const (
+	KindCall     = Kind("call")     // the body of a request to Mesos
+	KindResponse = Kind("response") // the body of a non-streaming response
+	KindEvent    = Kind("event")    // a single message of a streaming response
+)
+
+// Record is a single recorded message. The body is kept in its original encoding, so that recordings
+// are faithful to what was exchanged on the wire; Decode unmarshals it.
+type Record struct {
+	Time        time.Time `json:"time"`
+	Kind        Kind      `json:"kind"`
+	Seq         uint64    `json:"seq"` // identifies the request that a record belongs to
+	Path        string    `json:"path"`
+	StatusCode  int       `json:"status,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	StreamID    string    `json:"stream_id,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+}
+
+// ErrUnsupportedMediaType is returned when decoding a record whose content type has no registered codec.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// Decode unmarshals the body of the record w/ the codec of its content type.
+func (r *Record) Decode(u encoding.Unmarshaler) error {
+	codec, ok := codecs.DefaultRegistry.Lookup(r.ContentType)
+	if !ok {
+		return ErrUnsupportedMediaType
+	}
+	return codec.NewDecoder(encoding.SourceReader(bytes.NewReader(r.Body))).Decode(u)
+}
+
+// Records is a recording, in the order that messages were observed.
+type Records []Record
+
+// Filter returns the records that satisfy f.
+func (rs Records) Filter(f func(*Record) bool) (result Records) {
+	for i := range rs {
+		if f(&rs[i]) {
+			result = append(result, rs[i])
+		}
+	}
+	return
+}
+
+// Filter returns a filter func that selects records of the kind and of the given (optional) path.
+func (k Kind) Filter(path string) func(*Record) bool {
+	return func(r *Record) bool { return r.Kind == k && (path == "" || r.Path == path) }
+}
+
+// Sink consumes records; implementations must be safe for concurrent use.
+type Sink interface {
+	Record(Record)
+}
+
+// SinkFunc is the functional adaptation of Sink.
+type SinkFunc func(Record)
+
+// Record implements Sink.
+func (f SinkFunc) Record(r Record) { f(r) }
+
+// JSONLines returns a Sink that writes records to w, one JSON object per line; see Read. Write errors
+// are reported to the (optional) error handler.
+func JSONLines(w io.Writer, eh func(error)) Sink {
+	var (
+		mu  sync.Mutex
+		enc = json.NewEncoder(w)
+	)
+	return SinkFunc(func(r Record) {
+		mu.Lock()
+		err := enc.Encode(&r)
+		mu.Unlock()
+		if err != nil && eh != nil {
+			eh(err)
+		}
+	})
+}
+
+// Read returns the records that were written by a JSONLines Sink.
+func Read(r io.Reader) (Records, error) {
+	var (
+		result Records
+		dec    = json.NewDecoder(bufio.NewReader(r))
+	)
+	for {
+		var rec Record
+		if err := dec.Decode(&rec); err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return result, err
+		}
+		result = append(result, rec)
+	}
+}
+
+// Memory is a Sink that keeps records in memory, useful for tests.
+type Memory struct {
+	mu      sync.Mutex
+	records Records
+}
+
+// Record implements Sink.
+func (m *Memory) Record(r Record) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, r)
+}
+
+// Records returns a copy of the records collected so far.
+func (m *Memory) Records() Records {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append(Records(nil), m.records...)
+}
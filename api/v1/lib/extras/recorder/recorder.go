@@ -0,0 +1,249 @@
+// Package recorder implements a reverse proxy that sits between a Mesos API client (e.g. a framework
+// scheduler) and a Mesos master or agent, and records the calls that it forwards along w/ the responses
+// and streamed events that it relays. Recordings keep messages in their original encoding and may be
+// written to disk (see JSONLines and Read) for later inspection or replay, for example by decoding the
+// recorded events and feeding them to a mesostest.Script.
+//
+//	sink := recorder.JSONLines(f, nil)
+//	http.ListenAndServe(":5050", recorder.New(masterURL, sink))
+//
+// The proxy doesn't follow or rewrite redirects: point it at the leading master.
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+)
+
+const (
+	mediaTypeRecordIO = "application/recordio"
+
+	// DefaultMaxMessageSize limits the size of recorded messages; larger messages are forwarded but not
+	// recorded, and terminate the recording of the stream that they're part of.
+	DefaultMaxMessageSize = 4 << 20
+
+	// streamBacklog is the number of chunks of a stream that may await recording; see recordingBody.
+	streamBacklog = 128
+)
+
+type (
+	// Proxy is an http.Handler that forwards requests to a Mesos server and records them.
+	Proxy struct {
+		sink           Sink
+		proxy          *httputil.ReverseProxy
+		seq            uint64
+		now            func() time.Time
+		maxMessageSize int
+		streamBacklog  int
+	}
+
+	// Opt is a functional option for a Proxy.
+	Opt func(*Proxy)
+
+	requestKey struct{}
+
+	requestInfo struct {
+		seq  uint64
+		path string
+	}
+
+	// recordingBody relays the body of a streaming response while it's being recorded. The chunks that
+	// are relayed are queued for recording w/o blocking: once the queue is full, the remainder of the
+	// stream isn't recorded, so that a slow Sink never delays the stream.
+	recordingBody struct {
+		io.ReadCloser
+		mu      sync.Mutex
+		chunks  chan []byte
+		stopped bool
+	}
+
+	// chunkReader reads the chunks that are queued by a recordingBody.
+	chunkReader struct {
+		chunks <-chan []byte
+		buf    []byte
+	}
+)
+
+// Transport returns an Opt that configures the RoundTripper that's used to forward requests.
+func Transport(rt http.RoundTripper) Opt { return func(p *Proxy) { p.proxy.Transport = rt } }
+
+// MaxMessageSize returns an Opt that limits the size of recorded messages.
+func MaxMessageSize(n int) Opt { return func(p *Proxy) { p.maxMessageSize = n } }
+
+// New returns a Proxy that forwards requests to the server at target, and records them to sink.
+func New(target *url.URL, sink Sink, opts ...Opt) *Proxy {
+	p := &Proxy{
+		sink:           sink,
+		now:            time.Now,
+		maxMessageSize: DefaultMaxMessageSize,
+		streamBacklog:  streamBacklog,
+		proxy: &httputil.ReverseProxy{
+			Director: func(r *http.Request) {
+				r.URL.Scheme = target.Scheme
+				r.URL.Host = target.Host
+				r.Host = target.Host
+			},
+			// events must be relayed as soon as they arrive
+			FlushInterval: 10 * time.Millisecond,
+		},
+	}
+	p.proxy.ModifyResponse = p.modifyResponse
+	for _, f := range opts {
+		if f != nil {
+			f(p)
+		}
+	}
+	return p
+}
+
+// ServeHTTP implements http.Handler.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	info := &requestInfo{
+		seq:  atomic.AddUint64(&p.seq, 1),
+		path: r.URL.Path,
+	}
+	if r.Body != nil && r.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		p.sink.Record(Record{
+			Time:        p.now(),
+			Kind:        KindCall,
+			Seq:         info.seq,
+			Path:        info.path,
+			ContentType: r.Header.Get("Content-Type"),
+			StreamID:    r.Header.Get("Mesos-Stream-Id"),
+			Body:        body,
+		})
+	}
+	p.proxy.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestKey{}, info)))
+}
+
+func (p *Proxy) modifyResponse(resp *http.Response) error {
+	info, ok := resp.Request.Context().Value(requestKey{}).(*requestInfo)
+	if !ok {
+		return nil
+	}
+	var (
+		contentType = resp.Header.Get("Content-Type")
+		streamID    = resp.Header.Get("Mesos-Stream-Id")
+		mt, _, _    = mime.ParseMediaType(contentType)
+		record      = func(kind Kind, body []byte) {
+			p.sink.Record(Record{
+				Time:        p.now(),
+				Kind:        kind,
+				Seq:         info.seq,
+				Path:        info.path,
+				StatusCode:  resp.StatusCode,
+				ContentType: contentType,
+				StreamID:    streamID,
+				Body:        body,
+			})
+		}
+	)
+	// Mesos streams events w/ chunked encoding: the content length of a legacy streaming response (i.e. one
+	// that isn't explicitly recordio-encoded) is unknown.
+	if resp.StatusCode != http.StatusOK || (mt != mediaTypeRecordIO && resp.ContentLength >= 0) {
+		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, int64(p.maxMessageSize)+1))
+		if err != nil {
+			return err
+		}
+		// the (possibly truncated) body is forwarded regardless of whether it's recorded
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		if len(body) > p.maxMessageSize {
+			body = nil
+		}
+		record(KindResponse, body)
+		return nil
+	}
+
+	// a streaming response: each recordio frame is a message
+	if mt == mediaTypeRecordIO {
+		contentType = resp.Header.Get("Message-Content-Type")
+	}
+	chunks := make(chan []byte, p.streamBacklog)
+	resp.Body = &recordingBody{ReadCloser: resp.Body, chunks: chunks}
+	go func() {
+		fr := recordio.NewReader(&chunkReader{chunks: chunks}, recordio.MaxMessageSize(p.maxMessageSize))
+		for {
+			frame, err := fr.ReadFrame()
+			if err != nil {
+				return
+			}
+			record(KindEvent, append([]byte(nil), frame...))
+		}
+	}()
+	return nil
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.record(append([]byte(nil), p[:n]...))
+	}
+	if err != nil {
+		b.stop()
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	b.stop()
+	return b.ReadCloser.Close()
+}
+
+// record queues a chunk for recording; the recording of the stream stops if the queue is full.
+func (b *recordingBody) record(chunk []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stopped {
+		return
+	}
+	select {
+	case b.chunks <- chunk:
+	default:
+		// a partial stream can't be decoded: drop the remainder
+		b.stopped = true
+		close(b.chunks)
+	}
+}
+
+func (b *recordingBody) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.stopped {
+		b.stopped = true
+		close(b.chunks)
+	}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, ok := <-r.chunks
+		if !ok {
+			return 0, io.EOF
+		}
+		r.buf = chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,185 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/mesostest"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func TestProxy(t *testing.T) {
+	for _, mediaType := range []encoding.MediaType{codecs.MediaTypeProtobuf, codecs.MediaTypeJSON} {
+		t.Run(string(mediaType), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			master := mesostest.NewMaster()
+			defer master.Close()
+			target, _ := url.Parse(master.URL())
+
+			var (
+				sink  Memory
+				proxy = httptest.NewServer(New(target, &sink))
+			)
+			defer proxy.Close()
+
+			cli := httpsched.NewCaller(httpcli.New(
+				httpcli.Endpoint(proxy.URL+mesostest.SchedulerPath),
+				httpcli.Codec(codecs.ByMediaType[mediaType]),
+			))
+			resp, err := cli.Call(ctx, calls.Subscribe(&mesos.FrameworkInfo{Name: "test", User: "root"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Close()
+			fid, err := master.WaitSubscribed(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			go master.Offer(fid, mesos.Offer{ID: mesos.OfferID{Value: "o1"}, AgentID: mesos.AgentID{Value: "a1"}, Hostname: "h1"})
+			for {
+				var e scheduler.Event
+				if err = resp.Decode(&e); err != nil {
+					t.Fatal(err)
+				}
+				if e.GetType() == scheduler.Event_OFFERS {
+					break
+				}
+			}
+			if _, err = cli.Call(ctx, calls.Decline(mesos.OfferID{Value: "o1"}).With(calls.Framework(fid.Value))); err != nil {
+				t.Fatal(err)
+			}
+
+			// the recording is only complete once the proxy has observed the last event
+			var rs Records
+			for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+				rs = sink.Records()
+				if len(rs.Filter(KindEvent.Filter(""))) >= 2 || time.Now().After(deadline) {
+					break
+				}
+			}
+
+			var callTypes []scheduler.Call_Type
+			for _, r := range rs.Filter(KindCall.Filter(mesostest.SchedulerPath)) {
+				var c scheduler.Call
+				if err := r.Decode(&c); err != nil {
+					t.Fatal(err)
+				}
+				callTypes = append(callTypes, c.GetType())
+			}
+			if want := []scheduler.Call_Type{scheduler.Call_SUBSCRIBE, scheduler.Call_DECLINE}; !reflect.DeepEqual(callTypes, want) {
+				t.Fatalf("expected calls %v instead of %v", want, callTypes)
+			}
+
+			var eventTypes []scheduler.Event_Type
+			for _, r := range rs.Filter(KindEvent.Filter(mesostest.SchedulerPath)) {
+				var e scheduler.Event
+				if err := r.Decode(&e); err != nil {
+					t.Fatal(err)
+				}
+				if r.StreamID == "" || r.Seq != 1 {
+					t.Fatalf("unexpected record %+v", r)
+				}
+				eventTypes = append(eventTypes, e.GetType())
+			}
+			if want := []scheduler.Event_Type{scheduler.Event_SUBSCRIBED, scheduler.Event_OFFERS}; !reflect.DeepEqual(eventTypes[:2], want) {
+				t.Fatalf("expected events %v instead of %v", want, eventTypes)
+			}
+
+			if declined := rs.Filter(KindResponse.Filter("")); len(declined) != 1 || declined[0].StatusCode != 202 || declined[0].Seq != 2 {
+				t.Fatalf("unexpected responses %+v", declined)
+			}
+		})
+	}
+}
+
+func TestProxy_SlowSink(t *testing.T) {
+	const frames = 1000
+	var (
+		frame   = []byte("5\nhello")
+		release = make(chan struct{})
+		sink    = SinkFunc(func(r Record) {
+			if r.Kind == KindEvent {
+				<-release
+			}
+		})
+	)
+	defer close(release)
+	master := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/recordio")
+		w.Header().Set("Message-Content-Type", "application/json")
+		for i := 0; i < frames; i++ {
+			w.Write(frame)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer master.Close()
+	target, _ := url.Parse(master.URL)
+	p := New(target, sink)
+	p.streamBacklog = 1
+	proxy := httptest.NewServer(p)
+	defer proxy.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		res, err := http.Post(proxy.URL+mesostest.SchedulerPath, "application/json", bytes.NewBufferString("{}"))
+		if err != nil {
+			done <- err
+			return
+		}
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		if err == nil && len(body) != frames*len(frame) {
+			err = fmt.Errorf("expected %d bytes instead of %d", frames*len(frame), len(body))
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the stream was blocked by the sink")
+	}
+}
+
+func TestJSONLines(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		sink = JSONLines(&buf, func(err error) { t.Fatal(err) })
+		want = Records{
+			{Time: time.Unix(1, 0).UTC(), Kind: KindCall, Seq: 1, Path: "/api/v1/scheduler", ContentType: "application/json", Body: []byte(`{"type":"SUBSCRIBE"}`)},
+			{Time: time.Unix(2, 0).UTC(), Kind: KindEvent, Seq: 1, Path: "/api/v1/scheduler", StatusCode: 200, ContentType: "application/json", StreamID: "s1", Body: []byte(`{"type":"HEARTBEAT"}`)},
+		}
+	)
+	for _, r := range want {
+		sink.Record(r)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v instead of %+v", want, got)
+	}
+	var e scheduler.Event
+	if err = got[1].Decode(&e); err != nil || e.GetType() != scheduler.Event_HEARTBEAT {
+		t.Fatalf("unexpected event %v, %v", e, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/version"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
 )
 
@@ -23,3 +25,63 @@ func WithFrameworkID(frameworkID func() string) Rule {
 		return ch(ctx, c, r, err)
 	}
 }
+
+// ApplyDeclinePolicy returns a Rule that keeps the given policy informed of the roles that are suppressed,
+// as per successful SUPPRESS and REVIVE calls, and that sets the Filters of DECLINE calls that don't specify
+// any. Since a DECLINE call doesn't specify the roles of its offers, the policy's refusal duration for the
+// "" role applies; see DeclinePolicy.Decline for role-aware declination.
+func ApplyDeclinePolicy(p *offers.DeclinePolicy) Rule {
+	return func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error, ch Chain) (context.Context, *scheduler.Call, mesos.Response, error) {
+		if c.GetType() == scheduler.Call_DECLINE && c.GetDecline().GetFilters() == nil {
+			c2 := *c
+			d := *c.Decline
+			c2.Decline = &d
+			c = c2.With(p.Filters(""))
+		}
+		ctx, c, r, err = ch(ctx, c, r, err)
+		if err == nil {
+			switch c.GetType() {
+			case scheduler.Call_SUPPRESS:
+				p.Suppress(c.GetSuppress().GetRoles()...)
+			case scheduler.Call_REVIVE:
+				p.Revive(c.GetRevive().GetRoles()...)
+			}
+		}
+		return ctx, c, r, err
+	}
+}
+
+// DiagnoseOffers returns a Rule that records the offers that are accepted, and declined, by successful
+// ACCEPT and DECLINE calls in the given Diagnostics. ACCEPT calls w/o operations are recorded as declines.
+// See also controller.DiagnoseOffers.
+func DiagnoseOffers(d *offers.Diagnostics) Rule {
+	return func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error, ch Chain) (context.Context, *scheduler.Call, mesos.Response, error) {
+		ctx, c, r, err = ch(ctx, c, r, err)
+		if err == nil {
+			switch c.GetType() {
+			case scheduler.Call_ACCEPT:
+				if accept := c.GetAccept(); len(accept.GetOperations()) > 0 {
+					d.Accepted(accept.GetOfferIDs()...)
+				} else {
+					d.Declined(accept.GetOfferIDs()...)
+				}
+			case scheduler.Call_DECLINE:
+				d.Declined(c.GetDecline().GetOfferIDs()...)
+			}
+		}
+		return ctx, c, r, err
+	}
+}
+
+// RequireFeatures returns a Rule that refuses to send calls that depend on features which the master, as
+// tracked by the given version tracker, doesn't support (see version.Required): such calls yield a
+// version.TooOldError and are not forwarded to the chain. Calls are never refused while the version of the
+// master is unknown. See also controller.WithVersionDetection and controller.TrackVersion.
+func RequireFeatures(t *version.Tracker) Rule {
+	return func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error, ch Chain) (context.Context, *scheduler.Call, mesos.Response, error) {
+		if err2 := t.Require(version.Required(c)...); err2 != nil {
+			return ctx, c, nil, Error2(err, err2)
+		}
+		return ch(ctx, c, r, err)
+	}
+}
@@ -0,0 +1,110 @@
+package callrules
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/version"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func TestApplyDeclinePolicy(t *testing.T) {
+	var (
+		p      = offers.NewDeclinePolicy(offers.RefuseFor(time.Minute), offers.RefuseSuppressedFor(time.Hour))
+		sent   []*scheduler.Call
+		failed bool
+		caller = ApplyDeclinePolicy(p).Caller(calls.CallerFunc(func(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+			sent = append(sent, c)
+			if failed {
+				return nil, errors.New("failed")
+			}
+			return nil, nil
+		}))
+		ctx     = context.Background()
+		decline = func() float64 {
+			call := calls.Decline(mesos.OfferID{Value: "o1"})
+			if _, err := caller.Call(ctx, call); err != nil {
+				t.Fatal(err)
+			}
+			if call.GetDecline().Filters != nil {
+				t.Fatal("unexpected modification of the original call")
+			}
+			return sent[len(sent)-1].GetDecline().GetFilters().GetRefuseSeconds()
+		}
+	)
+	if s := decline(); s != 60 {
+		t.Fatalf("expected to refuse for 60s instead of %v", s)
+	}
+	failed = true
+	caller.Call(ctx, calls.Suppress())
+	failed = false
+	if p.Suppressed("") {
+		t.Fatal("unexpected suppression after a failed call")
+	}
+	caller.Call(ctx, calls.Suppress())
+	if s := decline(); s != 3600 {
+		t.Fatalf("expected to refuse for 3600s instead of %v", s)
+	}
+	caller.Call(ctx, calls.Revive())
+	if p.Suppressed("") {
+		t.Fatal("expected offers to be revived")
+	}
+
+	explicit := calls.Decline(mesos.OfferID{Value: "o2"}).With(calls.RefuseSeconds(time.Second))
+	caller.Call(ctx, explicit)
+	if s := sent[len(sent)-1].GetDecline().GetFilters().GetRefuseSeconds(); s != 1 {
+		t.Fatalf("expected explicit filters to be retained instead of %v", s)
+	}
+}
+
+func TestDiagnoseOffers(t *testing.T) {
+	var (
+		d      = offers.NewDiagnostics(offers.DeclineLoopThreshold(2))
+		ctx    = context.Background()
+		caller = DiagnoseOffers(d).Caller(calls.CallerFunc(func(context.Context, *scheduler.Call) (mesos.Response, error) {
+			return nil, nil
+		}))
+		offer = func(id string) mesos.Offer {
+			return mesos.Offer{ID: mesos.OfferID{Value: id}, AgentID: mesos.AgentID{Value: "a1"}}
+		}
+	)
+	d.Offered(offer("o1"), offer("o2"), offer("o3"))
+	caller.Call(ctx, calls.Decline(mesos.OfferID{Value: "o1"}))
+	caller.Call(ctx, calls.Accept(calls.OfferOperations{}.WithOffers(mesos.OfferID{Value: "o2"})))
+	if r := d.Report(); len(r.Reasons) != 1 || r.Agents[0].Declined != 2 {
+		t.Fatalf("expected a decline loop instead of %+v", r)
+	}
+	caller.Call(ctx, calls.Accept(calls.OfferOperations{calls.OpLaunch()}.WithOffers(mesos.OfferID{Value: "o3"})))
+	if r := d.Report(); len(r.Reasons) != 0 || r.Agents[0].Accepted != 1 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestRequireFeatures(t *testing.T) {
+	var (
+		tracker version.Tracker
+		sent    int
+		caller  = RequireFeatures(&tracker).Caller(calls.CallerFunc(func(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+			sent++
+			return nil, nil
+		}))
+		call = calls.ReconcileOperations(nil)
+	)
+	if _, err := caller.Call(context.Background(), call); err != nil || sent != 1 {
+		t.Fatalf("expected the call to be sent while the version is unknown: %v", err)
+	}
+	tracker.Set(version.Version{Major: 1, Minor: 5})
+	if _, err := caller.Call(context.Background(), call); sent != 1 {
+		t.Fatal("unexpected call sent to an old master")
+	} else if _, ok := err.(*version.TooOldError); !ok {
+		t.Fatalf("expected a TooOldError instead of %v", err)
+	}
+	if _, err := caller.Call(context.Background(), calls.Revive()); err != nil || sent != 2 {
+		t.Fatalf("expected the call to be sent: %v", err)
+	}
+}
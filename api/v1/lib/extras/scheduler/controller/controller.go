@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/version"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
@@ -19,7 +21,11 @@ type (
 		frameworkIDFunc        func() string
 		handler                events.Handler
 		registrationTokens     <-chan struct{}
+		registrationBackoff    *backoff.Backoff
+		retryBudget            *backoff.Budget
 		subscriptionTerminated func(error)
+		versionTracker         *version.Tracker
+		versionSources         []version.Source
 	}
 )
 
@@ -69,6 +75,43 @@ func WithRegistrationTokens(registrationTokens <-chan struct{}) Option {
 	}
 }
 
+// WithRegistrationBackoff delays framework re-registration w/ Mesos according to the given Backoff,
+// which may be configured w/ a backoff.Strategy and a budget. The first registration attempt is not
+// delayed, and the Backoff is Reset after every successful subscription. Run returns backoff.ErrExhausted
+// once the budget of the Backoff is exhausted. May be combined w/ WithRegistrationTokens.
+func WithRegistrationBackoff(b *backoff.Backoff) Option {
+	return func(c *Config) Option {
+		old := c.registrationBackoff
+		c.registrationBackoff = b
+		return WithRegistrationBackoff(old)
+	}
+}
+
+// WithRetryBudget limits framework re-registration attempts w/ the given retry Budget, which may be shared
+// w/ other subsystems (for example, see httpsched.RedirectBudget). Run returns backoff.ErrBudgetExceeded
+// once a re-registration attempt is denied by the budget.
+func WithRetryBudget(b *backoff.Budget) Option {
+	return func(c *Config) Option {
+		old := c.retryBudget
+		c.retryBudget = b
+		return WithRetryBudget(old)
+	}
+}
+
+// WithVersionDetection detects the version of the master from the given sources, and records it in the
+// given tracker, prior to every subscription attempt; for example GET_VERSION of an httpmaster.Client w/ a
+// fallback to version.EndpointOf. SUBSCRIBE calls that are sent via a caller that's wrapped by
+// callrules.RequireFeatures w/ the same tracker are thus refused if the master doesn't support the
+// capabilities or roles of the framework. The version is unknown (and calls aren't refused) if detection
+// fails; see also TrackVersion.
+func WithVersionDetection(tracker *version.Tracker, sources ...version.Source) Option {
+	return func(c *Config) Option {
+		oldTracker, oldSources := c.versionTracker, c.versionSources
+		c.versionTracker, c.versionSources = tracker, sources
+		return WithVersionDetection(oldTracker, oldSources...)
+	}
+}
+
 func (c *Config) tryFrameworkID() (result string) {
 	if c.frameworkIDFunc != nil {
 		result = c.frameworkIDFunc()
@@ -99,7 +142,7 @@ func Run(ctx context.Context, framework *mesos.FrameworkInfo, caller calls.Calle
 		config.handler = DefaultHandler
 	}
 	subscribe := calls.Subscribe(framework)
-	for !isDone(ctx) {
+	for attempt := 0; !isDone(ctx); attempt++ {
 		frameworkID := config.tryFrameworkID()
 		if framework.GetFailoverTimeout() > 0 && frameworkID != "" {
 			subscribe.With(calls.SubscribeTo(frameworkID))
@@ -115,7 +158,22 @@ func Run(ctx context.Context, framework *mesos.FrameworkInfo, caller calls.Calle
 				return ctx.Err()
 			}
 		}
+		if attempt > 0 && !config.retryBudget.Allow() {
+			return backoff.ErrBudgetExceeded
+		}
+		if config.registrationBackoff != nil && attempt > 0 {
+			if err := config.registrationBackoff.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		if config.versionTracker != nil {
+			// an unknown version is recorded upon failure, which doesn't prevent subscription
+			config.versionTracker.Refresh(ctx, config.versionSources...)
+		}
 		resp, err := caller.Call(ctx, subscribe)
+		if err == nil && config.registrationBackoff != nil {
+			config.registrationBackoff.Reset()
+		}
 		lastErr = processSubscription(ctx, config, resp, err)
 		if config.subscriptionTerminated != nil {
 			config.subscriptionTerminated(lastErr)
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/callrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/version"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func TestRegistrationBackoff(t *testing.T) {
+	var (
+		attempts int
+		caller   = calls.CallerFunc(func(context.Context, *scheduler.Call) (mesos.Response, error) {
+			attempts++
+			return nil, errors.New("unavailable")
+		})
+		b = backoff.New(time.Millisecond, time.Millisecond,
+			backoff.WithStrategy(backoff.Constant), backoff.MaxElapsed(50*time.Millisecond))
+	)
+	err := Run(context.Background(), &mesos.FrameworkInfo{}, caller, WithRegistrationBackoff(b))
+	if err != backoff.ErrExhausted {
+		t.Fatalf("expected backoff.ErrExhausted instead of %v", err)
+	}
+	if attempts < 2 {
+		t.Fatalf("expected multiple registration attempts instead of %d", attempts)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	var (
+		attempts int
+		caller   = calls.CallerFunc(func(context.Context, *scheduler.Call) (mesos.Response, error) {
+			attempts++
+			return nil, errors.New("unavailable")
+		})
+	)
+	err := Run(context.Background(), &mesos.FrameworkInfo{}, caller, WithRetryBudget(backoff.NewBudget(2, time.Hour)))
+	if err != backoff.ErrBudgetExceeded {
+		t.Fatalf("expected backoff.ErrBudgetExceeded instead of %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected an initial attempt and two retries instead of %d attempts", attempts)
+	}
+}
+
+func TestVersionDetection(t *testing.T) {
+	var (
+		tracker version.Tracker
+		sent    int
+		caller  = callrules.RequireFeatures(&tracker).Caller(calls.CallerFunc(func(context.Context, *scheduler.Call) (mesos.Response, error) {
+			sent++
+			return nil, errors.New("unavailable")
+		}))
+		source = version.SourceFunc(func(context.Context) (mesos.VersionInfo, error) {
+			return mesos.VersionInfo{Version: "1.2.0"}, nil
+		})
+		framework = &mesos.FrameworkInfo{
+			Capabilities: []mesos.FrameworkInfo_Capability{{Type: mesos.FrameworkInfo_Capability_MULTI_ROLE}},
+		}
+		tokens = make(chan struct{}, 1)
+	)
+	tokens <- struct{}{}
+	close(tokens)
+	err := Run(context.Background(), framework, caller,
+		WithRegistrationTokens(tokens),
+		WithVersionDetection(&tracker, source),
+	)
+	if e, ok := err.(*version.TooOldError); !ok || e.Feature != version.FeatureMultiRole {
+		t.Fatalf("expected a TooOldError for multi-role frameworks instead of %v", err)
+	}
+	if sent != 0 {
+		t.Fatalf("expected SUBSCRIBE to be refused instead of sent")
+	}
+}
@@ -7,7 +7,11 @@ import (
 	"time"
 
 	. "github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/quotas"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/tasks"
 	"github.com/mesos/mesos-go/api/v1/lib/extras/store"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/version"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
 )
@@ -72,6 +76,82 @@ func TrackSubscription(frameworkIDStore store.Singleton, failoverTimeout time.Du
 	}
 }
 
+// TrackOffers maintains the given offer cache: offers and inverse offers are added upon receipt and removed
+// when rescinded, or when their agent fails. The cache is cleared upon (re)subscription since previously
+// received offers are no longer valid. Events are always propagated to the chain.
+func TrackOffers(cache *offers.Cache) Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, chain Chain) (context.Context, *scheduler.Event, error) {
+		switch e.GetType() {
+		case scheduler.Event_SUBSCRIBED:
+			cache.Clear()
+		case scheduler.Event_OFFERS:
+			cache.Add(e.GetOffers().GetOffers()...)
+		case scheduler.Event_INVERSE_OFFERS:
+			cache.AddInverse(e.GetInverseOffers().GetInverseOffers()...)
+		case scheduler.Event_RESCIND:
+			cache.Rescind(e.GetRescind().GetOfferID())
+		case scheduler.Event_RESCIND_INVERSE_OFFER:
+			cache.RescindInverse(e.GetRescindInverseOffer().GetInverseOfferID())
+		case scheduler.Event_FAILURE:
+			// the failure of an agent, rather than of an executor
+			if f := e.GetFailure(); f.ExecutorID == nil && f.AgentID != nil {
+				cache.AgentRemoved(*f.AgentID)
+			}
+		}
+		return chain(ctx, e, err)
+	}
+}
+
+// DiagnoseOffers records the offers that are received, and rescinded, in the given Diagnostics; outstanding
+// offers are forgotten upon (re-)subscription. Events are always propagated to the chain. See also
+// callrules.DiagnoseOffers.
+func DiagnoseOffers(d *offers.Diagnostics) Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, chain Chain) (context.Context, *scheduler.Event, error) {
+		switch e.GetType() {
+		case scheduler.Event_SUBSCRIBED:
+			d.Subscribed()
+		case scheduler.Event_OFFERS:
+			d.Offered(e.GetOffers().GetOffers()...)
+		case scheduler.Event_RESCIND:
+			d.Rescinded(e.GetRescind().GetOfferID())
+		}
+		return chain(ctx, e, err)
+	}
+}
+
+// TrackTasks records the task status updates received from Mesos in the given task tracker. Events are
+// always propagated to the chain.
+func TrackTasks(tracker *tasks.Tracker) Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, chain Chain) (context.Context, *scheduler.Event, error) {
+		if e.GetType() == scheduler.Event_UPDATE {
+			tracker.Update(e.GetUpdate().GetStatus())
+		}
+		return chain(ctx, e, err)
+	}
+}
+
+// TrackQuotas records the task status updates received from Mesos in the given quota tracker, which then
+// stops accounting for the resources of the tasks. Events are always propagated to the chain.
+func TrackQuotas(tracker *quotas.Tracker) Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, chain Chain) (context.Context, *scheduler.Event, error) {
+		if e.GetType() == scheduler.Event_UPDATE {
+			tracker.Update(e.GetUpdate().GetStatus())
+		}
+		return chain(ctx, e, err)
+	}
+}
+
+// TrackVersion records the version of the master, as reported by SUBSCRIBED events, in the given version
+// tracker; see callrules.RequireFeatures. Events are always propagated to the chain.
+func TrackVersion(tracker *version.Tracker) Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, chain Chain) (context.Context, *scheduler.Event, error) {
+		if e.GetType() == scheduler.Event_SUBSCRIBED {
+			tracker.Update(e.GetSubscribed().GetMasterInfo())
+		}
+		return chain(ctx, e, err)
+	}
+}
+
 // AckStatusUpdates sends an acknowledgement of a task status update back to mesos and drops the event if
 // sending the ack fails. If successful, the specified err param (if any) is forwarded. Acknowledgements
 // are only attempted for task status updates tagged with a UUID.
@@ -0,0 +1,204 @@
+// Package lazy implements partial decoding of scheduler events. OFFERS events may be megabytes in size;
+// a lazy Event parses the event type and the identity of each offer (ID, agent, hostname) eagerly, and
+// defers decoding of the remainder of an offer (resources, attributes, etc.) until it's accessed.
+// Frameworks that decline most offers avoid the cost of decoding them.
+//
+// Laziness applies to protobuf encoded events only: JSON encoded events are decoded in full.
+package lazy
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+// ErrMalformed is returned when an event can't be parsed.
+var ErrMalformed = errors.New("malformed protobuf event")
+
+const (
+	fieldEventType   = 1 // scheduler.Event.type
+	fieldEventOffers = 3 // scheduler.Event.offers
+	fieldOffersOffer = 1 // scheduler.Event.Offers.offers
+
+	fieldOfferID       = 1 // mesos.Offer.id
+	fieldOfferAgentID  = 3 // mesos.Offer.agent_id
+	fieldOfferHostname = 4 // mesos.Offer.hostname
+
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+type (
+	// Event is a scheduler event that's decoded on demand; it implements encoding.Unmarshaler and may be
+	// used in place of a scheduler.Event when decoding a subscription's response.
+	Event struct {
+		Type   scheduler.Event_Type
+		Offers []*Offer // offers of an OFFERS event
+
+		raw     []byte
+		once    sync.Once
+		decoded *scheduler.Event
+		err     error
+	}
+
+	// Offer is a resource offer whose identity is decoded eagerly; the full offer is decoded on demand.
+	Offer struct {
+		ID       mesos.OfferID
+		AgentID  mesos.AgentID
+		Hostname string
+
+		raw     []byte
+		once    sync.Once
+		decoded *mesos.Offer
+		err     error
+	}
+)
+
+// Reset implements proto.Message.
+func (e *Event) Reset() { *e = Event{} }
+
+// String implements proto.Message.
+func (e *Event) String() string { return "lazy " + e.Type.String() + " event" }
+
+// ProtoMessage implements proto.Message.
+func (*Event) ProtoMessage() {}
+
+// Unmarshal implements proto.Unmarshaler. The given buffer is copied, the Event doesn't retain it.
+func (e *Event) Unmarshal(b []byte) error {
+	*e = Event{raw: append([]byte(nil), b...)}
+	return walk(e.raw, func(num, wt int, v []byte, x uint64) error {
+		switch {
+		case num == fieldEventType && wt == wireVarint:
+			e.Type = scheduler.Event_Type(x)
+		case num == fieldEventOffers && wt == wireBytes:
+			return walk(v, func(num, wt int, v []byte, _ uint64) error {
+				if num != fieldOffersOffer || wt != wireBytes {
+					return nil
+				}
+				o, err := newOffer(v)
+				if err == nil {
+					e.Offers = append(e.Offers, o)
+				}
+				return err
+			})
+		}
+		return nil
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler; the event is decoded in full.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var decoded scheduler.Event
+	if err := decoded.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	*e = Event{Type: decoded.Type, decoded: &decoded}
+	for i := range decoded.GetOffers().GetOffers() {
+		o := &decoded.Offers.Offers[i]
+		e.Offers = append(e.Offers, &Offer{ID: o.ID, AgentID: o.AgentID, Hostname: o.Hostname, decoded: o})
+	}
+	return nil
+}
+
+// Event returns the fully decoded event. The result is cached; callers must not modify it.
+func (e *Event) Event() (*scheduler.Event, error) {
+	e.once.Do(func() {
+		if e.decoded == nil {
+			e.decoded = new(scheduler.Event)
+			e.err = e.decoded.Unmarshal(e.raw)
+		}
+	})
+	return e.decoded, e.err
+}
+
+// OfferIDs returns the IDs of the offers of an OFFERS event.
+func (e *Event) OfferIDs() []mesos.OfferID {
+	ids := make([]mesos.OfferID, len(e.Offers))
+	for i, o := range e.Offers {
+		ids[i] = o.ID
+	}
+	return ids
+}
+
+func newOffer(b []byte) (*Offer, error) {
+	o := &Offer{raw: b}
+	err := walk(b, func(num, wt int, v []byte, _ uint64) error {
+		if wt != wireBytes {
+			return nil
+		}
+		switch num {
+		case fieldOfferID:
+			return o.ID.Unmarshal(v)
+		case fieldOfferAgentID:
+			return o.AgentID.Unmarshal(v)
+		case fieldOfferHostname:
+			o.Hostname = string(v)
+		}
+		return nil
+	})
+	return o, err
+}
+
+// Offer returns the fully decoded offer. The result is cached; callers must not modify it.
+func (o *Offer) Offer() (*mesos.Offer, error) {
+	o.once.Do(func() {
+		if o.decoded == nil {
+			o.decoded = new(mesos.Offer)
+			o.err = o.decoded.Unmarshal(o.raw)
+		}
+	})
+	return o.decoded, o.err
+}
+
+// walk invokes f for every field of the protobuf encoded message b; v is the payload of length-delimited
+// fields and x is the value of varint and fixed-size fields.
+func walk(b []byte, f func(num, wt int, v []byte, x uint64) error) error {
+	for len(b) > 0 {
+		tag, n := proto.DecodeVarint(b)
+		if n == 0 {
+			return ErrMalformed
+		}
+		b = b[n:]
+		var (
+			num, wt = int(tag >> 3), int(tag & 7)
+			v       []byte
+			x       uint64
+		)
+		switch wt {
+		case wireVarint:
+			if x, n = proto.DecodeVarint(b); n == 0 {
+				return ErrMalformed
+			}
+		case wireFixed64, wireFixed32:
+			n = 8
+			if wt == wireFixed32 {
+				n = 4
+			}
+			if len(b) < n {
+				return ErrMalformed
+			}
+			for i := n - 1; i >= 0; i-- {
+				x = x<<8 | uint64(b[i])
+			}
+		case wireBytes:
+			l, ln := proto.DecodeVarint(b)
+			if ln == 0 || l > uint64(len(b)-ln) {
+				return ErrMalformed
+			}
+			v, n = b[ln:ln+int(l)], ln+int(l)
+		default:
+			// groups are not used by the Mesos protos
+			return ErrMalformed
+		}
+		b = b[n:]
+		if err := f(num, wt, v, x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package lazy_test
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/lazy"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+func offersEvent(n int) *scheduler.Event {
+	e := &scheduler.Event{Type: scheduler.Event_OFFERS, Offers: &scheduler.Event_Offers{}}
+	for i := 0; i < n; i++ {
+		s := strconv.Itoa(i)
+		e.Offers.Offers = append(e.Offers.Offers, mesos.Offer{
+			ID:          mesos.OfferID{Value: "o" + s},
+			FrameworkID: mesos.FrameworkID{Value: "f"},
+			AgentID:     mesos.AgentID{Value: "a" + s},
+			Hostname:    "h" + s,
+			Resources: mesos.Resources{
+				resources.NewCPUs(4).Resource,
+				resources.NewMemory(1024).Resource,
+			},
+		})
+	}
+	return e
+}
+
+func TestEvent(t *testing.T) {
+	want := offersEvent(3)
+	for _, mt := range []encoding.MediaType{codecs.MediaTypeProtobuf, codecs.MediaTypeJSON} {
+		codec := codecs.ByMediaType[mt]
+		var (
+			frame []byte
+			enc   = codec.NewEncoder(func() framing.Writer {
+				return framing.WriterFunc(func(b []byte) error { frame = b; return nil })
+			})
+		)
+		if err := enc.Encode(want); err != nil {
+			t.Fatal(err)
+		}
+		var e lazy.Event
+		if err := codec.NewDecoder(encoding.SourceReader(bytes.NewReader(frame))).Decode(&e); err != nil {
+			t.Fatalf("%s: %v", mt, err)
+		}
+		if e.Type != scheduler.Event_OFFERS || len(e.Offers) != 3 {
+			t.Fatalf("%s: unexpected event %v w/ %d offers", mt, e.Type, len(e.Offers))
+		}
+		for i, o := range e.Offers {
+			w := &want.Offers.Offers[i]
+			if o.ID != w.ID || o.AgentID != w.AgentID || o.Hostname != w.Hostname {
+				t.Fatalf("%s: expected offer %v instead of %v", mt, w, o)
+			}
+			full, err := o.Offer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !full.Equal(w) {
+				t.Fatalf("%s: expected offer %v instead of %v", mt, w, full)
+			}
+		}
+		if ids := e.OfferIDs(); len(ids) != 3 || ids[2].Value != "o2" {
+			t.Fatalf("%s: unexpected offer IDs %v", mt, ids)
+		}
+		full, err := e.Event()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !full.Equal(want) {
+			t.Fatalf("%s: expected event %v instead of %v", mt, want, full)
+		}
+	}
+}
+
+func TestEvent_Malformed(t *testing.T) {
+	var e lazy.Event
+	for i, b := range [][]byte{
+		{0x1a},             // offers, missing length
+		{0x1a, 0x05, 0x0a}, // offers, truncated
+		{0x0b},             // group
+	} {
+		if err := e.Unmarshal(b); err != lazy.ErrMalformed {
+			t.Errorf("test case %d failed: expected ErrMalformed instead of %v", i, err)
+		}
+	}
+}
+
+func BenchmarkEvent(b *testing.B) {
+	frame, err := offersEvent(100).Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var e scheduler.Event
+			if err := e.Unmarshal(frame); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var e lazy.Event
+			if err := e.Unmarshal(frame); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package offers
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type (
+	// Cache tracks the outstanding offers and inverse offers of a framework, along w/ the agents that are
+	// draining: those that are scheduled for maintenance, as indicated by the Unavailability of their
+	// offers or by inverse offers. All Cache funcs are safe to invoke concurrently.
+	Cache struct {
+		mu         sync.Mutex
+		offers     map[mesos.OfferID]mesos.Offer
+		inverse    map[mesos.OfferID]mesos.InverseOffer
+		drains     map[string]AgentDrain // by agent ID
+		onDrain    func(AgentDrain)
+		onUndrain  func(mesos.AgentID)
+		notifyLock sync.Mutex // serializes callbacks
+	}
+
+	// AgentDrain describes an agent that's draining.
+	AgentDrain struct {
+		AgentID  mesos.AgentID
+		Hostname string // unknown ("") if the drain was only indicated by inverse offers
+		// Unavailability is the window of the maintenance of the agent; tasks should be migrated off
+		// of the agent before it begins.
+		Unavailability mesos.Unavailability
+	}
+
+	// CacheOpt is a functional option type for Cache.
+	CacheOpt func(*Cache)
+)
+
+// OnDrain specifies a func that's invoked when an agent begins draining, or when the unavailability of a
+// draining agent changes; frameworks may use it to proactively migrate tasks before the agent goes down.
+// The func is invoked outside of the lock of the Cache, but never concurrently.
+func OnDrain(f func(AgentDrain)) CacheOpt { return func(c *Cache) { c.onDrain = f } }
+
+// OnUndrain specifies a func that's invoked when an agent is no longer draining: its maintenance has been
+// canceled, or it's gone. The func is invoked outside of the lock of the Cache, but never concurrently.
+func OnUndrain(f func(mesos.AgentID)) CacheOpt { return func(c *Cache) { c.onUndrain = f } }
+
+// NewCache returns an empty Cache.
+func NewCache(opts ...CacheOpt) *Cache {
+	c := &Cache{
+		offers:  make(map[mesos.OfferID]mesos.Offer),
+		inverse: make(map[mesos.OfferID]mesos.InverseOffer),
+		drains:  make(map[string]AgentDrain),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// Add records the given offers as outstanding. An offer that specifies an Unavailability marks its agent
+// as draining; an offer that doesn't unmarks its agent, unless an inverse offer for the agent is
+// outstanding.
+func (c *Cache) Add(offers ...mesos.Offer) {
+	var drained, undrained []AgentDrain
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i := range offers {
+			o := &offers[i]
+			c.offers[o.ID] = *o
+			if o.Unavailability != nil {
+				if d, ok := c.drain(o.AgentID, o.Hostname, *o.Unavailability); ok {
+					drained = append(drained, d)
+				}
+			} else if d, ok := c.drains[o.AgentID.Value]; ok && !c.hasInverse(o.AgentID.Value) {
+				delete(c.drains, o.AgentID.Value)
+				undrained = append(undrained, d)
+			}
+		}
+	}()
+	c.notify(drained, undrained)
+}
+
+// AddInverse records the given inverse offers as outstanding, and marks their agents as draining.
+func (c *Cache) AddInverse(offers ...mesos.InverseOffer) {
+	var drained []AgentDrain
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i := range offers {
+			o := &offers[i]
+			c.inverse[o.OfferID] = *o
+			if o.AgentID != nil {
+				if d, ok := c.drain(*o.AgentID, "", o.Unavailability); ok {
+					drained = append(drained, d)
+				}
+			}
+		}
+	}()
+	c.notify(drained, nil)
+}
+
+// drain marks the agent as draining; returns true if the agent wasn't draining, or if its unavailability
+// has changed. Must be invoked while holding the lock.
+func (c *Cache) drain(agentID mesos.AgentID, hostname string, u mesos.Unavailability) (AgentDrain, bool) {
+	d, ok := c.drains[agentID.Value]
+	if hostname == "" {
+		hostname = d.Hostname
+	}
+	changed := !ok || !d.Unavailability.Equal(&u)
+	d = AgentDrain{AgentID: agentID, Hostname: hostname, Unavailability: u}
+	c.drains[agentID.Value] = d
+	return d, changed
+}
+
+// hasInverse returns true if an inverse offer for the agent is outstanding; must be invoked while holding
+// the lock.
+func (c *Cache) hasInverse(agentID string) bool {
+	for _, io := range c.inverse {
+		if io.GetAgentID().GetValue() == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cache) notify(drained, undrained []AgentDrain) {
+	if len(drained)+len(undrained) == 0 || (c.onDrain == nil && c.onUndrain == nil) {
+		return
+	}
+	c.notifyLock.Lock()
+	defer c.notifyLock.Unlock()
+	for _, d := range undrained {
+		if c.onUndrain != nil {
+			c.onUndrain(d.AgentID)
+		}
+	}
+	for _, d := range drained {
+		if c.onDrain != nil {
+			c.onDrain(d)
+		}
+	}
+}
+
+// AgentRemoved forgets the outstanding offers and inverse offers of the agent, which is gone (for
+// example, as per a FAILURE event, or an AGENT_REMOVED event of the operator API of the master), and
+// unmarks it if it was draining.
+func (c *Cache) AgentRemoved(agentID mesos.AgentID) {
+	var undrained []AgentDrain
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for id, o := range c.offers {
+			if o.AgentID.Value == agentID.Value {
+				delete(c.offers, id)
+			}
+		}
+		for id, io := range c.inverse {
+			if io.GetAgentID().GetValue() == agentID.Value {
+				delete(c.inverse, id)
+			}
+		}
+		if d, ok := c.drains[agentID.Value]; ok {
+			delete(c.drains, agentID.Value)
+			undrained = append(undrained, d)
+		}
+	}()
+	c.notify(nil, undrained)
+}
+
+// DrainingAgents returns the agents that are draining, ordered by the start of their unavailability.
+func (c *Cache) DrainingAgents() []AgentDrain {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := make([]AgentDrain, 0, len(c.drains))
+	for _, d := range c.drains {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Unavailability.Start.Nanoseconds < result[j].Unavailability.Start.Nanoseconds
+	})
+	return result
+}
+
+// IsDraining returns true if the agent is draining.
+func (c *Cache) IsDraining(agentID mesos.AgentID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.drains[agentID.Value]
+	return ok
+}
+
+// Rescind forgets the identified offer; returns false if the offer was not outstanding.
+func (c *Cache) Rescind(id mesos.OfferID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.offers[id]
+	delete(c.offers, id)
+	return ok
+}
+
+// RescindInverse forgets the identified inverse offer; returns false if the inverse offer was not outstanding.
+func (c *Cache) RescindInverse(id mesos.OfferID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.inverse[id]
+	delete(c.inverse, id)
+	return ok
+}
+
+// Take removes the identified offers from the Cache and returns those that were outstanding; callers are
+// expected to subsequently accept or decline the returned offers.
+func (c *Cache) Take(ids ...mesos.OfferID) (result Slice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		if o, ok := c.offers[id]; ok {
+			result = append(result, o)
+			delete(c.offers, id)
+		}
+	}
+	return
+}
+
+// TakeInverse removes the identified inverse offers from the Cache and returns those that were outstanding;
+// callers are expected to subsequently accept or decline the returned inverse offers.
+func (c *Cache) TakeInverse(ids ...mesos.OfferID) (result InverseSlice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		if o, ok := c.inverse[id]; ok {
+			result = append(result, o)
+			delete(c.inverse, id)
+		}
+	}
+	return
+}
+
+// Offers returns a snapshot of the outstanding offers.
+func (c *Cache) Offers() Slice {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := make(Slice, 0, len(c.offers))
+	for _, o := range c.offers {
+		result = append(result, o)
+	}
+	return result
+}
+
+// InverseOffers returns a snapshot of the outstanding inverse offers.
+func (c *Cache) InverseOffers() InverseSlice {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := make(InverseSlice, 0, len(c.inverse))
+	for _, o := range c.inverse {
+		result = append(result, o)
+	}
+	return result
+}
+
+// Draining returns the outstanding offers for agents that are draining; maintenance-aware frameworks
+// should avoid launching new tasks via such offers.
+func (c *Cache) Draining() (result Slice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, o := range c.offers {
+		if _, ok := c.drains[o.AgentID.Value]; ok {
+			result = append(result, o)
+		}
+	}
+	return
+}
+
+// Clear forgets all outstanding offers and inverse offers; for example, upon re-subscription, after
+// which previously received offers are no longer valid. Draining agents are retained since maintenance
+// outlives subscriptions; subsequent offers update them.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offers = make(map[mesos.OfferID]mesos.Offer)
+	c.inverse = make(map[mesos.OfferID]mesos.InverseOffer)
+}
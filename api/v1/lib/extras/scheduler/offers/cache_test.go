@@ -0,0 +1,141 @@
+package offers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func offer(id, agent string) mesos.Offer {
+	return mesos.Offer{ID: mesos.OfferID{Value: id}, AgentID: mesos.AgentID{Value: agent}}
+}
+
+func inverseOffer(id, agent string) mesos.InverseOffer {
+	return mesos.InverseOffer{OfferID: mesos.OfferID{Value: id}, AgentID: &mesos.AgentID{Value: agent}}
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache()
+	c.Add(offer("o1", "a1"), offer("o2", "a2"), offer("o3", "a1"))
+	c.AddInverse(inverseOffer("i1", "a1"))
+
+	if n := len(c.Offers()); n != 3 {
+		t.Fatalf("expected 3 offers instead of %d", n)
+	}
+	if d := c.Draining(); len(d) != 2 || d[0].AgentID.Value != "a1" || d[1].AgentID.Value != "a1" {
+		t.Fatalf("expected 2 draining offers for agent a1 instead of %v", d)
+	}
+	if !c.Rescind(mesos.OfferID{Value: "o3"}) {
+		t.Fatalf("expected o3 to be rescinded")
+	}
+	if c.Rescind(mesos.OfferID{Value: "o3"}) {
+		t.Fatalf("unexpected rescind of o3")
+	}
+	taken := c.Take(mesos.OfferID{Value: "o1"}, mesos.OfferID{Value: "o4"})
+	if len(taken) != 1 || taken[0].ID.Value != "o1" {
+		t.Fatalf("expected to take o1 instead of %v", taken)
+	}
+	if n := len(c.Draining()); n != 0 {
+		t.Fatalf("expected no draining offers instead of %d", n)
+	}
+	if inv := c.TakeInverse(mesos.OfferID{Value: "i1"}); len(inv) != 1 {
+		t.Fatalf("expected to take i1 instead of %v", inv)
+	}
+	if n := len(c.InverseOffers()); n != 0 {
+		t.Fatalf("expected no inverse offers instead of %d", n)
+	}
+	c.AddInverse(inverseOffer("i2", "a2"))
+	c.Clear()
+	if len(c.Offers())+len(c.InverseOffers()) != 0 {
+		t.Fatalf("expected empty cache after Clear")
+	}
+}
+
+func TestInverseSlice_AcceptDecline(t *testing.T) {
+	var (
+		received []*scheduler.Call
+		caller   = calls.CallerFunc(func(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+			received = append(received, c)
+			return nil, nil
+		})
+		ctx     = context.Background()
+		inverse = InverseSlice{inverseOffer("i1", "a1"), inverseOffer("i2", "a1")}
+	)
+	if err := (InverseSlice)(nil).Accept(ctx, caller); err != nil || len(received) != 0 {
+		t.Fatalf("expected no call for empty slice: %v, %v", err, received)
+	}
+	if err := inverse.Accept(ctx, caller); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := inverse.Decline(ctx, caller, calls.RefuseSeconds(5*time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected 2 calls instead of %d", len(received))
+	}
+	if c := received[0]; c.GetType() != scheduler.Call_ACCEPT_INVERSE_OFFERS || len(c.GetAcceptInverseOffers().GetInverseOfferIDs()) != 2 {
+		t.Fatalf("unexpected accept call %v", c)
+	}
+	if c := received[1]; c.GetType() != scheduler.Call_DECLINE_INVERSE_OFFERS || c.GetDeclineInverseOffers().GetFilters().GetRefuseSeconds() != 5 {
+		t.Fatalf("unexpected decline call %v", c)
+	}
+}
+
+func TestCache_Draining(t *testing.T) {
+	var (
+		drained   []AgentDrain
+		undrained []string
+		c         = NewCache(
+			OnDrain(func(d AgentDrain) { drained = append(drained, d) }),
+			OnUndrain(func(id mesos.AgentID) { undrained = append(undrained, id.Value) }),
+		)
+		now    = time.Now()
+		soon   = mesos.NewUnavailability(now.Add(time.Hour), time.Hour)
+		sooner = mesos.NewUnavailability(now.Add(time.Minute), time.Hour)
+		o1     = offer("o1", "a1")
+	)
+	o1.Hostname = "h1"
+	o1.Unavailability = &soon
+	c.Add(o1, offer("o2", "a2"))
+	if len(drained) != 1 || drained[0].AgentID.Value != "a1" || drained[0].Hostname != "h1" {
+		t.Fatalf("expected a1 to drain instead of %v", drained)
+	}
+	if !c.IsDraining(mesos.AgentID{Value: "a1"}) || c.IsDraining(mesos.AgentID{Value: "a2"}) {
+		t.Fatal("expected only a1 to be draining")
+	}
+
+	// unchanged unavailability doesn't notify again
+	o1.ID.Value = "o3"
+	c.Add(o1)
+	i1 := inverseOffer("i1", "a2")
+	i1.Unavailability = sooner
+	c.AddInverse(i1)
+	if len(drained) != 2 || drained[1].AgentID.Value != "a2" {
+		t.Fatalf("expected a2 to drain instead of %v", drained)
+	}
+	if agents := c.DrainingAgents(); len(agents) != 2 || agents[0].AgentID.Value != "a2" {
+		t.Fatalf("expected a2 to drain first instead of %v", agents)
+	}
+	if d := c.Draining(); len(d) != 3 {
+		t.Fatalf("expected 3 draining offers instead of %v", d)
+	}
+
+	// offers w/o unavailability undrain their agent, unless it has an outstanding inverse offer
+	c.Add(offer("o4", "a1"), offer("o5", "a2"))
+	if len(undrained) != 1 || undrained[0] != "a1" || !c.IsDraining(mesos.AgentID{Value: "a2"}) {
+		t.Fatalf("expected a1 to be undrained instead of %v", undrained)
+	}
+	c.Clear()
+	if !c.IsDraining(mesos.AgentID{Value: "a2"}) {
+		t.Fatal("expected a2 to remain draining after Clear")
+	}
+	c.Add(offer("o6", "a2"))
+	c.AgentRemoved(mesos.AgentID{Value: "a2"})
+	if len(undrained) != 2 || undrained[1] != "a2" || len(c.Offers()) != 0 || len(c.DrainingAgents()) != 0 {
+		t.Fatalf("expected a2 to be forgotten instead of %v", undrained)
+	}
+}
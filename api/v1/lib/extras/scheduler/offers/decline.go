@@ -0,0 +1,177 @@
+package offers
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+const (
+	// DefaultRefuseSeconds is the default duration for which declined resources are refused; it matches
+	// the default of the Mesos master.
+	DefaultRefuseSeconds = 5 * time.Second
+
+	// DefaultSuppressedRefuseSeconds is the default duration for which declined resources are refused by
+	// roles that are suppressed. Such roles don't need offers until they're revived, which clears all
+	// filters anyway.
+	DefaultSuppressedRefuseSeconds = time.Hour
+)
+
+type (
+	// DeclinePolicy determines the Filters of the calls that decline offers (and the Filters of the calls
+	// that accept offers, which apply to the unused resources of the offers), replacing ad-hoc refusal
+	// durations that are sprinkled throughout scheduler code. Refusal durations may be configured per role;
+	// roles that are suppressed refuse declined resources for longer. All DeclinePolicy funcs are safe to
+	// invoke concurrently.
+	DeclinePolicy struct {
+		mu               sync.Mutex
+		refuse           time.Duration
+		suppressedRefuse time.Duration
+		roles            map[string]time.Duration
+		suppressAll      bool
+		suppressed       map[string]struct{} // roles that are suppressed, in addition to suppressAll
+		revived          map[string]struct{} // roles that are revived, despite suppressAll
+	}
+
+	// DeclinePolicyOpt is a functional option type for DeclinePolicy.
+	DeclinePolicyOpt func(*DeclinePolicy)
+)
+
+// RefuseFor overrides DefaultRefuseSeconds.
+func RefuseFor(d time.Duration) DeclinePolicyOpt { return func(p *DeclinePolicy) { p.refuse = d } }
+
+// RefuseRoleFor overrides the refusal duration for resources that are allocated to the given role.
+func RefuseRoleFor(role string, d time.Duration) DeclinePolicyOpt {
+	return func(p *DeclinePolicy) { p.roles[role] = d }
+}
+
+// RefuseSuppressedFor overrides DefaultSuppressedRefuseSeconds.
+func RefuseSuppressedFor(d time.Duration) DeclinePolicyOpt {
+	return func(p *DeclinePolicy) { p.suppressedRefuse = d }
+}
+
+// NewDeclinePolicy returns a DeclinePolicy that's configured by the given options.
+func NewDeclinePolicy(opts ...DeclinePolicyOpt) *DeclinePolicy {
+	p := &DeclinePolicy{
+		refuse:           DefaultRefuseSeconds,
+		suppressedRefuse: DefaultSuppressedRefuseSeconds,
+		roles:            make(map[string]time.Duration),
+		suppressed:       make(map[string]struct{}),
+		revived:          make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	return p
+}
+
+// Suppress records that offers have been suppressed for the given roles, or for all roles of the
+// framework if none are given; see calls.SuppressWith.
+func (p *DeclinePolicy) Suppress(roles ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(roles) == 0 {
+		p.suppressAll = true
+		p.suppressed = make(map[string]struct{})
+		p.revived = make(map[string]struct{})
+		return
+	}
+	for _, role := range roles {
+		p.suppressed[role] = struct{}{}
+		delete(p.revived, role)
+	}
+}
+
+// Revive records that offers have been revived for the given roles, or for all roles of the framework if
+// none are given; see calls.ReviveWith.
+func (p *DeclinePolicy) Revive(roles ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(roles) == 0 {
+		p.suppressAll = false
+		p.suppressed = make(map[string]struct{})
+		p.revived = make(map[string]struct{})
+		return
+	}
+	for _, role := range roles {
+		delete(p.suppressed, role)
+		if p.suppressAll {
+			p.revived[role] = struct{}{}
+		}
+	}
+}
+
+// Suppressed returns true if offers are suppressed for the given role.
+func (p *DeclinePolicy) Suppressed(role string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isSuppressed(role)
+}
+
+func (p *DeclinePolicy) isSuppressed(role string) bool {
+	if _, ok := p.suppressed[role]; ok {
+		return true
+	}
+	_, ok := p.revived[role]
+	return p.suppressAll && !ok
+}
+
+// RefuseSeconds returns the duration for which resources that are allocated to the given role should
+// be refused once declined. The empty role yields the default duration (which also applies to offers of
+// masters that predate multi-role support).
+func (p *DeclinePolicy) RefuseSeconds(role string) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.isSuppressed(role) {
+		return p.suppressedRefuse
+	}
+	if d, ok := p.roles[role]; ok {
+		return d
+	}
+	return p.refuse
+}
+
+// Filters returns a call option that sets the Filters of an ACCEPT or DECLINE call for offers of the given
+// role, as per RefuseSeconds.
+func (p *DeclinePolicy) Filters(role string) scheduler.CallOpt {
+	return calls.RefuseSeconds(p.RefuseSeconds(role))
+}
+
+// Decline declines the offers as per the policy. Offers are grouped by their refusal duration, which
+// yields a DECLINE call per distinct duration; the given call options are applied after the Filters of
+// the policy and so may override them, for example calls.RefuseSeconds for a one-off refusal duration.
+func (p *DeclinePolicy) Decline(ctx context.Context, caller calls.Caller, offers Slice, opts ...scheduler.CallOpt) error {
+	var (
+		groups    = make(map[time.Duration][]mesos.OfferID)
+		durations []time.Duration
+	)
+	for i := range offers {
+		d := p.RefuseSeconds(offers[i].GetAllocationInfo().GetRole())
+		if _, ok := groups[d]; !ok {
+			durations = append(durations, d)
+		}
+		groups[d] = append(groups[d], offers[i].ID)
+	}
+	// deterministic call order
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	for _, d := range durations {
+		call := calls.Decline(groups[d]...).With(calls.RefuseSeconds(d)).With(opts...)
+		if err := calls.CallNoData(ctx, caller, call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decline removes the identified offers from the Cache and declines those that were outstanding as per
+// the given policy; see DeclinePolicy.Decline.
+func (c *Cache) Decline(ctx context.Context, caller calls.Caller, p *DeclinePolicy, ids []mesos.OfferID, opts ...scheduler.CallOpt) error {
+	return p.Decline(ctx, caller, c.Take(ids...), opts...)
+}
@@ -0,0 +1,99 @@
+package offers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func roleOffer(id, role string) mesos.Offer {
+	o := offer(id, "a1")
+	o.AllocationInfo = &mesos.Resource_AllocationInfo{Role: &role}
+	return o
+}
+
+func TestDeclinePolicy(t *testing.T) {
+	var (
+		p = NewDeclinePolicy(
+			RefuseFor(10*time.Second),
+			RefuseRoleFor("batch", time.Minute),
+			RefuseSuppressedFor(time.Hour),
+		)
+		declined []*scheduler.Call
+		caller   = calls.CallerFunc(func(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+			declined = append(declined, c)
+			return nil, nil
+		})
+		ctx = context.Background()
+	)
+	for _, tc := range []struct {
+		role     string
+		expected time.Duration
+	}{
+		{"", 10 * time.Second},
+		{"web", 10 * time.Second},
+		{"batch", time.Minute},
+	} {
+		if d := p.RefuseSeconds(tc.role); d != tc.expected {
+			t.Errorf("expected %v for role %q instead of %v", tc.expected, tc.role, d)
+		}
+	}
+
+	p.Suppress("batch")
+	if !p.Suppressed("batch") || p.Suppressed("web") || p.RefuseSeconds("batch") != time.Hour {
+		t.Fatal("expected only batch to be suppressed")
+	}
+	p.Suppress()
+	p.Revive("web")
+	if !p.Suppressed("batch") || p.Suppressed("web") || !p.Suppressed("other") {
+		t.Fatal("expected all roles but web to be suppressed")
+	}
+	p.Revive()
+	if p.Suppressed("batch") || p.Suppressed("other") {
+		t.Fatal("expected all roles to be revived")
+	}
+
+	p.Suppress("batch")
+	offers := Slice{roleOffer("o1", "web"), roleOffer("o2", "batch"), offer("o3", "a2")}
+	if err := p.Decline(ctx, caller, offers); err != nil {
+		t.Fatal(err)
+	}
+	if len(declined) != 2 {
+		t.Fatalf("expected 2 decline calls instead of %d", len(declined))
+	}
+	for i, expected := range []struct {
+		ids    []string
+		refuse float64
+	}{
+		{[]string{"o1", "o3"}, 10},
+		{[]string{"o2"}, 3600},
+	} {
+		d := declined[i].GetDecline()
+		if len(d.OfferIDs) != len(expected.ids) || d.GetFilters().GetRefuseSeconds() != expected.refuse {
+			t.Fatalf("unexpected decline call %v", declined[i])
+		}
+		for j := range d.OfferIDs {
+			if d.OfferIDs[j].Value != expected.ids[j] {
+				t.Fatalf("unexpected decline call %v", declined[i])
+			}
+		}
+	}
+
+	// per-decline overrides, via the Cache
+	declined = nil
+	c := NewCache()
+	c.Add(roleOffer("o4", "web"), roleOffer("o5", "web"))
+	if err := c.Decline(ctx, caller, p, []mesos.OfferID{{Value: "o4"}, {Value: "o6"}}, calls.RefuseSeconds(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if len(declined) != 1 || len(declined[0].GetDecline().OfferIDs) != 1 || declined[0].GetDecline().GetFilters().GetRefuseSeconds() != 1 {
+		t.Fatalf("unexpected decline calls %v", declined)
+	}
+	if n := len(c.Offers()); n != 1 {
+		t.Fatalf("expected 1 outstanding offer instead of %d", n)
+	}
+}
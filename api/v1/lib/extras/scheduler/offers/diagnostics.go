@@ -0,0 +1,290 @@
+package offers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resourcefilters"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+const (
+	// DefaultDeclineLoopThreshold is the default number of consecutive declines of the offers of an agent
+	// after which Diagnostics reports a decline loop.
+	DefaultDeclineLoopThreshold = 10
+
+	// DefaultStarvationThreshold is the default number of offers that must have been insufficient for a
+	// resource, since the last accepted offer, before Diagnostics reports starvation.
+	DefaultStarvationThreshold = 10
+)
+
+const (
+	// ReasonDeclineLoop indicates that the offers of an agent are repeatedly declined; for example,
+	// because the agent doesn't satisfy the constraints of any pending work.
+	ReasonDeclineLoop = ReasonType("DECLINE_LOOP")
+
+	// ReasonInsufficientResource indicates that offers are repeatedly insufficient for the work of the
+	// framework because of a specific resource; for example, no offer ever has enough memory.
+	ReasonInsufficientResource = ReasonType("INSUFFICIENT_RESOURCE")
+)
+
+type (
+	// ReasonType categorizes the Reasons that are reported by Diagnostics.
+	ReasonType string
+
+	// Reason explains why a framework may be starved of resources.
+	Reason struct {
+		Type ReasonType
+		// AgentID and Hostname identify the agent of a ReasonDeclineLoop.
+		AgentID  string
+		Hostname string
+		// Resource is the name of the resource of a ReasonInsufficientResource.
+		Resource string
+		// Count is the number of consecutive declines (ReasonDeclineLoop) or insufficient offers
+		// (ReasonInsufficientResource), out of Total offers.
+		Count, Total int
+		Message      string
+	}
+
+	// AgentStats are the statistics of the offers of an agent.
+	AgentStats struct {
+		AgentID             string
+		Hostname            string
+		Received            int
+		Accepted            int
+		Declined            int
+		ConsecutiveDeclines int
+	}
+
+	// Report is a snapshot of the statistics that are tracked by Diagnostics, and the Reasons that they
+	// yield. Agents are ordered by ID and Reasons by Type (and then by agent or resource).
+	Report struct {
+		Agents  []AgentStats
+		Reasons []Reason
+	}
+
+	// Diagnostics tracks how often the offers of each agent are received, accepted and declined, and which
+	// resources of the offers were insufficient for the work of the framework. It detects patterns that
+	// starve a framework, such as offers that are declined over and over, or offers that never have enough
+	// of some resource, and reports them as Reasons. See controller.DiagnoseOffers and
+	// callrules.DiagnoseOffers for Rules that feed Diagnostics. All Diagnostics funcs are safe to invoke
+	// concurrently.
+	Diagnostics struct {
+		mu                   sync.Mutex
+		declineLoopThreshold int
+		starvationThreshold  int
+		agents               map[string]*AgentStats
+		outstanding          map[mesos.OfferID]string // agent IDs of the offers that are outstanding
+		evaluated            int                      // offers evaluated by Unsatisfied since an accept
+		shortfalls           map[string]*shortfall    // by resource name
+	}
+
+	shortfall struct {
+		count   int
+		offered float64 // largest amount offered, for scalars
+		wanted  float64 // smallest amount wanted, for scalars
+	}
+
+	// DiagnosticsOpt is a functional option type for Diagnostics.
+	DiagnosticsOpt func(*Diagnostics)
+)
+
+func (r Reason) String() string { return r.Message }
+
+// DeclineLoopThreshold overrides DefaultDeclineLoopThreshold.
+func DeclineLoopThreshold(n int) DiagnosticsOpt {
+	return func(d *Diagnostics) { d.declineLoopThreshold = n }
+}
+
+// StarvationThreshold overrides DefaultStarvationThreshold.
+func StarvationThreshold(n int) DiagnosticsOpt {
+	return func(d *Diagnostics) { d.starvationThreshold = n }
+}
+
+// NewDiagnostics returns a Diagnostics that's configured by the given options.
+func NewDiagnostics(opts ...DiagnosticsOpt) *Diagnostics {
+	d := &Diagnostics{
+		declineLoopThreshold: DefaultDeclineLoopThreshold,
+		starvationThreshold:  DefaultStarvationThreshold,
+		agents:               make(map[string]*AgentStats),
+		outstanding:          make(map[mesos.OfferID]string),
+		shortfalls:           make(map[string]*shortfall),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	return d
+}
+
+func (d *Diagnostics) agent(id, hostname string) *AgentStats {
+	a, ok := d.agents[id]
+	if !ok {
+		a = &AgentStats{AgentID: id}
+		d.agents[id] = a
+	}
+	if hostname != "" {
+		a.Hostname = hostname
+	}
+	return a
+}
+
+// Offered records the receipt of the offers.
+func (d *Diagnostics) Offered(offers ...mesos.Offer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range offers {
+		d.agent(offers[i].AgentID.Value, offers[i].Hostname).Received++
+		d.outstanding[offers[i].ID] = offers[i].AgentID.Value
+	}
+}
+
+// Accepted records that the identified offers were accepted; offers that aren't outstanding are ignored.
+func (d *Diagnostics) Accepted(ids ...mesos.OfferID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	accepted := false
+	for _, id := range ids {
+		if agentID, ok := d.outstanding[id]; ok {
+			delete(d.outstanding, id)
+			a := d.agent(agentID, "")
+			a.Accepted++
+			a.ConsecutiveDeclines = 0
+			accepted = true
+		}
+	}
+	if accepted {
+		// some offer was sufficient, so start over
+		d.evaluated = 0
+		d.shortfalls = make(map[string]*shortfall)
+	}
+}
+
+// Declined records that the identified offers were declined; offers that aren't outstanding are ignored.
+func (d *Diagnostics) Declined(ids ...mesos.OfferID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, id := range ids {
+		if agentID, ok := d.outstanding[id]; ok {
+			delete(d.outstanding, id)
+			a := d.agent(agentID, "")
+			a.Declined++
+			a.ConsecutiveDeclines++
+		}
+	}
+}
+
+// Subscribed forgets the outstanding offers, which Mesos rescinds when a framework (re-)subscribes; the
+// statistics of the agents and the shortfalls are retained.
+func (d *Diagnostics) Subscribed() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.outstanding = make(map[mesos.OfferID]string)
+}
+
+// Rescinded forgets the identified offer.
+func (d *Diagnostics) Rescinded(id mesos.OfferID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.outstanding, id)
+}
+
+// Unsatisfied records that the offer was evaluated against the wanted resources (for example, those of a
+// pending task) and found insufficient; the resources that the offer lacks are recorded as shortfalls.
+// Reservations and allocations are disregarded when comparing resources.
+func (d *Diagnostics) Unsatisfied(offer *mesos.Offer, wanted mesos.Resources) {
+	var (
+		offered = mesos.Resources(offer.Resources).Clone().Unallocate().ToUnreserved()
+		lacking = make(map[string]*shortfall)
+	)
+	wanted = wanted.Clone().Unallocate().ToUnreserved()
+	for _, name := range resources.NamesOf(wanted...) {
+		var (
+			filter = resourcefilters.Filter(name.Filter)
+			w      = resourcefilters.Select(filter, wanted...)
+			o      = resourcefilters.Select(filter, offered...)
+		)
+		if resources.ContainsAll(o, w) {
+			continue
+		}
+		s := &shortfall{}
+		if sum, ok := name.Sum(w...); ok && sum.GetScalar() != nil {
+			s.wanted = sum.GetScalar().GetValue()
+		}
+		if sum, ok := name.Sum(o...); ok && sum.GetScalar() != nil {
+			s.offered = sum.GetScalar().GetValue()
+		}
+		lacking[name.String()] = s
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.evaluated++
+	for name, s := range lacking {
+		if prev, ok := d.shortfalls[name]; ok {
+			s.count = prev.count
+			if prev.offered > s.offered {
+				s.offered = prev.offered
+			}
+			if prev.wanted < s.wanted {
+				s.wanted = prev.wanted
+			}
+		}
+		s.count++
+		d.shortfalls[name] = s
+	}
+}
+
+// Report returns the current statistics and the Reasons that they yield.
+func (d *Diagnostics) Report() (r Report) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, a := range d.agents {
+		r.Agents = append(r.Agents, *a)
+	}
+	sort.Slice(r.Agents, func(i, j int) bool { return r.Agents[i].AgentID < r.Agents[j].AgentID })
+
+	for _, a := range r.Agents {
+		if a.ConsecutiveDeclines >= d.declineLoopThreshold {
+			name := a.AgentID
+			if a.Hostname != "" {
+				name = a.Hostname + " (" + a.AgentID + ")"
+			}
+			r.Reasons = append(r.Reasons, Reason{
+				Type:     ReasonDeclineLoop,
+				AgentID:  a.AgentID,
+				Hostname: a.Hostname,
+				Count:    a.ConsecutiveDeclines,
+				Total:    a.Received,
+				Message: fmt.Sprintf("the last %d offers of agent %s were declined; it may not satisfy the constraints of any pending work",
+					a.ConsecutiveDeclines, name),
+			})
+		}
+	}
+
+	var names []string
+	for name, s := range d.shortfalls {
+		if s.count >= d.starvationThreshold {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := d.shortfalls[name]
+		msg := fmt.Sprintf("%s was insufficient in %d of the last %d offers", name, s.count, d.evaluated)
+		if s.wanted > 0 {
+			msg += fmt.Sprintf("; the largest offer had %g, while %g is wanted", s.offered, s.wanted)
+		}
+		r.Reasons = append(r.Reasons, Reason{
+			Type:     ReasonInsufficientResource,
+			Resource: name,
+			Count:    s.count,
+			Total:    d.evaluated,
+			Message:  msg,
+		})
+	}
+	return r
+}
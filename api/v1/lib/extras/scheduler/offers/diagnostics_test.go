@@ -0,0 +1,74 @@
+package offers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestDiagnostics(t *testing.T) {
+	var (
+		d      = NewDiagnostics(DeclineLoopThreshold(3), StarvationThreshold(2))
+		wanted = mesos.Resources{resources.NewCPUs(1).Resource, resources.NewMemory(1024).Resource}
+		id     = func(i int) mesos.OfferID { return mesos.OfferID{Value: "o" + string(rune('0'+i))} }
+	)
+	for i := 0; i < 4; i++ {
+		o := offer(id(i).Value, "a1")
+		o.Hostname = "h1"
+		o.Resources = mesos.Resources{resources.NewCPUs(4).Resource, resources.NewMemory(float64(256 * (i + 1))).Resource}
+		d.Offered(o)
+		d.Unsatisfied(&o, wanted)
+		d.Declined(o.ID)
+	}
+	d.Offered(offer("o9", "a2"))
+	d.Accepted(mesos.OfferID{Value: "o9"}, mesos.OfferID{Value: "unknown"})
+
+	r := d.Report()
+	if len(r.Agents) != 2 || r.Agents[0].Received != 4 || r.Agents[0].ConsecutiveDeclines != 4 || r.Agents[1].Accepted != 1 {
+		t.Fatalf("unexpected agent stats %+v", r.Agents)
+	}
+	// the accept resets the shortfalls
+	if len(r.Reasons) != 1 || r.Reasons[0].Type != ReasonDeclineLoop || r.Reasons[0].Hostname != "h1" {
+		t.Fatalf("unexpected reasons %v", r.Reasons)
+	}
+
+	for i := 0; i < 2; i++ {
+		o := offer("o"+string(rune('a'+i)), "a1")
+		o.Resources = mesos.Resources{resources.NewCPUs(4).Resource, resources.NewMemory(512).Resource}
+		d.Offered(o)
+		d.Unsatisfied(&o, wanted)
+		d.Unsatisfied(&o, mesos.Resources{resources.NewMemory(768).Resource})
+	}
+	r = d.Report()
+	if len(r.Reasons) != 2 {
+		t.Fatalf("expected 2 reasons instead of %v", r.Reasons)
+	}
+	if reason := r.Reasons[1]; reason.Type != ReasonInsufficientResource || reason.Resource != "mem" || reason.Count != 4 || reason.Total != 4 {
+		t.Fatalf("unexpected reason %+v", reason)
+	}
+	if msg := r.Reasons[1].String(); !strings.Contains(msg, "largest offer had 512, while 768 is wanted") {
+		t.Fatalf("unexpected message %q", msg)
+	}
+
+	// accepting offers that aren't outstanding doesn't reset the shortfalls
+	d.Accepted(mesos.OfferID{Value: "o9"})
+	if r = d.Report(); len(r.Reasons) != 2 {
+		t.Fatalf("expected 2 reasons instead of %v", r.Reasons)
+	}
+	// nor does accepting an offer that was outstanding prior to re-subscription
+	d.Subscribed()
+	d.Accepted(mesos.OfferID{Value: "ob"})
+	if r = d.Report(); len(r.Reasons) != 2 || r.Agents[0].Accepted != 0 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+
+	// accepting an offer of the agent ends its decline loop
+	o := offer("oc", "a1")
+	d.Offered(o)
+	d.Accepted(o.ID)
+	if r = d.Report(); len(r.Reasons) != 0 {
+		t.Fatalf("unexpected reasons %v", r.Reasons)
+	}
+}
@@ -66,6 +66,29 @@ func ByUnavailability(f func(u *mesos.Unavailability) bool) Filter {
 	})
 }
 
+// ByRegion returns a Filter that accepts offers from agents in the given fault domain region.
+func ByRegion(region string) Filter {
+	return FilterFunc(func(o *mesos.Offer) bool {
+		return o.GetDomain().Region() == region
+	})
+}
+
+// ByZone returns a Filter that accepts offers from agents in the given fault domain zone.
+func ByZone(zone string) Filter {
+	return FilterFunc(func(o *mesos.Offer) bool {
+		return o.GetDomain().Zone() == zone
+	})
+}
+
+// LocalTo returns a Filter that accepts offers from agents that are not remote w/ respect to the given
+// (usually the master's) domain; see DomainInfo.IsRemote. Only REGION_AWARE frameworks receive offers
+// from remote agents. The domain of the master is reported by the SUBSCRIBED event.
+func LocalTo(local *mesos.DomainInfo) Filter {
+	return FilterFunc(func(o *mesos.Offer) bool {
+		return !o.GetDomain().IsRemote(local)
+	})
+}
+
 // ContainsResources returns a filter that returns true if the Resources of an Offer
 // contain the wanted Resources.
 func ContainsResources(wanted mesos.Resources) Filter {
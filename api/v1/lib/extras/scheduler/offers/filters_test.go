@@ -0,0 +1,47 @@
+package offers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestDomainFilters(t *testing.T) {
+	var (
+		withDomain = func(id, region, zone string) mesos.Offer {
+			o := offer(id, id)
+			if region != "" {
+				o.Domain = mesos.NewDomainInfo(region, zone)
+			}
+			return o
+		}
+		offers = Slice{
+			withDomain("o1", "east", "a"),
+			withDomain("o2", "east", "b"),
+			withDomain("o3", "west", "a"),
+			withDomain("o4", "", ""),
+		}
+		accepted = func(f Filter) (result []string) {
+			for i := range offers {
+				if f.Accept(&offers[i]) {
+					result = append(result, offers[i].ID.Value)
+				}
+			}
+			return
+		}
+	)
+	for i, tc := range []struct {
+		filter Filter
+		want   []string
+	}{
+		{ByRegion("east"), []string{"o1", "o2"}},
+		{ByZone("a"), []string{"o1", "o3"}},
+		{LocalTo(mesos.NewDomainInfo("east", "a")), []string{"o1", "o2", "o4"}},
+		{LocalTo(nil), []string{"o1", "o2", "o3", "o4"}},
+	} {
+		if got := accepted(tc.filter); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package offers
+
+import (
+	"context"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+// InverseSlice is a convenience type wrapper for a slice of mesos InverseOffer messages
+type InverseSlice []mesos.InverseOffer
+
+// IDs extracts the ID field from a Slice of inverse offers
+func (offers InverseSlice) IDs() []mesos.OfferID {
+	ids := make([]mesos.OfferID, len(offers))
+	for i := range offers {
+		ids[i] = offers[i].OfferID
+	}
+	return ids
+}
+
+// UnavailableBy returns the subset of inverse offers whose unavailability begins at, or before, the given time.
+func (offers InverseSlice) UnavailableBy(t time.Time) (result InverseSlice) {
+	for i := range offers {
+		if !offers[i].Unavailability.StartTime().After(t) {
+			result = append(result, offers[i])
+		}
+	}
+	return
+}
+
+// GroupByAgent groups inverse offers by the ID of the agent that they pertain to. Inverse offers that don't
+// specify an agent are grouped under the empty string.
+func (offers InverseSlice) GroupByAgent() map[string]InverseSlice {
+	result := make(map[string]InverseSlice)
+	for i := range offers {
+		id := offers[i].GetAgentID().GetValue()
+		result[id] = append(result[id], offers[i])
+	}
+	return result
+}
+
+// Accept accepts the inverse offers, signaling that the framework is prepared for the unavailability of
+// the resources. Call options may specify, for example, calls.Filters.
+func (offers InverseSlice) Accept(ctx context.Context, caller calls.Caller, opts ...scheduler.CallOpt) error {
+	if len(offers) == 0 {
+		return nil
+	}
+	return calls.CallNoData(ctx, caller, calls.AcceptInverseOffers(offers.IDs()...).With(opts...))
+}
+
+// Decline declines the inverse offers, signaling that the framework is not yet prepared for the
+// unavailability of the resources. Call options may specify, for example, calls.Filters.
+func (offers InverseSlice) Decline(ctx context.Context, caller calls.Caller, opts ...scheduler.CallOpt) error {
+	if len(offers) == 0 {
+		return nil
+	}
+	return calls.CallNoData(ctx, caller, calls.DeclineInverseOffers(offers.IDs()...).With(opts...))
+}
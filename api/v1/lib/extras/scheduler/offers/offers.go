@@ -56,7 +56,7 @@ func (offers Index) Find(filter Filter) *mesos.Offer {
 
 // Filter returns the subset of the Slice that matches the given filter.
 func (offers Slice) Filter(filter Filter) (result Slice) {
-	if sz := len(result); sz > 0 {
+	if sz := len(offers); sz > 0 {
 		result = make(Slice, 0, sz)
 		for i := range offers {
 			if filter.Accept(&offers[i]) {
@@ -69,7 +69,7 @@ func (offers Slice) Filter(filter Filter) (result Slice) {
 
 // Filter returns the subset of the Index that matches the given filter.
 func (offers Index) Filter(filter Filter) (result Index) {
-	if sz := len(result); sz > 0 {
+	if sz := len(offers); sz > 0 {
 		result = make(Index, sz)
 		for id, offer := range offers {
 			if filter.Accept(offer) {
@@ -0,0 +1,31 @@
+package offers
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestFilter(t *testing.T) {
+	var (
+		offers = Slice{offer("o1", "a1"), offer("o2", "a2"), offer("o3", "a1")}
+		onA1   = FilterFunc(func(o *mesos.Offer) bool { return o.AgentID.Value == "a1" })
+	)
+	if got := offers.Filter(onA1); len(got) != 2 || got[0].ID.Value != "o1" || got[1].ID.Value != "o3" {
+		t.Errorf("unexpected offers %v", got)
+	}
+	if got := offers.FilterNot(onA1); len(got) != 1 || got[0].ID.Value != "o2" {
+		t.Errorf("unexpected offers %v", got)
+	}
+	if got := Slice(nil).Filter(onA1); got != nil {
+		t.Errorf("expected nil instead of %v", got)
+	}
+
+	index := NewIndex(offers, nil)
+	if got := index.Filter(onA1); len(got) != 2 || got[mesos.OfferID{Value: "o1"}] == nil || got[mesos.OfferID{Value: "o3"}] == nil {
+		t.Errorf("unexpected offers %v", got)
+	}
+	if got := index.FilterNot(onA1); len(got) != 1 || got[mesos.OfferID{Value: "o2"}] == nil {
+		t.Errorf("unexpected offers %v", got)
+	}
+}
@@ -0,0 +1,161 @@
+package offers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+type (
+	// Partition tracks the unspent resources of an offer by the role to which they're allocated, for
+	// frameworks that subscribe w/ multiple roles. Resources that are taken from a Partition are allocated
+	// to the role that they're taken for, so that operations for one role never spend the resources of
+	// another role. Resources w/o AllocationInfo (offered by masters that predate multi-role support) are
+	// partitioned under the empty role. A Partition isn't safe to use concurrently.
+	//
+	// The operations that spend the resources of a Partition must be sent in a single ACCEPT call, along w/
+	// the operations for the other roles of the offer: Mesos declines the resources of an offer that an
+	// ACCEPT call doesn't use, regardless of their role.
+	Partition struct {
+		Offer     *mesos.Offer
+		remaining map[string]mesos.Resources
+	}
+)
+
+// NewPartition partitions the resources of the offer.
+func NewPartition(offer *mesos.Offer) *Partition {
+	p := &Partition{Offer: offer, remaining: make(map[string]mesos.Resources)}
+	for i := range offer.Resources {
+		role := offer.Resources[i].GetAllocationInfo().GetRole()
+		p.remaining[role] = p.remaining[role].Plus(offer.Resources[i])
+	}
+	return p
+}
+
+// Roles returns the roles for which resources remain, in order.
+func (p *Partition) Roles() (roles []string) {
+	for role, rs := range p.remaining {
+		if len(rs) > 0 {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	return
+}
+
+// Remaining returns (a copy of) the resources that remain for the role.
+func (p *Partition) Remaining(role string) mesos.Resources {
+	return p.remaining[role].Clone()
+}
+
+// Take locates the wanted resources among those that remain for the role (see resources.Find), which are
+// then spent. The resources that are found are returned, allocated to the role; returns false if the
+// wanted resources aren't found, in which case nothing is spent. The AllocationInfo of the wanted resources
+// is disregarded.
+func (p *Partition) Take(role string, wanted mesos.Resources) (mesos.Resources, bool) {
+	remaining := p.remaining[role]
+	found := resources.Find(wanted.Clone().Unallocate(), remaining.Clone().Unallocate()...)
+	if len(found) == 0 {
+		return nil, false
+	}
+	if role != "" {
+		found.Allocate(role)
+	}
+	p.remaining[role] = resources.Subtract(remaining, found...)
+	return found, true
+}
+
+// SplitByRole splits each offer into an offer per role, w/ only the resources that are allocated to the
+// role, and returns them by role; see Partition for the handling of resources w/o AllocationInfo. The split
+// offers keep the ID of the original offer, and so must be accepted as per Partition.
+func (offers Slice) SplitByRole() map[string]Slice {
+	result := make(map[string]Slice)
+	for i := range offers {
+		p := NewPartition(&offers[i])
+		for _, role := range p.Roles() {
+			offer := offers[i]
+			offer.Resources = p.Remaining(role)
+			offer.AllocationInfo = nil
+			if role != "" {
+				r := role
+				offer.AllocationInfo = &mesos.Resource_AllocationInfo{Role: &r}
+			}
+			result[role] = append(result[role], offer)
+		}
+	}
+	return result
+}
+
+// ValidateAllocations returns an error if an operation spends resources that are allocated to multiple
+// roles, or to a role for which the offer has no resources; i.e. when an operation for one role would spend
+// the resources of another. Offers w/o allocated resources are always valid.
+func ValidateAllocations(offer *mesos.Offer, ops ...mesos.Offer_Operation) error {
+	offered := make(map[string]struct{})
+	for i := range offer.Resources {
+		if role := offer.Resources[i].GetAllocationInfo().GetRole(); role != "" {
+			offered[role] = struct{}{}
+		}
+	}
+	if len(offered) == 0 {
+		return nil
+	}
+	for i := range ops {
+		var role string
+		for _, res := range operationResources(&ops[i]) {
+			r := res.GetAllocationInfo().GetRole()
+			if _, ok := offered[r]; !ok {
+				return fmt.Errorf("%v operation spends resource %q that's not allocated to a role of offer %q",
+					ops[i].GetType(), res.Name, offer.ID.Value)
+			}
+			if role == "" {
+				role = r
+			} else if r != role {
+				return fmt.Errorf("%v operation spends resources of multiple roles (%q and %q) of offer %q",
+					ops[i].GetType(), role, r, offer.ID.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// operationResources returns the resources that the operation spends or converts.
+func operationResources(op *mesos.Offer_Operation) (rs mesos.Resources) {
+	task := func(t *mesos.TaskInfo) {
+		rs = append(rs, t.Resources...)
+		if t.Executor != nil {
+			rs = append(rs, t.Executor.Resources...)
+		}
+	}
+	switch op.GetType() {
+	case mesos.Offer_Operation_LAUNCH:
+		for i := range op.Launch.GetTaskInfos() {
+			task(&op.Launch.TaskInfos[i])
+		}
+	case mesos.Offer_Operation_LAUNCH_GROUP:
+		if group := op.GetLaunchGroup(); group != nil {
+			rs = append(rs, group.Executor.Resources...)
+			for i := range group.TaskGroup.Tasks {
+				task(&group.TaskGroup.Tasks[i])
+			}
+		}
+	case mesos.Offer_Operation_RESERVE:
+		rs = op.Reserve.GetResources()
+	case mesos.Offer_Operation_UNRESERVE:
+		rs = op.Unreserve.GetResources()
+	case mesos.Offer_Operation_CREATE:
+		rs = op.Create.GetVolumes()
+	case mesos.Offer_Operation_DESTROY:
+		rs = op.Destroy.GetVolumes()
+	case mesos.Offer_Operation_CREATE_VOLUME:
+		rs = mesos.Resources{op.CreateVolume.GetSource()}
+	case mesos.Offer_Operation_DESTROY_VOLUME:
+		rs = mesos.Resources{op.DestroyVolume.GetVolume()}
+	case mesos.Offer_Operation_CREATE_BLOCK:
+		rs = mesos.Resources{op.CreateBlock.GetSource()}
+	case mesos.Offer_Operation_DESTROY_BLOCK:
+		rs = mesos.Resources{op.DestroyBlock.GetBlock()}
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package offers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func allocated(role string, rs ...*resources.Builder) (result mesos.Resources) {
+	for _, rb := range rs {
+		result = append(result, rb.Resource)
+	}
+	if role != "" {
+		result.Allocate(role)
+	}
+	return
+}
+
+func TestPartition(t *testing.T) {
+	o := offer("o1", "a1")
+	o.Resources = append(
+		allocated("web", resources.NewCPUs(2), resources.NewMemory(512)),
+		allocated("batch", resources.NewCPUs(4), resources.NewMemory(1024))...,
+	)
+
+	p := NewPartition(&o)
+	if roles := p.Roles(); !reflect.DeepEqual(roles, []string{"batch", "web"}) {
+		t.Fatalf("unexpected roles %v", roles)
+	}
+	if _, ok := p.Take("web", allocated("", resources.NewCPUs(3))); ok {
+		t.Fatal("expected the resources of role batch to be off limits for role web")
+	}
+	found, ok := p.Take("web", allocated("batch", resources.NewCPUs(1.5), resources.NewMemory(512)))
+	if !ok {
+		t.Fatal("expected to take resources for role web")
+	}
+	for i := range found {
+		if role := found[i].GetAllocationInfo().GetRole(); role != "web" {
+			t.Fatalf("expected resources to be allocated to web instead of %q", role)
+		}
+	}
+	if cpus, _ := resources.CPUs(p.Remaining("web")...); cpus != 0.5 {
+		t.Fatalf("expected 0.5 remaining cpus instead of %v", cpus)
+	}
+	if cpus, _ := resources.CPUs(p.Remaining("batch")...); cpus != 4 {
+		t.Fatalf("expected 4 remaining cpus for batch instead of %v", cpus)
+	}
+	if _, ok := p.Take("web", allocated("", resources.NewMemory(1))); ok {
+		t.Fatal("expected no remaining memory for web")
+	}
+	if roles := p.Roles(); !reflect.DeepEqual(roles, []string{"batch", "web"}) {
+		t.Fatalf("unexpected roles %v", roles)
+	}
+
+	legacy := offer("o2", "a2")
+	legacy.Resources = allocated("", resources.NewCPUs(1))
+	split := Slice{o, legacy}.SplitByRole()
+	if len(split) != 3 || len(split["web"]) != 1 || len(split["batch"]) != 1 || len(split[""]) != 1 {
+		t.Fatalf("unexpected split %v", split)
+	}
+	if got := split["batch"][0]; got.ID != o.ID || got.GetAllocationInfo().GetRole() != "batch" || len(got.Resources) != 2 {
+		t.Fatalf("unexpected offer for batch: %v", got)
+	}
+	if got := split[""][0]; got.ID != legacy.ID || got.AllocationInfo != nil {
+		t.Fatalf("unexpected legacy offer: %v", got)
+	}
+	if len(o.Resources) != 4 {
+		t.Fatal("expected the original offer to be left intact")
+	}
+}
+
+func TestValidateAllocations(t *testing.T) {
+	o := offer("o1", "a1")
+	o.Resources = append(
+		allocated("web", resources.NewCPUs(2)),
+		allocated("batch", resources.NewCPUs(4))...,
+	)
+	launch := func(rs ...mesos.Resources) mesos.Offer_Operation {
+		var tasks []mesos.TaskInfo
+		for _, r := range rs {
+			tasks = append(tasks, mesos.TaskInfo{Resources: r})
+		}
+		return mesos.Offer_Operation{
+			Type:   mesos.Offer_Operation_LAUNCH,
+			Launch: &mesos.Offer_Operation_Launch{TaskInfos: tasks},
+		}
+	}
+	for i, tc := range []struct {
+		ops     []mesos.Offer_Operation
+		wantErr bool
+	}{
+		{nil, false},
+		{[]mesos.Offer_Operation{launch(allocated("web", resources.NewCPUs(1)))}, false},
+		{[]mesos.Offer_Operation{
+			launch(allocated("web", resources.NewCPUs(1))),
+			launch(allocated("batch", resources.NewCPUs(1))),
+		}, false},
+		{[]mesos.Offer_Operation{launch(allocated("", resources.NewCPUs(1)))}, true},
+		{[]mesos.Offer_Operation{launch(allocated("other", resources.NewCPUs(1)))}, true},
+		{[]mesos.Offer_Operation{launch(allocated("web", resources.NewCPUs(1)), allocated("batch", resources.NewCPUs(1)))}, true},
+		{[]mesos.Offer_Operation{{
+			Type:    mesos.Offer_Operation_RESERVE,
+			Reserve: &mesos.Offer_Operation_Reserve{Resources: allocated("batch", resources.NewCPUs(1))},
+		}}, false},
+	} {
+		if err := ValidateAllocations(&o, tc.ops...); (err != nil) != tc.wantErr {
+			t.Errorf("test case %d: unexpected error %v", i, err)
+		}
+	}
+
+	legacy := offer("o2", "a2")
+	legacy.Resources = allocated("", resources.NewCPUs(1))
+	if err := ValidateAllocations(&legacy, launch(allocated("", resources.NewCPUs(1)))); err != nil {
+		t.Fatalf("unexpected error for an offer w/o allocated resources: %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+// Package pending provides a queue of pending work for schedulers that revive offers on demand: offers are
+// revived while work is pending, matched against the pending work as they arrive, and suppressed once the
+// queue drains.
+package pending
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+type (
+	// Request is a task that's waiting for resources. The resources of the task are located within an
+	// offer via resources.Find; the task is launched w/ the resources that are found, on the agent of
+	// the offer.
+	Request struct {
+		Task mesos.TaskInfo
+		// Role restricts the request to offers for the given role; the empty role matches offers for
+		// any role.
+		Role string
+		// Filter (optional) restricts the request to offers that it accepts.
+		Filter offers.Filter
+		// AgentID (optional) is the agent that's hinted to the allocator when resource requests are
+		// enabled; see RequestResources. It doesn't restrict the offers that satisfy the request.
+		AgentID string
+	}
+
+	// Queue is a FIFO queue of pending Requests that revives and suppresses offers on demand. Offers are
+	// suppressed once the queue is empty, and revived when requests are enqueued. A framework that
+	// subscribes w/ multiple roles should specify them via Roles so that offers are only revived for the
+	// roles that have pending requests. All Queue funcs are safe to invoke concurrently.
+	Queue struct {
+		mu         sync.Mutex
+		caller     calls.Caller
+		declines   *offers.DeclinePolicy
+		roles      []string
+		launched   func(context.Context, []mesos.TaskInfo)
+		requests   bool
+		diagnosis  *offers.Diagnostics
+		maxBatch   int
+		pending    []Request
+		suppressed map[string]bool // by role; "" is the key for all roles if Roles is unspecified
+	}
+
+	// Opt is a functional option type for Queue.
+	Opt func(*Queue)
+)
+
+// Roles specifies the roles of the framework, which enables offers to be revived and suppressed per role.
+func Roles(roles ...string) Opt { return func(q *Queue) { q.roles = roles } }
+
+// Declines specifies the policy that determines the refusal filters of declined offers; the policy is
+// kept informed of the roles that the queue suppresses. Defaults to offers.NewDeclinePolicy().
+func Declines(p *offers.DeclinePolicy) Opt { return func(q *Queue) { q.declines = p } }
+
+// Launched specifies a func that's invoked w/ the tasks that are launched by the queue, for example
+// tasks.Tracker.Launched.
+func Launched(f func(context.Context, []mesos.TaskInfo)) Opt {
+	return func(q *Queue) { q.launched = f }
+}
+
+// RequestResources enables REQUEST calls that hint the resources of enqueued requests to allocators that
+// honor them, w/ at most maxBatch requests per call (zero or less for no limit); see calls.RequestBatches.
+// The hints are advisory: the built-in allocator of Mesos ignores them, and offers are revived regardless.
+func RequestResources(maxBatch int) Opt {
+	return func(q *Queue) { q.requests, q.maxBatch = true, maxBatch }
+}
+
+// Diagnose records the offers that are insufficient for pending requests in the given Diagnostics.
+func Diagnose(d *offers.Diagnostics) Opt { return func(q *Queue) { q.diagnosis = d } }
+
+// NewQueue returns an empty Queue that sends calls via the given Caller.
+func NewQueue(caller calls.Caller, opts ...Opt) *Queue {
+	q := &Queue{
+		caller:     caller,
+		suppressed: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(q)
+		}
+	}
+	if q.declines == nil {
+		q.declines = offers.NewDeclinePolicy()
+	}
+	return q
+}
+
+// Enqueue appends the requests to the queue and revives offers as needed. If RequestResources is enabled
+// then the resources of the requests are hinted to the allocator before offers are revived; offers are
+// revived even if the hints fail, in which case the error of the hints is returned.
+func (q *Queue) Enqueue(ctx context.Context, reqs ...Request) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, reqs...)
+	var err error
+	if q.requests {
+		err = q.request(ctx, reqs)
+	}
+	if err2 := q.sync(ctx); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// request sends the REQUEST calls that hint the resources of the given requests.
+func (q *Queue) request(ctx context.Context, reqs []Request) error {
+	requests := make([]mesos.Request, 0, len(reqs))
+	for i := range reqs {
+		requests = append(requests, calls.ResourceRequest(reqs[i].AgentID, reqs[i].Task.Resources...))
+	}
+	for _, call := range calls.RequestBatches(q.maxBatch, requests...) {
+		if err := calls.CallNoData(ctx, q.caller, call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes the request for the identified task from the queue, and suppresses offers as needed;
+// returns false if no such request is pending.
+func (q *Queue) Remove(ctx context.Context, id mesos.TaskID) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := range q.pending {
+		if q.pending[i].Task.TaskID.Equal(&id) {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return true, q.sync(ctx)
+		}
+	}
+	return false, nil
+}
+
+// Len returns the number of pending requests.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// HandleEvent implements events.HandlerFunc for SUBSCRIBED and OFFERS events; other events are ignored.
+// Subscription revives offers for all roles, so the queue suppresses them again if it's empty. Offers
+// are handled as per HandleOffers.
+func (q *Queue) HandleEvent(ctx context.Context, e *scheduler.Event) error {
+	switch e.GetType() {
+	case scheduler.Event_SUBSCRIBED:
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.suppressed = make(map[string]bool)
+		q.declines.Revive()
+		return q.sync(ctx)
+	case scheduler.Event_OFFERS:
+		return q.HandleOffers(ctx, e.GetOffers().GetOffers())
+	}
+	return nil
+}
+
+// HandleOffers matches the pending requests, in FIFO order, against the offers. Each offer is accepted
+// w/ the tasks of the requests that it satisfies and offers that satisfy no request are declined, as per
+// the decline policy. If an ACCEPT call fails then its requests are returned to the queue, no further
+// offers are matched, and the offer of the failed call is declined along w/ the remaining offers; Mesos
+// would otherwise hold them (there's no offer timeout by default). The error of the failed ACCEPT call is
+// returned in that case. Offers are suppressed once the queue is empty.
+func (q *Queue) HandleOffers(ctx context.Context, offered []mesos.Offer) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var (
+		unused    []mesos.Offer
+		acceptErr error
+	)
+	for i := range offered {
+		offer := &offered[i]
+		matched, tasks := q.match(offer)
+		if len(tasks) == 0 {
+			unused = append(unused, *offer)
+			continue
+		}
+		accept := calls.Accept(calls.OfferOperations{calls.OpLaunch(tasks...)}.WithOffers(offer.ID)).
+			With(q.declines.Filters(offer.GetAllocationInfo().GetRole()))
+		if acceptErr = calls.CallNoData(ctx, q.caller, accept); acceptErr != nil {
+			// the requests are returned to the queue, and the offers are declined below
+			q.pending = append(matched, q.pending...)
+			unused = append(unused, offered[i:]...)
+			break
+		}
+		if q.launched != nil {
+			q.launched(ctx, tasks)
+		}
+	}
+	err := q.declines.Decline(ctx, q.caller, unused)
+	if acceptErr != nil {
+		return acceptErr
+	}
+	if err != nil {
+		return err
+	}
+	return q.sync(ctx)
+}
+
+// match removes the requests that the offer satisfies from the queue, and returns them along w/ the tasks
+// that launch them.
+func (q *Queue) match(offer *mesos.Offer) (matched []Request, tasks []mesos.TaskInfo) {
+	var (
+		role      = offer.GetAllocationInfo().GetRole()
+		remaining = mesos.Resources(offer.Resources).Clone().Unallocate()
+		pending   = q.pending[:0]
+	)
+	for _, req := range q.pending {
+		if (req.Role != "" && role != "" && req.Role != role) || (req.Filter != nil && !req.Filter.Accept(offer)) {
+			pending = append(pending, req)
+			continue
+		}
+		found := resources.Find(req.Task.Resources, remaining...)
+		if len(found) == 0 {
+			if q.diagnosis != nil {
+				q.diagnosis.Unsatisfied(offer, req.Task.Resources)
+			}
+			pending = append(pending, req)
+			continue
+		}
+		remaining = resources.Subtract(remaining, found...)
+		if role != "" {
+			found.Allocate(role)
+		}
+		task := req.Task
+		task.AgentID = offer.AgentID
+		task.Resources = found
+		matched = append(matched, req)
+		tasks = append(tasks, task)
+	}
+	q.pending = pending
+	return
+}
+
+// sync revives offers for the roles w/ pending requests, and suppresses them for the other roles; it
+// must be invoked while holding the lock.
+func (q *Queue) sync(ctx context.Context) error {
+	if len(q.roles) == 0 {
+		switch wanted := len(q.pending) > 0; {
+		case wanted && q.suppressed[""]:
+			return q.revive(ctx)
+		case !wanted && !q.suppressed[""]:
+			return q.suppress(ctx)
+		}
+		return nil
+	}
+	wanted := make(map[string]bool, len(q.roles))
+	for i := range q.pending {
+		if q.pending[i].Role == "" {
+			for _, role := range q.roles {
+				wanted[role] = true
+			}
+			break
+		}
+		wanted[q.pending[i].Role] = true
+	}
+	var revive, suppress []string
+	for _, role := range q.roles {
+		switch {
+		case wanted[role] && q.suppressed[role]:
+			revive = append(revive, role)
+		case !wanted[role] && !q.suppressed[role]:
+			suppress = append(suppress, role)
+		}
+	}
+	if len(revive) > 0 {
+		if err := q.revive(ctx, revive...); err != nil {
+			return err
+		}
+	}
+	if len(suppress) > 0 {
+		return q.suppress(ctx, suppress...)
+	}
+	return nil
+}
+
+func (q *Queue) revive(ctx context.Context, roles ...string) error {
+	call := calls.Revive()
+	if len(roles) > 0 {
+		call = calls.ReviveWith(roles)
+	}
+	if err := calls.CallNoData(ctx, q.caller, call); err != nil {
+		return err
+	}
+	q.setSuppressed(false, roles)
+	q.declines.Revive(roles...)
+	return nil
+}
+
+func (q *Queue) suppress(ctx context.Context, roles ...string) error {
+	call := calls.Suppress()
+	if len(roles) > 0 {
+		call = calls.SuppressWith(roles)
+	}
+	if err := calls.CallNoData(ctx, q.caller, call); err != nil {
+		return err
+	}
+	q.setSuppressed(true, roles)
+	q.declines.Suppress(roles...)
+	return nil
+}
+
+func (q *Queue) setSuppressed(suppressed bool, roles []string) {
+	if len(roles) == 0 {
+		q.suppressed[""] = suppressed
+	}
+	for _, role := range roles {
+		q.suppressed[role] = suppressed
+	}
+}
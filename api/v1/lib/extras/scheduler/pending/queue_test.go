@@ -0,0 +1,228 @@
+package pending
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/offers"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+type recorder struct {
+	calls     []*scheduler.Call
+	err       error
+	acceptErr error // acceptErr, if non-nil, fails ACCEPT calls only
+}
+
+func (r *recorder) Call(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+	r.calls = append(r.calls, c)
+	if r.acceptErr != nil && c.GetType() == scheduler.Call_ACCEPT {
+		return nil, r.acceptErr
+	}
+	return nil, r.err
+}
+
+// types returns the types of the recorded calls, and forgets them.
+func (r *recorder) types() (types []scheduler.Call_Type) {
+	for _, c := range r.calls {
+		types = append(types, c.GetType())
+	}
+	r.calls = nil
+	return
+}
+
+func request(id string, cpus float64) Request {
+	return Request{Task: mesos.TaskInfo{
+		TaskID:    mesos.TaskID{Value: id},
+		Resources: mesos.Resources{resources.NewCPUs(cpus).Resource},
+	}}
+}
+
+func offer(id, role string, cpus float64) mesos.Offer {
+	o := mesos.Offer{
+		ID:        mesos.OfferID{Value: id},
+		AgentID:   mesos.AgentID{Value: "a1"},
+		Resources: mesos.Resources{resources.NewCPUs(cpus).Resource},
+	}
+	if role != "" {
+		o.AllocationInfo = &mesos.Resource_AllocationInfo{Role: &role}
+		mesos.Resources(o.Resources).Allocate(role)
+	}
+	return o
+}
+
+func TestQueue(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		caller   = &recorder{}
+		launched []mesos.TaskInfo
+		q        = NewQueue(caller, Launched(func(_ context.Context, tasks []mesos.TaskInfo) {
+			launched = append(launched, tasks...)
+		}))
+		subscribed = &scheduler.Event{Type: scheduler.Event_SUBSCRIBED}
+	)
+	if err := q.HandleEvent(ctx, subscribed); err != nil {
+		t.Fatal(err)
+	}
+	if types := caller.types(); !reflect.DeepEqual(types, []scheduler.Call_Type{scheduler.Call_SUPPRESS}) {
+		t.Fatalf("expected offers to be suppressed instead of %v", types)
+	}
+
+	if err := q.Enqueue(ctx, request("t1", 1), request("t2", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if types := caller.types(); !reflect.DeepEqual(types, []scheduler.Call_Type{scheduler.Call_REVIVE}) {
+		t.Fatalf("expected offers to be revived instead of %v", types)
+	}
+
+	if err := q.HandleOffers(ctx, []mesos.Offer{offer("o1", "", 1.5), offer("o2", "", 0.5)}); err != nil {
+		t.Fatal(err)
+	}
+	if len(caller.calls) != 2 || caller.calls[1].GetDecline().GetOfferIDs()[0].Value != "o2" {
+		t.Fatalf("expected o1 to be accepted and o2 to be declined instead of %v", caller.calls)
+	}
+	if ops := caller.calls[0].GetAccept().GetOperations(); len(ops) != 1 || ops[0].GetLaunch().TaskInfos[0].TaskID.Value != "t1" {
+		t.Fatalf("expected t1 to be launched instead of %v", ops)
+	}
+	caller.types()
+	if len(launched) != 1 || launched[0].AgentID.Value != "a1" || q.Len() != 1 {
+		t.Fatalf("unexpected launched tasks %v", launched)
+	}
+
+	// failed accepts return the requests to the queue
+	caller.err = errors.New("failed")
+	if err := q.HandleOffers(ctx, []mesos.Offer{offer("o3", "", 1)}); err != caller.err {
+		t.Fatalf("expected %v instead of %v", caller.err, err)
+	}
+	caller.err = nil
+	caller.types()
+	if q.Len() != 1 || len(launched) != 1 {
+		t.Fatalf("expected t2 to remain pending")
+	}
+
+	// offers aren't held when an accept fails: unmatched, failed, and remaining offers are declined
+	caller.acceptErr = errors.New("failed")
+	if err := q.HandleOffers(ctx, []mesos.Offer{offer("o5", "", 0.5), offer("o6", "", 1), offer("o7", "", 1)}); err != caller.acceptErr {
+		t.Fatalf("expected %v instead of %v", caller.acceptErr, err)
+	}
+	caller.acceptErr = nil
+	if len(caller.calls) != 2 || caller.calls[1].GetType() != scheduler.Call_DECLINE {
+		t.Fatalf("expected an accept and a decline instead of %v", caller.calls)
+	}
+	var declined []string
+	for _, id := range caller.calls[1].GetDecline().GetOfferIDs() {
+		declined = append(declined, id.Value)
+	}
+	if !reflect.DeepEqual(declined, []string{"o5", "o6", "o7"}) {
+		t.Fatalf("expected o5, o6, and o7 to be declined instead of %v", declined)
+	}
+	caller.types()
+	if q.Len() != 1 {
+		t.Fatalf("expected t2 to remain pending")
+	}
+
+	if err := q.HandleOffers(ctx, []mesos.Offer{offer("o4", "", 1)}); err != nil {
+		t.Fatal(err)
+	}
+	if types := caller.types(); !reflect.DeepEqual(types, []scheduler.Call_Type{scheduler.Call_ACCEPT, scheduler.Call_SUPPRESS}) {
+		t.Fatalf("expected t2 to be launched and offers to be suppressed instead of %v", types)
+	}
+	if q.Len() != 0 || len(launched) != 2 {
+		t.Fatalf("expected t2 to be launched")
+	}
+}
+
+func TestQueue_Roles(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		caller = &recorder{}
+		q      = NewQueue(caller, Roles("a", "b"))
+	)
+	if err := q.HandleEvent(ctx, &scheduler.Event{Type: scheduler.Event_SUBSCRIBED}); err != nil {
+		t.Fatal(err)
+	}
+	if roles := caller.calls[0].GetSuppress().GetRoles(); !reflect.DeepEqual(roles, []string{"a", "b"}) {
+		t.Fatalf("expected roles a and b to be suppressed instead of %v", roles)
+	}
+	caller.types()
+
+	req := request("t1", 1)
+	req.Role = "b"
+	if err := q.Enqueue(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if roles := caller.calls[0].GetRevive().GetRoles(); !reflect.DeepEqual(roles, []string{"b"}) {
+		t.Fatalf("expected role b to be revived instead of %v", roles)
+	}
+	caller.types()
+
+	// offers for other roles don't satisfy the request
+	if err := q.HandleOffers(ctx, []mesos.Offer{offer("o1", "a", 1), offer("o2", "b", 1)}); err != nil {
+		t.Fatal(err)
+	}
+	accept := caller.calls[0].GetAccept()
+	if accept.GetOfferIDs()[0].Value != "o2" {
+		t.Fatalf("expected o2 to be accepted instead of %v", accept)
+	}
+	if rs := accept.GetOperations()[0].GetLaunch().TaskInfos[0].Resources; rs[0].GetAllocationInfo().GetRole() != "b" {
+		t.Fatalf("expected resources allocated to role b instead of %v", rs)
+	}
+	if decline := caller.calls[1].GetDecline(); decline.GetOfferIDs()[0].Value != "o1" || decline.GetFilters().GetRefuseSeconds() != 3600 {
+		t.Fatalf("expected o1 to be declined w/ the filters of a suppressed role instead of %v", decline)
+	}
+	if roles := caller.calls[2].GetSuppress().GetRoles(); !reflect.DeepEqual(roles, []string{"b"}) {
+		t.Fatalf("expected role b to be suppressed instead of %v", roles)
+	}
+}
+
+func TestQueue_RequestResources(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		caller = &recorder{}
+		q      = NewQueue(caller, RequestResources(2))
+		reqs   = []Request{request("t1", 1), request("t2", 2), request("t3", 3)}
+	)
+	reqs[0].AgentID = "a1"
+	q.HandleEvent(ctx, &scheduler.Event{Type: scheduler.Event_SUBSCRIBED})
+	caller.types()
+
+	if err := q.Enqueue(ctx, reqs...); err != nil {
+		t.Fatal(err)
+	}
+	if types := caller.types(); !reflect.DeepEqual(types, []scheduler.Call_Type{scheduler.Call_REQUEST, scheduler.Call_REQUEST, scheduler.Call_REVIVE}) {
+		t.Fatalf("expected 2 batches of requests and a revive instead of %v", types)
+	}
+
+	// offers are revived even if the hints fail
+	q = NewQueue(caller, RequestResources(0))
+	q.HandleEvent(ctx, &scheduler.Event{Type: scheduler.Event_SUBSCRIBED})
+	caller.types()
+	caller.err = errors.New("failed")
+	if err := q.Enqueue(ctx, reqs...); err != caller.err {
+		t.Fatalf("expected %v instead of %v", caller.err, err)
+	}
+	if len(caller.calls) != 2 || caller.calls[0].GetRequest().GetRequests()[0].GetAgentID().GetValue() != "a1" {
+		t.Fatalf("unexpected calls %v", caller.calls)
+	}
+}
+
+func TestQueue_Diagnose(t *testing.T) {
+	var (
+		ctx = context.Background()
+		d   = offers.NewDiagnostics(offers.StarvationThreshold(2))
+		q   = NewQueue(&recorder{}, Diagnose(d))
+	)
+	q.Enqueue(ctx, request("t1", 2))
+	for _, id := range []string{"o1", "o2"} {
+		if err := q.HandleOffers(ctx, []mesos.Offer{offer(id, "", 1)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r := d.Report(); len(r.Reasons) != 1 || r.Reasons[0].Resource != "cpus" {
+		t.Fatalf("expected cpus to be insufficient instead of %v", r.Reasons)
+	}
+}
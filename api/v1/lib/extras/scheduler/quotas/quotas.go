@@ -0,0 +1,195 @@
+// Package quotas helps multi-role frameworks to avoid launching tasks that the allocator would starve because
+// of the quota of their roles: the quota and the allocations of roles, as reported by the master, are combined
+// w/ the tasks that the framework has launched but that the master may not account for yet.
+package quotas
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+type (
+	// Source provides the quota and the allocations of roles; for example httpmaster.Client, via the
+	// GET_QUOTA and GET_ROLES calls of the operator API.
+	Source interface {
+		GetQuota(context.Context) (quota.QuotaStatus, error)
+		GetRoles(context.Context) ([]mesos.Role, error)
+	}
+
+	// Tracker tracks the remaining quota of roles. The remaining quota of a role is its quota guarantee,
+	// less the resources that the master reports as allocated to the role, less the resources of the tasks
+	// that the framework launched for the role and for which no status update has been received yet (the
+	// master's report may predate such tasks). The remaining quota is conservative: a task that's launched
+	// shortly before Refresh may be accounted for twice until its first status update. All Tracker funcs
+	// are safe to invoke concurrently.
+	Tracker struct {
+		mu         sync.Mutex
+		guarantees map[string]mesos.Resources // by role
+		allocated  map[string]mesos.Resources // by role
+		launching  map[mesos.TaskID]launch
+	}
+
+	launch struct {
+		role      string
+		resources mesos.Resources
+	}
+)
+
+// NewTracker returns a Tracker that's unaware of any quota until it's refreshed.
+func NewTracker() *Tracker {
+	return &Tracker{
+		guarantees: make(map[string]mesos.Resources),
+		allocated:  make(map[string]mesos.Resources),
+		launching:  make(map[mesos.TaskID]launch),
+	}
+}
+
+// Refresh fetches the quota and the allocations of roles from the source, and replaces the previously
+// fetched ones w/ them; see Set.
+func (t *Tracker) Refresh(ctx context.Context, src Source) error {
+	status, err := src.GetQuota(ctx)
+	if err != nil {
+		return err
+	}
+	roles, err := src.GetRoles(ctx)
+	if err != nil {
+		return err
+	}
+	t.Set(status, roles)
+	return nil
+}
+
+// Set replaces the quota and the allocations of roles w/ the given ones, for frameworks that obtain them by
+// other means than Refresh; for example, from the event stream of the operator API.
+func (t *Tracker) Set(status quota.QuotaStatus, roles []mesos.Role) {
+	var (
+		guarantees = make(map[string]mesos.Resources, len(status.Infos))
+		allocated  = make(map[string]mesos.Resources, len(roles))
+	)
+	for i := range status.Infos {
+		info := &status.Infos[i]
+		guarantees[info.GetRole()] = normalize(info.Guarantee)
+	}
+	for i := range roles {
+		allocated[roles[i].Name] = normalize(roles[i].Resources)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.guarantees, t.allocated = guarantees, allocated
+}
+
+// Launched accounts for the resources of the given tasks until their first status update; it should be
+// invoked upon accepting an offer w/ LAUNCH or LAUNCH_GROUP operations. The role of a task is determined by
+// the AllocationInfo of its resources: tasks w/o allocated resources (e.g. those of frameworks that lack the
+// MULTI_ROLE capability) are disregarded.
+func (t *Tracker) Launched(tasks ...mesos.TaskInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range tasks {
+		for j := range tasks[i].Resources {
+			if role := tasks[i].Resources[j].GetAllocationInfo().GetRole(); role != "" {
+				t.launching[tasks[i].TaskID] = launch{role: role, resources: normalize(tasks[i].Resources)}
+				break
+			}
+		}
+	}
+}
+
+// Update stops accounting for the resources of the task of the status, which the master accounts for by
+// the time that it forwards a status update for the task. Returns false if the task wasn't accounted for.
+func (t *Tracker) Update(status mesos.TaskStatus) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.launching[status.TaskID]; !ok {
+		return false
+	}
+	delete(t.launching, status.TaskID)
+	return true
+}
+
+// Remaining returns the remaining quota of the role as scalar resources, ordered by name; the remaining
+// amount of a resource is never less than zero. Returns false if the role has no quota.
+func (t *Tracker) Remaining(role string) (mesos.Resources, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	remaining, ok := t.remaining(role)
+	if !ok {
+		return nil, false
+	}
+	names := make(resources.Names, 0, len(remaining))
+	for name := range remaining {
+		names = append(names, name)
+	}
+	names.Sort()
+	result := make(mesos.Resources, 0, len(names))
+	for _, name := range names {
+		v := remaining[name].GetValue()
+		if v < 0 {
+			v = 0
+		}
+		result = append(result, resources.Build().Name(name).Scalar(v).Resource)
+	}
+	return result, true
+}
+
+// Fits returns true if launching a task w/ the given resources for the role wouldn't exceed the remaining
+// quota of the role. Roles w/o quota, and resources that the quota of the role doesn't limit, always fit.
+func (t *Tracker) Fits(role string, rs ...mesos.Resource) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	remaining, ok := t.remaining(role)
+	if !ok {
+		return true
+	}
+	for name, wanted := range scalars(normalize(rs)) {
+		if left, ok := remaining[name]; ok && wanted.Compare(left) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// remaining returns the remaining amount of each resource that the quota of the role limits, which may be
+// negative; it must be invoked while holding the lock.
+func (t *Tracker) remaining(role string) (map[resources.Name]*mesos.Value_Scalar, bool) {
+	guarantee, ok := t.guarantees[role]
+	if !ok {
+		return nil, false
+	}
+	remaining := scalars(guarantee)
+	consume := func(rs mesos.Resources) {
+		for name, v := range scalars(rs) {
+			if left, ok := remaining[name]; ok {
+				remaining[name] = left.Subtract(v)
+			}
+		}
+	}
+	consume(t.allocated[role])
+	for _, l := range t.launching {
+		if l.role == role {
+			consume(l.resources)
+		}
+	}
+	return remaining, true
+}
+
+// normalize strips the allocations and reservations of the resources, which quota disregards.
+func normalize(rs mesos.Resources) mesos.Resources {
+	return rs.Clone().Unallocate().ToUnreserved()
+}
+
+// scalars returns the sums of the scalar resources, by name.
+func scalars(rs mesos.Resources) map[resources.Name]*mesos.Value_Scalar {
+	sums := make(map[resources.Name]*mesos.Value_Scalar)
+	for i := range rs {
+		if rs[i].GetType() == mesos.SCALAR {
+			name := resources.Name(rs[i].Name)
+			sums[name] = sums[name].Add(rs[i].GetScalar())
+		}
+	}
+	return sums
+}
@@ -0,0 +1,115 @@
+package quotas
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+type source struct {
+	status quota.QuotaStatus
+	roles  []mesos.Role
+	err    error
+}
+
+func (s *source) GetQuota(context.Context) (quota.QuotaStatus, error) { return s.status, s.err }
+func (s *source) GetRoles(context.Context) ([]mesos.Role, error)      { return s.roles, nil }
+
+func allocated(role string, rs ...*resources.Builder) (result mesos.Resources) {
+	for _, rb := range rs {
+		result = append(result, rb.Resource)
+	}
+	result.Allocate(role)
+	return
+}
+
+func TestTracker(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		role = "analytics"
+		src  = &source{
+			status: quota.QuotaStatus{Infos: []quota.QuotaInfo{{
+				Role: &role,
+				Guarantee: mesos.Resources{
+					resources.NewCPUs(10).Resource,
+					resources.NewMemory(1024).Resource,
+				},
+			}}},
+			roles: []mesos.Role{
+				{Name: role, Resources: allocated(role, resources.NewCPUs(4).Reserve(role, ""), resources.NewMemory(256))},
+				{Name: "web", Resources: allocated("web", resources.NewCPUs(100))},
+			},
+		}
+		tracker = NewTracker()
+	)
+	if !tracker.Fits(role, resources.NewCPUs(100).Resource) {
+		t.Fatal("expected resources to fit before the quota is known")
+	}
+
+	src.err = errors.New("unavailable")
+	if err := tracker.Refresh(ctx, src); err != src.err {
+		t.Fatalf("expected error %v instead of %v", src.err, err)
+	}
+	src.err = nil
+	if err := tracker.Refresh(ctx, src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := tracker.Remaining("web"); ok {
+		t.Fatal("expected no quota for role web")
+	}
+	if !tracker.Fits("web", resources.NewCPUs(1000).Resource) {
+		t.Fatal("expected resources of a role w/o quota to fit")
+	}
+
+	remaining, ok := tracker.Remaining(role)
+	if !ok {
+		t.Fatal("expected quota for role " + role)
+	}
+	if cpus, _ := resources.CPUs(remaining...); cpus != 6 {
+		t.Fatalf("expected 6 remaining cpus instead of %v", cpus)
+	}
+	if mem, _ := resources.Memory(remaining...); mem != 768 {
+		t.Fatalf("expected 768 remaining mem instead of %v", mem)
+	}
+
+	task := mesos.TaskInfo{
+		TaskID:    mesos.TaskID{Value: "t1"},
+		Resources: allocated(role, resources.NewCPUs(5), resources.NewMemory(128), resources.NewDisk(4096)),
+	}
+	tracker.Launched(task, mesos.TaskInfo{
+		TaskID:    mesos.TaskID{Value: "unallocated"},
+		Resources: mesos.Resources{resources.NewCPUs(100).Resource},
+	})
+	if !tracker.Fits(role, resources.NewCPUs(1).Resource, resources.NewDisk(100000).Resource) {
+		t.Fatal("expected resources to fit the remaining quota, disk is unlimited")
+	}
+	if tracker.Fits(role, resources.NewCPUs(1.5).Resource) {
+		t.Fatal("expected resources to exceed the remaining quota")
+	}
+	if tracker.Update(mesos.TaskStatus{TaskID: mesos.TaskID{Value: "unallocated"}}) {
+		t.Fatal("expected a task w/o allocated resources to be disregarded")
+	}
+
+	// the master now accounts for the task, and more
+	src.roles[0].Resources = allocated(role, resources.NewCPUs(11), resources.NewMemory(384))
+	if err := tracker.Refresh(ctx, src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tracker.Update(mesos.TaskStatus{TaskID: task.TaskID}) {
+		t.Fatal("expected the task to be accounted for")
+	}
+	remaining, _ = tracker.Remaining(role)
+	if cpus, _ := resources.CPUs(remaining...); cpus != 0 {
+		t.Fatalf("expected no remaining cpus instead of %v", cpus)
+	}
+	if tracker.Fits(role, resources.NewCPUs(0.1).Resource) {
+		t.Fatal("expected resources to exceed the exhausted quota")
+	}
+	if !tracker.Fits(role, resources.NewMemory(640).Resource) {
+		t.Fatal("expected memory to fit the remaining quota")
+	}
+}
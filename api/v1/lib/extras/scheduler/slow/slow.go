@@ -0,0 +1,111 @@
+// Package slow detects event handlers that are slow to process scheduler events. Events are processed
+// serially, so a slow handler (typically for UPDATE events) delays all subsequent events, including
+// HEARTBEATs, and may cause the framework to miss heartbeats and disconnect.
+//
+//	d := slow.New(500*time.Millisecond, slow.Latency(myWatcher))
+//	handler := d.Handlers(events.Handlers{
+//		scheduler.Event_UPDATE: updateHandler,
+//		scheduler.Event_OFFERS: offersHandler,
+//	})
+package slow
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/extras/metrics"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+type (
+	// Report describes a handler invocation that exceeded the threshold.
+	Report struct {
+		Handler   string               // Handler is the name of the slow handler
+		EventType scheduler.Event_Type // EventType is the type of the event that was processed
+		Elapsed   time.Duration        // Elapsed is the time spent by the handler
+		Threshold time.Duration        // Threshold is the configured threshold
+	}
+
+	// Reporter is invoked for each handler invocation that exceeds the threshold.
+	Reporter func(Report)
+
+	// Detector measures the latency of event handlers.
+	Detector struct {
+		threshold time.Duration
+		report    Reporter
+		latency   metrics.Watcher
+		now       func() time.Time
+	}
+
+	// Opt is a functional option for a Detector.
+	Opt func(*Detector)
+)
+
+// LogReporter returns a Reporter that logs warnings w/ the given logger; if nil, the standard logger.
+func LogReporter(logger *log.Logger) Reporter {
+	logf := log.Printf
+	if logger != nil {
+		logf = logger.Printf
+	}
+	return func(r Report) {
+		logf("slow event handler %q: processed %v event in %v (threshold %v)",
+			r.Handler, r.EventType, r.Elapsed, r.Threshold)
+	}
+}
+
+// WithReporter returns an Opt that sets the Reporter of a Detector; the default is LogReporter(nil).
+func WithReporter(r Reporter) Opt { return func(d *Detector) { d.report = r } }
+
+// Latency returns an Opt that records the latency (in microseconds) of every handler invocation w/ the
+// given Watcher, labeled by handler name. Useful for alerting on handlers that approach the threshold.
+func Latency(w metrics.Watcher) Opt { return func(d *Detector) { d.latency = w } }
+
+// New returns a Detector that reports handler invocations that take longer than the given threshold.
+func New(threshold time.Duration, opts ...Opt) *Detector {
+	d := &Detector{threshold: threshold, report: LogReporter(nil), now: time.Now}
+	for _, f := range opts {
+		if f != nil {
+			f(d)
+		}
+	}
+	return d
+}
+
+func (d *Detector) observe(name string, e *scheduler.Event, start time.Time) {
+	elapsed := d.now().Sub(start)
+	if d.latency != nil {
+		d.latency(metrics.InMicroseconds(elapsed), name)
+	}
+	if elapsed > d.threshold && d.report != nil {
+		d.report(Report{Handler: name, EventType: e.GetType(), Elapsed: elapsed, Threshold: d.threshold})
+	}
+}
+
+// Handler returns a Handler that measures the latency of the given, named Handler.
+func (d *Detector) Handler(name string, h events.Handler) events.Handler {
+	return events.HandlerFunc(func(ctx context.Context, e *scheduler.Event) error {
+		defer d.observe(name, e, d.now())
+		return h.HandleEvent(ctx, e)
+	})
+}
+
+// Handlers returns a copy of the given Handlers map in which every Handler is measured; handlers are
+// named after the type of event that they process.
+func (d *Detector) Handlers(hs events.Handlers) events.Handlers {
+	result := make(events.Handlers, len(hs))
+	for t, h := range hs {
+		result[t] = d.Handler(t.String(), h)
+	}
+	return result
+}
+
+// Rule returns a Rule that measures the latency of the remainder of the chain that follows it.
+func (d *Detector) Rule(name string) eventrules.Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, ch eventrules.Chain) (context.Context, *scheduler.Event, error) {
+		defer d.observe(name, e, d.now())
+		return ch(ctx, e, err)
+	}
+}
@@ -0,0 +1,50 @@
+package slow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+func TestDetector(t *testing.T) {
+	var (
+		clock    time.Time
+		reports  []Report
+		observed = map[string]float64{}
+		d        = New(time.Second,
+			WithReporter(func(r Report) { reports = append(reports, r) }),
+			Latency(func(x float64, labels ...string) { observed[labels[0]] = x }),
+		)
+		advance = func(delay time.Duration) events.HandlerFunc {
+			return func(context.Context, *scheduler.Event) error {
+				clock = clock.Add(delay)
+				return nil
+			}
+		}
+		handler = d.Handlers(events.Handlers{
+			scheduler.Event_UPDATE: advance(2 * time.Second),
+			scheduler.Event_OFFERS: advance(time.Millisecond),
+		})
+	)
+	d.now = func() time.Time { return clock }
+
+	handler.HandleEvent(context.Background(), &scheduler.Event{Type: scheduler.Event_UPDATE})
+	handler.HandleEvent(context.Background(), &scheduler.Event{Type: scheduler.Event_OFFERS})
+	d.Rule("rules").HandleF(advance(3*time.Second)).HandleEvent(context.Background(), &scheduler.Event{Type: scheduler.Event_RESCIND})
+
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports instead of %+v", reports)
+	}
+	if r := reports[0]; r.Handler != "UPDATE" || r.EventType != scheduler.Event_UPDATE || r.Elapsed != 2*time.Second {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if r := reports[1]; r.Handler != "rules" || r.Elapsed != 3*time.Second {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if observed["OFFERS"] != 1000 || len(observed) != 3 {
+		t.Errorf("unexpected latency observations %v", observed)
+	}
+}
@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+const (
+	// DefaultGracePeriod is the grace period that the agent allots to a task that's being killed when
+	// neither the KILL call nor the TaskInfo specify a kill policy.
+	DefaultGracePeriod = 3 * time.Second
+
+	// DefaultEscalationDelay is the amount of time, beyond the grace period, that a task that's being
+	// killed is allotted to terminate before its executor is shut down.
+	DefaultEscalationDelay = 5 * time.Second
+)
+
+type (
+	// KillOpt is a functional option for Tracker.Kill.
+	KillOpt func(*killConfig)
+
+	killConfig struct {
+		policy          *mesos.KillPolicy
+		escalationDelay time.Duration
+	}
+)
+
+// WithKillPolicy overrides the kill policy of the task.
+func WithKillPolicy(kp *mesos.KillPolicy) KillOpt {
+	return func(c *killConfig) { c.policy = kp }
+}
+
+// EscalationDelay overrides DefaultEscalationDelay.
+func EscalationDelay(d time.Duration) KillOpt {
+	return func(c *killConfig) { c.escalationDelay = d }
+}
+
+// Kill kills the identified task and blocks until the task has terminated, escalating if necessary:
+// a KILL call is sent (w/ the kill policy specified via WithKillPolicy, if any), and if the task doesn't
+// terminate within its grace period plus the escalation delay then its executor is shut down. Kill only
+// returns nil once the task has terminated; it otherwise returns the error of a failed call, or that of
+// the context. Note that shutting down the executor of a task group terminates all the tasks of the group.
+//
+// Status updates must be fed to the Tracker (e.g. via controller.TrackTasks) for Kill to observe the
+// termination of the task.
+func (t *Tracker) Kill(ctx context.Context, caller calls.Caller, id mesos.TaskID, opts ...KillOpt) error {
+	task, done, ok := t.lookup(id)
+	if !ok {
+		return ErrUnknownTask
+	}
+	config := killConfig{escalationDelay: DefaultEscalationDelay}
+	for _, f := range opts {
+		if f != nil {
+			f(&config)
+		}
+	}
+
+	agentID := task.Info.AgentID.Value
+	kill := calls.Kill(id.Value, agentID)
+	kill.Kill.KillPolicy = config.policy
+	if err := calls.CallNoData(ctx, caller, kill); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(gracePeriod(task.Info, config.policy) + config.escalationDelay)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+	if err := calls.CallNoData(ctx, caller, calls.Shutdown(executorID(task.Info).Value, agentID)); err != nil {
+		return err
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func gracePeriod(info mesos.TaskInfo, policy *mesos.KillPolicy) time.Duration {
+	if gp := policy.GetGracePeriod(); gp != nil {
+		return gp.Duration()
+	}
+	if gp := info.GetKillPolicy().GetGracePeriod(); gp != nil {
+		return gp.Duration()
+	}
+	return DefaultGracePeriod
+}
+
+// executorID returns the ID of the executor of the task; the command executor of a task shares the
+// ID of the task.
+func executorID(info mesos.TaskInfo) mesos.ExecutorID {
+	if e := info.GetExecutor(); e != nil {
+		return e.ExecutorID
+	}
+	return mesos.ExecutorID{Value: info.TaskID.Value}
+}
@@ -0,0 +1,107 @@
+// Package tasks provides scheduler-side tracking of the tasks launched by a framework.
+package tasks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+type (
+	// Task is a snapshot of a tracked task.
+	Task struct {
+		Info mesos.TaskInfo
+		// Status is the most recently reported status of the task; nil until the first status update
+		// has been received.
+		Status *mesos.TaskStatus
+	}
+
+	// Tracker tracks the tasks launched by a framework, and their most recently reported status, until they
+	// reach a terminal state. All Tracker funcs are safe to invoke concurrently.
+	Tracker struct {
+		mu    sync.Mutex
+		tasks map[mesos.TaskID]*task
+	}
+
+	task struct {
+		Task
+		done chan struct{} // closed once the task has reached a terminal state
+	}
+)
+
+// ErrUnknownTask is returned when attempting to operate upon a task that the Tracker is not tracking.
+var ErrUnknownTask = errors.New("unknown task")
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{tasks: make(map[mesos.TaskID]*task)}
+}
+
+// Launched begins tracking the given tasks; it should be invoked upon accepting an offer w/ LAUNCH or
+// LAUNCH_GROUP operations. Tasks of a LAUNCH_GROUP operation should specify the executor of the group.
+func (t *Tracker) Launched(tasks ...mesos.TaskInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range tasks {
+		t.tasks[tasks[i].TaskID] = &task{
+			Task: Task{Info: tasks[i]},
+			done: make(chan struct{}),
+		}
+	}
+}
+
+// Update records the status of a tracked task; tasks are forgotten once they reach a terminal state.
+// Returns false if the task is not being tracked.
+func (t *Tracker) Update(status mesos.TaskStatus) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tt, ok := t.tasks[status.TaskID]
+	if !ok {
+		return false
+	}
+	tt.Status = &status
+	if status.GetState().IsTerminal() {
+		close(tt.done)
+		delete(t.tasks, status.TaskID)
+	}
+	return true
+}
+
+// Get returns a snapshot of the identified task; false if the task is not being tracked.
+func (t *Tracker) Get(id mesos.TaskID) (Task, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tt, ok := t.tasks[id]; ok {
+		return tt.Task, true
+	}
+	return Task{}, false
+}
+
+// Tasks returns snapshots of all tracked tasks, in no particular order.
+func (t *Tracker) Tasks() []Task {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]Task, 0, len(t.tasks))
+	for _, tt := range t.tasks {
+		result = append(result, tt.Task)
+	}
+	return result
+}
+
+// Len returns the number of tracked tasks.
+func (t *Tracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.tasks)
+}
+
+// lookup returns a snapshot of the identified task and a chan that's closed once the task terminates.
+func (t *Tracker) lookup(id mesos.TaskID) (Task, <-chan struct{}, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tt, ok := t.tasks[id]; ok {
+		return tt.Task, tt.done, true
+	}
+	return Task{}, nil, false
+}
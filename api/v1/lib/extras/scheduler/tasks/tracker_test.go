@@ -0,0 +1,133 @@
+package tasks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+type recordingCaller struct {
+	mu    sync.Mutex
+	calls []*scheduler.Call
+}
+
+func (rc *recordingCaller) Call(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.calls = append(rc.calls, c)
+	return nil, nil
+}
+
+func (rc *recordingCaller) types() (result []scheduler.Call_Type) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, c := range rc.calls {
+		result = append(result, c.GetType())
+	}
+	return
+}
+
+var _ = calls.Caller(&recordingCaller{})
+
+func status(id string, state mesos.TaskState) mesos.TaskStatus {
+	return mesos.TaskStatus{TaskID: mesos.TaskID{Value: id}, State: state.Enum()}
+}
+
+func TestTracker(t *testing.T) {
+	tr := NewTracker()
+	tr.Launched(
+		mesos.TaskInfo{TaskID: mesos.TaskID{Value: "a"}},
+		mesos.TaskInfo{TaskID: mesos.TaskID{Value: "b"}},
+	)
+	if tr.Len() != 2 {
+		t.Fatalf("expected 2 tasks instead of %d", tr.Len())
+	}
+	if !tr.Update(status("a", mesos.TASK_RUNNING)) {
+		t.Fatal("expected task a to be tracked")
+	}
+	if task, ok := tr.Get(mesos.TaskID{Value: "a"}); !ok || task.Status.GetState() != mesos.TASK_RUNNING {
+		t.Fatalf("unexpected task %v", task)
+	}
+	if !tr.Update(status("b", mesos.TASK_FINISHED)) || tr.Len() != 1 {
+		t.Fatal("expected terminal task b to be forgotten")
+	}
+	if tr.Update(status("c", mesos.TASK_RUNNING)) {
+		t.Fatal("unexpected update of an unknown task")
+	}
+	if tasks := tr.Tasks(); len(tasks) != 1 || tasks[0].Info.TaskID.Value != "a" {
+		t.Fatalf("unexpected tasks %v", tasks)
+	}
+}
+
+func TestTracker_Kill(t *testing.T) {
+	ctx := context.Background()
+	info := mesos.TaskInfo{
+		TaskID:     mesos.TaskID{Value: "a"},
+		AgentID:    mesos.AgentID{Value: "agent"},
+		KillPolicy: mesos.NewKillPolicy(time.Hour),
+	}
+
+	t.Run("terminates", func(t *testing.T) {
+		var (
+			tr     = NewTracker()
+			caller = &recordingCaller{}
+			errCh  = make(chan error, 1)
+		)
+		tr.Launched(info)
+		go func() { errCh <- tr.Kill(ctx, caller, info.TaskID) }()
+		time.Sleep(10 * time.Millisecond)
+		tr.Update(status("a", mesos.TASK_KILLED))
+		if err := <-errCh; err != nil {
+			t.Fatal(err)
+		}
+		if types := caller.types(); len(types) != 1 || types[0] != scheduler.Call_KILL {
+			t.Fatalf("unexpected calls %v", types)
+		}
+	})
+	t.Run("escalates", func(t *testing.T) {
+		var (
+			tr     = NewTracker()
+			caller = &recordingCaller{}
+			errCh  = make(chan error, 1)
+		)
+		tr.Launched(info)
+		go func() {
+			errCh <- tr.Kill(ctx, caller, info.TaskID,
+				WithKillPolicy(mesos.NewKillPolicy(time.Millisecond)),
+				EscalationDelay(time.Millisecond),
+			)
+		}()
+		deadline := time.After(5 * time.Second)
+		for len(caller.types()) < 2 {
+			select {
+			case err := <-errCh:
+				t.Fatalf("Kill returned before the task terminated: %v", err)
+			case <-deadline:
+				t.Fatal("timed out waiting for escalation")
+			case <-time.After(time.Millisecond):
+			}
+		}
+		tr.Update(status("a", mesos.TASK_KILLED))
+		if err := <-errCh; err != nil {
+			t.Fatal(err)
+		}
+		if types := caller.types(); len(types) != 2 || types[0] != scheduler.Call_KILL || types[1] != scheduler.Call_SHUTDOWN {
+			t.Fatalf("unexpected calls %v", types)
+		}
+		kill, shutdown := caller.calls[0].GetKill(), caller.calls[1].GetShutdown()
+		if kill.GetKillPolicy().GetGracePeriod().Duration() != time.Millisecond {
+			t.Errorf("unexpected kill policy %v", kill.GetKillPolicy())
+		}
+		if shutdown.ExecutorID.Value != "a" || shutdown.AgentID.Value != "agent" {
+			t.Errorf("unexpected shutdown %v", shutdown)
+		}
+	})
+	if err := NewTracker().Kill(ctx, &recordingCaller{}, info.TaskID); err != ErrUnknownTask {
+		t.Fatalf("expected ErrUnknownTask instead of %v", err)
+	}
+}
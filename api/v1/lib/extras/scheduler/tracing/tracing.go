@@ -0,0 +1,156 @@
+// Package tracing creates trace spans for the interactions of a scheduler w/ Mesos. It's agnostic of the
+// tracing backend: a small adapter implements the Tracer and Span interfaces for, e.g., OpenTelemetry.
+//
+// Every call yields a span that's annotated w/ the call type, framework ID and endpoint. The span of a
+// successful SUBSCRIBE call becomes the subscription span, which lasts until the subscription terminates;
+// the spans of the events that are received via the subscription are linked to it.
+//
+//	t := tracing.New(myTracer, tracing.FrameworkID(store.Get), tracing.Endpoint(cli.Endpoint))
+//	caller := t.CallRule().Caller(cli)
+//	handler := t.EventRule().Handle(myHandler)
+//	controller.Run(ctx, framework, caller,
+//		controller.WithEventHandler(handler),
+//		controller.WithSubscriptionTerminated(t.SubscriptionTerminated))
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/callrules"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+// Span attribute keys.
+const (
+	AttrCallType    = "mesos.call.type"
+	AttrEventType   = "mesos.event.type"
+	AttrFrameworkID = "mesos.framework.id"
+	AttrEndpoint    = "mesos.endpoint"
+)
+
+type (
+	// Span is a unit of traced work.
+	Span interface {
+		SetAttribute(key, value string)
+		RecordError(error)
+		End()
+	}
+
+	// Tracer starts spans. The returned context carries the new span, as per the conventions of the
+	// tracing backend. The new span is linked to the given spans, if any.
+	Tracer interface {
+		Start(ctx context.Context, name string, links ...Span) (context.Context, Span)
+	}
+
+	// Tracing creates spans for scheduler calls and events.
+	Tracing struct {
+		tracer      Tracer
+		frameworkID func() string
+		endpoint    func() string
+
+		mu           sync.Mutex
+		subscription Span // span of the current subscription, if any
+	}
+
+	// Opt is a functional option for Tracing.
+	Opt func(*Tracing)
+)
+
+// FrameworkID returns an Opt that annotates spans w/ the framework ID that's reported by the given func.
+func FrameworkID(f func() string) Opt { return func(t *Tracing) { t.frameworkID = f } }
+
+// Endpoint returns an Opt that annotates call spans w/ the endpoint that's reported by the given func,
+// e.g. httpcli.Client.Endpoint.
+func Endpoint(f func() string) Opt { return func(t *Tracing) { t.endpoint = f } }
+
+// New returns a Tracing that creates spans w/ the given Tracer.
+func New(tracer Tracer, opts ...Opt) *Tracing {
+	t := &Tracing{tracer: tracer}
+	for _, f := range opts {
+		if f != nil {
+			f(t)
+		}
+	}
+	return t
+}
+
+func (t *Tracing) annotate(s Span) {
+	if t.frameworkID != nil {
+		if id := t.frameworkID(); id != "" {
+			s.SetAttribute(AttrFrameworkID, id)
+		}
+	}
+}
+
+// CallRule returns a rule that creates a span for every call. The span of a successful SUBSCRIBE call
+// is ended by SubscriptionTerminated, rather than upon the return of the call.
+func (t *Tracing) CallRule() callrules.Rule {
+	return func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error, ch callrules.Chain) (context.Context, *scheduler.Call, mesos.Response, error) {
+		typ := strings.ToLower(c.GetType().String())
+		ctx, span := t.tracer.Start(ctx, "mesos.scheduler.call."+typ)
+		span.SetAttribute(AttrCallType, typ)
+		t.annotate(span)
+		if t.endpoint != nil {
+			span.SetAttribute(AttrEndpoint, t.endpoint())
+		}
+		ctx, c, r, err = ch(ctx, c, r, err)
+		if err != nil {
+			span.RecordError(err)
+		}
+		if err == nil && c.GetType() == scheduler.Call_SUBSCRIBE {
+			t.mu.Lock()
+			old := t.subscription
+			t.subscription = span
+			t.mu.Unlock()
+			if old != nil {
+				old.End()
+			}
+		} else {
+			span.End()
+		}
+		return ctx, c, r, err
+	}
+}
+
+// EventRule returns a rule that creates a span for every event, linked to the subscription span.
+func (t *Tracing) EventRule() eventrules.Rule {
+	return func(ctx context.Context, e *scheduler.Event, err error, ch eventrules.Chain) (context.Context, *scheduler.Event, error) {
+		var links []Span
+		t.mu.Lock()
+		if t.subscription != nil {
+			links = append(links, t.subscription)
+		}
+		t.mu.Unlock()
+
+		typ := strings.ToLower(e.GetType().String())
+		ctx, span := t.tracer.Start(ctx, "mesos.scheduler.event."+typ, links...)
+		defer span.End()
+		span.SetAttribute(AttrEventType, typ)
+		t.annotate(span)
+
+		ctx, e, err = ch(ctx, e, err)
+		if err != nil {
+			span.RecordError(err)
+		}
+		return ctx, e, err
+	}
+}
+
+// SubscriptionTerminated ends the subscription span; it's intended for use w/ the scheduler controller's
+// WithSubscriptionTerminated option.
+func (t *Tracing) SubscriptionTerminated(err error) {
+	t.mu.Lock()
+	span := t.subscription
+	t.subscription = nil
+	t.mu.Unlock()
+	if span != nil {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
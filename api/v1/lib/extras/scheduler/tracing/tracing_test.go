@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/scheduler/eventrules"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+type span struct {
+	name  string
+	attrs map[string]string
+	links []Span
+	err   error
+	ended bool
+}
+
+func (s *span) SetAttribute(k, v string) { s.attrs[k] = v }
+func (s *span) RecordError(err error)    { s.err = err }
+func (s *span) End()                     { s.ended = true }
+
+type tracer []*span
+
+func (t *tracer) Start(ctx context.Context, name string, links ...Span) (context.Context, Span) {
+	s := &span{name: name, attrs: map[string]string{}, links: links}
+	*t = append(*t, s)
+	return ctx, s
+}
+
+func TestTracing(t *testing.T) {
+	var (
+		spans   tracer
+		tr      = New(&spans, FrameworkID(func() string { return "fw" }), Endpoint(func() string { return "http://m1" }))
+		failure = errors.New("failure")
+		ctx     = context.Background()
+		call    = func(c *scheduler.Call) {
+			tr.CallRule().Eval(ctx, c, nil, nil, func(ctx context.Context, c *scheduler.Call, r mesos.Response, err error) (context.Context, *scheduler.Call, mesos.Response, error) {
+				if c.GetType() == scheduler.Call_DECLINE {
+					err = failure
+				}
+				return ctx, c, r, err
+			})
+		}
+		handle = func(e *scheduler.Event) { tr.EventRule().Eval(ctx, e, nil, eventrules.ChainIdentity) }
+	)
+	call(&scheduler.Call{Type: scheduler.Call_SUBSCRIBE})
+	handle(&scheduler.Event{Type: scheduler.Event_HEARTBEAT})
+	call(&scheduler.Call{Type: scheduler.Call_DECLINE})
+	tr.SubscriptionTerminated(failure)
+
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans instead of %d", len(spans))
+	}
+	sub, event, decline := spans[0], spans[1], spans[2]
+	if sub.name != "mesos.scheduler.call.subscribe" || sub.attrs[AttrEndpoint] != "http://m1" ||
+		sub.attrs[AttrFrameworkID] != "fw" || !sub.ended || sub.err != failure {
+		t.Errorf("unexpected subscription span %+v", sub)
+	}
+	if event.attrs[AttrEventType] != "heartbeat" || len(event.links) != 1 || event.links[0] != sub || !event.ended {
+		t.Errorf("unexpected event span %+v", event)
+	}
+	if decline.attrs[AttrCallType] != "decline" || decline.err != failure || !decline.ended {
+		t.Errorf("unexpected call span %+v", decline)
+	}
+}
@@ -0,0 +1,251 @@
+// Package version gates the use of Mesos features by the version of the master: calls that depend on
+// features that the master doesn't implement are refused w/ a TooOldError, rather than being rejected by
+// the master w/ an opaque "malformed request". The version is determined once a connection is established;
+// for example via Detect prior to subscription (see controller.WithVersionDetection of the scheduler extras),
+// or from the MasterInfo of the SUBSCRIBED event of the scheduler API.
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/apierrors"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+type (
+	// Version is a Mesos release version; the zero value represents an unknown version.
+	Version struct {
+		Major, Minor, Patch int
+	}
+
+	// Feature is a Mesos feature that's implemented by masters of (at least) a given version.
+	Feature struct {
+		Name  string
+		Since Version
+	}
+
+	// TooOldError is returned when a feature is required of a master that doesn't implement it.
+	TooOldError struct {
+		Feature Feature
+		Version Version // Version is the version of the master
+	}
+
+	// Source provides the version information of a master; for example httpmaster.Client, via the
+	// GET_VERSION call of the operator API, or Endpoint.
+	Source interface {
+		GetVersion(context.Context) (mesos.VersionInfo, error)
+	}
+
+	// SourceFunc is the functional adapter for Source.
+	SourceFunc func(context.Context) (mesos.VersionInfo, error)
+
+	// Tracker tracks the version of the master that a client is connected to, which may change upon
+	// failover of the master. The zero value is ready to use. All Tracker funcs are safe to invoke
+	// concurrently.
+	Tracker struct {
+		mu      sync.RWMutex
+		version Version
+	}
+)
+
+var (
+	// FeatureHierarchicalRoles is the use of roles that are nested, such as "eng/backend".
+	FeatureHierarchicalRoles = Feature{"hierarchical roles", Version{1, 2, 0}}
+	// FeatureMultiRole is the subscription of frameworks w/ the MULTI_ROLE capability.
+	FeatureMultiRole = Feature{"multi-role frameworks", Version{1, 3, 0}}
+	// FeatureOperationFeedback is the ACKNOWLEDGE_OPERATION_STATUS and RECONCILE_OPERATIONS calls, and
+	// offer operations w/ IDs.
+	FeatureOperationFeedback = Feature{"operation feedback", Version{1, 6, 0}}
+)
+
+// Parse parses a version of the form "major.minor.patch", optionally w/ a suffix such as "-rc1"; the
+// patch level may be omitted.
+func Parse(s string) (Version, error) {
+	var (
+		v     Version
+		parts = strings.SplitN(s, ".", 3)
+	)
+	if len(parts) < 2 {
+		return v, fmt.Errorf("invalid mesos version %q", s)
+	}
+	if i := strings.IndexAny(parts[len(parts)-1], "-+"); i >= 0 {
+		parts[len(parts)-1] = parts[len(parts)-1][:i]
+	}
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch}[:len(parts)] {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid mesos version %q", s)
+		}
+		*p = n
+	}
+	return v, nil
+}
+
+func (v Version) String() string { return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch) }
+
+// IsZero returns true if the version is unknown.
+func (v Version) IsZero() bool { return v == Version{} }
+
+// Less returns true if the version precedes the other.
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// Supports returns true if a master of the version implements the feature, or if the version is unknown.
+func (v Version) Supports(f Feature) bool { return v.IsZero() || !v.Less(f.Since) }
+
+// Require returns a TooOldError for the first of the features that's not supported; see Supports.
+func (v Version) Require(fs ...Feature) error {
+	for _, f := range fs {
+		if !v.Supports(f) {
+			return &TooOldError{Feature: f, Version: v}
+		}
+	}
+	return nil
+}
+
+func (err *TooOldError) Error() string {
+	return fmt.Sprintf("mesos master too old for %s: version %v, requires %v or later",
+		err.Feature.Name, err.Version, err.Feature.Since)
+}
+
+// GetVersion implements Source.
+func (f SourceFunc) GetVersion(ctx context.Context) (mesos.VersionInfo, error) { return f(ctx) }
+
+// Endpoint returns a Source that queries the "/version" endpoint at the given URL (for example
+// "http://master:5050/version"), for masters that predate the operator API. If client is nil then
+// http.DefaultClient is used.
+func Endpoint(client *http.Client, url string) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return SourceFunc(func(ctx context.Context) (vi mesos.VersionInfo, err error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("Accept", "application/json")
+		res, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+		if err = apierrors.FromResponse(res); err != nil {
+			return
+		}
+		err = json.NewDecoder(res.Body).Decode(&vi)
+		return
+	})
+}
+
+// EndpointOf returns a Source that queries the "/version" endpoint of the host of the URL that's yielded
+// by endpoint upon every query; for example httpcli.Client.Endpoint, which follows the redirects of a
+// scheduler client to the leading master. See Endpoint.
+func EndpointOf(client *http.Client, endpoint func() string) Source {
+	return SourceFunc(func(ctx context.Context) (mesos.VersionInfo, error) {
+		u, err := url.Parse(endpoint())
+		if err != nil {
+			return mesos.VersionInfo{}, err
+		}
+		u.Path, u.RawQuery, u.Fragment = "/version", "", ""
+		return Endpoint(client, u.String()).GetVersion(ctx)
+	})
+}
+
+// Detect returns the version of the master as reported by the first of the sources that doesn't fail;
+// for example, GET_VERSION w/ a fallback to Endpoint. The error of the last source is returned if all fail.
+func Detect(ctx context.Context, sources ...Source) (v Version, err error) {
+	err = fmt.Errorf("no source of the mesos version")
+	for _, src := range sources {
+		var vi mesos.VersionInfo
+		if vi, err = src.GetVersion(ctx); err == nil {
+			return Parse(vi.Version)
+		}
+	}
+	return
+}
+
+// Refresh detects the version of the master and records it; see Detect. An unknown version is recorded
+// if detection fails, so that the version of a previous master doesn't gate the calls to the current one.
+func (t *Tracker) Refresh(ctx context.Context, sources ...Source) error {
+	v, err := Detect(ctx, sources...)
+	t.Set(v)
+	return err
+}
+
+// Set records the version of the master.
+func (t *Tracker) Set(v Version) {
+	t.mu.Lock()
+	t.version = v
+	t.mu.Unlock()
+}
+
+// Update records the version of the master that's reported by the given MasterInfo, for example that of a
+// SUBSCRIBED event. A MasterInfo w/o a (valid) version yields an unknown version.
+func (t *Tracker) Update(info *mesos.MasterInfo) {
+	v, _ := Parse(info.GetVersion())
+	t.Set(v)
+}
+
+// Version returns the recorded version of the master; see Set.
+func (t *Tracker) Version() Version {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.version
+}
+
+// Require returns a TooOldError for the first of the features that the master doesn't support. The
+// features are assumed to be supported while the version of the master is unknown.
+func (t *Tracker) Require(fs ...Feature) error { return t.Version().Require(fs...) }
+
+// Required returns the features that are required by the given scheduler call.
+func Required(c *scheduler.Call) (fs []Feature) {
+	switch c.GetType() {
+	case scheduler.Call_SUBSCRIBE:
+		fi := c.GetSubscribe().GetFrameworkInfo()
+		for i := range fi.GetCapabilities() {
+			if fi.Capabilities[i].GetType() == mesos.FrameworkInfo_Capability_MULTI_ROLE {
+				fs = append(fs, FeatureMultiRole)
+				break
+			}
+		}
+		for _, role := range append([]string{fi.GetRole()}, fi.GetRoles()...) {
+			if strings.Contains(role, "/") {
+				fs = append(fs, FeatureHierarchicalRoles)
+				break
+			}
+		}
+	case scheduler.Call_ACKNOWLEDGE_OPERATION_STATUS, scheduler.Call_RECONCILE_OPERATIONS:
+		fs = append(fs, FeatureOperationFeedback)
+	case scheduler.Call_ACCEPT:
+		for _, op := range c.GetAccept().GetOperations() {
+			if op.GetID() != nil {
+				fs = append(fs, FeatureOperationFeedback)
+				break
+			}
+		}
+	case scheduler.Call_SUPPRESS:
+		if len(c.GetSuppress().GetRoles()) > 0 {
+			fs = append(fs, FeatureMultiRole)
+		}
+	case scheduler.Call_REVIVE:
+		if len(c.GetRevive().GetRoles()) > 0 {
+			fs = append(fs, FeatureMultiRole)
+		}
+	}
+	return
+}
@@ -0,0 +1,127 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func TestParse(t *testing.T) {
+	for i, tc := range []struct {
+		s       string
+		want    Version
+		wantErr bool
+	}{
+		{"1.9.0", Version{1, 9, 0}, false},
+		{"1.10.2-rc1", Version{1, 10, 2}, false},
+		{"0.28", Version{0, 28, 0}, false},
+		{"1.4.0+build", Version{1, 4, 0}, false},
+		{"", Version{}, true},
+		{"1", Version{}, true},
+		{"1.x.0", Version{}, true},
+	} {
+		got, err := Parse(tc.s)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("test case %d: unexpected version %v, %v", i, got, err)
+		}
+	}
+}
+
+func TestRequire(t *testing.T) {
+	var tracker Tracker
+	if err := tracker.Require(FeatureOperationFeedback); err != nil {
+		t.Fatalf("unexpected error for an unknown version: %v", err)
+	}
+	tracker.Update(&mesos.MasterInfo{Version: proto("1.5.1")})
+	if err := tracker.Require(FeatureMultiRole, FeatureHierarchicalRoles); err != nil {
+		t.Fatal(err)
+	}
+	err := tracker.Require(FeatureMultiRole, FeatureOperationFeedback)
+	if e, ok := err.(*TooOldError); !ok || e.Feature != FeatureOperationFeedback {
+		t.Fatalf("expected a TooOldError for operation feedback instead of %v", err)
+	}
+	if msg := err.Error(); msg != "mesos master too old for operation feedback: version 1.5.1, requires 1.6.0 or later" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+}
+
+func TestRequired(t *testing.T) {
+	multiRole := mesos.FrameworkInfo{
+		Roles:        []string{"eng/backend"},
+		Capabilities: []mesos.FrameworkInfo_Capability{{Type: mesos.FrameworkInfo_Capability_MULTI_ROLE}},
+	}
+	for i, tc := range []struct {
+		call *scheduler.Call
+		want []Feature
+	}{
+		{calls.Subscribe(&mesos.FrameworkInfo{}), nil},
+		{calls.Subscribe(&multiRole), []Feature{FeatureMultiRole, FeatureHierarchicalRoles}},
+		{calls.Revive(), nil},
+		{calls.ReviveWith([]string{"a"}), []Feature{FeatureMultiRole}},
+		{calls.ReconcileOperations(nil), []Feature{FeatureOperationFeedback}},
+		{calls.Accept(calls.OfferOperations{calls.OpReserve()}.WithOffers()), nil},
+		{calls.Accept(calls.OfferOperations{calls.OpWithID("op1", calls.OpReserve())}.WithOffers()), []Feature{FeatureOperationFeedback}},
+	} {
+		got := Required(tc.call)
+		if len(got) != len(tc.want) {
+			t.Errorf("test case %d: expected %v instead of %v", i, tc.want, got)
+			continue
+		}
+		for j := range got {
+			if got[j] != tc.want[j] {
+				t.Errorf("test case %d: expected %v instead of %v", i, tc.want, got)
+			}
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, `{"version": "0.28.2", "build_user": "root"}`)
+	}))
+	defer ts.Close()
+
+	operator := SourceFunc(func(context.Context) (mesos.VersionInfo, error) {
+		return mesos.VersionInfo{}, errors.New("mesos http endpoint not found")
+	})
+	v, err := Detect(context.Background(), operator, Endpoint(nil, ts.URL+"/version"))
+	if err != nil || v != (Version{0, 28, 2}) {
+		t.Fatalf("unexpected version %v, %v", v, err)
+	}
+	if _, err = Detect(context.Background(), Endpoint(nil, ts.URL+"/api/v1")); err == nil {
+		t.Fatal("expected an error")
+	}
+	endpoint := func() string { return ts.URL + "/api/v1/scheduler?x=y" }
+	if v, err = Detect(context.Background(), EndpointOf(nil, endpoint)); err != nil || v != (Version{0, 28, 2}) {
+		t.Fatalf("unexpected version %v, %v", v, err)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	var (
+		tracker Tracker
+		vi      = mesos.VersionInfo{Version: "1.5.0"}
+		source  = SourceFunc(func(context.Context) (mesos.VersionInfo, error) { return vi, nil })
+	)
+	if err := tracker.Refresh(context.Background(), source); err != nil || tracker.Version() != (Version{1, 5, 0}) {
+		t.Fatalf("unexpected version %v, %v", tracker.Version(), err)
+	}
+	// the version of a previous master is forgotten
+	vi.Version = ""
+	if err := tracker.Refresh(context.Background(), source); err == nil || !tracker.Version().IsZero() {
+		t.Fatalf("unexpected version %v, %v", tracker.Version(), err)
+	}
+}
+
+func proto(s string) *string { return &s }
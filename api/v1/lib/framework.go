@@ -0,0 +1,11 @@
+package mesos
+
+// HasCapability returns true if the framework has declared the given capability.
+func (fi *FrameworkInfo) HasCapability(t FrameworkInfo_Capability_Type) bool {
+	for _, c := range fi.GetCapabilities() {
+		if c.Type == t {
+			return true
+		}
+	}
+	return false
+}
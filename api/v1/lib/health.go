@@ -0,0 +1,50 @@
+package mesos
+
+// Health is the health of a task as last reported by its health check.
+type Health int
+
+const (
+	// HealthUnknown indicates that no health check result has been reported, e.g. because the task
+	// doesn't specify a health check or its grace period hasn't elapsed.
+	HealthUnknown Health = iota
+	HealthHealthy
+	HealthUnhealthy
+)
+
+func (h Health) String() string {
+	switch h {
+	case HealthHealthy:
+		return "healthy"
+	case HealthUnhealthy:
+		return "unhealthy"
+	}
+	return "unknown"
+}
+
+// Health returns the health of the task as reported by the status.
+func (s *TaskStatus) Health() Health {
+	if s == nil || s.Healthy == nil {
+		return HealthUnknown
+	}
+	if *s.Healthy {
+		return HealthHealthy
+	}
+	return HealthUnhealthy
+}
+
+// IsHealthCheckUpdate returns true if the status was generated because the result of the task's
+// health check changed, rather than because the state of the task changed.
+func (s *TaskStatus) IsHealthCheckUpdate() bool {
+	return s.GetReason() == REASON_TASK_HEALTH_CHECK_STATUS_UPDATED
+}
+
+// HealthTransition returns the health of the task reported by the status if it differs from the
+// previously observed health, and false otherwise. Statuses that don't report health never yield a
+// transition, so that frameworks only react to actual health check results.
+func (s *TaskStatus) HealthTransition(previous Health) (Health, bool) {
+	h := s.Health()
+	if h == HealthUnknown || h == previous {
+		return previous, false
+	}
+	return h, true
+}
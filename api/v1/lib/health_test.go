@@ -0,0 +1,37 @@
+package mesos_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestTaskStatus_Health(t *testing.T) {
+	healthy, unhealthy := true, false
+	status := func(h *bool, r mesos.TaskStatus_Reason) *mesos.TaskStatus {
+		return &mesos.TaskStatus{State: mesos.TASK_RUNNING.Enum(), Healthy: h, Reason: r.Enum()}
+	}
+	for i, tc := range []struct {
+		status     *mesos.TaskStatus
+		previous   mesos.Health
+		health     mesos.Health
+		transition bool
+	}{
+		{status(nil, mesos.REASON_RECONCILIATION), mesos.HealthUnknown, mesos.HealthUnknown, false},
+		{status(nil, mesos.REASON_RECONCILIATION), mesos.HealthHealthy, mesos.HealthHealthy, false},
+		{status(&healthy, mesos.REASON_TASK_HEALTH_CHECK_STATUS_UPDATED), mesos.HealthUnknown, mesos.HealthHealthy, true},
+		{status(&healthy, mesos.REASON_RECONCILIATION), mesos.HealthHealthy, mesos.HealthHealthy, false},
+		{status(&unhealthy, mesos.REASON_TASK_HEALTH_CHECK_STATUS_UPDATED), mesos.HealthHealthy, mesos.HealthUnhealthy, true},
+	} {
+		h, ok := tc.status.HealthTransition(tc.previous)
+		if h != tc.health || ok != tc.transition {
+			t.Errorf("test case %d failed: expected (%v, %v) instead of (%v, %v)", i, tc.health, tc.transition, h, ok)
+		}
+	}
+	if s := status(&unhealthy, mesos.REASON_TASK_HEALTH_CHECK_STATUS_UPDATED); !s.IsHealthCheckUpdate() || s.Health() != mesos.HealthUnhealthy {
+		t.Fatalf("unexpected interpretation of %v", s)
+	}
+	if (*mesos.TaskStatus)(nil).Health() != mesos.HealthUnknown {
+		t.Fatal("expected unknown health for a nil status")
+	}
+}
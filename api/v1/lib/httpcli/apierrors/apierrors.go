@@ -1,9 +1,12 @@
 package apierrors
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 // Code is a Mesos HTTP v1 API response status code
@@ -59,10 +62,26 @@ var (
 	}
 )
 
+var (
+	// ErrNotLeader matches errors of calls that were sent to a non-leading master; see Is.
+	ErrNotLeader = &Error{code: CodeNotLeader, message: MsgNotLeader}
+	// ErrUnauthorized matches errors of calls that were not successfully authenticated; see Is.
+	ErrUnauthorized = &Error{code: CodeNotAuthenticated, message: MsgAuth}
+	// ErrUnsubscribed matches errors of calls that were sent before a subscription was established; see Is.
+	ErrUnsubscribed = &Error{code: CodeUnsubscribed, message: MsgUnsubscribed}
+	// ErrMalformedCall matches errors of calls that were rejected as malformed; the reason that Mesos
+	// gives for the rejection (e.g. a validation failure) is reported by the Details of the error. See Is.
+	ErrMalformedCall = &Error{code: CodeMalformedRequest, message: MsgMalformed}
+	// ErrTemporary matches errors of temporary conditions that should clear, such that the call may be
+	// retried; see Is and Error.Temporary.
+	ErrTemporary = errors.New("temporary mesos API error")
+)
+
 // Error captures HTTP v1 API error codes and messages generated by Mesos.
 type Error struct {
 	code    Code   // code is the HTTP response status code generated by Mesos
 	message string // message briefly summarizes the nature of the error, possibly includes details from Mesos
+	details string // details is the (possibly truncated) body of the response, if any
 }
 
 // IsError returns true for all HTTP status codes that are not considered informational or successful.
@@ -89,13 +108,28 @@ func FromResponse(res *http.Response) error {
 
 	if res.Body != nil {
 		defer res.Body.Close()
-		buf, _ := ioutil.ReadAll(io.LimitReader(res.Body, MaxSizeDetails))
-		details = string(buf)
+		details = ReadDetails(res.Body)
 	}
 
 	return code.Error(details)
 }
 
+// ReadDetails reads the details of an error from a response body: Mesos reports the reason for an error,
+// for example why a call failed validation, as plain text. At most MaxSizeDetails bytes are read; longer
+// details are truncated and marked as such. Leading and trailing white space is trimmed.
+func ReadDetails(r io.Reader) string {
+	buf, _ := ioutil.ReadAll(io.LimitReader(r, MaxSizeDetails+1)) // intentionally discard any error here
+	truncated := len(buf) > MaxSizeDetails
+	if truncated {
+		buf = buf[:MaxSizeDetails]
+	}
+	details := strings.TrimSpace(string(buf))
+	if truncated {
+		details += "..."
+	}
+	return details
+}
+
 // Error generates an error from the given status code and detail string.
 func (code Code) Error(details string) error {
 	if !code.IsError() {
@@ -104,6 +138,13 @@ func (code Code) Error(details string) error {
 	err := &Error{
 		code:    code,
 		message: ErrorTable[code],
+		details: details,
+	}
+	if err.message == "" {
+		err.message = fmt.Sprintf("unexpected mesos HTTP response code: %d", code)
+		if text := http.StatusText(int(code)); text != "" {
+			err.message += " " + text
+		}
 	}
 	if details != "" {
 		err.message = err.message + ": " + details
@@ -114,6 +155,35 @@ func (code Code) Error(details string) error {
 // Error implements error interface
 func (e *Error) Error() string { return e.message }
 
+// Code returns the HTTP response status code generated by Mesos.
+func (e *Error) Code() Code { return e.code }
+
+// Details returns the body of the response that generated the error (truncated to MaxSizeDetails), which
+// usually explains the error; for example, why a call is malformed.
+func (e *Error) Details() string { return e.details }
+
+// Is returns true if the target is ErrTemporary and the error is temporary, or if the target is an API
+// error w/ the same code; e.g. ErrNotLeader. It implements the errors.Is protocol of Go 1.13 and later.
+func (e *Error) Is(target error) bool {
+	if target == ErrTemporary {
+		return e.Temporary()
+	}
+	t, ok := target.(*Error)
+	return ok && t.code == e.code
+}
+
+// Is returns true if err matches the target as per Error.Is, or if err equals the target; for versions of
+// Go that lack errors.Is.
+func Is(err, target error) bool {
+	if err == target {
+		return true
+	}
+	if e, ok := err.(*Error); ok {
+		return e.Is(target)
+	}
+	return false
+}
+
 // Temporary returns true if the error is a temporary condition that should eventually clear.
 func (e *Error) Temporary() bool {
 	switch e.code {
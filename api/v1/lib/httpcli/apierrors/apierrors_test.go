@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -20,7 +21,7 @@ func TestFromResponse(t *testing.T) {
 		},
 		{
 			&http.Response{StatusCode: 400, Body: ioutil.NopCloser(bytes.NewBufferString("missing framework id"))},
-			&Error{400, ErrorTable[CodeMalformedRequest] + ": missing framework id"},
+			&Error{400, ErrorTable[CodeMalformedRequest] + ": missing framework id", "missing framework id"},
 		},
 	} {
 		rr := FromResponse(tt.r)
@@ -64,3 +65,46 @@ func TestError(t *testing.T) {
 		}
 	}
 }
+
+func TestIs(t *testing.T) {
+	res := &http.Response{StatusCode: 400, Body: ioutil.NopCloser(bytes.NewBufferString("invalid offer id"))}
+	err := FromResponse(res)
+	if !Is(err, ErrMalformedCall) || Is(err, ErrNotLeader) || Is(err, ErrTemporary) {
+		t.Fatalf("unexpected matches for %q", err)
+	}
+	if d := err.(*Error).Details(); d != "invalid offer id" {
+		t.Fatalf("expected details %q instead of %q", "invalid offer id", d)
+	}
+	for _, tt := range []struct {
+		code   Code
+		target error
+	}{
+		{CodeNotLeader, ErrNotLeader},
+		{CodeNotAuthenticated, ErrUnauthorized},
+		{CodeUnsubscribed, ErrUnsubscribed},
+		{CodeMesosUnavailable, ErrTemporary},
+		{CodeRateLimitExceeded, ErrTemporary},
+	} {
+		if err := tt.code.Error(""); !Is(err, tt.target) {
+			t.Errorf("expected %q to match %q", err, tt.target)
+		}
+	}
+	if Is(nil, ErrTemporary) || !Is(ErrTemporary, ErrTemporary) {
+		t.Fatal("unexpected match of ErrTemporary")
+	}
+
+	// codes that Mesos doesn't document don't yield empty messages
+	if msg := Code(http.StatusBadGateway).Error("").Error(); msg != "unexpected mesos HTTP response code: 502 Bad Gateway" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+}
+
+func TestReadDetails(t *testing.T) {
+	if d := ReadDetails(bytes.NewBufferString(" Invalid offer ID\n")); d != "Invalid offer ID" {
+		t.Fatalf("unexpected details %q", d)
+	}
+	long := strings.Repeat("x", MaxSizeDetails+1)
+	if d := ReadDetails(bytes.NewBufferString(long)); d != long[:MaxSizeDetails]+"..." {
+		t.Fatalf("expected details truncated to %d bytes instead of %d", MaxSizeDetails, len(d))
+	}
+}
@@ -0,0 +1,115 @@
+package httpcli
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"sync"
+)
+
+type (
+	// Authenticatee implements an authentication mechanism for the requests of a Client; see Authentication.
+	Authenticatee interface {
+		// Authenticate performs the handshake of the mechanism, if any, w/ the server via the given
+		// round-tripper, and returns the value of the Authorization header of subsequent requests.
+		Authenticate(ctx context.Context, rt http.RoundTripper) (string, error)
+	}
+
+	// AuthenticateeFunc is the functional adaptation of Authenticatee.
+	AuthenticateeFunc func(ctx context.Context, rt http.RoundTripper) (string, error)
+)
+
+// Authenticate implements Authenticatee for AuthenticateeFunc.
+func (f AuthenticateeFunc) Authenticate(ctx context.Context, rt http.RoundTripper) (string, error) {
+	return f(ctx, rt)
+}
+
+// BasicAuthenticatee returns an Authenticatee for HTTP Basic authentication w/ the principal and secret of
+// the credentials as username and password.
+func BasicAuthenticatee(cf CredentialsFunc) Authenticatee {
+	return AuthenticateeFunc(func(context.Context, http.RoundTripper) (string, error) {
+		c, err := cf()
+		if err != nil {
+			return "", err
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Principal+":"+c.Secret)), nil
+	})
+}
+
+// TokenAuthenticatee returns an Authenticatee for HTTP Bearer token authentication, for example w/ a JWT.
+func TokenAuthenticatee(tf TokenFunc) Authenticatee {
+	return AuthenticateeFunc(func(context.Context, http.RoundTripper) (string, error) {
+		t, err := tf()
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + t, nil
+	})
+}
+
+// Authentication generates a functional config option that authenticates the requests of a Client w/ the
+// given Authenticatee. Authentication happens lazily, before the first request is sent (e.g. prior to
+// subscription), and the resulting Authorization header is cached. If the server rejects a request with
+// 401 (Unauthorized) then authentication is repeated: if the Authorization header has changed then the
+// request is retried once, provided that the request body may be replayed. Streaming requests are never
+// retried, though subsequent requests will use the new Authorization header. Authenticatees that obtain
+// their credentials from a TokenFunc or a CredentialsFunc thus pick up rotated credentials transparently.
+func Authentication(a Authenticatee) ConfigOpt {
+	var (
+		mu     sync.Mutex
+		cached string
+		loaded bool
+	)
+	authorization := func(ctx context.Context, rt http.RoundTripper, refresh bool) (string, bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if loaded && !refresh {
+			return cached, false, nil
+		}
+		h, err := a.Authenticate(ctx, rt)
+		if err != nil {
+			return "", false, err
+		}
+		changed := !loaded || h != cached
+		cached, loaded = h, true
+		return h, changed, nil
+	}
+	return WrapRoundTripper(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			h, _, err := authorization(req.Context(), rt, false)
+			if err != nil {
+				return nil, err
+			}
+			res, err := rt.RoundTrip(withAuthorization(req, h))
+			if err != nil || res.StatusCode != http.StatusUnauthorized {
+				return res, err
+			}
+			h, changed, authErr := authorization(req.Context(), rt, true)
+			if authErr != nil || !changed || req.GetBody == nil {
+				return res, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return res, err
+			}
+			// discard the rejected response and retry w/ the new credentials
+			drainAndClose(res)
+			retry := withAuthorization(req, h)
+			retry.Body = body
+			return rt.RoundTrip(retry)
+		})
+	})
+}
+
+// withAuthorization returns a shallow copy of req that carries the given Authorization header; according
+// to the stdlib we're not supposed to mutate the original Request.
+func withAuthorization(req *http.Request, authorization string) *http.Request {
+	h := make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		h[k] = append(make([]string, 0, len(v)), v...)
+	}
+	h.Set("Authorization", authorization)
+	clonedReq := *req
+	clonedReq.Header = h
+	return &clonedReq
+}
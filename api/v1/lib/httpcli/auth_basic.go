@@ -10,7 +10,8 @@ type roundTripperFunc func(*http.Request) (*http.Response, error)
 // RoundTrip implements RoundTripper for roundTripperFunc
 func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
 
-// BasicAuth generates a functional config option that sets HTTP Basic authentication for a Client
+// BasicAuth generates a functional config option that sets HTTP Basic authentication for a Client. For
+// credentials that may be rotated use Authentication(BasicAuthenticatee(...)) instead.
 func BasicAuth(username, passwd string) ConfigOpt {
 	// TODO(jdef) this could be more efficient. according to the stdlib we're not supposed to
 	// mutate the original Request, so we copy here (including headers). another approach would
@@ -0,0 +1,41 @@
+package httpcli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// TokenFunc yields the current value of an authentication token.
+type TokenFunc func() (string, error)
+
+// StaticToken returns a TokenFunc that always yields the given token.
+func StaticToken(token string) TokenFunc {
+	return func() (string, error) { return token, nil }
+}
+
+// FileToken returns a TokenFunc that reads a token from the file at the given path; surrounding whitespace
+// is trimmed. The file is read upon every invocation, so rotated tokens are picked up.
+func FileToken(path string) TokenFunc {
+	return func() (string, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes.TrimSpace(b)), nil
+	}
+}
+
+// BearerAuth generates a functional config option that sets HTTP Bearer token authentication for a Client.
+// The token is obtained lazily and then cached. If the server rejects a request with 401 (Unauthorized)
+// then the token is re-read: if it has changed (for example, it was rotated on disk) then the request is
+// retried once with the new token, provided that the request body may be replayed. Streaming requests are
+// never retried, though subsequent requests will use the new token. See also Authentication.
+func BearerAuth(tf TokenFunc) ConfigOpt { return Authentication(TokenAuthenticatee(tf)) }
+
+func drainAndClose(res *http.Response) {
+	if res != nil && res.Body != nil {
+		ioutil.ReadAll(res.Body) // intentionally discard any error here
+		res.Body.Close()
+	}
+}
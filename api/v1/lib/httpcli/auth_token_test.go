@@ -0,0 +1,87 @@
+package httpcli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBearerAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bearer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenPath := filepath.Join(dir, "token")
+	writeToken := func(token string) {
+		if err := ioutil.WriteFile(tokenPath, []byte(token+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeToken("a")
+
+	var (
+		accepted = "a"
+		seen     []string
+		config   = &Config{client: &http.Client{}}
+		server   = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			auth := req.Header.Get("Authorization")
+			seen = append(seen, auth)
+			code := http.StatusOK
+			if auth != "Bearer "+accepted {
+				code = http.StatusUnauthorized
+			}
+			return &http.Response{StatusCode: code, Body: ioutil.NopCloser(new(bytes.Buffer))}, nil
+		})
+	)
+	config.client.Transport = server
+	BearerAuth(FileToken(tokenPath))(config)
+
+	send := func() int {
+		req, err := http.NewRequest("POST", "http://localhost/api/v1/executor", bytes.NewBufferString("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := config.client.Transport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Fatalf("original request was modified")
+		}
+		return res.StatusCode
+	}
+
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("expected 200 instead of %d", code)
+	}
+
+	// rotate the token: the first attempt is rejected, the retry succeeds
+	accepted = "b"
+	writeToken("b")
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("expected 200 instead of %d", code)
+	}
+	expected := []string{"Bearer a", "Bearer a", "Bearer b"}
+	if len(seen) != len(expected) {
+		t.Fatalf("expected %v instead of %v", expected, seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("expected %v instead of %v", expected, seen)
+		}
+	}
+
+	// token didn't change; no retry
+	accepted = "c"
+	if code := send(); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 instead of %d", code)
+	}
+	if len(seen) != 4 {
+		t.Fatalf("unexpected retry: %v", seen)
+	}
+}
@@ -0,0 +1,229 @@
+// Package chaos injects faults into the HTTP interactions of Mesos API clients, for resilience testing of
+// frameworks and of the controllers of this library. An Injector decorates the DoFunc of an httpcli.Client
+// and, according to a schedule that's derived from a random seed, adds latency, resets connections,
+// truncates (recordio) response streams, and answers requests w/ bursts of redirects or server errors.
+// The same seed yields the same sequence of faults for the same sequence of requests.
+//
+//	inj := chaos.New(seed, chaos.Resets(0.05), chaos.Redirects(0.02, 10), chaos.ServerErrors(0.02, 5))
+//	cli := httpcli.New(httpcli.Endpoint(url), inj.Opt())
+package chaos
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+)
+
+// Fault is a kind of injected fault.
+type Fault int
+
+const (
+	None Fault = iota
+	Latency
+	Reset
+	Truncate
+	Redirect
+	ServerError
+)
+
+var faultNames = [...]string{"NONE", "LATENCY", "RESET", "TRUNCATE", "REDIRECT", "SERVER_ERROR"}
+
+func (f Fault) String() string {
+	if f >= 0 && int(f) < len(faultNames) {
+		return faultNames[f]
+	}
+	return "UNKNOWN"
+}
+
+type (
+	// Injector decides, per request, which faults to inject.
+	Injector struct {
+		latency           time.Duration
+		latencyP          float64
+		resetP            float64
+		truncateP         float64
+		maxTruncate       int
+		redirectP         float64
+		redirectBurst     int
+		redirectTo        string
+		serverErrorP      float64
+		serverErrorBurst  int
+		serverErrorStatus int
+		sleep             func(time.Duration)
+
+		mu      sync.Mutex
+		rand    *rand.Rand
+		burst   Fault // the fault of the current burst, if any
+		pending int   // remaining requests of the current burst
+		log     []Fault
+	}
+
+	// Opt is a functional option for an Injector.
+	Opt func(*Injector)
+)
+
+// WithLatency returns an Opt that delays requests by up to the given duration, w/ the given probability.
+func WithLatency(max time.Duration, p float64) Opt {
+	return func(i *Injector) { i.latency, i.latencyP = max, p }
+}
+
+// Resets returns an Opt that fails requests w/ a "connection reset by peer" error, w/ the given probability.
+func Resets(p float64) Opt { return func(i *Injector) { i.resetP = p } }
+
+// Truncations returns an Opt that truncates response bodies, w/ the given probability, after a random
+// number of bytes (less than max); reading beyond yields io.ErrUnexpectedEOF. For streaming responses this
+// simulates a connection that drops in the middle of a recordio frame.
+func Truncations(p float64, max int) Opt {
+	return func(i *Injector) { i.truncateP, i.maxTruncate = p, max }
+}
+
+// Redirects returns an Opt that starts, w/ the given probability, a burst of the given number of 307
+// responses. The Location of a redirect is the host of the request, unless set by RedirectTo.
+func Redirects(p float64, burst int) Opt {
+	return func(i *Injector) { i.redirectP, i.redirectBurst = p, burst }
+}
+
+// RedirectTo returns an Opt that sets the Location of injected redirects, e.g. "//host:port".
+func RedirectTo(location string) Opt { return func(i *Injector) { i.redirectTo = location } }
+
+// ServerErrors returns an Opt that starts, w/ the given probability, a burst of the given number of 503
+// (Service Unavailable) responses.
+func ServerErrors(p float64, burst int) Opt {
+	return func(i *Injector) { i.serverErrorP, i.serverErrorBurst = p, burst }
+}
+
+// ServerErrorStatus returns an Opt that sets the HTTP status code of injected server errors.
+func ServerErrorStatus(code int) Opt { return func(i *Injector) { i.serverErrorStatus = code } }
+
+// New returns an Injector whose schedule of faults is derived from the given seed.
+func New(seed int64, opts ...Opt) *Injector {
+	i := &Injector{
+		rand:              rand.New(rand.NewSource(seed)),
+		serverErrorStatus: http.StatusServiceUnavailable,
+		sleep:             time.Sleep,
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(i)
+		}
+	}
+	return i
+}
+
+// Opt returns an httpcli.Opt that injects faults into the requests of an httpcli.Client.
+func (i *Injector) Opt() httpcli.Opt { return httpcli.WrapDoer(i.Wrap) }
+
+// Faults returns the faults that have been injected so far, one per request (None if a request wasn't
+// subject to a fault).
+func (i *Injector) Faults() []Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]Fault(nil), i.log...)
+}
+
+// decision is the plan for a single request.
+type decision struct {
+	fault    Fault
+	delay    time.Duration
+	truncate int
+}
+
+func (i *Injector) decide() (d decision) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	defer func() { i.log = append(i.log, d.fault) }()
+
+	if i.pending > 0 {
+		i.pending--
+		d.fault = i.burst
+		return
+	}
+	chance := func(p float64) bool { return p > 0 && i.rand.Float64() < p }
+	switch {
+	case chance(i.resetP):
+		d.fault = Reset
+	case chance(i.redirectP):
+		d.fault, i.burst, i.pending = Redirect, Redirect, i.redirectBurst-1
+	case chance(i.serverErrorP):
+		d.fault, i.burst, i.pending = ServerError, ServerError, i.serverErrorBurst-1
+	case chance(i.truncateP) && i.maxTruncate > 0:
+		d.fault, d.truncate = Truncate, i.rand.Intn(i.maxTruncate)
+	case chance(i.latencyP) && i.latency > 0:
+		d.fault, d.delay = Latency, time.Duration(i.rand.Int63n(int64(i.latency)))
+	}
+	return
+}
+
+// Wrap decorates the given DoFunc w/ fault injection.
+func (i *Injector) Wrap(do httpcli.DoFunc) httpcli.DoFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		d := i.decide()
+		switch d.fault {
+		case Latency:
+			i.sleep(d.delay)
+		case Reset:
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+		case Redirect:
+			location := i.redirectTo
+			if location == "" {
+				location = "//" + req.URL.Host
+			}
+			return response(req, http.StatusTemporaryRedirect, http.Header{"Location": {location}}), nil
+		case ServerError:
+			return response(req, i.serverErrorStatus, nil), nil
+		}
+		res, err := do(req)
+		if err == nil && d.fault == Truncate {
+			res.Body = &truncatedBody{ReadCloser: res.Body, remaining: d.truncate}
+		}
+		return res, err
+	}
+}
+
+func response(req *http.Request, code int, header http.Header) *http.Response {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:     http.StatusText(code),
+		StatusCode: code,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+}
+
+// truncatedBody yields io.ErrUnexpectedEOF once the remaining bytes have been read.
+type truncatedBody struct {
+	io.ReadCloser
+	remaining int
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= n
+	return n, err
+}
@@ -0,0 +1,84 @@
+package chaos
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInjector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 100))
+	}))
+	defer srv.Close()
+
+	run := func(seed int64) (*Injector, map[string]int) {
+		inj := New(seed,
+			WithLatency(time.Second, 0.1),
+			Resets(0.1),
+			Truncations(0.1, 50),
+			Redirects(0.1, 3),
+			ServerErrors(0.1, 2),
+		)
+		inj.sleep = func(time.Duration) {}
+		var (
+			cl      = &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+			do      = inj.Wrap(cl.Do)
+			results = map[string]int{}
+		)
+		for n := 0; n < 200; n++ {
+			req, _ := http.NewRequest("GET", srv.URL, nil)
+			res, err := do(req)
+			if err != nil {
+				results["error"]++
+				continue
+			}
+			b, err := ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			switch {
+			case err == io.ErrUnexpectedEOF && len(b) < 50:
+				results["truncated"]++
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			default:
+				results[fmt.Sprint(res.StatusCode)]++
+			}
+		}
+		return inj, results
+	}
+
+	a, results := run(1)
+	for _, k := range []string{"error", "truncated", "200", "307", "503"} {
+		if results[k] == 0 {
+			t.Errorf("expected some %q results: %v", k, results)
+		}
+	}
+	// bursts may be back-to-back, but never cut short
+	burst := map[Fault]int{Redirect: 3, ServerError: 2}
+	faults := append(a.Faults(), None)
+	for i, run := 1, 1; i < len(faults); i++ {
+		if faults[i] == faults[i-1] {
+			run++
+			continue
+		}
+		if n := burst[faults[i-1]]; n > 0 && run%n != 0 {
+			t.Errorf("unexpected burst of %d %v faults", run, faults[i-1])
+		}
+		run = 1
+	}
+
+	b, _ := run(1)
+	if !reflect.DeepEqual(a.Faults(), b.Faults()) {
+		t.Error("expected the same schedule of faults for the same seed")
+	}
+	c, _ := run(2)
+	if reflect.DeepEqual(a.Faults(), c.Faults()) {
+		t.Error("expected a different schedule of faults for a different seed")
+	}
+}
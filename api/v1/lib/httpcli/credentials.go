@@ -0,0 +1,98 @@
+package httpcli
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// Credentials are a principal and its secret, for example the username and password of HTTP Basic
+	// authentication.
+	Credentials struct {
+		Principal string `json:"principal"`
+		Secret    string `json:"secret"`
+	}
+
+	// CredentialsFunc yields the current credentials. Authenticatees invoke it upon every authentication
+	// (see Authentication), so credentials that are rotated by the source of a CredentialsFunc are picked
+	// up once the server rejects the previous ones, w/o restarting the framework.
+	CredentialsFunc func() (Credentials, error)
+)
+
+// StaticCredentials returns a CredentialsFunc that always yields the given credentials.
+func StaticCredentials(principal, secret string) CredentialsFunc {
+	return func() (Credentials, error) { return Credentials{Principal: principal, Secret: secret}, nil }
+}
+
+// CredentialsFile returns a CredentialsFunc that reads credentials from the file at the given path, in the
+// format of the --credential flag of Mesos: either a JSON object w/ "principal" and "secret" fields, or a
+// line of text that consists of the principal and the secret, separated by whitespace. The file is read
+// upon every invocation, so rotated credentials are picked up.
+func CredentialsFile(path string) CredentialsFunc {
+	return func() (Credentials, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return Credentials{}, err
+		}
+		return parseCredentials(path, bytes.TrimSpace(b))
+	}
+}
+
+func parseCredentials(path string, b []byte) (c Credentials, err error) {
+	if len(b) > 0 && b[0] == '{' {
+		err = json.Unmarshal(b, &c)
+	} else if fields := bytes.Fields(b); len(fields) == 2 {
+		c = Credentials{Principal: string(fields[0]), Secret: string(fields[1])}
+	}
+	if err == nil && (c.Principal == "" || c.Secret == "") {
+		err = fmt.Errorf("no credentials found in %q", path)
+	}
+	return
+}
+
+// ClientCertificateFiles returns a func, suitable for tls.Config.GetClientCertificate, that presents the
+// client certificate in certFile and keyFile. The files are reloaded once either of them is modified, so
+// rotated certificates are presented by subsequent TLS handshakes (i.e. by new connections) w/o restarting
+// the framework. If reloading fails then the previously loaded certificate is presented.
+func ClientCertificateFiles(certFile, keyFile string) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	var (
+		mu      sync.Mutex
+		cert    *tls.Certificate
+		modTime time.Time
+	)
+	return func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var latest time.Time
+		for _, f := range []string{certFile, keyFile} {
+			fi, err := os.Stat(f)
+			if err != nil {
+				if cert != nil {
+					return cert, nil
+				}
+				return nil, err
+			}
+			if fi.ModTime().After(latest) {
+				latest = fi.ModTime()
+			}
+		}
+		if cert != nil && !latest.After(modTime) {
+			return cert, nil
+		}
+		loaded, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			if cert != nil {
+				return cert, nil
+			}
+			return nil, err
+		}
+		cert, modTime = &loaded, latest
+		return cert, nil
+	}
+}
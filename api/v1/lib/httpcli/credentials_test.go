@@ -0,0 +1,178 @@
+package httpcli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCredentialsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "credential")
+	cf := CredentialsFile(path)
+	if _, err := cf(); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+	for i, tc := range []struct {
+		content string
+		want    Credentials
+		wantErr bool
+	}{
+		{"framework secret\n", Credentials{"framework", "secret"}, false},
+		{`{"principal": "framework", "secret": "rotated"}`, Credentials{"framework", "rotated"}, false},
+		{"framework", Credentials{}, true},
+		{`{"principal": "framework"}`, Credentials{}, true},
+		{`{"principal": `, Credentials{}, true},
+	} {
+		if err := ioutil.WriteFile(path, []byte(tc.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := cf()
+		if (err != nil) != tc.wantErr || (err == nil && got != tc.want) {
+			t.Errorf("test case %d: unexpected credentials %v, %v", i, got, err)
+		}
+	}
+}
+
+func TestAuthentication_RotatedCredentials(t *testing.T) {
+	var (
+		password = "a"
+		seen     []string
+		config   = &Config{client: &http.Client{}}
+	)
+	config.client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		u, p, _ := req.BasicAuth()
+		seen = append(seen, u+":"+p)
+		code := http.StatusOK
+		if p != password {
+			code = http.StatusUnauthorized
+		}
+		return &http.Response{StatusCode: code, Body: ioutil.NopCloser(new(bytes.Buffer))}, nil
+	})
+	current := Credentials{Principal: "framework", Secret: "a"}
+	Authentication(BasicAuthenticatee(func() (Credentials, error) { return current, nil }))(config)
+
+	send := func() int {
+		req, err := http.NewRequest("POST", "http://localhost/api/v1/scheduler", bytes.NewBufferString("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := config.client.Transport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode
+	}
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("expected 200 instead of %d", code)
+	}
+	password = "b"
+	current.Secret = "b"
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("expected 200 instead of %d", code)
+	}
+	expected := []string{"framework:a", "framework:a", "framework:b"}
+	if len(seen) != len(expected) {
+		t.Fatalf("expected %v instead of %v", expected, seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("expected %v instead of %v", expected, seen)
+		}
+	}
+}
+
+func writeKeyPair(t *testing.T, certFile, keyFile, cn string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientCertificateFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		certFile = filepath.Join(dir, "cert.pem")
+		keyFile  = filepath.Join(dir, "key.pem")
+		get      = ClientCertificateFiles(certFile, keyFile)
+		subject  = func(c *tls.Certificate) string {
+			x, err := x509.ParseCertificate(c.Certificate[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return x.Subject.CommonName
+		}
+	)
+	if _, err := get(nil); err == nil {
+		t.Fatal("expected an error for missing files")
+	}
+	writeKeyPair(t, certFile, keyFile, "first")
+	cert, err := get(nil)
+	if err != nil || subject(cert) != "first" {
+		t.Fatalf("unexpected certificate: %v", err)
+	}
+
+	// a partially rotated key pair doesn't load, so the previous certificate is presented
+	future := time.Now().Add(time.Minute)
+	if err = ioutil.WriteFile(certFile, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(certFile, future, future)
+	if cert, err = get(nil); err != nil || subject(cert) != "first" {
+		t.Fatalf("expected the previous certificate: %v", err)
+	}
+
+	writeKeyPair(t, certFile, keyFile, "second")
+	future = future.Add(time.Minute)
+	os.Chtimes(certFile, future, future)
+	if cert, err = get(nil); err != nil || subject(cert) != "second" {
+		t.Fatalf("expected the rotated certificate: %v", err)
+	}
+
+	tc, err := NewTLSConfig("", certFile, keyFile)
+	if err != nil || tc.GetClientCertificate == nil || len(tc.Certificates) != 1 {
+		t.Fatalf("unexpected config %v: %v", tc, err)
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -80,6 +82,9 @@ type Client struct {
 	requestOpts      []RequestOpt
 	buildRequestFunc func(client.Request, client.ResponseClass, ...RequestOpt) (*http.Request, error)
 	handleResponse   ResponseHandler
+	maxMessageSize   int
+	registry         *encoding.Registry
+	streamBody       bool
 }
 
 var (
@@ -107,6 +112,7 @@ func New(opts ...Opt) *Client {
 		do:          With(DefaultConfigOpt...),
 		header:      cloneHeaders(DefaultHeaders),
 		errorMapper: DefaultErrorMapper,
+		registry:    codecs.DefaultRegistry,
 	}
 	c.buildRequestFunc = c.buildRequest
 	c.handleResponse = c.HandleResponse
@@ -198,15 +204,23 @@ func (c *Client) buildRequest(cr client.Request, rc client.ResponseClass, opt ..
 		return nil, err
 	}
 
-	//TODO(jdef): use a pool to allocate these (and reduce garbage)?
-	// .. or else, use a pipe (like streaming does) to avoid the intermediate buffer?
-	var body bytes.Buffer
-	if err := c.codec.NewEncoder(encoding.SinkWriter(&body)).Encode(cr.Marshaler()); err != nil {
-		return nil, err
+	var body io.Reader
+	if c.streamBody {
+		body = c.encodeBody(cr.Marshaler())
+	} else {
+		//TODO(jdef): use a pool to allocate these (and reduce garbage)?
+		var buf bytes.Buffer
+		if err := c.codec.NewEncoder(encoding.SinkWriter(&buf)).Encode(cr.Marshaler()); err != nil {
+			return nil, err
+		}
+		body = &buf
 	}
 
-	req, err := http.NewRequest("POST", c.url, &body)
+	req, err := http.NewRequest("POST", c.url, body)
 	if err != nil {
+		if rc, ok := body.(io.Closer); ok {
+			rc.Close() // ignore error
+		}
 		return nil, err
 	}
 
@@ -220,6 +234,19 @@ func (c *Client) buildRequest(cr client.Request, rc client.ResponseClass, opt ..
 		Request, nil
 }
 
+// encodeBody returns a reader that yields the encoding of the given marshaler; the message is encoded
+// into a pipe (by a separate goroutine) as the request body is consumed, w/o an intermediate buffer.
+// Encoding errors are reported by the reader. The reader must be closed by the consumer (which is the
+// case for request bodies sent by an http.Client) in order to release the encoding goroutine.
+func (c *Client) encodeBody(m encoding.Marshaler) io.ReadCloser {
+	pr, pw := io.Pipe()
+	enc := c.codec.NewEncoder(encoding.SinkWriter(pw))
+	go func() {
+		pw.CloseWithError(enc.Encode(m))
+	}()
+	return pr
+}
+
 func (c *Client) buildRequestStream(f func() encoding.Marshaler, rc client.ResponseClass, opt ...RequestOpt) (*http.Request, error) {
 	accept, err := prepareForResponse(rc, c.codec)
 	if err != nil {
@@ -267,53 +294,86 @@ func (c *Client) buildRequestStream(f func() encoding.Marshaler, rc client.Respo
 		Request, nil
 }
 
-func validateSuccessfulResponse(codec encoding.Codec, res *http.Response, rc client.ResponseClass) error {
+// validateSuccessfulResponse returns the codec that should be used to decode the response. It's the
+// requested codec, unless the response declares a different media type that's known to the registry.
+func validateSuccessfulResponse(codec encoding.Codec, registry *encoding.Registry, res *http.Response, rc client.ResponseClass) (encoding.Codec, error) {
 	switch res.StatusCode {
 	case http.StatusOK:
 		ct := res.Header.Get("Content-Type")
 		switch rc {
 		case client.ResponseClassNoData:
 			if ct != "" {
-				return ProtocolError(fmt.Sprintf("unexpected content type: %q", ct))
+				return codec, ProtocolError(fmt.Sprintf("unexpected content type: %q", ct))
 			}
 		case client.ResponseClassSingleton, client.ResponseClassAuto:
-			if ct != codec.Type.ContentType() {
-				return ProtocolError(fmt.Sprintf("unexpected content type: %q", ct))
+			c, ok := negotiateCodec(ct, codec, registry)
+			if !ok {
+				return codec, ProtocolError(fmt.Sprintf("unexpected content type: %q", ct))
 			}
+			return c, nil
 		case client.ResponseClassStreaming:
-			if ct != mediaTypeRecordIO.ContentType() {
-				return ProtocolError(fmt.Sprintf("unexpected content type: %q", ct))
+			if !matchesMediaType(ct, mediaTypeRecordIO) {
+				return codec, ProtocolError(fmt.Sprintf("unexpected content type: %q", ct))
 			}
 			ct = res.Header.Get("Message-Content-Type")
-			if ct != codec.Type.ContentType() {
-				return ProtocolError(fmt.Sprintf("unexpected message content type: %q", ct))
+			c, ok := negotiateCodec(ct, codec, registry)
+			if !ok {
+				return codec, ProtocolError(fmt.Sprintf("unexpected message content type: %q", ct))
 			}
+			return c, nil
 		default:
-			return ProtocolError(fmt.Sprintf("unsupported response-class: %q", rc))
+			return codec, ProtocolError(fmt.Sprintf("unsupported response-class: %q", rc))
 		}
 
 	case http.StatusAccepted:
 		// nothing to validate, we're not expecting any response entity in this case.
 		// TODO(jdef) perhaps check Content-Length == 0 here?
 	}
-	return nil
+	return codec, nil
+}
+
+// negotiateCodec returns the codec for the given content type: the requested codec if it matches,
+// otherwise the codec registered for the content type (if any).
+func negotiateCodec(ct string, codec encoding.Codec, registry *encoding.Registry) (encoding.Codec, bool) {
+	if matchesMediaType(ct, codec.Type) {
+		return codec, true
+	}
+	if registry != nil {
+		return registry.Lookup(ct)
+	}
+	return codec, false
+}
+
+// matchesMediaType returns true if the media type of the given Content-Type header value matches the
+// wanted media type; media type parameters (e.g. "charset=utf-8") are ignored.
+func matchesMediaType(ct string, want encoding.MediaType) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && strings.EqualFold(mt, want.ContentType())
 }
 
-func newSourceFactory(rc client.ResponseClass) encoding.SourceFactoryFunc {
+// newSourceFactory returns a factory for Sources of the given response class; a positive maxMessageSize
+// limits the size of the messages that may be read from a Source.
+func newSourceFactory(rc client.ResponseClass, maxMessageSize int) encoding.SourceFactoryFunc {
 	switch rc {
 	case client.ResponseClassNoData:
 		return nil
 	case client.ResponseClassSingleton:
-		return encoding.SourceReader
+		return encoding.SourceReaderLimit(int64(maxMessageSize))
 	case client.ResponseClassStreaming, client.ResponseClassAuto:
-		return recordIOSourceFactory
+		return recordIOSourceFactory(maxMessageSize)
 	default:
 		panic(fmt.Sprintf("unsupported response-class: %q", rc))
 	}
 }
 
-func recordIOSourceFactory(r io.Reader) encoding.Source {
-	return func() framing.Reader { return recordio.NewReader(r) }
+func recordIOSourceFactory(maxMessageSize int) encoding.SourceFactoryFunc {
+	var opt recordio.Opt
+	if maxMessageSize > 0 {
+		opt = recordio.MaxMessageSize(maxMessageSize)
+	}
+	return func(r io.Reader) encoding.Source {
+		return func() framing.Reader { return recordio.NewReader(r, opt) }
+	}
 }
 
 // HandleResponse parses an HTTP response from a Mesos service endpoint, transforming the
@@ -331,10 +391,11 @@ func (c *Client) HandleResponse(res *http.Response, rc client.ResponseClass, err
 		Header: res.Header,
 	}
 	if err = c.errorMapper(res); err != nil {
+		debug.Logf("request failed w/ HTTP status %d: %v", res.StatusCode, err)
 		return result, err
 	}
 
-	err = validateSuccessfulResponse(c.codec, res, rc)
+	codec, err := validateSuccessfulResponse(c.codec, c.registry, res, rc)
 	if err != nil {
 		res.Body.Close()
 		return nil, err
@@ -344,7 +405,7 @@ func (c *Client) HandleResponse(res *http.Response, rc client.ResponseClass, err
 	case http.StatusOK:
 		debug.Log("request OK, decoding response")
 
-		sf := newSourceFactory(rc)
+		sf := newSourceFactory(rc, c.maxMessageSize)
 		if sf == nil {
 			if rc != client.ResponseClassNoData {
 				panic("nil Source for response that expected data")
@@ -356,7 +417,7 @@ func (c *Client) HandleResponse(res *http.Response, rc client.ResponseClass, err
 			return nil, err
 		}
 
-		result.Decoder = c.codec.NewDecoder(sf.NewSource(res.Body))
+		result.Decoder = codec.NewDecoder(sf.NewSource(res.Body))
 
 	case http.StatusAccepted:
 		debug.Log("request Accepted")
@@ -367,11 +428,15 @@ func (c *Client) HandleResponse(res *http.Response, rc client.ResponseClass, err
 		return nil, err
 
 	default:
-		debug.Log("unexpected HTTP status", res.StatusCode)
-
 		defer res.Body.Close()
+		msg := fmt.Sprintf("unexpected mesos HTTP response code: %d", res.StatusCode)
+		if details := apierrors.ReadDetails(res.Body); details != "" {
+			msg += ": " + details
+		}
 		io.Copy(ioutil.Discard, res.Body) // intentionally discard any error here
-		return nil, ProtocolError(fmt.Sprintf("unexpected mesos HTTP response code: %d", res.StatusCode))
+
+		debug.Log(msg)
+		return nil, ProtocolError(msg)
 	}
 
 	return result, nil
@@ -448,6 +513,41 @@ func Codec(codec encoding.Codec) Opt {
 	}
 }
 
+// StreamRequestBody returns an Opt that determines whether the bodies of (non-streaming) requests are
+// encoded while the request is being sent, rather than buffered in full beforehand. Streamed request
+// bodies use chunked transfer encoding. Useful for very large calls, such as an ACCEPT that launches
+// hundreds of tasks or an UPDATE_MAINTENANCE_SCHEDULE for a big cluster.
+func StreamRequestBody(b bool) Opt {
+	return func(c *Client) Opt {
+		old := c.streamBody
+		c.streamBody = b
+		return StreamRequestBody(old)
+	}
+}
+
+// CodecRegistry returns an Opt that sets the registry that's consulted in order to decode responses w/ a
+// media type other than that of the Client's Codec; defaults to codecs.DefaultRegistry. When nil, such
+// responses yield a ProtocolError.
+func CodecRegistry(r *encoding.Registry) Opt {
+	return func(c *Client) Opt {
+		old := c.registry
+		c.registry = r
+		return CodecRegistry(old)
+	}
+}
+
+// MaxMessageSize returns an Opt that limits the size, in bytes, of the messages that the Client decodes
+// from response bodies; larger messages yield framing.ErrorOversizedFrame rather than being buffered.
+// When unset (or non-positive), singleton responses are unlimited and streamed messages are limited to
+// the recordio default of 4MiB.
+func MaxMessageSize(max int) Opt {
+	return func(c *Client) Opt {
+		old := c.maxMessageSize
+		c.maxMessageSize = max
+		return MaxMessageSize(old)
+	}
+}
+
 // DefaultHeader returns an Opt that adds a header to an Client's headers.
 func DefaultHeader(k, v string) Opt {
 	return func(c *Client) Opt {
@@ -465,6 +565,30 @@ func DefaultHeader(k, v string) Opt {
 	}
 }
 
+// SetDefaultHeader returns an Opt that sets a header of an Client's headers, replacing any values of the
+// header; for example, the ID of a trace that spans the calls of a framework. Unlike request options, the
+// headers of a Client are sent w/ every request, including those that are replayed upon redirects and
+// resubscriptions.
+func SetDefaultHeader(k string, v ...string) Opt {
+	return func(c *Client) Opt {
+		k = http.CanonicalHeaderKey(k)
+		old, found := c.header[k]
+		c.header[k] = append([]string{}, v...)
+		return func(c *Client) Opt {
+			if found {
+				c.header[k] = old
+			} else {
+				c.header.Del(k)
+			}
+			return SetDefaultHeader(k, v...)
+		}
+	}
+}
+
+// UserAgent returns an Opt that sets the User-Agent header of an Client's requests, for example to the
+// name and version of the framework: "my-framework/1.2.3"; see SetDefaultHeader.
+func UserAgent(ua string) Opt { return SetDefaultHeader("User-Agent", ua) }
+
 // HandleResponse returns a functional config option to set the HTTP response handler of the client.
 func HandleResponse(f ResponseHandler) Opt {
 	return func(c *Client) Opt {
@@ -602,7 +726,8 @@ func (r *HTTPRequestHelper) withOptions(optsets ...RequestOpts) *HTTPRequestHelp
 
 func (r *HTTPRequestHelper) withHeaders(hh http.Header) *HTTPRequestHelper {
 	for k, v := range hh {
-		r.Header[k] = v
+		// clone, so that request options don't modify the headers of the client
+		r.Header[k] = append([]string{}, v...)
 		debug.Log("request header " + k + ": " + v[0])
 	}
 	return r
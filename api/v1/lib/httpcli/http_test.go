@@ -1,11 +1,26 @@
 package httpcli
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/mesos/mesos-go/api/v1/lib"
 	"github.com/mesos/mesos-go/api/v1/lib/client"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/apierrors"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
 )
 
 func TestPrepareForResponse(t *testing.T) {
@@ -66,7 +81,7 @@ func TestNewSourceFactory(t *testing.T) {
 					panic(x)
 				}
 			}()
-			return newSourceFactory(tc.rc)
+			return newSourceFactory(tc.rc, 0)
 		}()
 		if tc.wantsPanic {
 			continue
@@ -80,3 +95,243 @@ func TestNewSourceFactory(t *testing.T) {
 		}
 	}
 }
+
+func TestNewSourceFactory_MaxMessageSize(t *testing.T) {
+	for ti, tc := range []struct {
+		rc  client.ResponseClass
+		in  string
+		max int
+		err error
+	}{
+		{client.ResponseClassSingleton, "abcd", 0, nil},
+		{client.ResponseClassSingleton, "abcd", 4, nil},
+		{client.ResponseClassSingleton, "abcde", 4, framing.ErrorOversizedFrame},
+		{client.ResponseClassStreaming, "4\nabcd", 4, nil},
+		{client.ResponseClassStreaming, "5\nabcde", 4, framing.ErrorOversizedFrame},
+	} {
+		r := newSourceFactory(tc.rc, tc.max).NewSource(strings.NewReader(tc.in))()
+		if _, err := r.ReadFrame(); err != tc.err {
+			t.Errorf("test case %d failed: expected error %v instead of %v", ti, tc.err, err)
+		}
+	}
+}
+
+func TestClient_JSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		var call scheduler.Call
+		if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+			t.Error(err)
+			return
+		}
+		if call.Type != scheduler.Call_SUBSCRIBE {
+			t.Errorf("unexpected call %v", call)
+		}
+		w.Header().Set("Content-Type", "application/recordio")
+		w.Header().Set("Message-Content-Type", "application/json; charset=utf-8")
+		rw := recordio.NewWriter(w, recordio.FlushEach())
+		for _, e := range []string{
+			`{"type":"SUBSCRIBED","subscribed":{"framework_id":{"value":"f1"}}}`,
+			`{"type":"HEARTBEAT"}`,
+		} {
+			rw.WriteFrame([]byte(e))
+		}
+	}))
+	defer ts.Close()
+
+	c := New(Endpoint(ts.URL), Codec(codecs.ByMediaType[codecs.MediaTypeJSON]))
+	resp, err := c.Send(client.RequestSingleton(&scheduler.Call{Type: scheduler.Call_SUBSCRIBE}), client.ResponseClassStreaming)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Close()
+	var types []scheduler.Event_Type
+	for {
+		var e scheduler.Event
+		if err := resp.Decode(&e); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, e.Type)
+	}
+	if len(types) != 2 || types[0] != scheduler.Event_SUBSCRIBED || types[1] != scheduler.Event_HEARTBEAT {
+		t.Fatalf("unexpected events %v", types)
+	}
+}
+
+func TestClient_DefaultHeaders(t *testing.T) {
+	var seen []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	c := New(Endpoint(ts.URL), Codec(codecs.ByMediaType[codecs.MediaTypeJSON]))
+	undo := c.With(UserAgent("example/1.0"), SetDefaultHeader("x-trace-id", "t1"))
+	c.With(UserAgent("example/1.1"))
+	send := func(opts ...RequestOpt) {
+		resp, err := c.Send(client.RequestSingleton(&scheduler.Call{Type: scheduler.Call_REVIVE}), client.ResponseClassAuto, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp != nil {
+			resp.Close()
+		}
+	}
+	send(Header("X-Request-Id", "r1"))
+	send()
+	c.With(undo)
+	send()
+
+	for i, tc := range []struct {
+		ua    string
+		trace []string
+	}{
+		{"example/1.1", []string{"t1"}},
+		{"example/1.1", []string{"t1"}},
+		{"Go-http-client/1.1", nil},
+	} {
+		if ua := seen[i].Get("User-Agent"); ua != tc.ua {
+			t.Errorf("request %d: expected User-Agent %q instead of %q", i, tc.ua, ua)
+		}
+		if trace := seen[i]["X-Trace-Id"]; !reflect.DeepEqual(trace, tc.trace) {
+			t.Errorf("request %d: expected X-Trace-Id %v instead of %v", i, tc.trace, trace)
+		}
+	}
+	if id := seen[1].Get("X-Request-Id"); id != "" {
+		t.Errorf("request option leaked into the headers of the client: %q", id)
+	}
+}
+
+func TestClient_ErrorDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Status") == "204" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, "Failed to validate scheduler::Call: Expecting 'framework_id' to be present\n")
+	}))
+	defer ts.Close()
+
+	c := New(Endpoint(ts.URL), Codec(codecs.ByMediaType[codecs.MediaTypeJSON]))
+	send := func(opts ...RequestOpt) error {
+		resp, err := c.Send(client.RequestSingleton(&scheduler.Call{Type: scheduler.Call_REVIVE}), client.ResponseClassAuto, opts...)
+		if resp != nil {
+			resp.Close()
+		}
+		return err
+	}
+	err := send()
+	if !apierrors.Is(err, apierrors.ErrMalformedCall) {
+		t.Fatalf("expected a malformed call error instead of %v", err)
+	}
+	const details = "Failed to validate scheduler::Call: Expecting 'framework_id' to be present"
+	if d := err.(*apierrors.Error).Details(); d != details {
+		t.Fatalf("expected details %q instead of %q", details, d)
+	}
+	if err = send(Header("X-Status", "204")); err == nil || err.Error() != "unexpected mesos HTTP response code: 204" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestMatchesMediaType(t *testing.T) {
+	for ti, tc := range []struct {
+		ct   string
+		want bool
+	}{
+		{"application/json", true},
+		{"application/json; charset=utf-8", true},
+		{"Application/JSON", true},
+		{"application/x-protobuf", false},
+		{"", false},
+	} {
+		if got := matchesMediaType(tc.ct, codecs.MediaTypeJSON); got != tc.want {
+			t.Errorf("test case %d failed: expected %v instead of %v", ti, tc.want, got)
+		}
+	}
+}
+
+func TestClient_NegotiateCodec(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// ignore the requested (protobuf) media type
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"type":"GET_HEALTH","get_health":{"healthy":true}}`)
+	}))
+	defer ts.Close()
+
+	for ti, tc := range []struct {
+		opts     []Opt
+		wantsErr bool
+	}{
+		{nil, false},
+		{[]Opt{CodecRegistry(nil)}, true},
+	} {
+		c := New(append([]Opt{Endpoint(ts.URL)}, tc.opts...)...)
+		resp, err := c.Send(client.RequestSingleton(&master.Call{Type: master.Call_GET_HEALTH}), client.ResponseClassSingleton)
+		if tc.wantsErr {
+			if _, ok := err.(ProtocolError); !ok {
+				t.Errorf("test case %d failed: expected protocol error instead of %v", ti, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test case %d failed: unexpected error %v", ti, err)
+		}
+		var r master.Response
+		err = resp.Decode(&r)
+		resp.Close()
+		if err != nil || !r.GetGetHealth().GetHealthy() {
+			t.Errorf("test case %d failed: unexpected response %v (err=%v)", ti, r, err)
+		}
+	}
+}
+
+type badMessage struct{ encoding.Marshaler }
+
+func (badMessage) Marshal() ([]byte, error) { return nil, errors.New("bad message") }
+func (badMessage) Reset()                   {}
+func (badMessage) String() string           { return "bad message" }
+func (badMessage) ProtoMessage()            {}
+
+func TestClient_StreamRequestBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("expected unknown content length instead of %d", r.ContentLength)
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		var call scheduler.Call
+		if err = call.Unmarshal(b); err != nil || call.GetAccept() == nil || len(call.GetAccept().GetOfferIDs()) != 1000 {
+			t.Errorf("unexpected call %v (err=%v)", call.Type, err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	accept := &scheduler.Call_Accept{}
+	for i := 0; i < 1000; i++ {
+		accept.OfferIDs = append(accept.OfferIDs, mesos.OfferID{Value: strconv.Itoa(i)})
+	}
+	c := New(Endpoint(ts.URL), StreamRequestBody(true))
+	resp, err := c.Send(client.RequestSingleton(&scheduler.Call{Type: scheduler.Call_ACCEPT, Accept: accept}), client.ResponseClassNoData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp != nil {
+		resp.Close()
+	}
+
+	// encoding errors are reported by Send
+	if _, err = c.Send(client.RequestSingleton(badMessage{}), client.ResponseClassNoData); err == nil || !strings.Contains(err.Error(), "bad message") {
+		t.Fatalf("expected encoding error instead of %v", err)
+	}
+}
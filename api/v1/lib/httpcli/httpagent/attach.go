@@ -0,0 +1,203 @@
+package httpagent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/agent/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+)
+
+// Output is the output of a container process, as streamed by the agent. Callers should consume Stdout
+// and Stderr concurrently (and both to completion) since a blocked reader stalls the entire stream,
+// similar to exec.Cmd.StdoutPipe. Both readers yield io.EOF once the output stream ends normally.
+type Output struct {
+	Stdout io.Reader
+	Stderr io.Reader
+
+	resp mesos.Response
+	done chan struct{}
+	err  error
+}
+
+// Close terminates the output stream.
+func (o *Output) Close() error {
+	return o.resp.Close()
+}
+
+// Wait blocks until the output stream ends and returns the error (if any) that ended it; a stream that
+// ends normally yields a nil error.
+func (o *Output) Wait() error {
+	<-o.done
+	return o.err
+}
+
+// AttachContainerOutput attaches to the output of the container's process; the caller is responsible for
+// closing the returned Output.
+func (c *Client) AttachContainerOutput(ctx context.Context, cid mesos.ContainerID) (*Output, error) {
+	return c.output(ctx, calls.AttachContainerOutput(cid))
+}
+
+// LaunchNestedContainerSession launches a nested container whose lifetime is tied to the returned Output:
+// the agent kills the container once the output stream is closed. The caller is responsible for closing
+// the returned Output.
+func (c *Client) LaunchNestedContainerSession(ctx context.Context, cid mesos.ContainerID, cmd *mesos.CommandInfo, ci *mesos.ContainerInfo) (*Output, error) {
+	return c.output(ctx, calls.LaunchNestedContainerSession(cid, cmd, ci))
+}
+
+func (c *Client) output(ctx context.Context, call *agent.Call) (*Output, error) {
+	resp, err := c.sender.Send(ctx, calls.NonStreaming(call))
+	if err != nil {
+		if resp != nil {
+			resp.Close()
+		}
+		return nil, err
+	}
+	var (
+		stdoutR, stdoutW = io.Pipe()
+		stderrR, stderrW = io.Pipe()
+		o                = &Output{Stdout: stdoutR, Stderr: stderrR, resp: resp, done: make(chan struct{})}
+	)
+	go func() {
+		defer close(o.done)
+		o.err = CopyProcessIO(resp, stdoutW, stderrW)
+		stdoutW.CloseWithError(o.err)
+		stderrW.CloseWithError(o.err)
+	}()
+	return o, nil
+}
+
+// CopyProcessIO decodes ProcessIO messages until the decoder is exhausted, copying the STDOUT and STDERR
+// data to the corresponding writers; control messages are ignored. Returns nil if the decoder yields io.EOF.
+func CopyProcessIO(d encoding.Decoder, stdout, stderr io.Writer) error {
+	for {
+		var pio agent.ProcessIO
+		if err := d.Decode(&pio); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if pio.GetType() != agent.ProcessIO_DATA {
+			continue
+		}
+		var (
+			w    io.Writer
+			data = pio.GetData()
+		)
+		switch data.GetType() {
+		case agent.ProcessIO_Data_STDOUT:
+			w = stdout
+		case agent.ProcessIO_Data_STDERR:
+			w = stderr
+		default:
+			continue
+		}
+		if _, err := w.Write(data.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// ErrInputClosed is returned when writing to an Input that has been closed.
+var ErrInputClosed = errors.New("container input stream is closed")
+
+// Input is a stream of input for a container process. Data written to an Input is delivered to the
+// process's stdin. Close must be invoked to end the stream and release its resources.
+type Input struct {
+	mu     sync.Mutex
+	calls  chan *agent.Call
+	closed bool
+	done   chan struct{}
+	err    error
+}
+
+// AttachContainerInput attaches to the input of the container's process. The input is streamed to the
+// agent in the body of a single, long-lived request.
+func (c *Client) AttachContainerInput(ctx context.Context, cid mesos.ContainerID) (*Input, error) {
+	in := &Input{
+		calls: make(chan *agent.Call),
+		done:  make(chan struct{}),
+	}
+	req := calls.FromChan(in.calls).Push(calls.AttachContainerInput(cid))
+	go func() {
+		defer close(in.done)
+		resp, err := c.sender.Send(ctx, req)
+		if resp != nil {
+			resp.Close()
+		}
+		in.err = err
+	}()
+	return in, nil
+}
+
+// send delivers the call to the input stream, unless the stream has ended.
+func (in *Input) send(call *agent.Call) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.closed {
+		return ErrInputClosed
+	}
+	select {
+	case in.calls <- call:
+		return nil
+	case <-in.done:
+		if in.err != nil {
+			return in.err
+		}
+		return ErrInputClosed
+	}
+}
+
+// Write sends the data to the stdin of the process.
+func (in *Input) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		// empty data signals EOF to the agent; see Close
+		return 0, nil
+	}
+	// the call is encoded asynchronously, so don't retain the caller's buffer
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := in.send(calls.AttachContainerInputData(data)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Resize informs the process that the size of its TTY window has changed.
+func (in *Input) Resize(rows, columns uint32) error {
+	return in.send(calls.AttachContainerInputTTY(&mesos.TTYInfo{
+		WindowSize: &mesos.TTYInfo_WindowSize{Rows: rows, Columns: columns},
+	}))
+}
+
+// Heartbeat informs the agent that the input stream is alive and that the next heartbeat should be
+// expected within the given interval. Idle streams should heartbeat regularly since intermediaries may
+// otherwise time out the connection.
+func (in *Input) Heartbeat(interval time.Duration) error {
+	return in.send(calls.AttachContainerInputHeartbeat(interval))
+}
+
+// Close signals EOF to the stdin of the process, ends the input stream, and waits for the agent's response.
+func (in *Input) Close() error {
+	err := in.send(calls.AttachContainerInputData(nil))
+	in.mu.Lock()
+	if !in.closed {
+		in.closed = true
+		close(in.calls)
+	}
+	in.mu.Unlock()
+	<-in.done
+	if in.err != nil {
+		return in.err
+	}
+	if err == ErrInputClosed {
+		err = nil
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package httpagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+)
+
+func processIO(t agent.ProcessIO_Data_Type, data string) agent.ProcessIO {
+	return agent.ProcessIO{
+		Type: agent.ProcessIO_DATA,
+		Data: &agent.ProcessIO_Data{Type: t, Data: []byte(data)},
+	}
+}
+
+func TestClient_AttachContainerOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var call agent.Call
+		if err := json.NewDecoder(r.Body).Decode(&call); err != nil || call.GetType() != agent.Call_ATTACH_CONTAINER_OUTPUT {
+			t.Errorf("unexpected call %v, %v", call, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/recordio")
+		w.Header().Set("Message-Content-Type", "application/json")
+		for _, pio := range []agent.ProcessIO{
+			processIO(agent.ProcessIO_Data_STDOUT, "hello "),
+			processIO(agent.ProcessIO_Data_STDERR, "oops"),
+			{Type: agent.ProcessIO_CONTROL, Control: &agent.ProcessIO_Control{Type: agent.ProcessIO_Control_HEARTBEAT}},
+			processIO(agent.ProcessIO_Data_STDOUT, "world"),
+		} {
+			b, err := json.Marshal(&pio)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			fmt.Fprintf(w, "%d\n%s", len(b), b)
+		}
+	}))
+	defer srv.Close()
+
+	cli := NewClient(NewSender(httpcli.New(
+		httpcli.Endpoint(srv.URL),
+		httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+	).Send))
+	out, err := cli.AttachContainerOutput(context.Background(), mesos.NewContainerID("c1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer out.Close()
+
+	var (
+		wg             sync.WaitGroup
+		stdout, stderr []byte
+		outErr, errErr error
+	)
+	wg.Add(2)
+	go func() { defer wg.Done(); stdout, outErr = ioutil.ReadAll(out.Stdout) }()
+	go func() { defer wg.Done(); stderr, errErr = ioutil.ReadAll(out.Stderr) }()
+	wg.Wait()
+
+	if outErr != nil || errErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", outErr, errErr)
+	}
+	if string(stdout) != "hello world" || string(stderr) != "oops" {
+		t.Fatalf("unexpected output: stdout=%q stderr=%q", stdout, stderr)
+	}
+	if err = out.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_AttachContainerInput(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []agent.Call
+		srv      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ct := r.Header.Get("Content-Type"); ct != "application/recordio" {
+				t.Errorf("unexpected content type %q", ct)
+			}
+			rd := recordio.NewReader(r.Body)
+			for {
+				frame, err := rd.ReadFrame()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Errorf("failed to read frame: %v", err)
+					return
+				}
+				var call agent.Call
+				if err = json.Unmarshal(frame, &call); err != nil {
+					t.Errorf("failed to decode call: %v", err)
+					return
+				}
+				mu.Lock()
+				received = append(received, call)
+				mu.Unlock()
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	)
+	defer srv.Close()
+
+	cli := NewClient(NewSender(httpcli.New(
+		httpcli.Endpoint(srv.URL),
+		httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+	).Send))
+	in, err := cli.AttachContainerInput(context.Background(), mesos.NewContainerID("c1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = io.WriteString(in, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = in.Resize(24, 80); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = in.Heartbeat(time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = in.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = in.Write([]byte("late")); err != ErrInputClosed {
+		t.Fatalf("expected ErrInputClosed instead of %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 5 {
+		t.Fatalf("expected 5 calls instead of %d: %v", len(received), received)
+	}
+	if id := received[0].GetAttachContainerInput().GetContainerID().GetValue(); id != "c1" {
+		t.Fatalf("expected attachment to container c1 instead of %q", id)
+	}
+	if data := received[1].GetAttachContainerInput().GetProcessIO().GetData().GetData(); string(data) != "hello" {
+		t.Fatalf("expected stdin data instead of %q", data)
+	}
+	if ws := received[2].GetAttachContainerInput().GetProcessIO().GetControl().GetTTYInfo().GetWindowSize(); ws.GetRows() != 24 {
+		t.Fatalf("unexpected window size %v", ws)
+	}
+	if hb := received[3].GetAttachContainerInput().GetProcessIO().GetControl().GetHeartbeat(); hb == nil {
+		t.Fatalf("expected heartbeat")
+	}
+	if data := received[4].GetAttachContainerInput().GetProcessIO().GetData(); data == nil || len(data.Data) != 0 {
+		t.Fatalf("expected EOF instead of %v", data)
+	}
+}
@@ -0,0 +1,242 @@
+package httpagent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/agent/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+)
+
+// API is implemented by Client. Code that depends on API, rather than *Client, may be unit-tested w/o
+// a Mesos agent, see mesostest/mock.
+type API interface {
+	Sender() calls.Sender
+	GetHealth(ctx context.Context) (bool, error)
+	GetFlags(ctx context.Context) ([]mesos.Flag, error)
+	GetVersion(ctx context.Context) (mesos.VersionInfo, error)
+	GetMetrics(ctx context.Context, timeout *time.Duration) ([]mesos.Metric, error)
+	GetLoggingLevel(ctx context.Context) (uint32, error)
+	SetLoggingLevel(ctx context.Context, level uint32, d time.Duration) error
+	ListFiles(ctx context.Context, path string) ([]mesos.FileInfo, error)
+	ReadFile(ctx context.Context, path string, offset uint64, length *uint64) (size uint64, data []byte, err error)
+	GetState(ctx context.Context) (*agent.Response_GetState, error)
+	GetContainers(ctx context.Context) ([]agent.Response_GetContainers_Container, error)
+	GetFrameworks(ctx context.Context) (*agent.Response_GetFrameworks, error)
+	GetExecutors(ctx context.Context) (*agent.Response_GetExecutors, error)
+	GetTasks(ctx context.Context) (*agent.Response_GetTasks, error)
+	GetAgent(ctx context.Context) (*mesos.AgentInfo, error)
+	LaunchNestedContainer(ctx context.Context, cid mesos.ContainerID, cmd *mesos.CommandInfo, ci *mesos.ContainerInfo) error
+	WaitNestedContainer(ctx context.Context, cid mesos.ContainerID) (*agent.Response_WaitNestedContainer, error)
+	KillNestedContainer(ctx context.Context, cid mesos.ContainerID, signal *int32) error
+	RemoveNestedContainer(ctx context.Context, cid mesos.ContainerID) error
+	LaunchContainer(ctx context.Context, cid mesos.ContainerID, cmd *mesos.CommandInfo, ci *mesos.ContainerInfo, rs []mesos.Resource) error
+	WaitContainer(ctx context.Context, cid mesos.ContainerID) (*agent.Response_WaitContainer, error)
+	KillContainer(ctx context.Context, cid mesos.ContainerID, signal *int32) error
+	RemoveContainer(ctx context.Context, cid mesos.ContainerID) error
+	AttachContainerOutput(ctx context.Context, cid mesos.ContainerID) (*Output, error)
+	LaunchNestedContainerSession(ctx context.Context, cid mesos.ContainerID, cmd *mesos.CommandInfo, ci *mesos.ContainerInfo) (*Output, error)
+	AttachContainerInput(ctx context.Context, cid mesos.ContainerID) (*Input, error)
+	TaskSandbox(ctx context.Context, taskID mesos.TaskID) (string, error)
+	Tail(ctx context.Context, taskID mesos.TaskID, name string, w io.Writer, opts ...TailOpt) error
+	TailFile(ctx context.Context, file string, w io.Writer, opts ...TailOpt) error
+	TaskContainer(ctx context.Context, taskID mesos.TaskID) (mesos.ContainerID, error)
+	Exec(ctx context.Context, taskID mesos.TaskID, cmd *mesos.CommandInfo, opts ...ExecOpt) (*agent.Response_WaitNestedContainer, error)
+}
+
+var _ = API(&Client{})
+
+// Client is a typed client for the v1 agent operator API: each func issues a single call and decodes
+// the response of the expected type. Authentication and codec options are configured via the httpcli.Client
+// that backs the Sender. For example:
+//
+//	cli := httpagent.NewClient(httpagent.NewSender(httpcli.New(
+//		httpcli.Endpoint(uri),
+//		httpcli.Do(httpcli.With(httpcli.BasicAuth(username, password))),
+//	).Send))
+//	state, err := cli.GetState(ctx)
+type Client struct {
+	sender calls.Sender
+}
+
+// NewClient returns a Client that sends operator calls via the given Sender.
+func NewClient(s calls.Sender) *Client {
+	return &Client{sender: s}
+}
+
+// Sender returns the Sender used by the Client, for example to issue calls that the Client doesn't
+// support explicitly.
+func (c *Client) Sender() calls.Sender { return c.sender }
+
+// call sends the given call and decodes a response of the expected type.
+func (c *Client) call(ctx context.Context, call *agent.Call, expected agent.Response_Type) (*agent.Response, error) {
+	resp, err := c.sender.Send(ctx, calls.NonStreaming(call))
+	if resp != nil {
+		defer resp.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r agent.Response
+	if err = resp.Decode(&r); err != nil {
+		return nil, err
+	}
+	if t := r.GetType(); t != expected {
+		return nil, httpcli.ProtocolError(fmt.Sprintf("unexpected agent.Response type %v, expected %v", t, expected))
+	}
+	return &r, nil
+}
+
+// callNoData sends the given call, for which no response data is expected.
+func (c *Client) callNoData(ctx context.Context, call *agent.Call) error {
+	resp, err := c.sender.Send(ctx, calls.NonStreaming(call))
+	if resp != nil {
+		resp.Close()
+	}
+	return err
+}
+
+// GetHealth returns true if the agent is healthy.
+func (c *Client) GetHealth(ctx context.Context) (bool, error) {
+	r, err := c.call(ctx, calls.GetHealth(), agent.Response_GET_HEALTH)
+	return r.GetGetHealth().GetHealthy(), err
+}
+
+// GetFlags returns the agent's overall flag configuration.
+func (c *Client) GetFlags(ctx context.Context) ([]mesos.Flag, error) {
+	r, err := c.call(ctx, calls.GetFlags(), agent.Response_GET_FLAGS)
+	return r.GetGetFlags().GetFlags(), err
+}
+
+// GetVersion returns the agent's version information.
+func (c *Client) GetVersion(ctx context.Context) (mesos.VersionInfo, error) {
+	r, err := c.call(ctx, calls.GetVersion(), agent.Response_GET_VERSION)
+	return r.GetGetVersion().GetVersionInfo(), err
+}
+
+// GetMetrics returns a snapshot of the agent's metrics. If timeout is non-nil then it bounds the amount
+// of time that the agent spends collecting metrics; some metrics may be omitted if it's exceeded.
+func (c *Client) GetMetrics(ctx context.Context, timeout *time.Duration) ([]mesos.Metric, error) {
+	r, err := c.call(ctx, calls.GetMetrics(timeout), agent.Response_GET_METRICS)
+	return r.GetGetMetrics().GetMetrics(), err
+}
+
+// GetLoggingLevel returns the agent's logging level.
+func (c *Client) GetLoggingLevel(ctx context.Context) (uint32, error) {
+	r, err := c.call(ctx, calls.GetLoggingLevel(), agent.Response_GET_LOGGING_LEVEL)
+	return r.GetGetLoggingLevel().GetLevel(), err
+}
+
+// SetLoggingLevel sets the agent's logging level for the given duration.
+func (c *Client) SetLoggingLevel(ctx context.Context, level uint32, d time.Duration) error {
+	return c.callNoData(ctx, calls.SetLoggingLevel(level, d))
+}
+
+// ListFiles returns the file listing of a directory on the agent.
+func (c *Client) ListFiles(ctx context.Context, path string) ([]mesos.FileInfo, error) {
+	r, err := c.call(ctx, calls.ListFiles(path), agent.Response_LIST_FILES)
+	return r.GetListFiles().GetFileInfos(), err
+}
+
+// ReadFile reads data from a file on the agent, starting at the given offset. If length is non-nil then
+// at most length bytes are read. The size of the file is returned along with the data that was read.
+func (c *Client) ReadFile(ctx context.Context, path string, offset uint64, length *uint64) (size uint64, data []byte, err error) {
+	call := calls.ReadFile(path, offset)
+	if length != nil {
+		call = calls.ReadFileWithLength(path, offset, *length)
+	}
+	r, err := c.call(ctx, call, agent.Response_READ_FILE)
+	return r.GetReadFile().GetSize(), r.GetReadFile().GetData(), err
+}
+
+// GetState returns the state of the agent: its frameworks, executors, and tasks.
+func (c *Client) GetState(ctx context.Context) (*agent.Response_GetState, error) {
+	r, err := c.call(ctx, calls.GetState(), agent.Response_GET_STATE)
+	return r.GetGetState(), err
+}
+
+// GetContainers returns information about the containers running on the agent.
+func (c *Client) GetContainers(ctx context.Context) ([]agent.Response_GetContainers_Container, error) {
+	r, err := c.call(ctx, calls.GetContainers(), agent.Response_GET_CONTAINERS)
+	return r.GetGetContainers().GetContainers(), err
+}
+
+// GetFrameworks returns information about the frameworks known to the agent.
+func (c *Client) GetFrameworks(ctx context.Context) (*agent.Response_GetFrameworks, error) {
+	r, err := c.call(ctx, calls.GetFrameworks(), agent.Response_GET_FRAMEWORKS)
+	return r.GetGetFrameworks(), err
+}
+
+// GetExecutors returns information about the executors known to the agent.
+func (c *Client) GetExecutors(ctx context.Context) (*agent.Response_GetExecutors, error) {
+	r, err := c.call(ctx, calls.GetExecutors(), agent.Response_GET_EXECUTORS)
+	return r.GetGetExecutors(), err
+}
+
+// GetTasks returns information about the tasks known to the agent.
+func (c *Client) GetTasks(ctx context.Context) (*agent.Response_GetTasks, error) {
+	r, err := c.call(ctx, calls.GetTasks(), agent.Response_GET_TASKS)
+	return r.GetGetTasks(), err
+}
+
+// GetAgent returns information about the agent.
+func (c *Client) GetAgent(ctx context.Context) (*mesos.AgentInfo, error) {
+	r, err := c.call(ctx, calls.GetAgent(), agent.Response_GET_AGENT)
+	return r.GetGetAgent().GetAgentInfo(), err
+}
+
+// LaunchNestedContainer launches a container that's nested within the parent of the given ContainerID
+// (see mesos.ContainerID.Child); the parent is typically the container of an executor.
+func (c *Client) LaunchNestedContainer(ctx context.Context, cid mesos.ContainerID, cmd *mesos.CommandInfo, ci *mesos.ContainerInfo) error {
+	return c.callNoData(ctx, calls.LaunchNestedContainer(cid, cmd, ci))
+}
+
+// WaitNestedContainer blocks until the nested container terminates and returns its termination status.
+// Callers should take care that neither the context nor the client's HTTP timeout prematurely aborts the call.
+func (c *Client) WaitNestedContainer(ctx context.Context, cid mesos.ContainerID) (*agent.Response_WaitNestedContainer, error) {
+	r, err := c.call(ctx, calls.WaitNestedContainer(cid), agent.Response_WAIT_NESTED_CONTAINER)
+	return r.GetWaitNestedContainer(), err
+}
+
+// KillNestedContainer sends a signal to the nested container; if signal is nil then the container is
+// sent SIGKILL.
+func (c *Client) KillNestedContainer(ctx context.Context, cid mesos.ContainerID, signal *int32) error {
+	call := calls.KillNestedContainer(cid)
+	call.KillNestedContainer.Signal = signal
+	return c.callNoData(ctx, call)
+}
+
+// RemoveNestedContainer removes the runtime artifacts (e.g. the sandbox) of a terminated nested container.
+func (c *Client) RemoveNestedContainer(ctx context.Context, cid mesos.ContainerID) error {
+	return c.callNoData(ctx, calls.RemoveNestedContainer(cid))
+}
+
+// LaunchContainer launches a standalone container, i.e. one that's not managed by an executor, w/ the
+// given resources (see the resources package for resource builders).
+func (c *Client) LaunchContainer(ctx context.Context, cid mesos.ContainerID, cmd *mesos.CommandInfo, ci *mesos.ContainerInfo, rs []mesos.Resource) error {
+	return c.callNoData(ctx, calls.LaunchContainer(cid, cmd, ci, rs))
+}
+
+// WaitContainer blocks until the standalone container terminates and returns its termination status.
+// Callers should take care that neither the context nor the client's HTTP timeout prematurely aborts the call.
+func (c *Client) WaitContainer(ctx context.Context, cid mesos.ContainerID) (*agent.Response_WaitContainer, error) {
+	r, err := c.call(ctx, calls.WaitContainer(cid), agent.Response_WAIT_CONTAINER)
+	return r.GetWaitContainer(), err
+}
+
+// KillContainer sends a signal to the standalone container; if signal is nil then the container is
+// sent SIGKILL.
+func (c *Client) KillContainer(ctx context.Context, cid mesos.ContainerID, signal *int32) error {
+	call := calls.KillContainer(cid)
+	call.KillContainer.Signal = signal
+	return c.callNoData(ctx, call)
+}
+
+// RemoveContainer removes the runtime artifacts (e.g. the sandbox) of a terminated standalone container.
+func (c *Client) RemoveContainer(ctx context.Context, cid mesos.ContainerID) error {
+	return c.callNoData(ctx, calls.RemoveContainer(cid))
+}
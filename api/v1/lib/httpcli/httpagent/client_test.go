@@ -0,0 +1,207 @@
+package httpagent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/builder"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestClient(t *testing.T) {
+	var (
+		received []agent.Call_Type
+		srv      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var call agent.Call
+			if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+				t.Errorf("failed to decode call: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received = append(received, call.GetType())
+
+			var resp agent.Response
+			switch call.GetType() {
+			case agent.Call_GET_HEALTH:
+				resp = agent.Response{
+					Type:      agent.Response_GET_HEALTH,
+					GetHealth: &agent.Response_GetHealth{Healthy: true},
+				}
+			case agent.Call_GET_CONTAINERS:
+				resp = agent.Response{
+					Type: agent.Response_GET_CONTAINERS,
+					GetContainers: &agent.Response_GetContainers{Containers: []agent.Response_GetContainers_Container{
+						{ContainerID: mesos.ContainerID{Value: "c1"}},
+					}},
+				}
+			case agent.Call_SET_LOGGING_LEVEL:
+				w.WriteHeader(http.StatusAccepted)
+				return
+			default:
+				// reply w/ the wrong response type
+				resp = agent.Response{Type: agent.Response_GET_FLAGS, GetFlags: &agent.Response_GetFlags{}}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(&resp)
+		}))
+		ctx = context.Background()
+		cli = NewClient(NewSender(httpcli.New(
+			httpcli.Endpoint(srv.URL),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+		).Send))
+	)
+	defer srv.Close()
+
+	healthy, err := cli.GetHealth(ctx)
+	if err != nil || !healthy {
+		t.Fatalf("expected healthy agent instead of %v, %v", healthy, err)
+	}
+	containers, err := cli.GetContainers(ctx)
+	if err != nil || len(containers) != 1 || containers[0].ContainerID.Value != "c1" {
+		t.Fatalf("expected container c1 instead of %v, %v", containers, err)
+	}
+	if err = cli.SetLoggingLevel(ctx, 1, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = cli.GetState(ctx); err == nil {
+		t.Fatalf("expected error for mismatched response type")
+	}
+	expected := []agent.Call_Type{
+		agent.Call_GET_HEALTH,
+		agent.Call_GET_CONTAINERS,
+		agent.Call_SET_LOGGING_LEVEL,
+		agent.Call_GET_STATE,
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Fatalf("expected calls %v instead of %v", expected, received)
+		}
+	}
+}
+
+// newTestClient returns a Client that sends calls to a test server; the server replies to each call
+// w/ the response generated by f, or else 202 Accepted if f returns nil.
+func newTestClient(t *testing.T, f func(*agent.Call) *agent.Response) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var call agent.Call
+		if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+			t.Errorf("failed to decode call: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := f(&call)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	return NewClient(NewSender(httpcli.New(
+		httpcli.Endpoint(srv.URL),
+		httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+	).Send)), srv.Close
+}
+
+func TestClient_NestedContainer(t *testing.T) {
+	var (
+		parent   = mesos.NewContainerID("executor")
+		cid      = parent.Child("debug")
+		signal   = int32(15)
+		received []*agent.Call
+	)
+	cli, closer := newTestClient(t, func(call *agent.Call) *agent.Response {
+		received = append(received, call)
+		if call.GetType() == agent.Call_WAIT_NESTED_CONTAINER {
+			status := int32(0)
+			return &agent.Response{
+				Type:                agent.Response_WAIT_NESTED_CONTAINER,
+				WaitNestedContainer: &agent.Response_WaitNestedContainer{ExitStatus: &status},
+			}
+		}
+		return nil
+	})
+	defer closer()
+	ctx := context.Background()
+
+	shell := "sleep 1"
+	if err := cli.LaunchNestedContainer(ctx, cid, &mesos.CommandInfo{Value: &shell}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.KillNestedContainer(ctx, cid, &signal); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wait, err := cli.WaitNestedContainer(ctx, cid)
+	if err != nil || wait.GetExitStatus() != 0 {
+		t.Fatalf("unexpected wait result %v, %v", wait, err)
+	}
+	if err = cli.RemoveNestedContainer(ctx, cid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 4 {
+		t.Fatalf("expected 4 calls instead of %d", len(received))
+	}
+	if id := received[0].GetLaunchNestedContainer().GetContainerID(); !id.Equal(&cid) {
+		t.Fatalf("expected launch of container %v instead of %v", cid, id)
+	}
+	if s := received[1].GetKillNestedContainer().GetSignal(); s != signal {
+		t.Fatalf("expected signal %d instead of %d", signal, s)
+	}
+}
+
+func TestClient_Container(t *testing.T) {
+	var (
+		cid      = mesos.NewContainerID("standalone")
+		received []agent.Call_Type
+	)
+	cli, closer := newTestClient(t, func(call *agent.Call) *agent.Response {
+		received = append(received, call.GetType())
+		if call.GetType() == agent.Call_WAIT_CONTAINER {
+			status := int32(9)
+			return &agent.Response{
+				Type:          agent.Response_WAIT_CONTAINER,
+				WaitContainer: &agent.Response_WaitContainer{ExitStatus: &status},
+			}
+		}
+		return nil
+	})
+	defer closer()
+	ctx := context.Background()
+
+	shell := "sleep 100"
+	ci, _ := builder.MesosContainer().DockerImage("busybox").Build()
+	rs := []mesos.Resource{resources.NewCPUs(0.1).Resource, resources.NewMemory(32).Resource}
+	if err := cli.LaunchContainer(ctx, cid, &mesos.CommandInfo{Value: &shell}, ci, rs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.KillContainer(ctx, cid, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wait, err := cli.WaitContainer(ctx, cid)
+	if err != nil || wait.GetExitStatus() != 9 {
+		t.Fatalf("unexpected wait result %v, %v", wait, err)
+	}
+	if err = cli.RemoveContainer(ctx, cid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []agent.Call_Type{
+		agent.Call_LAUNCH_CONTAINER,
+		agent.Call_KILL_CONTAINER,
+		agent.Call_WAIT_CONTAINER,
+		agent.Call_REMOVE_CONTAINER,
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+}
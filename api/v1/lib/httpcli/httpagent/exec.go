@@ -0,0 +1,197 @@
+package httpagent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+)
+
+// DefaultExecHeartbeat is the default interval at which Exec heartbeats the input stream of the process.
+const DefaultExecHeartbeat = 30 * time.Second
+
+type (
+	execConfig struct {
+		stdin     io.Reader
+		stdout    io.Writer
+		stderr    io.Writer
+		tty       *mesos.TTYInfo
+		resize    <-chan mesos.TTYInfo_WindowSize
+		heartbeat time.Duration
+		container *mesos.ContainerInfo
+	}
+
+	// ExecOpt is a functional option type for Exec.
+	ExecOpt func(*execConfig)
+)
+
+// ExecStdin attaches r to the stdin of the process; the process receives EOF once r is exhausted.
+func ExecStdin(r io.Reader) ExecOpt { return func(c *execConfig) { c.stdin = r } }
+
+// ExecStdout copies the stdout of the process to w; a process w/ a TTY writes all of its output to stdout.
+func ExecStdout(w io.Writer) ExecOpt { return func(c *execConfig) { c.stdout = w } }
+
+// ExecStderr copies the stderr of the process to w.
+func ExecStderr(w io.Writer) ExecOpt { return func(c *execConfig) { c.stderr = w } }
+
+// ExecTTY allocates a TTY of the given size for the process.
+func ExecTTY(rows, columns uint32) ExecOpt {
+	return func(c *execConfig) {
+		c.tty = &mesos.TTYInfo{WindowSize: &mesos.TTYInfo_WindowSize{Rows: rows, Columns: columns}}
+	}
+}
+
+// ExecResize forwards window size changes that are received from ch to the TTY of the process; it has no
+// effect unless both ExecTTY and ExecStdin are specified.
+func ExecResize(ch <-chan mesos.TTYInfo_WindowSize) ExecOpt {
+	return func(c *execConfig) { c.resize = ch }
+}
+
+// ExecHeartbeat overrides DefaultExecHeartbeat.
+func ExecHeartbeat(d time.Duration) ExecOpt { return func(c *execConfig) { c.heartbeat = d } }
+
+// ExecContainer specifies the ContainerInfo of the debug container, for example to run it from an image;
+// the TTYInfo of ci is overridden by ExecTTY.
+func ExecContainer(ci *mesos.ContainerInfo) ExecOpt { return func(c *execConfig) { c.container = ci } }
+
+// TaskContainer returns the ID of the container that runs the task, as reported by the latest status of
+// the task that carries a container status. Tasks that don't report a container status (e.g. those of
+// older agents) are matched to the container of their executor.
+func (c *Client) TaskContainer(ctx context.Context, taskID mesos.TaskID) (mesos.ContainerID, error) {
+	state, err := c.GetState(ctx)
+	if err != nil {
+		return mesos.ContainerID{}, err
+	}
+	task := findTask(state.GetGetTasks(), taskID)
+	if task == nil {
+		return mesos.ContainerID{}, fmt.Errorf("task %q is not known to the agent", taskID.Value)
+	}
+	for i := len(task.Statuses) - 1; i >= 0; i-- {
+		if cid := task.Statuses[i].GetContainerStatus().GetContainerID(); cid != nil {
+			return *cid, nil
+		}
+	}
+	// tasks that are launched via the command executor share their ID w/ the executor
+	executorID := mesos.ExecutorID{Value: taskID.Value}
+	if task.ExecutorID != nil {
+		executorID = *task.ExecutorID
+	}
+	containers, err := c.GetContainers(ctx)
+	if err != nil {
+		return mesos.ContainerID{}, err
+	}
+	for i := range containers {
+		ct := &containers[i]
+		if ct.GetExecutorID().Equal(&executorID) && ct.GetFrameworkID().Equal(&task.FrameworkID) {
+			return ct.ContainerID, nil
+		}
+	}
+	return mesos.ContainerID{}, fmt.Errorf("failed to locate the container of task %q", taskID.Value)
+}
+
+// Exec runs the command in a debug container that's nested within the container of the task, similar to
+// `kubectl exec`: the debug container shares the namespaces of the task. The output of the command is
+// copied to the writers given by ExecStdout and ExecStderr (and discarded otherwise) and the reader given
+// by ExecStdin (if any) is streamed to its stdin. Exec blocks until the command exits and returns the
+// result of waiting for the debug container; the agent removes the debug container if ctx is done before
+// the command exits.
+func (c *Client) Exec(ctx context.Context, taskID mesos.TaskID, cmd *mesos.CommandInfo, opts ...ExecOpt) (*agent.Response_WaitNestedContainer, error) {
+	config := execConfig{heartbeat: DefaultExecHeartbeat, stdout: ioutil.Discard, stderr: ioutil.Discard}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&config)
+		}
+	}
+	parent, err := c.TaskContainer(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	cid, err := parent.NewChild()
+	if err != nil {
+		return nil, err
+	}
+	ci := &mesos.ContainerInfo{Type: mesos.ContainerInfo_MESOS.Enum()}
+	if config.container != nil {
+		copied := *config.container
+		ci = &copied
+	}
+	ci.TTYInfo = config.tty
+
+	out, err := c.LaunchNestedContainerSession(ctx, cid, cmd, ci)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	if config.stdin != nil {
+		in, err := c.AttachContainerInput(ctx, cid)
+		if err != nil {
+			return nil, err
+		}
+		done := make(chan struct{})
+		defer func() {
+			close(done)
+			in.Close()
+		}()
+		go func() {
+			// Write fails once the input stream is closed, which ends the copy
+			if _, err := io.Copy(in, config.stdin); err == nil {
+				in.Close()
+			}
+		}()
+		go execControl(in, &config, done)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		forward = func(w io.Writer, r io.Reader) {
+			defer wg.Done()
+			if _, err := io.Copy(w, r); err != nil {
+				// unblock the other reader
+				out.Close()
+			}
+		}
+	)
+	wg.Add(2)
+	go forward(config.stdout, out.Stdout)
+	go forward(config.stderr, out.Stderr)
+	wg.Wait()
+	if err = out.Wait(); err != nil {
+		return nil, err
+	}
+	return c.WaitNestedContainer(ctx, cid)
+}
+
+// execControl heartbeats the input stream, and forwards TTY window size changes to it, until done is closed
+// or the stream fails.
+func execControl(in *Input, config *execConfig, done <-chan struct{}) {
+	resize := config.resize
+	if config.tty == nil {
+		resize = nil
+	}
+	ticker := time.NewTicker(config.heartbeat)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			err = in.Heartbeat(config.heartbeat)
+		case ws, ok := <-resize:
+			if !ok {
+				resize = nil
+				continue
+			}
+			err = in.Resize(ws.Rows, ws.Columns)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
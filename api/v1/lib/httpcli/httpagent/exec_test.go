@@ -0,0 +1,175 @@
+package httpagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+)
+
+func TestClient_Exec(t *testing.T) {
+	var (
+		frameworkID = mesos.FrameworkID{Value: "f1"}
+		executorID  = mesos.ExecutorID{Value: "legacy"}
+		taskCID     = mesos.NewContainerID("executor").Child("task")
+		stdin       = make(chan string, 1)
+		exitStatus  = int32(1 << 8)
+		shell       = "cat"
+
+		mu       sync.Mutex
+		launched *agent.Call_LaunchNestedContainerSession
+		inputs   []agent.Call
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") == "application/recordio" {
+			// the input stream: collect the calls and report the data once the client signals EOF
+			var data bytes.Buffer
+			rd := recordio.NewReader(r.Body)
+			for {
+				frame, err := rd.ReadFrame()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Errorf("failed to read frame: %v", err)
+					return
+				}
+				var call agent.Call
+				if err = json.Unmarshal(frame, &call); err != nil {
+					t.Errorf("failed to decode call: %v", err)
+					return
+				}
+				mu.Lock()
+				inputs = append(inputs, call)
+				mu.Unlock()
+				data.Write(call.GetAttachContainerInput().GetProcessIO().GetData().GetData())
+			}
+			stdin <- data.String()
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		var call agent.Call
+		if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+			t.Errorf("failed to decode call: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var resp *agent.Response
+		switch call.GetType() {
+		case agent.Call_GET_STATE:
+			resp = &agent.Response{
+				Type: agent.Response_GET_STATE,
+				GetState: &agent.Response_GetState{GetTasks: &agent.Response_GetTasks{LaunchedTasks: []mesos.Task{
+					{
+						TaskID:      mesos.TaskID{Value: "t1"},
+						FrameworkID: frameworkID,
+						Statuses: []mesos.TaskStatus{
+							{ContainerStatus: &mesos.ContainerStatus{ContainerID: &taskCID}},
+							{},
+						},
+					},
+					{TaskID: mesos.TaskID{Value: "t2"}, FrameworkID: frameworkID, ExecutorID: &executorID},
+				}}},
+			}
+		case agent.Call_GET_CONTAINERS:
+			resp = &agent.Response{
+				Type: agent.Response_GET_CONTAINERS,
+				GetContainers: &agent.Response_GetContainers{Containers: []agent.Response_GetContainers_Container{
+					{FrameworkID: &frameworkID, ExecutorID: &mesos.ExecutorID{Value: "other"}, ContainerID: mesos.NewContainerID("c1")},
+					{FrameworkID: &frameworkID, ExecutorID: &executorID, ContainerID: mesos.NewContainerID("c2")},
+				}},
+			}
+		case agent.Call_LAUNCH_NESTED_CONTAINER_SESSION:
+			mu.Lock()
+			launched = call.GetLaunchNestedContainerSession()
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/recordio")
+			w.Header().Set("Message-Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			var data string
+			select {
+			case data = <-stdin:
+			case <-time.After(5 * time.Second):
+				t.Errorf("timed out waiting for stdin")
+				return
+			}
+			for _, pio := range []agent.ProcessIO{
+				processIO(agent.ProcessIO_Data_STDOUT, "echo: "+data),
+				processIO(agent.ProcessIO_Data_STDERR, "bye"),
+			} {
+				b, _ := json.Marshal(&pio)
+				fmt.Fprintf(w, "%d\n%s", len(b), b)
+			}
+			return
+		case agent.Call_WAIT_NESTED_CONTAINER:
+			resp = &agent.Response{
+				Type:                agent.Response_WAIT_NESTED_CONTAINER,
+				WaitNestedContainer: &agent.Response_WaitNestedContainer{ExitStatus: &exitStatus},
+			}
+		default:
+			t.Errorf("unexpected call %v", call)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	var (
+		ctx = context.Background()
+		cli = NewClient(NewSender(httpcli.New(
+			httpcli.Endpoint(srv.URL),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+		).Send))
+	)
+	if cid, err := cli.TaskContainer(ctx, mesos.TaskID{Value: "t2"}); err != nil || cid.Value != "c2" {
+		t.Fatalf("expected container c2 instead of %v, %v", cid, err)
+	}
+	if _, err := cli.TaskContainer(ctx, mesos.TaskID{Value: "t3"}); err == nil {
+		t.Fatal("expected an error for an unknown task")
+	}
+
+	var stdout, stderr bytes.Buffer
+	wait, err := cli.Exec(ctx, mesos.TaskID{Value: "t1"}, &mesos.CommandInfo{Value: &shell},
+		ExecStdin(strings.NewReader("hello")),
+		ExecStdout(&stdout),
+		ExecStderr(&stderr),
+		ExecTTY(24, 80),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wait.GetExitStatus() != exitStatus {
+		t.Fatalf("expected exit status %d instead of %d", exitStatus, wait.GetExitStatus())
+	}
+	if stdout.String() != "echo: hello" || stderr.String() != "bye" {
+		t.Fatalf("unexpected output: stdout=%q stderr=%q", stdout.String(), stderr.String())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if cid := launched.ContainerID; !cid.GetParent().Equal(&taskCID) || cid.Value == "" {
+		t.Fatalf("expected a container nested within %v instead of %v", taskCID, cid)
+	}
+	if ws := launched.GetContainer().GetTTYInfo().GetWindowSize(); ws.GetRows() != 24 || ws.GetColumns() != 80 {
+		t.Fatalf("unexpected window size %v", ws)
+	}
+	if id := inputs[0].GetAttachContainerInput().GetContainerID(); !id.Equal(&launched.ContainerID) {
+		t.Fatalf("expected input to be attached to %v instead of %v", launched.ContainerID, id)
+	}
+}
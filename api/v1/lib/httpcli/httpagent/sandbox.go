@@ -0,0 +1,67 @@
+package httpagent
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+)
+
+// ExecutorSandbox returns the virtual path of the sandbox of the latest run of an executor; the path is
+// suitable for use w/ ListFiles and ReadFile.
+func ExecutorSandbox(frameworkID mesos.FrameworkID, executorID mesos.ExecutorID) string {
+	return path.Join("/frameworks", frameworkID.Value, "executors", executorID.Value, "runs", "latest")
+}
+
+// TaskSandbox locates the sandbox of the task, as reported by GET_STATE, and returns its virtual path; the
+// path is suitable for use w/ ListFiles and ReadFile. Tasks that are launched by the default executor (e.g.
+// via LAUNCH_GROUP) have their own sandbox that's nested within the executor's sandbox.
+func (c *Client) TaskSandbox(ctx context.Context, taskID mesos.TaskID) (string, error) {
+	state, err := c.GetState(ctx)
+	if err != nil {
+		return "", err
+	}
+	task := findTask(state.GetGetTasks(), taskID)
+	if task == nil {
+		return "", fmt.Errorf("task %q is not known to the agent", taskID.Value)
+	}
+	// tasks that are launched via the command executor share their ID w/ the executor
+	executorID := mesos.ExecutorID{Value: taskID.Value}
+	if task.ExecutorID != nil {
+		executorID = *task.ExecutorID
+	}
+	sandbox := ExecutorSandbox(task.FrameworkID, executorID)
+
+	executors := state.GetGetExecutors()
+	for _, es := range [][]agent.Response_GetExecutors_Executor{executors.GetExecutors(), executors.GetCompletedExecutors()} {
+		for i := range es {
+			ei := &es[i].ExecutorInfo
+			if ei.ExecutorID.Equal(&executorID) && ei.GetFrameworkID().Equal(&task.FrameworkID) {
+				if ei.GetType() == mesos.ExecutorInfo_DEFAULT {
+					sandbox = path.Join(sandbox, "tasks", taskID.Value)
+				}
+				return sandbox, nil
+			}
+		}
+	}
+	return sandbox, nil
+}
+
+func findTask(tasks *agent.Response_GetTasks, taskID mesos.TaskID) *mesos.Task {
+	for _, ts := range [][]mesos.Task{
+		tasks.GetLaunchedTasks(),
+		tasks.GetQueuedTasks(),
+		tasks.GetPendingTasks(),
+		tasks.GetTerminatedTasks(),
+		tasks.GetCompletedTasks(),
+	} {
+		for i := range ts {
+			if ts[i].TaskID.Equal(&taskID) {
+				return &ts[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package httpagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+)
+
+func TestClient_TaskSandbox(t *testing.T) {
+	var (
+		frameworkID = mesos.FrameworkID{Value: "f1"}
+		groupExec   = mesos.ExecutorID{Value: "default"}
+	)
+	cli, closer := newTestClient(t, func(call *agent.Call) *agent.Response {
+		if call.GetType() != agent.Call_GET_STATE {
+			t.Errorf("unexpected call %v", call)
+			return nil
+		}
+		return &agent.Response{
+			Type: agent.Response_GET_STATE,
+			GetState: &agent.Response_GetState{
+				GetTasks: &agent.Response_GetTasks{
+					LaunchedTasks: []mesos.Task{
+						{TaskID: mesos.TaskID{Value: "cmd"}, FrameworkID: frameworkID},
+						{TaskID: mesos.TaskID{Value: "grouped"}, FrameworkID: frameworkID, ExecutorID: &groupExec},
+					},
+				},
+				GetExecutors: &agent.Response_GetExecutors{
+					Executors: []agent.Response_GetExecutors_Executor{{
+						ExecutorInfo: mesos.ExecutorInfo{
+							ExecutorID:  groupExec,
+							FrameworkID: &frameworkID,
+							Type:        mesos.ExecutorInfo_DEFAULT,
+						},
+					}},
+				},
+				GetFrameworks: &agent.Response_GetFrameworks{},
+			},
+		}
+	})
+	defer closer()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		taskID, sandbox string
+	}{
+		{"cmd", "/frameworks/f1/executors/cmd/runs/latest"},
+		{"grouped", "/frameworks/f1/executors/default/runs/latest/tasks/grouped"},
+	} {
+		sandbox, err := cli.TaskSandbox(ctx, mesos.TaskID{Value: tc.taskID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sandbox != tc.sandbox {
+			t.Errorf("expected sandbox %q for task %q instead of %q", tc.sandbox, tc.taskID, sandbox)
+		}
+	}
+	if _, err := cli.TaskSandbox(ctx, mesos.TaskID{Value: "unknown"}); err == nil {
+		t.Fatalf("expected error for unknown task")
+	}
+}
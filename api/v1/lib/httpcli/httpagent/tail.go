@@ -0,0 +1,105 @@
+package httpagent
+
+import (
+	"context"
+	"io"
+	"path"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// DefaultTailInterval is the default interval at which Tail polls for new data.
+const DefaultTailInterval = time.Second
+
+// DefaultTailChunkSize is the default maximum number of bytes that Tail reads via a single call.
+const DefaultTailChunkSize = 64 * 1024
+
+type (
+	tailConfig struct {
+		interval  time.Duration
+		chunkSize uint64
+		last      uint64
+		fromStart bool
+	}
+
+	// TailOpt is a functional option type for Tail.
+	TailOpt func(*tailConfig)
+)
+
+// TailInterval overrides DefaultTailInterval.
+func TailInterval(d time.Duration) TailOpt { return func(c *tailConfig) { c.interval = d } }
+
+// TailChunkSize overrides DefaultTailChunkSize.
+func TailChunkSize(n uint64) TailOpt { return func(c *tailConfig) { c.chunkSize = n } }
+
+// TailLast begins tailing the file n bytes before its current end, rather than at its current end.
+func TailLast(n uint64) TailOpt { return func(c *tailConfig) { c.last, c.fromStart = n, false } }
+
+// TailFromStart begins tailing the file at its beginning, rather than at its current end.
+func TailFromStart() TailOpt { return func(c *tailConfig) { c.fromStart = true } }
+
+// Tail copies data that's appended to the named file in the sandbox of the task (e.g. "stdout") to w,
+// similar to `tail -f`, until the context is done or an error occurs. Tail polls for new data at regular
+// intervals. If the file shrinks then it's assumed to have been rotated (or truncated) and Tail begins
+// copying from the beginning of the file again.
+func (c *Client) Tail(ctx context.Context, taskID mesos.TaskID, name string, w io.Writer, opts ...TailOpt) error {
+	sandbox, err := c.TaskSandbox(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return c.TailFile(ctx, path.Join(sandbox, name), w, opts...)
+}
+
+// TailFile is like Tail, but for a file at the given (virtual) path on the agent.
+func (c *Client) TailFile(ctx context.Context, file string, w io.Writer, opts ...TailOpt) error {
+	config := tailConfig{interval: DefaultTailInterval, chunkSize: DefaultTailChunkSize}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&config)
+		}
+	}
+	var offset uint64
+	if !config.fromStart {
+		var zero uint64
+		size, _, err := c.ReadFile(ctx, file, 0, &zero)
+		if err != nil {
+			return err
+		}
+		if size > config.last {
+			offset = size - config.last
+		}
+	}
+	ticker := time.NewTicker(config.interval)
+	defer ticker.Stop()
+	for {
+		// read until we've caught up w/ the end of the file
+		for {
+			size, data, err := c.ReadFile(ctx, file, offset, &config.chunkSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					// the call was (likely) aborted because the context is done
+					return ctx.Err()
+				}
+				return err
+			}
+			if size < offset {
+				// rotated or truncated
+				offset = 0
+				continue
+			}
+			if len(data) == 0 {
+				break
+			}
+			if _, err = w.Write(data); err != nil {
+				return err
+			}
+			offset += uint64(len(data))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package httpagent
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+)
+
+func TestClient_TailFile(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		content = []byte("old data\n")
+		reads   int
+	)
+	cli, closer := newTestClient(t, func(call *agent.Call) *agent.Response {
+		mu.Lock()
+		defer mu.Unlock()
+		rf := call.GetReadFile()
+		if call.GetType() != agent.Call_READ_FILE || rf.GetPath() != "/sandbox/stdout" {
+			t.Errorf("unexpected call %v", call)
+			return nil
+		}
+		reads++
+		switch reads {
+		case 3:
+			content = append(content, "new data\n"...)
+		case 6:
+			// rotate
+			content = []byte("rotated\n")
+		}
+		var data []byte
+		if off := rf.GetOffset(); off < uint64(len(content)) {
+			data = content[off:]
+			if n := rf.GetLength(); n < uint64(len(data)) {
+				data = data[:n]
+			}
+		}
+		return &agent.Response{
+			Type:     agent.Response_READ_FILE,
+			ReadFile: &agent.Response_ReadFile{Size: uint64(len(content)), Data: data},
+		}
+	})
+	defer closer()
+
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+		buf         bytes.Buffer
+		expected    = "new data\nrotated\n"
+	)
+	defer cancel()
+	w := writerFunc(func(b []byte) (int, error) {
+		buf.Write(b)
+		if buf.String() == expected {
+			cancel()
+		}
+		return len(b), nil
+	})
+	err := cli.TailFile(ctx, "/sandbox/stdout", w, TailInterval(time.Millisecond), TailChunkSize(4))
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled instead of %v", err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("expected %q instead of %q", expected, buf.String())
+	}
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) { return f(b) }
@@ -0,0 +1,42 @@
+package httpexec
+
+import (
+	"sync"
+
+	"github.com/mesos/mesos-go/api/v1/lib/executor/config"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+)
+
+// AuthConfigOpt returns a ConfigOpt that authenticates the executor w/ the agent using the token that the
+// agent provides via the executor environment; nil when executor authentication is not enabled. Should the
+// agent reject the token (401) then a rotated token is read from the AuthenticationTokenFile of the
+// configuration, if any, and the request is retried; see httpcli.BearerAuth.
+func AuthConfigOpt(cfg config.Config) httpcli.ConfigOpt {
+	switch {
+	case cfg.AuthenticationTokenFile != "":
+		return httpcli.BearerAuth(initialToken(cfg.AuthenticationToken, httpcli.FileToken(cfg.AuthenticationTokenFile)))
+	case cfg.AuthenticationToken != "":
+		return httpcli.BearerAuth(httpcli.StaticToken(cfg.AuthenticationToken))
+	default:
+		return nil
+	}
+}
+
+// initialToken returns a TokenFunc that yields the given token, if any, upon its first invocation and
+// defers to tf thereafter.
+func initialToken(token string, tf httpcli.TokenFunc) httpcli.TokenFunc {
+	var (
+		mu   sync.Mutex
+		used = token == ""
+	)
+	return func() (string, error) {
+		mu.Lock()
+		first := !used
+		used = true
+		mu.Unlock()
+		if first {
+			return token, nil
+		}
+		return tf()
+	}
+}
@@ -0,0 +1,86 @@
+package httpexec
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/config"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+)
+
+func TestAuthConfigOpt(t *testing.T) {
+	if opt := AuthConfigOpt(config.Config{}); opt != nil {
+		t.Fatal("expected a nil ConfigOpt w/o a token")
+	}
+
+	dir, err := ioutil.TempDir("", "httpexec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+
+	var (
+		mu       sync.Mutex
+		accepted = "a"
+		seen     []string
+		srv      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			auth := r.Header.Get("Authorization")
+			seen = append(seen, auth)
+			if auth != "Bearer "+accepted {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		cfg = config.Config{AuthenticationToken: "a", AuthenticationTokenFile: tokenFile}
+		cli = httpcli.New(
+			httpcli.Endpoint(srv.URL),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+			httpcli.Do(httpcli.With(AuthConfigOpt(cfg))),
+		)
+		sender = NewSender(cli.Send)
+		send   = func() error {
+			resp, err := sender.Send(context.Background(), calls.NonStreaming(calls.Message([]byte("hello"))))
+			if resp != nil {
+				resp.Close()
+			}
+			return err
+		}
+	)
+	defer srv.Close()
+
+	// the token of the environment is used until the agent rejects it
+	if err := send(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mu.Lock()
+	accepted = "b"
+	mu.Unlock()
+	if err := ioutil.WriteFile(tokenFile, []byte("b\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := send(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"Bearer a", "Bearer a", "Bearer b", "Bearer b"}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(seen, expected) {
+		t.Fatalf("expected %v instead of %v", expected, seen)
+	}
+}
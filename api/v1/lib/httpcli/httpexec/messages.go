@@ -0,0 +1,48 @@
+package httpexec
+
+import (
+	"context"
+
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/events"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/messages"
+)
+
+// SendMessage sends the given data to the scheduler as one or more MESSAGE calls, according to the
+// framing performed by the given Framer. A nil Framer sends the data as-is, in a single call.
+func SendMessage(ctx context.Context, s calls.Sender, f *messages.Framer, data []byte) error {
+	if f == nil {
+		f = messages.NewFramer()
+	}
+	frames, err := f.Frames(data)
+	if err != nil {
+		return err
+	}
+	for _, frame := range frames {
+		resp, err := s.Send(ctx, calls.NonStreaming(calls.Message(frame)))
+		if resp != nil {
+			resp.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleMessages returns a handler for MESSAGE events that reassembles framed message data via the given
+// Assembler and then invokes f for each complete message.
+func HandleMessages(a *messages.Assembler, f func(context.Context, []byte) error) events.HandlerFunc {
+	return func(ctx context.Context, e *executor.Event) error {
+		msg := e.GetMessage()
+		if msg == nil {
+			return nil
+		}
+		data, complete, err := a.Add("", msg.Data)
+		if err != nil || !complete {
+			return err
+		}
+		return f(ctx, data)
+	}
+}
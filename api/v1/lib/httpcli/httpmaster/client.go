@@ -0,0 +1,565 @@
+package httpmaster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/maintenance"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/master/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/operations"
+)
+
+// API is implemented by Client. Code that depends on API, rather than *Client, may be unit-tested w/o
+// a Mesos master, see mesostest/mock.
+type API interface {
+	Sender() calls.Sender
+	GetHealth(ctx context.Context) (bool, error)
+	GetFlags(ctx context.Context) ([]mesos.Flag, error)
+	GetVersion(ctx context.Context) (mesos.VersionInfo, error)
+	GetMetrics(ctx context.Context, timeout *time.Duration) ([]mesos.Metric, error)
+	GetLoggingLevel(ctx context.Context) (uint32, error)
+	SetLoggingLevel(ctx context.Context, level uint32, d time.Duration) error
+	ListFiles(ctx context.Context, path string) ([]mesos.FileInfo, error)
+	ReadFile(ctx context.Context, path string, offset uint64, length *uint64) (size uint64, data []byte, err error)
+	GetState(ctx context.Context) (*master.Response_GetState, error)
+	GetAgents(ctx context.Context) (*master.Response_GetAgents, error)
+	GetFrameworks(ctx context.Context) (*master.Response_GetFrameworks, error)
+	GetExecutors(ctx context.Context) (*master.Response_GetExecutors, error)
+	GetTasks(ctx context.Context) (*master.Response_GetTasks, error)
+	GetRoles(ctx context.Context) ([]mesos.Role, error)
+	GetWeights(ctx context.Context) ([]mesos.WeightInfo, error)
+	GetMaster(ctx context.Context) (*master.Response_GetMaster, error)
+	GetMaintenanceStatus(ctx context.Context) (maintenance.ClusterStatus, error)
+	GetMaintenanceSchedule(ctx context.Context) (maintenance.Schedule, error)
+	UpdateMaintenanceSchedule(ctx context.Context, s maintenance.Schedule) error
+	StartMaintenance(ctx context.Context, machines ...mesos.MachineID) error
+	StopMaintenance(ctx context.Context, machines ...mesos.MachineID) error
+	GetQuota(ctx context.Context) (quota.QuotaStatus, error)
+	SetQuota(ctx context.Context, qr quota.QuotaRequest) error
+	RemoveQuota(ctx context.Context, role string) error
+	UpdateQuota(ctx context.Context, qr quota.QuotaRequest) error
+	UpdateWeights(ctx context.Context, weights ...mesos.WeightInfo) error
+	ReconcileWeights(ctx context.Context, desired []mesos.WeightInfo, prune bool) ([]mesos.WeightInfo, error)
+	GetAgent(ctx context.Context, id mesos.AgentID) (*master.Response_GetAgents_Agent, error)
+	ReserveResources(ctx context.Context, agentID mesos.AgentID, rs ...mesos.Resource) error
+	UnreserveResources(ctx context.Context, agentID mesos.AgentID, rs ...mesos.Resource) error
+	CreateVolumes(ctx context.Context, agentID mesos.AgentID, volumes ...mesos.Resource) error
+	DestroyVolumes(ctx context.Context, agentID mesos.AgentID, volumes ...mesos.Resource) error
+	ForceDestroyVolumes(ctx context.Context, agentID mesos.AgentID, volumes ...mesos.Resource) error
+	MarkAgentGone(ctx context.Context, id mesos.AgentID) error
+	DrainProgress(ctx context.Context, id mesos.AgentID) (*DrainStatus, error)
+	WaitForDrain(ctx context.Context, id mesos.AgentID, interval time.Duration, f func(*DrainStatus)) error
+	Teardown(ctx context.Context, id mesos.FrameworkID) error
+	ConfirmTeardown(ctx context.Context, id mesos.FrameworkID, confirm func(*TeardownSummary) bool) (bool, error)
+}
+
+var _ = API(&Client{})
+
+// Client is a typed client for the v1 master operator API: each func issues a single call and decodes
+// the response of the expected type. For example:
+//
+//	cli := httpmaster.NewClient(httpmaster.NewSender(httpcli.New(httpcli.Endpoint(uri)).Send))
+//	state, err := cli.GetState(ctx)
+type Client struct {
+	sender calls.Sender
+}
+
+// NewClient returns a Client that sends operator calls via the given Sender.
+func NewClient(s calls.Sender) *Client {
+	return &Client{sender: s}
+}
+
+// Sender returns the Sender used by the Client, for example to issue calls that the Client doesn't
+// support explicitly.
+func (c *Client) Sender() calls.Sender { return c.sender }
+
+// call sends the given call and decodes a response of the expected type.
+func (c *Client) call(ctx context.Context, call *master.Call, expected master.Response_Type) (*master.Response, error) {
+	resp, err := c.sender.Send(ctx, calls.NonStreaming(call))
+	if resp != nil {
+		defer resp.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r master.Response
+	if err = resp.Decode(&r); err != nil {
+		return nil, err
+	}
+	if t := r.GetType(); t != expected {
+		return nil, httpcli.ProtocolError(fmt.Sprintf("unexpected master.Response type %v, expected %v", t, expected))
+	}
+	return &r, nil
+}
+
+// callNoData sends the given call, for which no response data is expected.
+func (c *Client) callNoData(ctx context.Context, call *master.Call) error {
+	resp, err := c.sender.Send(ctx, calls.NonStreaming(call))
+	if resp != nil {
+		resp.Close()
+	}
+	return err
+}
+
+// GetHealth returns true if the master is healthy.
+func (c *Client) GetHealth(ctx context.Context) (bool, error) {
+	r, err := c.call(ctx, calls.GetHealth(), master.Response_GET_HEALTH)
+	return r.GetGetHealth().GetHealthy(), err
+}
+
+// GetFlags returns the master's overall flag configuration.
+func (c *Client) GetFlags(ctx context.Context) ([]mesos.Flag, error) {
+	r, err := c.call(ctx, calls.GetFlags(), master.Response_GET_FLAGS)
+	return r.GetGetFlags().GetFlags(), err
+}
+
+// GetVersion returns the master's version information.
+func (c *Client) GetVersion(ctx context.Context) (mesos.VersionInfo, error) {
+	r, err := c.call(ctx, calls.GetVersion(), master.Response_GET_VERSION)
+	return r.GetGetVersion().GetVersionInfo(), err
+}
+
+// GetMetrics returns a snapshot of the master's metrics. If timeout is non-nil then it bounds the amount
+// of time that the master spends collecting metrics; some metrics may be omitted if it's exceeded.
+func (c *Client) GetMetrics(ctx context.Context, timeout *time.Duration) ([]mesos.Metric, error) {
+	r, err := c.call(ctx, calls.GetMetrics(timeout), master.Response_GET_METRICS)
+	return r.GetGetMetrics().GetMetrics(), err
+}
+
+// GetLoggingLevel returns the master's logging level.
+func (c *Client) GetLoggingLevel(ctx context.Context) (uint32, error) {
+	r, err := c.call(ctx, calls.GetLoggingLevel(), master.Response_GET_LOGGING_LEVEL)
+	return r.GetGetLoggingLevel().GetLevel(), err
+}
+
+// SetLoggingLevel sets the master's logging level for the given duration.
+func (c *Client) SetLoggingLevel(ctx context.Context, level uint32, d time.Duration) error {
+	return c.callNoData(ctx, calls.SetLoggingLevel(level, d))
+}
+
+// ListFiles returns the file listing of a directory on the master.
+func (c *Client) ListFiles(ctx context.Context, path string) ([]mesos.FileInfo, error) {
+	r, err := c.call(ctx, calls.ListFiles(path), master.Response_LIST_FILES)
+	return r.GetListFiles().GetFileInfos(), err
+}
+
+// ReadFile reads data from a file on the master, starting at the given offset. If length is non-nil then
+// at most length bytes are read. The size of the file is returned along with the data that was read.
+func (c *Client) ReadFile(ctx context.Context, path string, offset uint64, length *uint64) (size uint64, data []byte, err error) {
+	call := calls.ReadFile(path, offset)
+	if length != nil {
+		call = calls.ReadFileWithLength(path, offset, *length)
+	}
+	r, err := c.call(ctx, call, master.Response_READ_FILE)
+	return r.GetReadFile().GetSize(), r.GetReadFile().GetData(), err
+}
+
+// GetState returns the overall cluster state.
+func (c *Client) GetState(ctx context.Context) (*master.Response_GetState, error) {
+	r, err := c.call(ctx, calls.GetState(), master.Response_GET_STATE)
+	return r.GetGetState(), err
+}
+
+// GetAgents returns information about the agents known to the master.
+func (c *Client) GetAgents(ctx context.Context) (*master.Response_GetAgents, error) {
+	r, err := c.call(ctx, calls.GetAgents(), master.Response_GET_AGENTS)
+	return r.GetGetAgents(), err
+}
+
+// GetFrameworks returns information about the frameworks known to the master.
+func (c *Client) GetFrameworks(ctx context.Context) (*master.Response_GetFrameworks, error) {
+	r, err := c.call(ctx, calls.GetFrameworks(), master.Response_GET_FRAMEWORKS)
+	return r.GetGetFrameworks(), err
+}
+
+// GetExecutors returns information about the executors known to the master.
+func (c *Client) GetExecutors(ctx context.Context) (*master.Response_GetExecutors, error) {
+	r, err := c.call(ctx, calls.GetExecutors(), master.Response_GET_EXECUTORS)
+	return r.GetGetExecutors(), err
+}
+
+// GetTasks returns information about the tasks known to the master.
+func (c *Client) GetTasks(ctx context.Context) (*master.Response_GetTasks, error) {
+	r, err := c.call(ctx, calls.GetTasks(), master.Response_GET_TASKS)
+	return r.GetGetTasks(), err
+}
+
+// GetRoles returns information about the roles known to the master.
+func (c *Client) GetRoles(ctx context.Context) ([]mesos.Role, error) {
+	r, err := c.call(ctx, calls.GetRoles(), master.Response_GET_ROLES)
+	return r.GetGetRoles().GetRoles(), err
+}
+
+// GetWeights returns the weights of roles.
+func (c *Client) GetWeights(ctx context.Context) ([]mesos.WeightInfo, error) {
+	r, err := c.call(ctx, calls.GetWeights(), master.Response_GET_WEIGHTS)
+	return r.GetGetWeights().GetWeightInfos(), err
+}
+
+// GetMaster returns information about the master.
+func (c *Client) GetMaster(ctx context.Context) (*master.Response_GetMaster, error) {
+	r, err := c.call(ctx, calls.GetMaster(), master.Response_GET_MASTER)
+	return r.GetGetMaster(), err
+}
+
+// GetMaintenanceStatus returns the maintenance status of the cluster.
+func (c *Client) GetMaintenanceStatus(ctx context.Context) (maintenance.ClusterStatus, error) {
+	r, err := c.call(ctx, calls.GetMaintenanceStatus(), master.Response_GET_MAINTENANCE_STATUS)
+	return r.GetGetMaintenanceStatus().GetStatus(), err
+}
+
+// GetMaintenanceSchedule returns the maintenance schedule of the cluster.
+func (c *Client) GetMaintenanceSchedule(ctx context.Context) (maintenance.Schedule, error) {
+	r, err := c.call(ctx, calls.GetMaintenanceSchedule(), master.Response_GET_MAINTENANCE_SCHEDULE)
+	return r.GetGetMaintenanceSchedule().GetSchedule(), err
+}
+
+// UpdateMaintenanceSchedule replaces the maintenance schedule of the cluster.
+func (c *Client) UpdateMaintenanceSchedule(ctx context.Context, s maintenance.Schedule) error {
+	return c.callNoData(ctx, calls.UpdateMaintenanceSchedule(s))
+}
+
+// StartMaintenance transitions the given machines, which must be scheduled for maintenance, to the DOWN mode.
+func (c *Client) StartMaintenance(ctx context.Context, machines ...mesos.MachineID) error {
+	return c.callNoData(ctx, calls.StartMaintenance(machines...))
+}
+
+// StopMaintenance transitions the given machines, which must be DOWN, back to the UP mode. The machines are
+// removed from the maintenance schedule.
+func (c *Client) StopMaintenance(ctx context.Context, machines ...mesos.MachineID) error {
+	return c.callNoData(ctx, calls.StopMaintenance(machines...))
+}
+
+// GetQuota returns the quotas that are configured for the cluster.
+func (c *Client) GetQuota(ctx context.Context) (quota.QuotaStatus, error) {
+	r, err := c.call(ctx, calls.GetQuota(), master.Response_GET_QUOTA)
+	return r.GetGetQuota().GetStatus(), err
+}
+
+// SetQuota sets the quota for the role of the given request, which is validated prior to being sent.
+// The master rejects the request if a quota is already configured for the role.
+func (c *Client) SetQuota(ctx context.Context, qr quota.QuotaRequest) error {
+	if err := qr.Validate(); err != nil {
+		return err
+	}
+	return c.callNoData(ctx, calls.SetQuota(qr))
+}
+
+// RemoveQuota removes the quota for the given role.
+func (c *Client) RemoveQuota(ctx context.Context, role string) error {
+	return c.callNoData(ctx, calls.RemoveQuota(role))
+}
+
+// UpdateQuota replaces the quota for the role of the given request, which is validated prior to being
+// sent. The v1 API of this version of Mesos doesn't support UPDATE_QUOTA, so an existing quota for the
+// role is first removed via REMOVE_QUOTA and then the new quota is applied via SET_QUOTA; the update is
+// not atomic and the role is without quota in the interim. Should SET_QUOTA fail then the previous quota
+// is restored via a forced SET_QUOTA; the returned error reports whether the restoration succeeded, or
+// else that the role is left w/o quota.
+func (c *Client) UpdateQuota(ctx context.Context, qr quota.QuotaRequest) error {
+	if err := qr.Validate(); err != nil {
+		return err
+	}
+	status, err := c.GetQuota(ctx)
+	if err != nil {
+		return err
+	}
+	previous := status.Find(qr.GetRole())
+	if previous != nil {
+		if err = c.RemoveQuota(ctx, qr.GetRole()); err != nil {
+			return err
+		}
+	}
+	err = c.callNoData(ctx, calls.SetQuota(qr))
+	if err == nil || previous == nil {
+		return err
+	}
+	restore := quota.NewRequest(previous.GetRole(), previous.Guarantee, quota.Force())
+	if rerr := c.callNoData(ctx, calls.SetQuota(restore)); rerr != nil {
+		return fmt.Errorf("failed to update the quota of role %q: %v; failed to restore the previous quota, "+
+			"the role is left w/o quota: %v", qr.GetRole(), err, rerr)
+	}
+	return fmt.Errorf("failed to update the quota of role %q, the previous quota was restored: %v", qr.GetRole(), err)
+}
+
+// UpdateWeights updates the weights of the given roles.
+func (c *Client) UpdateWeights(ctx context.Context, weights ...mesos.WeightInfo) error {
+	return c.callNoData(ctx, calls.UpdateWeights(weights...))
+}
+
+// ReconcileWeights updates role weights, as needed, such that they match the desired weights; see
+// mesos.DiffWeights for the semantics of prune. The updates that were applied are returned.
+func (c *Client) ReconcileWeights(ctx context.Context, desired []mesos.WeightInfo, prune bool) ([]mesos.WeightInfo, error) {
+	current, err := c.GetWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updates := mesos.DiffWeights(current, desired, prune)
+	if len(updates) == 0 {
+		return nil, nil
+	}
+	if err = c.UpdateWeights(ctx, updates...); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// GetAgent returns information about the identified agent, as reported by GET_AGENTS. Returns an error if
+// the agent is not known to the master.
+func (c *Client) GetAgent(ctx context.Context, id mesos.AgentID) (*master.Response_GetAgents_Agent, error) {
+	agents, err := c.GetAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range agents.GetAgents() {
+		if a := &agents.Agents[i]; a.AgentInfo.GetID().Equal(&id) {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("agent %q is not known to the master", id.Value)
+}
+
+// ReserveResources dynamically reserves resources on the identified agent. The resources must specify
+// the reservations to apply (see resources.Builder.Reserve); they're validated against the agent's
+// unallocated resources, as reported by GET_AGENTS, prior to sending the call.
+func (c *Client) ReserveResources(ctx context.Context, agentID mesos.AgentID, rs ...mesos.Resource) error {
+	op := &mesos.Offer_Operation{
+		Type:    mesos.Offer_Operation_RESERVE,
+		Reserve: &mesos.Offer_Operation_Reserve{Resources: rs},
+	}
+	if err := c.validateOperation(ctx, agentID, op); err != nil {
+		return err
+	}
+	return c.callNoData(ctx, calls.ReserveResources(agentID, rs...))
+}
+
+// UnreserveResources removes the most recent dynamic reservation of the given resources on the identified
+// agent. The resources are validated against the agent's unallocated resources, as reported by GET_AGENTS,
+// prior to sending the call.
+func (c *Client) UnreserveResources(ctx context.Context, agentID mesos.AgentID, rs ...mesos.Resource) error {
+	op := &mesos.Offer_Operation{
+		Type:      mesos.Offer_Operation_UNRESERVE,
+		Unreserve: &mesos.Offer_Operation_Unreserve{Resources: rs},
+	}
+	if err := c.validateOperation(ctx, agentID, op); err != nil {
+		return err
+	}
+	return c.callNoData(ctx, calls.UnreserveResources(agentID, rs...))
+}
+
+// validateOperation checks that the operation may be applied to the unallocated resources of the agent.
+func (c *Client) validateOperation(ctx context.Context, agentID mesos.AgentID, op *mesos.Offer_Operation) error {
+	agent, err := c.GetAgent(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	_, err = operations.Apply(op, unallocated(agent), nil)
+	return err
+}
+
+// unallocated returns the resources of the agent that are not allocated to frameworks.
+func unallocated(agent *master.Response_GetAgents_Agent) mesos.Resources {
+	allocated := mesos.Resources(agent.AllocatedResources).Unallocate()
+	return mesos.Resources(agent.TotalResources).Minus(allocated...)
+}
+
+// CreateVolumes creates persistent volumes on the identified agent from reserved disk resources (see
+// resources.Builder.Persistence). The volumes are validated against the agent's unallocated resources, as
+// reported by GET_AGENTS, prior to sending the call.
+func (c *Client) CreateVolumes(ctx context.Context, agentID mesos.AgentID, volumes ...mesos.Resource) error {
+	op := &mesos.Offer_Operation{
+		Type:   mesos.Offer_Operation_CREATE,
+		Create: &mesos.Offer_Operation_Create{Volumes: volumes},
+	}
+	if err := c.validateOperation(ctx, agentID, op); err != nil {
+		return err
+	}
+	return c.callNoData(ctx, calls.CreateVolumes(agentID, volumes...))
+}
+
+// DestroyVolumes destroys persistent volumes on the identified agent. The volumes must exist on the agent,
+// as reported by GET_AGENTS, and the call is refused if GET_TASKS reports that any non-terminal task is
+// using any of the volumes; see ForceDestroyVolumes.
+func (c *Client) DestroyVolumes(ctx context.Context, agentID mesos.AgentID, volumes ...mesos.Resource) error {
+	return c.destroyVolumes(ctx, agentID, false, volumes)
+}
+
+// ForceDestroyVolumes is like DestroyVolumes, but skips the check for volumes that are in use by tasks.
+func (c *Client) ForceDestroyVolumes(ctx context.Context, agentID mesos.AgentID, volumes ...mesos.Resource) error {
+	return c.destroyVolumes(ctx, agentID, true, volumes)
+}
+
+func (c *Client) destroyVolumes(ctx context.Context, agentID mesos.AgentID, force bool, volumes []mesos.Resource) error {
+	agent, err := c.GetAgent(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	op := &mesos.Offer_Operation{
+		Type:    mesos.Offer_Operation_DESTROY,
+		Destroy: &mesos.Offer_Operation_Destroy{Volumes: volumes},
+	}
+	if _, err = operations.Apply(op, agent.TotalResources, nil); err != nil {
+		return err
+	}
+	if !force {
+		tasks, err := c.GetTasks(ctx)
+		if err != nil {
+			return err
+		}
+		if inUse := VolumesInUse(agentID, tasks, volumes...); len(inUse) > 0 {
+			return fmt.Errorf("persistent volumes %q are in use by tasks", inUse)
+		}
+	}
+	return c.callNoData(ctx, calls.DestroyVolumes(agentID, volumes...))
+}
+
+// activeTasks returns the non-terminal tasks, pending or otherwise, on the identified agent.
+func activeTasks(agentID mesos.AgentID, tasks *master.Response_GetTasks) (result []mesos.Task) {
+	for _, ts := range [][]mesos.Task{tasks.GetPendingTasks(), tasks.GetTasks()} {
+		for i := range ts {
+			if ts[i].AgentID.Equal(&agentID) && !ts[i].GetState().IsTerminal() {
+				result = append(result, ts[i])
+			}
+		}
+	}
+	return
+}
+
+// VolumesInUse returns the IDs of the persistent volumes that are used by the non-terminal tasks, pending
+// or otherwise, on the identified agent.
+func VolumesInUse(agentID mesos.AgentID, tasks *master.Response_GetTasks, volumes ...mesos.Resource) (result []string) {
+	used := make(map[string]struct{})
+	for _, t := range activeTasks(agentID, tasks) {
+		for j := range t.Resources {
+			if id := t.Resources[j].GetDisk().GetPersistence().GetID(); id != "" {
+				used[id] = struct{}{}
+			}
+		}
+	}
+	for i := range volumes {
+		id := volumes[i].GetDisk().GetPersistence().GetID()
+		if _, ok := used[id]; ok && id != "" {
+			result = append(result, id)
+			delete(used, id)
+		}
+	}
+	return
+}
+
+// MarkAgentGone marks the identified agent as gone, which is irreversible: the agent's tasks are
+// transitioned to TASK_GONE_BY_OPERATOR and the agent may not re-register with the master. Operators
+// should ensure that the agent has been terminated prior to marking it as gone.
+//
+// NOTE: the DRAIN_AGENT and DEACTIVATE_AGENT calls aren't supported by the v1 API of this version of Mesos;
+// agents may be drained by scheduling maintenance for them instead (see UpdateMaintenanceSchedule and
+// StartMaintenance), and the progress of draining is reported by DrainProgress.
+func (c *Client) MarkAgentGone(ctx context.Context, id mesos.AgentID) error {
+	return c.callNoData(ctx, calls.MarkAgentGone(id))
+}
+
+// DrainStatus reports the progress of draining an agent.
+type DrainStatus struct {
+	// Tasks are the non-terminal tasks, pending or otherwise, that remain on the agent.
+	Tasks []mesos.Task
+	// Allocated are the resources of the agent that remain allocated to frameworks.
+	Allocated []mesos.Resource
+}
+
+// Drained returns true if no tasks remain on the agent and none of its resources are allocated.
+func (s *DrainStatus) Drained() bool {
+	return len(s.Tasks) == 0 && len(s.Allocated) == 0
+}
+
+// DrainProgress reports the tasks and allocated resources that remain on the identified agent, as reported
+// by GET_AGENTS and GET_TASKS.
+func (c *Client) DrainProgress(ctx context.Context, id mesos.AgentID) (*DrainStatus, error) {
+	agent, err := c.GetAgent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := c.GetTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &DrainStatus{Tasks: activeTasks(id, tasks), Allocated: agent.AllocatedResources}, nil
+}
+
+// WaitForDrain polls DrainProgress at the given interval until the identified agent is drained, or the
+// context is done. If non-nil, f is invoked with the status obtained by each poll.
+func (c *Client) WaitForDrain(ctx context.Context, id mesos.AgentID, interval time.Duration, f func(*DrainStatus)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s, err := c.DrainProgress(ctx, id)
+		if err != nil {
+			return err
+		}
+		if f != nil {
+			f(s)
+		}
+		if s.Drained() {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Teardown removes the identified framework: all of its tasks and executors are killed and the framework
+// may not re-subscribe. See ConfirmTeardown for a safer alternative.
+func (c *Client) Teardown(ctx context.Context, id mesos.FrameworkID) error {
+	return c.callNoData(ctx, calls.Teardown(id))
+}
+
+// TeardownSummary describes the impact of tearing down a framework.
+type TeardownSummary struct {
+	Framework *master.Response_GetFrameworks_Framework
+	// ActiveTasks is the number of non-terminal tasks, pending or otherwise, of the framework.
+	ActiveTasks int
+}
+
+// ConfirmTeardown tears down the identified framework only if confirm returns true for a summary of the
+// framework, as reported by GET_FRAMEWORKS and GET_TASKS; returns true if the framework was torn down.
+// Returns an error if the framework is not known to the master, or has already completed.
+func (c *Client) ConfirmTeardown(ctx context.Context, id mesos.FrameworkID, confirm func(*TeardownSummary) bool) (bool, error) {
+	frameworks, err := c.GetFrameworks(ctx)
+	if err != nil {
+		return false, err
+	}
+	var summary TeardownSummary
+	for i := range frameworks.GetFrameworks() {
+		if f := &frameworks.Frameworks[i]; f.FrameworkInfo.GetID().Equal(&id) {
+			summary.Framework = f
+			break
+		}
+	}
+	if summary.Framework == nil {
+		return false, fmt.Errorf("framework %q is not known to the master", id.Value)
+	}
+	tasks, err := c.GetTasks(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, ts := range [][]mesos.Task{tasks.GetPendingTasks(), tasks.GetTasks()} {
+		for i := range ts {
+			if ts[i].FrameworkID.Equal(&id) && !ts[i].GetState().IsTerminal() {
+				summary.ActiveTasks++
+			}
+		}
+	}
+	if !confirm(&summary) {
+		return false, nil
+	}
+	if err = c.Teardown(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
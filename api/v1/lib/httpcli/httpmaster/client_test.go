@@ -0,0 +1,467 @@
+package httpmaster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestClient(t *testing.T) {
+	var (
+		received []master.Call_Type
+		srv      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var call master.Call
+			if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+				t.Errorf("failed to decode call: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received = append(received, call.GetType())
+
+			var resp master.Response
+			switch call.GetType() {
+			case master.Call_GET_HEALTH:
+				resp = master.Response{
+					Type:      master.Response_GET_HEALTH,
+					GetHealth: &master.Response_GetHealth{Healthy: true},
+				}
+			case master.Call_GET_LOGGING_LEVEL:
+				resp = master.Response{
+					Type:            master.Response_GET_LOGGING_LEVEL,
+					GetLoggingLevel: &master.Response_GetLoggingLevel{Level: 3},
+				}
+			case master.Call_SET_LOGGING_LEVEL:
+				w.WriteHeader(http.StatusAccepted)
+				return
+			default:
+				// reply w/ the wrong response type
+				resp = master.Response{Type: master.Response_GET_FLAGS, GetFlags: &master.Response_GetFlags{}}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(&resp)
+		}))
+		ctx = context.Background()
+		cli = NewClient(NewSender(httpcli.New(
+			httpcli.Endpoint(srv.URL),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+		).Send))
+	)
+	defer srv.Close()
+
+	healthy, err := cli.GetHealth(ctx)
+	if err != nil || !healthy {
+		t.Fatalf("expected healthy master instead of %v, %v", healthy, err)
+	}
+	level, err := cli.GetLoggingLevel(ctx)
+	if err != nil || level != 3 {
+		t.Fatalf("expected logging level 3 instead of %v, %v", level, err)
+	}
+	if err = cli.SetLoggingLevel(ctx, 1, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = cli.GetState(ctx); err == nil {
+		t.Fatalf("expected error for mismatched response type")
+	}
+	expected := []master.Call_Type{
+		master.Call_GET_HEALTH,
+		master.Call_GET_LOGGING_LEVEL,
+		master.Call_SET_LOGGING_LEVEL,
+		master.Call_GET_STATE,
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Fatalf("expected calls %v instead of %v", expected, received)
+		}
+	}
+}
+
+func TestClient_UpdateQuota(t *testing.T) {
+	var (
+		role     = "dev"
+		received []master.Call_Type
+		srv      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var call master.Call
+			if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+				t.Errorf("failed to decode call: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received = append(received, call.GetType())
+			if call.GetType() != master.Call_GET_QUOTA {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			resp := master.Response{
+				Type: master.Response_GET_QUOTA,
+				GetQuota: &master.Response_GetQuota{
+					Status: quota.QuotaStatus{Infos: []quota.QuotaInfo{{Role: &role}}},
+				},
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(&resp)
+		}))
+		ctx = context.Background()
+		cli = NewClient(NewSender(httpcli.New(
+			httpcli.Endpoint(srv.URL),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+		).Send))
+	)
+	defer srv.Close()
+
+	if err := cli.UpdateQuota(ctx, quota.NewRequest("*", nil)); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if len(received) != 0 {
+		t.Fatalf("unexpected calls for invalid request: %v", received)
+	}
+	qr := quota.NewRequest(role, []mesos.Resource{resources.NewCPUs(1).Resource})
+	if err := cli.UpdateQuota(ctx, qr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []master.Call_Type{master.Call_GET_QUOTA, master.Call_REMOVE_QUOTA, master.Call_SET_QUOTA}
+	if len(received) != len(expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Fatalf("expected calls %v instead of %v", expected, received)
+		}
+	}
+}
+
+func TestClient_UpdateQuota_Restore(t *testing.T) {
+	var (
+		role     = "dev"
+		previous = []mesos.Resource{resources.NewCPUs(2).Resource}
+		ctx      = context.Background()
+		qr       = quota.NewRequest(role, []mesos.Resource{resources.NewCPUs(1).Resource})
+	)
+	for i, tc := range []struct {
+		failRestore bool
+		wantMessage string
+	}{
+		{false, "the previous quota was restored"},
+		{true, "the role is left w/o quota"},
+	} {
+		var (
+			restored *quota.QuotaRequest
+			srv      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var call master.Call
+				if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+					t.Errorf("failed to decode call: %v", err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				switch call.GetType() {
+				case master.Call_GET_QUOTA:
+					resp := master.Response{
+						Type: master.Response_GET_QUOTA,
+						GetQuota: &master.Response_GetQuota{
+							Status: quota.QuotaStatus{Infos: []quota.QuotaInfo{{Role: &role, Guarantee: previous}}},
+						},
+					}
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(&resp)
+					return
+				case master.Call_SET_QUOTA:
+					if r := call.GetSetQuota().QuotaRequest; r.GetForce() {
+						restored = &r
+						if !tc.failRestore {
+							break
+						}
+					}
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			cli = NewClient(NewSender(httpcli.New(
+				httpcli.Endpoint(srv.URL),
+				httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+			).Send))
+		)
+		err := cli.UpdateQuota(ctx, qr)
+		srv.Close()
+		if err == nil || !strings.Contains(err.Error(), tc.wantMessage) {
+			t.Errorf("test case %d: unexpected error %v", i, err)
+		}
+		if restored == nil || restored.GetRole() != role || !reflect.DeepEqual(restored.Guarantee, previous) {
+			t.Errorf("test case %d: unexpected restoration %v", i, restored)
+		}
+	}
+}
+
+// newTestClient returns a Client that sends calls to a test server; the server replies to each call
+// w/ the response generated by f, or else 202 Accepted if f returns nil.
+func newTestClient(t *testing.T, f func(*master.Call) *master.Response) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var call master.Call
+		if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+			t.Errorf("failed to decode call: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := f(&call)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	return NewClient(NewSender(httpcli.New(
+		httpcli.Endpoint(srv.URL),
+		httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+	).Send)), srv.Close
+}
+
+func TestClient_ReserveResources(t *testing.T) {
+	var (
+		agentID  = mesos.AgentID{Value: "a1"}
+		received []master.Call_Type
+		reserved = resources.NewCPUs(1).Reserve("dev", "ops").Resource
+	)
+	cli, closer := newTestClient(t, func(call *master.Call) *master.Response {
+		received = append(received, call.GetType())
+		if call.GetType() != master.Call_GET_AGENTS {
+			return nil
+		}
+		allocated := resources.NewCPUs(2).Resource
+		allocated.Allocate("web")
+		return &master.Response{
+			Type: master.Response_GET_AGENTS,
+			GetAgents: &master.Response_GetAgents{
+				Agents: []master.Response_GetAgents_Agent{{
+					AgentInfo:          mesos.AgentInfo{ID: &agentID},
+					TotalResources:     []mesos.Resource{resources.NewCPUs(3).Resource, reserved},
+					AllocatedResources: []mesos.Resource{allocated},
+				}},
+			},
+		}
+	})
+	defer closer()
+	ctx := context.Background()
+
+	if err := cli.ReserveResources(ctx, agentID, resources.NewCPUs(1).Reserve("dev", "ops").Resource); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.ReserveResources(ctx, agentID, resources.NewCPUs(2).Reserve("dev", "ops").Resource); err == nil {
+		t.Fatalf("expected error reserving unavailable resources")
+	}
+	if err := cli.ReserveResources(ctx, mesos.AgentID{Value: "a2"}, reserved); err == nil {
+		t.Fatalf("expected error for unknown agent")
+	}
+	if err := cli.UnreserveResources(ctx, agentID, reserved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.UnreserveResources(ctx, agentID, resources.NewCPUs(1).Reserve("prod", "").Resource); err == nil {
+		t.Fatalf("expected error unreserving resources that aren't reserved")
+	}
+	expected := []master.Call_Type{
+		master.Call_GET_AGENTS, master.Call_RESERVE_RESOURCES,
+		master.Call_GET_AGENTS,
+		master.Call_GET_AGENTS,
+		master.Call_GET_AGENTS, master.Call_UNRESERVE_RESOURCES,
+		master.Call_GET_AGENTS,
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Fatalf("expected calls %v instead of %v", expected, received)
+		}
+	}
+}
+
+func TestClient_Volumes(t *testing.T) {
+	var (
+		agentID  = mesos.AgentID{Value: "a1"}
+		received []master.Call_Type
+		reserved = resources.NewDisk(64).Reserve("dev", "ops").Resource
+		volume   = resources.NewDisk(64).Reserve("dev", "ops").Persistence("v1", "ops").Volume("data", mesos.RW).Resource
+		running  = mesos.TASK_RUNNING
+	)
+	cli, closer := newTestClient(t, func(call *master.Call) *master.Response {
+		received = append(received, call.GetType())
+		switch call.GetType() {
+		case master.Call_GET_AGENTS:
+			return &master.Response{
+				Type: master.Response_GET_AGENTS,
+				GetAgents: &master.Response_GetAgents{
+					Agents: []master.Response_GetAgents_Agent{{
+						AgentInfo:      mesos.AgentInfo{ID: &agentID},
+						TotalResources: []mesos.Resource{reserved, volume},
+					}},
+				},
+			}
+		case master.Call_GET_TASKS:
+			return &master.Response{
+				Type: master.Response_GET_TASKS,
+				GetTasks: &master.Response_GetTasks{
+					Tasks: []mesos.Task{{AgentID: agentID, State: &running, Resources: []mesos.Resource{volume}}},
+				},
+			}
+		}
+		return nil
+	})
+	defer closer()
+	ctx := context.Background()
+
+	v2 := resources.NewDisk(64).Reserve("dev", "ops").Persistence("v2", "ops").Volume("data", mesos.RW).Resource
+	if err := cli.CreateVolumes(ctx, agentID, v2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.CreateVolumes(ctx, agentID, resources.NewDisk(64).Persistence("v3", "").Resource); err == nil {
+		t.Fatalf("expected error creating a volume from unreserved resources")
+	}
+	if err := cli.DestroyVolumes(ctx, agentID, volume); err == nil {
+		t.Fatalf("expected error destroying a volume that's in use")
+	}
+	if err := cli.ForceDestroyVolumes(ctx, agentID, volume); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cli.ForceDestroyVolumes(ctx, agentID, v2); err == nil {
+		t.Fatalf("expected error destroying a volume that doesn't exist")
+	}
+	expected := []master.Call_Type{
+		master.Call_GET_AGENTS, master.Call_CREATE_VOLUMES,
+		master.Call_GET_AGENTS,
+		master.Call_GET_AGENTS, master.Call_GET_TASKS,
+		master.Call_GET_AGENTS, master.Call_DESTROY_VOLUMES,
+		master.Call_GET_AGENTS,
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Fatalf("expected calls %v instead of %v", expected, received)
+		}
+	}
+}
+
+func TestClient_WaitForDrain(t *testing.T) {
+	var (
+		agentID = mesos.AgentID{Value: "a1"}
+		polls   int
+	)
+	cli, closer := newTestClient(t, func(call *master.Call) *master.Response {
+		switch call.GetType() {
+		case master.Call_GET_AGENTS:
+			polls++
+			return &master.Response{
+				Type: master.Response_GET_AGENTS,
+				GetAgents: &master.Response_GetAgents{
+					Agents: []master.Response_GetAgents_Agent{{AgentInfo: mesos.AgentInfo{ID: &agentID}}},
+				},
+			}
+		case master.Call_GET_TASKS:
+			// the task on the agent terminates after the second poll
+			state := mesos.TASK_RUNNING
+			if polls > 2 {
+				state = mesos.TASK_KILLED
+			}
+			return &master.Response{
+				Type: master.Response_GET_TASKS,
+				GetTasks: &master.Response_GetTasks{
+					Tasks: []mesos.Task{
+						{AgentID: agentID, State: &state},
+						{AgentID: mesos.AgentID{Value: "a2"}, State: mesos.TASK_RUNNING.Enum()},
+					},
+				},
+			}
+		}
+		return nil
+	})
+	defer closer()
+
+	var remaining []int
+	err := cli.WaitForDrain(context.Background(), agentID, time.Millisecond, func(s *DrainStatus) {
+		remaining = append(remaining, len(s.Tasks))
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []int{1, 1, 0}; !reflect.DeepEqual(remaining, expected) {
+		t.Fatalf("expected remaining tasks %v instead of %v", expected, remaining)
+	}
+}
+
+func TestClient_ConfirmTeardown(t *testing.T) {
+	var (
+		frameworkID = mesos.FrameworkID{Value: "f1"}
+		received    []master.Call_Type
+	)
+	cli, closer := newTestClient(t, func(call *master.Call) *master.Response {
+		received = append(received, call.GetType())
+		switch call.GetType() {
+		case master.Call_GET_FRAMEWORKS:
+			return &master.Response{
+				Type: master.Response_GET_FRAMEWORKS,
+				GetFrameworks: &master.Response_GetFrameworks{
+					Frameworks: []master.Response_GetFrameworks_Framework{{
+						FrameworkInfo: mesos.FrameworkInfo{ID: &frameworkID},
+					}},
+				},
+			}
+		case master.Call_GET_TASKS:
+			return &master.Response{
+				Type: master.Response_GET_TASKS,
+				GetTasks: &master.Response_GetTasks{
+					PendingTasks: []mesos.Task{{FrameworkID: frameworkID, State: mesos.TASK_STAGING.Enum()}},
+					Tasks: []mesos.Task{
+						{FrameworkID: frameworkID, State: mesos.TASK_RUNNING.Enum()},
+						{FrameworkID: frameworkID, State: mesos.TASK_FINISHED.Enum()},
+						{FrameworkID: mesos.FrameworkID{Value: "f2"}, State: mesos.TASK_RUNNING.Enum()},
+					},
+				},
+			}
+		}
+		return nil
+	})
+	defer closer()
+	ctx := context.Background()
+
+	var activeTasks int
+	ok, err := cli.ConfirmTeardown(ctx, frameworkID, func(s *TeardownSummary) bool {
+		activeTasks = s.ActiveTasks
+		return false
+	})
+	if ok || err != nil {
+		t.Fatalf("expected unconfirmed teardown instead of %v, %v", ok, err)
+	}
+	if activeTasks != 2 {
+		t.Fatalf("expected 2 active tasks instead of %d", activeTasks)
+	}
+	ok, err = cli.ConfirmTeardown(ctx, frameworkID, func(*TeardownSummary) bool { return true })
+	if !ok || err != nil {
+		t.Fatalf("expected confirmed teardown instead of %v, %v", ok, err)
+	}
+	if _, err = cli.ConfirmTeardown(ctx, mesos.FrameworkID{Value: "f3"}, nil); err == nil {
+		t.Fatalf("expected error for unknown framework")
+	}
+	expected := []master.Call_Type{
+		master.Call_GET_FRAMEWORKS, master.Call_GET_TASKS,
+		master.Call_GET_FRAMEWORKS, master.Call_GET_TASKS, master.Call_TEARDOWN,
+		master.Call_GET_FRAMEWORKS,
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected calls %v instead of %v", expected, received)
+	}
+}
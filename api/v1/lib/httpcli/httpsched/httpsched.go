@@ -21,6 +21,9 @@ var (
 	errNotHTTPCli  = httpcli.ProtocolError("expected an httpcli.Response object, found something else instead")
 	errBadLocation = httpcli.ProtocolError("failed to build new Mesos service endpoint URL from Location header")
 
+	// DefaultRedirectSettings are copied into every client that's created by NewCaller; use the
+	// MaxRedirects, RedirectBackoffPeriod and RedirectBackoffStrategy options to customize the settings
+	// of a particular client.
 	DefaultRedirectSettings = RedirectSettings{
 		MaxAttempts:      9,
 		MaxBackoffPeriod: 13 * time.Second,
@@ -30,15 +33,20 @@ var (
 
 type (
 	RedirectSettings struct {
-		MaxAttempts      int           // per httpDo invocation
-		MaxBackoffPeriod time.Duration // should be more than minBackoffPeriod
-		MinBackoffPeriod time.Duration // should be less than maxBackoffPeriod
+		MaxAttempts      int              // per httpDo invocation
+		MaxBackoffPeriod time.Duration    // should be more than minBackoffPeriod
+		MinBackoffPeriod time.Duration    // should be less than maxBackoffPeriod
+		Strategy         backoff.Strategy // optional; defaults to backoff.Exponential
 	}
 
 	client struct {
 		*httpcli.Client
 		redirect       RedirectSettings
-		allowReconnect bool // feature flag
+		allowReconnect bool                          // feature flag
+		leaders        <-chan mesos.MasterInfo       // optional; leadership change notifications
+		redirectBudget *backoff.Budget               // optional; limits redirects across calls
+		listener       mesosclient.LifecycleListener // optional; notified of lifecycle transitions
+		introspection  introspection
 	}
 
 	// Caller is the public interface a framework scheduler's should consume
@@ -97,6 +105,38 @@ func MaxRedirects(mr int) Option {
 	}
 }
 
+// RedirectBackoffPeriod is a functional option that sets the bounds of the backoff between per-call HTTP
+// redirects for a scheduler client.
+func RedirectBackoffPeriod(min, max time.Duration) Option {
+	return func(c *client) Option {
+		oldMin, oldMax := c.redirect.MinBackoffPeriod, c.redirect.MaxBackoffPeriod
+		c.redirect.MinBackoffPeriod, c.redirect.MaxBackoffPeriod = min, max
+		return RedirectBackoffPeriod(oldMin, oldMax)
+	}
+}
+
+// RedirectBackoffStrategy is a functional option that sets the Strategy of the backoff between per-call
+// HTTP redirects for a scheduler client.
+func RedirectBackoffStrategy(s backoff.Strategy) Option {
+	return func(c *client) Option {
+		old := c.redirect.Strategy
+		c.redirect.Strategy = s
+		return RedirectBackoffStrategy(old)
+	}
+}
+
+// RedirectBudget is a functional option that limits the number of HTTP redirects that are followed by a
+// scheduler client, across calls, w/ the given retry Budget; the budget may be shared w/ other subsystems
+// (for example, see controller.WithRetryBudget). A redirect that's denied by the budget is reported to the
+// caller as an error.
+func RedirectBudget(b *backoff.Budget) Option {
+	return func(c *client) Option {
+		old := c.redirectBudget
+		c.redirectBudget = b
+		return RedirectBudget(old)
+	}
+}
+
 // AllowReconnection allows a subsequent SUBSCRIBE call before a prior SUBSCRIBE has experienced a network
 // or protocol error. Useful in concert with heartbeat detection and for other edge error cases not handled
 // by the connection state machine.
@@ -108,42 +148,58 @@ func AllowReconnection(v bool) Option {
 	}
 }
 
+// TrackLeader is a functional option that points the client at the leading master, as reported by the
+// given chan (for example, one returned by a detector.Master), as soon as leadership changes. A
+// subscription to a former leader is disconnected so that the framework may re-subscribe to the new
+// leader right away, instead of waiting for the former leader to hang up. The scheme and path of the
+// client endpoint are retained. Leadership changes are tracked until the chan is closed.
+func TrackLeader(leaders <-chan mesos.MasterInfo) Option {
+	return func(c *client) Option {
+		old := c.leaders
+		c.leaders = leaders
+		return TrackLeader(old)
+	}
+}
+
+// WithLifecycleListener is a functional option that notifies the given listener of the lifecycle
+// transitions of a scheduler client: the establishment of a subscription stream (Connected), receipt of
+// the SUBSCRIBED event (Subscribed), termination of the stream (Disconnected) and changes of the
+// endpoint because of redirects or leadership changes (Redirected).
+func WithLifecycleListener(l mesosclient.LifecycleListener) Option {
+	return func(c *client) Option {
+		old := c.listener
+		c.listener = l
+		return WithLifecycleListener(old)
+	}
+}
+
 // NewCaller returns a scheduler API Client in the form of a Caller. Concurrent invocations
-// of Call upon the returned caller are safely executed in a serial fashion. It is expected that
-// there are no other users of the given Client since its state may be modified by this impl.
+// of Call upon the returned caller are safely executed in a serial fashion. The returned Caller
+// implements Introspector. It is expected that there are no other users of the given Client
+// since its state may be modified by this impl.
 func NewCaller(cl *httpcli.Client, opts ...Option) calls.Caller {
 	result := &client{Client: cl, redirect: DefaultRedirectSettings}
+	result.introspection.snapshot.Endpoint = cl.Endpoint()
 	cl.With(result.redirectHandler())
 	for _, o := range opts {
 		if o != nil {
 			o(result)
 		}
 	}
-	return &state{
+	s := &state{
 		client: result,
 		fn:     disconnectedFn,
 	}
+	if result.leaders != nil {
+		go s.followLeader(result.leaders)
+	}
+	return s
 }
 
 // httpDo decorates the inherited behavior w/ support for HTTP redirection to follow Mesos leadership changes.
 // NOTE: this implementation will change the state of the client upon Mesos leadership changes.
 func (cli *client) httpDo(ctx context.Context, m encoding.Marshaler, opt ...httpcli.RequestOpt) (resp mesos.Response, err error) {
-	var (
-		done            chan struct{} // avoid allocating these chans unless we actually need to redirect
-		redirectBackoff <-chan struct{}
-		getBackoff      = func() <-chan struct{} {
-			if redirectBackoff == nil {
-				done = make(chan struct{})
-				redirectBackoff = backoff.Notifier(cli.redirect.MinBackoffPeriod, cli.redirect.MaxBackoffPeriod, done)
-			}
-			return redirectBackoff
-		}
-	)
-	defer func() {
-		if done != nil {
-			close(done)
-		}
-	}()
+	var redirectBackoff *backoff.Backoff // avoid allocating this unless we actually need to redirect
 	opt = append(opt, httpcli.Context(ctx))
 	for attempt := 0; ; attempt++ {
 		resp, err = cli.Client.Do(m, opt...)
@@ -151,15 +207,26 @@ func (cli *client) httpDo(ctx context.Context, m encoding.Marshaler, opt ...http
 		if !ok {
 			return resp, err
 		}
-		if attempt < cli.redirect.MaxAttempts {
+		if attempt < cli.redirect.MaxAttempts && cli.redirectBudget.Allow() {
 			if debug {
 				log.Println("redirecting to " + redirectErr.newURL)
 			}
-			cli.With(httpcli.Endpoint(redirectErr.newURL))
-			select {
-			case <-getBackoff():
-			case <-ctx.Done():
-				return nil, ctx.Err()
+			previous := cli.Endpoint()
+			cli.setEndpoint(redirectErr.newURL)
+			mesosclient.Notify(cli.listener, mesosclient.LifecycleEvent{
+				Transition: mesosclient.Redirected,
+				Endpoint:   redirectErr.newURL,
+				Previous:   previous,
+			})
+			if attempt > 0 {
+				// the first redirect is followed right away, subsequent redirects are throttled
+				if redirectBackoff == nil {
+					redirectBackoff = backoff.New(cli.redirect.MinBackoffPeriod, cli.redirect.MaxBackoffPeriod,
+						backoff.WithStrategy(cli.redirect.Strategy))
+				}
+				if err := redirectBackoff.Wait(ctx); err != nil {
+					return nil, err
+				}
 			}
 			continue
 		}
@@ -167,6 +234,12 @@ func (cli *client) httpDo(ctx context.Context, m encoding.Marshaler, opt ...http
 	}
 }
 
+// setEndpoint points the client at the given endpoint.
+func (cli *client) setEndpoint(endpoint string) {
+	cli.With(httpcli.Endpoint(endpoint))
+	cli.introspection.update(func(s *Snapshot) { s.Endpoint = endpoint })
+}
+
 // Call implements Client
 func (cli *client) Call(ctx context.Context, call *scheduler.Call) (mesos.Response, error) {
 	return cli.httpDo(ctx, call)
@@ -0,0 +1,69 @@
+package httpsched
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+// redirectingDoer simulates a non-leading master (m1) that redirects to the leading master (m2), which
+// accepts every call; no network I/O is involved.
+func redirectingDoer(req *http.Request) (*http.Response, error) {
+	io.Copy(ioutil.Discard, req.Body)
+	req.Body.Close()
+	res := &http.Response{
+		StatusCode: http.StatusAccepted,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+	if req.URL.Host == "m1:5050" {
+		res.StatusCode = http.StatusTemporaryRedirect
+		res.Header.Set("Location", "//m2:5050")
+	}
+	return res, nil
+}
+
+func BenchmarkCall(b *testing.B) {
+	const (
+		follower = "http://m1:5050/api/v1/scheduler"
+		leader   = "http://m2:5050/api/v1/scheduler"
+	)
+	var (
+		ctx     = context.Background()
+		decline = calls.Decline(mesos.OfferID{Value: "o1"}).With(calls.Framework("f"))
+	)
+	for _, bc := range []struct {
+		name     string
+		endpoint string
+	}{
+		{"leader", leader},
+		{"redirect", follower},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			// the redirect path is exercised by the client, regardless of the state of the subscription
+			cli := NewCaller(httpcli.New(httpcli.Endpoint(bc.endpoint), httpcli.Do(redirectingDoer))).(*state).client
+			for i := 0; i < b.N; i++ {
+				cli.setEndpoint(bc.endpoint)
+				resp, err := cli.Call(ctx, decline)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if resp != nil {
+					resp.Close()
+				}
+			}
+			if cli.Endpoint() != leader {
+				b.Fatalf("expected endpoint %q instead of %q", leader, cli.Endpoint())
+			}
+		})
+	}
+}
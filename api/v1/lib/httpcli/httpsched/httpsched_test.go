@@ -0,0 +1,76 @@
+package httpsched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/backoff"
+	mesosclient "github.com/mesos/mesos-go/api/v1/lib/client"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+)
+
+func TestTrackLeader(t *testing.T) {
+	var (
+		leaders      = make(chan mesos.MasterInfo)
+		hostname     = "m2"
+		disconnected = 0
+		redirects    []string
+		listener     = mesosclient.LifecycleListenerFunc(func(e mesosclient.LifecycleEvent) {
+			if e.Transition == mesosclient.Redirected {
+				redirects = append(redirects, e.Previous+" -> "+e.Endpoint)
+			}
+		})
+		state = &state{client: &client{
+			Client:   httpcli.New(httpcli.Endpoint("http://m0:5050/api/v1/scheduler")),
+			listener: listener,
+		}}
+		done = make(chan struct{})
+	)
+	state.disconnect = func() { disconnected++ }
+	go func() {
+		defer close(done)
+		state.followLeader(leaders)
+	}()
+
+	leaders <- mesos.MasterInfo{ID: "m1"} // w/o an address: ignored
+	leaders <- mesos.MasterInfo{ID: "m2", Hostname: &hostname}
+
+	leaders <- mesos.MasterInfo{ID: "m2", Hostname: &hostname} // no change
+	leaders <- mesos.MasterInfo{ID: "m2", Address: &mesos.Address{Hostname: &hostname, Port: 5051}}
+	close(leaders)
+	<-done
+
+	if ep := state.client.Endpoint(); ep != "http://m2:5051/api/v1/scheduler" {
+		t.Fatalf("unexpected endpoint %q", ep)
+	}
+	if disconnected != 2 {
+		t.Fatalf("expected a disconnection per leadership change instead of %d", disconnected)
+	}
+	if len(redirects) != 2 || redirects[1] != "http://m2:5050/api/v1/scheduler -> http://m2:5051/api/v1/scheduler" {
+		t.Fatalf("unexpected redirects %v", redirects)
+	}
+}
+
+func TestRedirectOptions(t *testing.T) {
+	var (
+		a = NewCaller(httpcli.New(), MaxRedirects(1), RedirectBackoffPeriod(time.Second, 2*time.Second)).(*state)
+		b = NewCaller(httpcli.New(), RedirectBackoffStrategy(backoff.Constant)).(*state)
+	)
+	if r := a.client.redirect; r.MaxAttempts != 1 || r.MinBackoffPeriod != time.Second || r.MaxBackoffPeriod != 2*time.Second {
+		t.Fatalf("unexpected redirect settings %+v", r)
+	}
+	if r := b.client.redirect; r.MaxAttempts != DefaultRedirectSettings.MaxAttempts ||
+		r.MinBackoffPeriod != DefaultRedirectSettings.MinBackoffPeriod || r.Strategy == nil {
+		t.Fatalf("unexpected redirect settings %+v", r)
+	}
+	if DefaultRedirectSettings.MaxAttempts == 1 || DefaultRedirectSettings.Strategy != nil {
+		t.Fatal("options modified the default redirect settings")
+	}
+
+	undo := RedirectBackoffPeriod(time.Minute, time.Hour)(a.client)
+	undo(a.client)
+	if r := a.client.redirect; r.MinBackoffPeriod != time.Second || r.MaxBackoffPeriod != 2*time.Second {
+		t.Fatalf("failed to undo redirect settings %+v", r)
+	}
+}
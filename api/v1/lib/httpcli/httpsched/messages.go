@@ -0,0 +1,46 @@
+package httpsched
+
+import (
+	"context"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/extras/messages"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+// SendMessage sends the given data to an executor as one or more MESSAGE calls, according to the framing
+// performed by the given Framer. A nil Framer sends the data as-is, in a single call.
+func SendMessage(ctx context.Context, caller calls.Caller, f *messages.Framer, agentID, executorID string, data []byte) error {
+	if f == nil {
+		f = messages.NewFramer()
+	}
+	frames, err := f.Frames(data)
+	if err != nil {
+		return err
+	}
+	for _, frame := range frames {
+		if err := calls.CallNoData(ctx, caller, calls.Message(agentID, executorID, frame)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleMessages returns a handler for MESSAGE events that reassembles framed message data via the given
+// Assembler and then invokes f for each complete message. Frames are reassembled per agent and executor.
+func HandleMessages(a *messages.Assembler, f func(context.Context, mesos.AgentID, mesos.ExecutorID, []byte) error) events.HandlerFunc {
+	return func(ctx context.Context, e *scheduler.Event) error {
+		msg := e.GetMessage()
+		if msg == nil {
+			return nil
+		}
+		source := msg.AgentID.Value + "/" + msg.ExecutorID.Value
+		data, complete, err := a.Add(source, msg.Data)
+		if err != nil || !complete {
+			return err
+		}
+		return f(ctx, msg.AgentID, msg.ExecutorID, data)
+	}
+}
@@ -0,0 +1,71 @@
+package httpsched
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// SubscriptionState is the state of a scheduler client's subscription.
+	SubscriptionState int
+
+	// Snapshot is a point-in-time view of the state of a scheduler client, e.g. for reporting via a
+	// framework's debug or health endpoint.
+	Snapshot struct {
+		Endpoint      string            // Endpoint is the current API endpoint of the client
+		StreamID      string            // StreamID identifies the subscription stream, if any
+		State         SubscriptionState // State is the state of the subscription
+		LastHeartbeat time.Time         // LastHeartbeat is the time of the most recent HEARTBEAT event, if any
+		PendingAcks   int               // PendingAcks counts the status updates of this stream that await acknowledgement
+	}
+
+	// Introspector is implemented by the Caller returned by NewCaller.
+	Introspector interface {
+		Snapshot() Snapshot
+	}
+
+	// introspection tracks the observable state of a client; it's guarded by its own lock so that
+	// snapshots may be taken while a call is in progress.
+	introspection struct {
+		sync.Mutex
+		snapshot Snapshot
+	}
+)
+
+const (
+	// Disconnected indicates that there's no subscription stream.
+	Disconnected SubscriptionState = iota
+	// Connected indicates that a subscription stream has been established, but that the SUBSCRIBED
+	// event has not yet been received.
+	Connected
+	// Subscribed indicates that the SUBSCRIBED event has been received via the subscription stream.
+	Subscribed
+)
+
+var _ = Introspector(&state{})
+
+func (s SubscriptionState) String() string {
+	switch s {
+	case Disconnected:
+		return "DISCONNECTED"
+	case Connected:
+		return "CONNECTED"
+	case Subscribed:
+		return "SUBSCRIBED"
+	}
+	return "UNKNOWN"
+}
+
+func (i *introspection) update(f func(*Snapshot)) {
+	i.Lock()
+	defer i.Unlock()
+	f(&i.snapshot)
+}
+
+// Snapshot implements Introspector.
+func (state *state) Snapshot() Snapshot {
+	i := &state.client.introspection
+	i.Lock()
+	defer i.Unlock()
+	return i.snapshot
+}
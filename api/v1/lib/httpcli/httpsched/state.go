@@ -6,8 +6,10 @@ import (
 	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/mesos/mesos-go/api/v1/lib"
+	mesosclient "github.com/mesos/mesos-go/api/v1/lib/client"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding"
 	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
 	"github.com/mesos/mesos-go/api/v1/lib/httpcli/apierrors"
@@ -41,6 +43,8 @@ type (
 		call *scheduler.Call // call is the next call to execute
 		resp mesos.Response  // resp is the Mesos response from the most recently executed call
 		err  error           // err is the error from the most recently executed call
+
+		disconnect func() // disconnect (maybe) terminates the current subscription
 	}
 
 	stateFn func(context.Context, *state) stateFn
@@ -157,16 +161,49 @@ func disconnectedFn(ctx context.Context, state *state) stateFn {
 		return disconnectedFn
 	}
 
+	var (
+		endpoint  = state.client.Endpoint()
+		listener  = state.client.listener
+		onceEnded sync.Once
+	)
 	transitionToDisconnected := func() {
 		state.m.Lock()
-		defer state.m.Unlock()
 		state.fn = disconnectedFn
+		state.disconnect = nil
 		_ = stateResp.Close() // swallow any error here
+		state.m.Unlock()
+
+		state.client.introspection.update(func(s *Snapshot) {
+			if s.StreamID == mesosStreamID {
+				// otherwise a subsequent subscription has already taken over
+				s.StreamID, s.State = "", Disconnected
+			}
+		})
+		onceEnded.Do(func() {
+			mesosclient.Notify(listener, mesosclient.LifecycleEvent{
+				Transition: mesosclient.Disconnected,
+				Endpoint:   endpoint,
+				StreamID:   mesosStreamID,
+			})
+		})
 	}
+	state.disconnect = transitionToDisconnected
 
 	// wrap the response: any errors processing the subscription stream should result in a
 	// transition to a disconnected state ASAP.
 	state.resp = DisconnectionDetector(transitionToDisconnected).Decorate(stateResp)
+	state.resp = &mesos.ResponseWrapper{
+		Response: state.resp,
+		Decoder:  state.observingDecoder(state.resp, endpoint, mesosStreamID),
+	}
+	state.client.introspection.update(func(s *Snapshot) {
+		s.StreamID, s.State, s.PendingAcks = mesosStreamID, Connected, 0
+	})
+	mesosclient.Notify(listener, mesosclient.LifecycleEvent{
+		Transition: mesosclient.Connected,
+		Endpoint:   endpoint,
+		StreamID:   mesosStreamID,
+	})
 
 	// (e) else prepare callerTemporary w/ special header, return connectedFn since we're now subscribed
 	state.caller = &callerTemporary{
@@ -176,6 +213,38 @@ func disconnectedFn(ctx context.Context, state *state) stateFn {
 	return connectedFn
 }
 
+// observingDecoder tracks the events of a subscription stream for the sake of introspection, and
+// notifies the lifecycle listener (if any) upon receipt of a SUBSCRIBED event.
+func (state *state) observingDecoder(decoder encoding.Decoder, endpoint, streamID string) encoding.Decoder {
+	return encoding.DecoderFunc(func(u encoding.Unmarshaler) (err error) {
+		err = decoder.Decode(u)
+		e, ok := u.(*scheduler.Event)
+		if !ok || err != nil {
+			return
+		}
+		switch e.GetType() {
+		case scheduler.Event_SUBSCRIBED:
+			state.client.introspection.update(func(s *Snapshot) {
+				if s.StreamID == streamID {
+					s.State = Subscribed
+				}
+			})
+			mesosclient.Notify(state.client.listener, mesosclient.LifecycleEvent{
+				Transition: mesosclient.Subscribed,
+				Endpoint:   endpoint,
+				StreamID:   streamID,
+			})
+		case scheduler.Event_HEARTBEAT:
+			state.client.introspection.update(func(s *Snapshot) { s.LastHeartbeat = time.Now() })
+		case scheduler.Event_UPDATE:
+			if e.GetUpdate().GetStatus().UUID != nil {
+				state.client.introspection.update(func(s *Snapshot) { s.PendingAcks++ })
+			}
+		}
+		return
+	})
+}
+
 func errorIndicatesSubscriptionLoss(err error) (result bool) {
 	type lossy interface {
 		SubscriptionLoss() bool
@@ -216,6 +285,13 @@ func connectedFn(ctx context.Context, state *state) stateFn {
 
 	// (b) execute call, save the result in resp, err
 	state.resp, state.err = state.caller.Call(ctx, state.call)
+	if state.err == nil && state.call.GetType() == scheduler.Call_ACKNOWLEDGE {
+		state.client.introspection.update(func(s *Snapshot) {
+			if s.PendingAcks > 0 {
+				s.PendingAcks--
+			}
+		})
+	}
 
 	if errorIndicatesSubscriptionLoss(state.err) {
 		// properly transition back to a disconnected state if mesos thinks that we're unsubscribed
@@ -238,3 +314,33 @@ func (state *state) Call(ctx context.Context, call *scheduler.Call) (resp mesos.
 
 	return state.resp, state.err
 }
+
+// followLeader points the client at the leading master upon every leadership change, disconnecting
+// any subscription to a former leader. It returns once the leaders chan is closed.
+func (state *state) followLeader(leaders <-chan mesos.MasterInfo) {
+	for mi := range leaders {
+		state.m.Lock()
+		endpoint, ok := buildNewEndpoint("//"+mi.HostPort(), state.client.Endpoint())
+		previous := state.client.Endpoint()
+		changed := ok && endpoint != previous
+		if changed {
+			if debug {
+				log.Println("leading master changed, new endpoint " + endpoint)
+			}
+			state.client.setEndpoint(endpoint)
+		}
+		disconnect := state.disconnect
+		state.m.Unlock()
+
+		if changed {
+			mesosclient.Notify(state.client.listener, mesosclient.LifecycleEvent{
+				Transition: mesosclient.Redirected,
+				Endpoint:   endpoint,
+				Previous:   previous,
+			})
+			if disconnect != nil {
+				disconnect()
+			}
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package httpsched
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"github.com/mesos/mesos-go/api/v1/lib"
+	mesosclient "github.com/mesos/mesos-go/api/v1/lib/client"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding"
 	"github.com/mesos/mesos-go/api/v1/lib/extras/latch"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
 )
 
 func TestDisconnectionDecoder(t *testing.T) {
@@ -53,3 +57,51 @@ func TestDisconnectionDecoder(t *testing.T) {
 		t.Error("disconnect func was not called")
 	}
 }
+
+func TestObservingDecoder(t *testing.T) {
+	var (
+		transitions []mesosclient.Transition
+		listener    = mesosclient.LifecycleListenerFunc(func(e mesosclient.LifecycleEvent) {
+			transitions = append(transitions, e.Transition)
+		})
+		state = &state{client: &client{listener: listener}}
+		uuid  = []byte("uuid")
+		queue = []scheduler.Event{
+			{Type: scheduler.Event_SUBSCRIBED},
+			{Type: scheduler.Event_HEARTBEAT},
+			{Type: scheduler.Event_UPDATE, Update: &scheduler.Event_Update{Status: mesos.TaskStatus{UUID: uuid}}},
+			{Type: scheduler.Event_UPDATE, Update: &scheduler.Event_Update{Status: mesos.TaskStatus{}}},
+		}
+		decoder = encoding.DecoderFunc(func(u encoding.Unmarshaler) error {
+			*(u.(*scheduler.Event)) = queue[0]
+			queue = queue[1:]
+			return nil
+		})
+	)
+	state.client.introspection.snapshot = Snapshot{Endpoint: "http://m1", StreamID: "s1", State: Connected}
+
+	d := state.observingDecoder(decoder, "http://m1", "s1")
+	for len(queue) > 0 {
+		if err := d.Decode(new(scheduler.Event)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := state.Snapshot()
+	if s.State != Subscribed || s.PendingAcks != 1 || s.LastHeartbeat.IsZero() || s.Endpoint != "http://m1" {
+		t.Fatalf("unexpected snapshot %+v", s)
+	}
+	if len(transitions) != 1 || transitions[0] != mesosclient.Subscribed {
+		t.Fatalf("unexpected transitions %v", transitions)
+	}
+
+	// acknowledgements are deducted from the pending count
+	state.caller = calls.CallerFunc(func(_ context.Context, _ *scheduler.Call) (mesos.Response, error) { return nil, nil })
+	state.fn = connectedFn
+	if _, err := state.Call(context.Background(), &scheduler.Call{Type: scheduler.Call_ACKNOWLEDGE}); err != nil {
+		t.Fatal(err)
+	}
+	if s = state.Snapshot(); s.PendingAcks != 0 {
+		t.Fatalf("unexpected snapshot %+v", s)
+	}
+}
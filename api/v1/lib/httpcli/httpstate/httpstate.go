@@ -0,0 +1,249 @@
+// Package httpstate is a client for the unversioned JSON state endpoints of Mesos masters and agents, for
+// tooling that must work w/ versions of Mesos that predate (parts of) the v1 operator API. The state is
+// decoded into typed structs that capture the commonly used fields of the JSON; httpmaster.Client and
+// httpagent.Client should be preferred where the v1 operator API is available.
+package httpstate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/apierrors"
+)
+
+const (
+	// MasterStatePath is the path of the state endpoint of a master.
+	MasterStatePath = "/master/state"
+	// AgentStatePath is the path of the state endpoint of an agent. Agents don't serve the state at
+	// "/slave/state"; the endpoint belongs to the "slave(1)" libprocess process.
+	AgentStatePath = "/slave(1)/state"
+)
+
+type (
+	// Resources are the well-known resources, as rendered by the state endpoints; for example
+	// {"cpus": 4, "mem": 1024, "disk": 2048, "ports": "[31000-32000]"}.
+	Resources struct {
+		CPUs  float64 `json:"cpus"`
+		Mem   float64 `json:"mem"`
+		Disk  float64 `json:"disk"`
+		GPUs  float64 `json:"gpus"`
+		Ports string  `json:"ports,omitempty"`
+	}
+
+	// TaskStatus is a status update of a task.
+	TaskStatus struct {
+		State     mesos.TaskState `json:"state"`
+		Timestamp float64         `json:"timestamp"`
+	}
+
+	// Task is a task of a framework.
+	Task struct {
+		ID          string          `json:"id"`
+		Name        string          `json:"name"`
+		FrameworkID string          `json:"framework_id"`
+		ExecutorID  string          `json:"executor_id"`
+		AgentID     string          `json:"slave_id"`
+		State       mesos.TaskState `json:"state"`
+		Role        string          `json:"role,omitempty"`
+		Resources   Resources       `json:"resources"`
+		Statuses    []TaskStatus    `json:"statuses"`
+	}
+
+	// Executor is an executor of a framework, as reported by an agent.
+	Executor struct {
+		ID             string    `json:"id"`
+		Name           string    `json:"name"`
+		Source         string    `json:"source"`
+		Directory      string    `json:"directory"`
+		Role           string    `json:"role,omitempty"`
+		Resources      Resources `json:"resources"`
+		Tasks          []Task    `json:"tasks"`
+		QueuedTasks    []Task    `json:"queued_tasks"`
+		CompletedTasks []Task    `json:"completed_tasks"`
+	}
+
+	// Framework is a framework, as reported by a master or by an agent; the latter reports executors
+	// rather than tasks.
+	Framework struct {
+		ID                 string     `json:"id"`
+		Name               string     `json:"name"`
+		PID                string     `json:"pid,omitempty"`
+		User               string     `json:"user"`
+		Hostname           string     `json:"hostname"`
+		Principal          string     `json:"principal,omitempty"`
+		WebUIURL           string     `json:"webui_url,omitempty"`
+		Role               string     `json:"role,omitempty"`
+		Roles              []string   `json:"roles,omitempty"`
+		Capabilities       []string   `json:"capabilities,omitempty"`
+		Active             bool       `json:"active"`
+		Connected          bool       `json:"connected"`
+		FailoverTimeout    float64    `json:"failover_timeout"`
+		RegisteredTime     float64    `json:"registered_time"`
+		UsedResources      Resources  `json:"used_resources"`
+		OfferedResources   Resources  `json:"offered_resources"`
+		Tasks              []Task     `json:"tasks"`
+		CompletedTasks     []Task     `json:"completed_tasks"`
+		Executors          []Executor `json:"executors"`
+		CompletedExecutors []Executor `json:"completed_executors"`
+	}
+
+	// Agent is an agent, as reported by a master.
+	Agent struct {
+		ID                  string                 `json:"id"`
+		PID                 string                 `json:"pid"`
+		Hostname            string                 `json:"hostname"`
+		Version             string                 `json:"version,omitempty"`
+		Active              bool                   `json:"active"`
+		RegisteredTime      float64                `json:"registered_time"`
+		Attributes          map[string]interface{} `json:"attributes"`
+		Resources           Resources              `json:"resources"`
+		UsedResources       Resources              `json:"used_resources"`
+		OfferedResources    Resources              `json:"offered_resources"`
+		UnreservedResources Resources              `json:"unreserved_resources"`
+		ReservedResources   map[string]Resources   `json:"reserved_resources"` // by role
+	}
+
+	// Build is the build information of a master or agent.
+	Build struct {
+		Version   string  `json:"version"`
+		GitSHA    string  `json:"git_sha,omitempty"`
+		GitTag    string  `json:"git_tag,omitempty"`
+		BuildDate string  `json:"build_date,omitempty"`
+		BuildTime float64 `json:"build_time,omitempty"`
+		BuildUser string  `json:"build_user,omitempty"`
+	}
+
+	// MasterState is the state of a master, as reported by MasterStatePath.
+	MasterState struct {
+		Build
+		ID                  string            `json:"id"`
+		PID                 string            `json:"pid"`
+		Hostname            string            `json:"hostname"`
+		Leader              string            `json:"leader"`
+		StartTime           float64           `json:"start_time"`
+		ElectedTime         float64           `json:"elected_time,omitempty"`
+		Cluster             string            `json:"cluster,omitempty"`
+		Flags               map[string]string `json:"flags"`
+		Agents              []Agent           `json:"slaves"`
+		Frameworks          []Framework       `json:"frameworks"`
+		CompletedFrameworks []Framework       `json:"completed_frameworks"`
+		OrphanTasks         []Task            `json:"orphan_tasks"`
+	}
+
+	// AgentState is the state of an agent, as reported by AgentStatePath.
+	AgentState struct {
+		Build
+		ID                  string                 `json:"id"`
+		PID                 string                 `json:"pid"`
+		Hostname            string                 `json:"hostname"`
+		MasterHostname      string                 `json:"master_hostname,omitempty"`
+		StartTime           float64                `json:"start_time"`
+		Attributes          map[string]interface{} `json:"attributes"`
+		Flags               map[string]string      `json:"flags"`
+		Resources           Resources              `json:"resources"`
+		Frameworks          []Framework            `json:"frameworks"`
+		CompletedFrameworks []Framework            `json:"completed_frameworks"`
+	}
+
+	// Client fetches the state of a master or agent.
+	Client struct {
+		endpoint string
+		client   *http.Client
+	}
+
+	// Opt is a functional option type for Client.
+	Opt func(*Client)
+)
+
+// HTTPClient sets the HTTP client that's used to fetch the state; http.DefaultClient by default.
+func HTTPClient(client *http.Client) Opt { return func(c *Client) { c.client = client } }
+
+// NewClient returns a Client for the master or agent at the given endpoint; for example,
+// "http://master:5050".
+func NewClient(endpoint string, opts ...Opt) *Client {
+	c := &Client{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		client:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// MasterState fetches the state of the master. Requests are not redirected to the leading master; the
+// Leader of the state should be consulted to that end.
+func (c *Client) MasterState(ctx context.Context) (*MasterState, error) {
+	var state MasterState
+	if err := c.get(ctx, MasterStatePath, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// AgentState fetches the state of the agent.
+func (c *Client) AgentState(ctx context.Context) (*AgentState, error) {
+	var state AgentState
+	if err := c.get(ctx, AgentStatePath, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// get decodes the JSON that's served at the given path; versions of Mesos prior to 0.25 only serve the
+// state w/ a ".json" suffix, which is attempted if the path isn't found.
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	err := c.getJSON(ctx, path, v)
+	if apierrors.CodeNotFound.Matches(err) {
+		err = c.getJSON(ctx, path+".json", v)
+	}
+	return err
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequest("GET", c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if err = apierrors.FromResponse(res); err != nil {
+		return err
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// FindTask returns the task w/ the given ID, active or completed, of any of the frameworks.
+func (s *MasterState) FindTask(id string) *Task {
+	for _, fs := range [][]Framework{s.Frameworks, s.CompletedFrameworks} {
+		for i := range fs {
+			for _, ts := range [][]Task{fs[i].Tasks, fs[i].CompletedTasks} {
+				for j := range ts {
+					if ts[j].ID == id {
+						return &ts[j]
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FindAgent returns the agent w/ the given ID.
+func (s *MasterState) FindAgent(id string) *Agent {
+	for i := range s.Agents {
+		if s.Agents[i].ID == id {
+			return &s.Agents[i]
+		}
+	}
+	return nil
+}
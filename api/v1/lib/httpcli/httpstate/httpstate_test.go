@@ -0,0 +1,109 @@
+package httpstate
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/apierrors"
+)
+
+const (
+	masterState = `{
+		"version": "0.24.1",
+		"id": "20150908-010203-16842879-5050-1",
+		"pid": "master@10.0.0.1:5050",
+		"hostname": "master1",
+		"leader": "master@10.0.0.1:5050",
+		"flags": {"quorum": "1"},
+		"slaves": [{
+			"id": "S1",
+			"hostname": "agent1",
+			"active": true,
+			"attributes": {"rack": "r1"},
+			"resources": {"cpus": 4, "mem": 1024, "disk": 2048, "ports": "[31000-32000]"},
+			"reserved_resources": {"web": {"cpus": 1, "mem": 0, "disk": 0}}
+		}],
+		"frameworks": [{
+			"id": "F1",
+			"name": "marathon",
+			"user": "root",
+			"role": "*",
+			"active": true,
+			"used_resources": {"cpus": 0.5, "mem": 128, "disk": 0},
+			"tasks": [{"id": "t1", "framework_id": "F1", "slave_id": "S1", "state": "TASK_RUNNING",
+				"resources": {"cpus": 0.5, "mem": 128, "disk": 0},
+				"statuses": [{"state": "TASK_RUNNING", "timestamp": 1441674123.5}]}],
+			"completed_tasks": [{"id": "t0", "framework_id": "F1", "slave_id": "S1", "state": "TASK_FINISHED"}]
+		}],
+		"completed_frameworks": []
+	}`
+	agentState = `{
+		"version": "1.4.0",
+		"id": "S1",
+		"hostname": "agent1",
+		"resources": {"cpus": 4, "mem": 1024, "disk": 2048},
+		"frameworks": [{
+			"id": "F1",
+			"name": "marathon",
+			"executors": [{"id": "t1", "directory": "/var/lib/mesos/slaves/S1/frameworks/F1/executors/t1/runs/r1",
+				"tasks": [{"id": "t1", "state": "TASK_RUNNING"}]}]
+		}]
+	}`
+)
+
+func TestClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/master/state.json", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, masterState)
+	})
+	mux.HandleFunc(AgentStatePath, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, agentState)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cli := NewClient(ts.URL + "/")
+	ms, err := cli.MasterState(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms.Version != "0.24.1" || ms.Flags["quorum"] != "1" {
+		t.Fatalf("unexpected master state %+v", ms)
+	}
+	agent := ms.FindAgent("S1")
+	if agent == nil || agent.Resources.CPUs != 4 || agent.Resources.Ports != "[31000-32000]" || agent.ReservedResources["web"].CPUs != 1 {
+		t.Fatalf("unexpected agent %+v", agent)
+	}
+	if task := ms.FindTask("t1"); task == nil || task.State != mesos.TASK_RUNNING || task.AgentID != "S1" || len(task.Statuses) != 1 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	if task := ms.FindTask("t0"); task == nil || task.State != mesos.TASK_FINISHED {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	as, err := cli.AgentState(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if as.ID != "S1" || len(as.Frameworks) != 1 || len(as.Frameworks[0].Executors) != 1 ||
+		as.Frameworks[0].Executors[0].Tasks[0].State != mesos.TASK_RUNNING {
+		t.Fatalf("unexpected agent state %+v", as)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, "Master is not elected yet")
+	}))
+	defer ts.Close()
+
+	_, err := NewClient(ts.URL).MasterState(context.Background())
+	if !apierrors.Is(err, apierrors.ErrTemporary) || err.(*apierrors.Error).Details() != "Master is not elected yet" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
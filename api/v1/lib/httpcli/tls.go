@@ -0,0 +1,184 @@
+package httpcli
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ErrDowngrade is returned by a Client that's configured w/ ForbidDowngrade upon an attempt to send a
+// request via HTTP after having sent one via HTTPS.
+var ErrDowngrade = errors.New("refusing to downgrade from https to http")
+
+// NewTLSConfig returns a TLS configuration that trusts the certificate authorities in the PEM-encoded
+// caFile (or the system roots, if caFile is empty) and that presents the client certificate in certFile
+// and keyFile (if specified); the client certificate is reloaded once the files are modified, see
+// ClientCertificateFiles. The configuration may be applied to a Client via TLSConfig, and shared w/
+// other clients of the cluster's services, for example a ZooKeeper master detector.
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	tc := &tls.Config{}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.Certificates = []tls.Certificate{cert}
+		tc.GetClientCertificate = ClientCertificateFiles(certFile, keyFile)
+	}
+	return tc, nil
+}
+
+// PinCertificates returns a ConfigOpt that requires a verified certificate chain of the TLS peer to include
+// a certificate whose public key matches one of the given pins, in addition to the usual verification of
+// the certificate chain. A pin is the base64-encoded SHA-256 digest of the DER-encoded SubjectPublicKeyInfo
+// of a certificate (as per RFC 7469), optionally prefixed w/ "sha256/"; pinning the key of an intermediate
+// or root CA allows for leaf certificates to be reissued. Certificates that the peer presents outside of
+// the verified chains never match, and pinned connections are refused if the chain isn't verified (see
+// tls.Config.InsecureSkipVerify). The option must be specified after TLSConfig, if any.
+func PinCertificates(pins ...string) ConfigOpt {
+	pinned := make(map[string]struct{}, len(pins))
+	for _, pin := range pins {
+		pinned[strings.TrimPrefix(pin, "sha256/")] = struct{}{}
+	}
+	return verifyPeer(func(_ []*x509.Certificate, verifiedChains [][]*x509.Certificate) error {
+		if len(verifiedChains) == 0 {
+			return errors.New("refusing to pin the public key of an unverified TLS peer")
+		}
+		for _, chain := range verifiedChains {
+			for _, cert := range chain {
+				if _, ok := pinned[CertificatePin(cert)]; ok {
+					return nil
+				}
+			}
+		}
+		return errors.New("no certificate of the TLS peer matches a pinned public key")
+	})
+}
+
+// CertificatePin returns the pin of the public key of the certificate, as per PinCertificates.
+func CertificatePin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// RequireSANs returns a ConfigOpt that requires the leaf certificate of the TLS peer to carry a subject
+// alternative name (a DNS name or an IP address) that matches one of the given patterns, for example
+// "master-*.mesos.example.com", in addition to the usual verification of the certificate chain. Patterns
+// are matched label by label, case-insensitively (see path.Match); as per RFC 6125, a "*" never spans more
+// than a single label. This restricts the peers that are trusted to the certificates of masters, as
+// opposed to any certificate of the CA for the host that's being connected to; for example, the host that
+// a redirect points at during a leadership change. The option must be specified after TLSConfig, if any.
+func RequireSANs(patterns ...string) ConfigOpt {
+	lower := make([]string, len(patterns))
+	for i := range patterns {
+		lower[i] = strings.ToLower(patterns[i])
+	}
+	return verifyPeer(func(certs []*x509.Certificate, _ [][]*x509.Certificate) error {
+		if len(certs) == 0 {
+			return errors.New("the TLS peer presented no certificate")
+		}
+		var names []string
+		names = append(names, certs[0].DNSNames...)
+		for _, ip := range certs[0].IPAddresses {
+			names = append(names, ip.String())
+		}
+		for _, name := range names {
+			for _, pattern := range lower {
+				if matchSAN(pattern, strings.ToLower(name)) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("no subject alternative name of the TLS peer (%s) matches the required patterns",
+			strings.Join(names, ", "))
+	})
+}
+
+// matchSAN returns true if every label of the name matches the respective label of the pattern.
+func matchSAN(pattern, name string) bool {
+	patterns, labels := strings.Split(pattern, "."), strings.Split(name, ".")
+	if len(patterns) != len(labels) {
+		return false
+	}
+	for i := range labels {
+		if ok, _ := path.Match(patterns[i], labels[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyPeer returns a ConfigOpt that chains f to the VerifyPeerCertificate func of the TLS configuration;
+// f is invoked w/ the certificates that the peer presented, leaf first, and w/ the verified chains.
+func verifyPeer(f func(certs []*x509.Certificate, verifiedChains [][]*x509.Certificate) error) ConfigOpt {
+	return func(c *Config) {
+		tc := c.transport.TLSClientConfig
+		if tc == nil {
+			tc = &tls.Config{}
+		} else {
+			tc = tc.Clone()
+		}
+		previous := tc.VerifyPeerCertificate
+		tc.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			if previous != nil {
+				if err := previous(rawCerts, verifiedChains); err != nil {
+					return err
+				}
+			}
+			certs := make([]*x509.Certificate, 0, len(rawCerts))
+			for _, raw := range rawCerts {
+				cert, err := x509.ParseCertificate(raw)
+				if err != nil {
+					return err
+				}
+				certs = append(certs, cert)
+			}
+			return f(certs, verifiedChains)
+		}
+		c.transport.TLSClientConfig = tc
+	}
+}
+
+// ForbidDowngrade returns a ConfigOpt that refuses to send requests via HTTP once a request has been sent
+// via HTTPS, yielding ErrDowngrade instead; for example, when a redirect (or a leadership change) points
+// a scheduler that subscribed via HTTPS at an HTTP endpoint.
+func ForbidDowngrade() ConfigOpt {
+	var (
+		mu     sync.Mutex
+		secure bool
+	)
+	return WrapRoundTripper(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			switch req.URL.Scheme {
+			case "https":
+				secure = true
+			case "http":
+				if secure {
+					mu.Unlock()
+					return nil, ErrDowngrade
+				}
+			}
+			mu.Unlock()
+			return rt.RoundTrip(req)
+		})
+	})
+}
@@ -0,0 +1,192 @@
+package httpcli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	tc, err := NewTLSConfig("", "", "")
+	if err != nil || tc.RootCAs != nil || len(tc.Certificates) != 0 {
+		t.Fatalf("unexpected config %v: %v", tc, err)
+	}
+
+	f, err := ioutil.TempFile("", "ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("not a certificate")
+	f.Close()
+
+	for i, files := range [][3]string{
+		{f.Name(), "", ""},
+		{"/does/not/exist", "", ""},
+		{"", "/does/not/exist", "/does/not/exist"},
+		{"", f.Name(), ""},
+	} {
+		if _, err := NewTLSConfig(files[0], files[1], files[2]); err == nil {
+			t.Errorf("test case %d: expected an error", i)
+		}
+	}
+}
+
+func TestStrictTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // rejected handshakes are expected
+	srv.StartTLS()
+	defer srv.Close()
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer plain.Close()
+
+	cert, err := x509.ParseCertificate(srv.TLS.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	get := func(url string, opts ...ConfigOpt) error {
+		transport := &http.Transport{}
+		config := &Config{client: &http.Client{Transport: transport}, transport: transport}
+		TLSConfig(&tls.Config{RootCAs: roots})(config)
+		for _, opt := range opts {
+			opt(config)
+		}
+		res, err := config.client.Get(url)
+		if err == nil {
+			res.Body.Close()
+		}
+		return err
+	}
+	for i, tc := range []struct {
+		opts    []ConfigOpt
+		wantErr bool
+	}{
+		{nil, false},
+		{[]ConfigOpt{PinCertificates("sha256/" + CertificatePin(cert))}, false},
+		{[]ConfigOpt{PinCertificates("bm90IGEgcGlu")}, true},
+		{[]ConfigOpt{TLSConfig(&tls.Config{InsecureSkipVerify: true}), PinCertificates(CertificatePin(cert))}, true},
+		{[]ConfigOpt{RequireSANs("*.com")}, false},
+		{[]ConfigOpt{RequireSANs("*.COM")}, false},
+		{[]ConfigOpt{RequireSANs("master-*.mesos.example.com")}, true},
+		{[]ConfigOpt{RequireSANs("127.0.0.*"), PinCertificates(CertificatePin(cert))}, false},
+		{[]ConfigOpt{RequireSANs("127.0.0.*"), PinCertificates("bm90IGEgcGlu")}, true},
+	} {
+		if err := get(srv.URL, tc.opts...); (err != nil) != tc.wantErr {
+			t.Errorf("test case %d: unexpected error %v", i, err)
+		}
+	}
+
+	transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+	config := &Config{client: &http.Client{Transport: transport}, transport: transport}
+	ForbidDowngrade()(config)
+	if res, err := config.client.Get(plain.URL); err != nil {
+		t.Fatalf("unexpected error before using https: %v", err)
+	} else {
+		res.Body.Close()
+	}
+	if res, err := config.client.Get(srv.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else {
+		res.Body.Close()
+	}
+	if _, err := config.client.Get(plain.URL); err == nil || !strings.Contains(err.Error(), ErrDowngrade.Error()) {
+		t.Fatalf("expected ErrDowngrade instead of %v", err)
+	}
+}
+
+func TestMatchSAN(t *testing.T) {
+	for i, tc := range []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"master-*.mesos.example.com", "master-a.mesos.example.com", true},
+		{"master-*.mesos.example.com", "master-a.b.mesos.example.com", false},
+		{"*.example.com", "example.com", false},
+		{"127.0.0.*", "127.0.0.1", true},
+		{"127.0.*", "127.0.0.1", false},
+	} {
+		if got := matchSAN(tc.pattern, tc.name); got != tc.want {
+			t.Errorf("test case %d: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+}
+
+func TestPinCertificates_Presented(t *testing.T) {
+	// the peer presents a pinned certificate that's not part of its verified chain
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pinned CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // rejected handshakes are expected
+	srv.StartTLS()
+	defer srv.Close()
+	leaf := srv.TLS.Certificates[0]
+	cert, err := x509.ParseCertificate(leaf.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv2 := httptest.NewUnstartedServer(srv.Config.Handler)
+	srv2.Config.ErrorLog = srv.Config.ErrorLog
+	srv2.TLS = &tls.Config{Certificates: []tls.Certificate{{
+		Certificate: append(leaf.Certificate[:1:1], der),
+		PrivateKey:  leaf.PrivateKey,
+	}}}
+	srv2.StartTLS()
+	defer srv2.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	for i, tc := range []struct {
+		pin     string
+		wantErr bool
+	}{
+		{CertificatePin(pinned), true},
+		{CertificatePin(cert), false},
+	} {
+		transport := &http.Transport{}
+		config := &Config{client: &http.Client{Transport: transport}, transport: transport}
+		TLSConfig(&tls.Config{RootCAs: roots})(config)
+		PinCertificates(tc.pin)(config)
+		res, err := config.client.Get(srv2.URL)
+		if err == nil {
+			res.Body.Close()
+		}
+		if (err != nil) != tc.wantErr {
+			t.Errorf("test case %d: unexpected error %v", i, err)
+		}
+	}
+}
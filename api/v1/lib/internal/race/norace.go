@@ -0,0 +1,8 @@
+// +build !race
+
+// Package race reports whether the race detector is enabled, so that tests that assert allocation
+// counts may skip themselves.
+package race
+
+// Enabled is true when the race detector is enabled; allocation counts are inflated by the detector.
+const Enabled = false
@@ -0,0 +1,6 @@
+// +build race
+
+package race
+
+// Enabled is true when the race detector is enabled; allocation counts are inflated by the detector.
+const Enabled = true
@@ -93,3 +93,31 @@ func (left *Labels) Format() string {
 	left.writeTo(&b)
 	return b.String()
 }
+
+// Get returns the value of the first label w/ the given key; false if there's no such label.
+func (left *Labels) Get(key string) (string, bool) {
+	for _, l := range left.GetLabels() {
+		if l.Key == key {
+			return l.GetValue(), true
+		}
+	}
+	return "", false
+}
+
+// Set sets the value of the label w/ the given key, replacing the value of an existing label (if any).
+func (left *Labels) Set(key, value string) {
+	for i := range left.Labels {
+		if left.Labels[i].Key == key {
+			left.Labels[i].Value = &value
+			return
+		}
+	}
+	left.Labels = append(left.Labels, Label{Key: key, Value: &value})
+}
+
+// Merge sets all of the labels of right into left; labels of right take precedence.
+func (left *Labels) Merge(right *Labels) {
+	for _, l := range right.GetLabels() {
+		left.Set(l.Key, l.GetValue())
+	}
+}
@@ -3,6 +3,8 @@ package mesos
 import (
 	"strconv"
 	"testing"
+
+	"github.com/gogo/protobuf/proto"
 )
 
 func TestEquivalent_Labels(t *testing.T) {
@@ -103,3 +105,25 @@ func TestLabels_Format(t *testing.T) {
 		})
 	}
 }
+
+func TestLabels_GetSetMerge(t *testing.T) {
+	var labels Labels
+	if _, ok := labels.Get("a"); ok {
+		t.Fatal("unexpected label a")
+	}
+	labels.Set("a", "1")
+	labels.Set("b", "2")
+	labels.Set("a", "3")
+	labels.Merge(&Labels{Labels: []Label{{Key: "b"}, {Key: "c", Value: proto.String("4")}}})
+	for k, want := range map[string]string{"a": "3", "b": "", "c": "4"} {
+		if v, ok := labels.Get(k); !ok || v != want {
+			t.Errorf("label %q: expected %q instead of %q", k, want, v)
+		}
+	}
+	if n := len(labels.Labels); n != 3 {
+		t.Fatalf("expected 3 labels instead of %d", n)
+	}
+	if _, ok := (*Labels)(nil).Get("a"); ok {
+		t.Fatal("unexpected label for nil Labels")
+	}
+}
@@ -0,0 +1,89 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// Machine returns the MachineID for the given hostname and IP address; either may be empty.
+func Machine(hostname, ip string) (m mesos.MachineID) {
+	if hostname != "" {
+		m.Hostname = &hostname
+	}
+	if ip != "" {
+		m.IP = &ip
+	}
+	return
+}
+
+// NewWindow returns a maintenance window during which the given machines are expected to be unavailable.
+func NewWindow(u mesos.Unavailability, machines ...mesos.MachineID) Window {
+	return Window{MachineIDs: machines, Unavailability: u}
+}
+
+// NewSchedule returns a Schedule comprised of the given windows.
+func NewSchedule(windows ...Window) Schedule {
+	return Schedule{Windows: windows}
+}
+
+// Rolling returns a Schedule that takes down batches of machines, one after another, beginning at start.
+// Each batch is unavailable for the duration d, and the unavailability of the next batch begins once the
+// gap has elapsed after the end of the previous batch.
+func Rolling(start time.Time, d, gap time.Duration, batches ...[]mesos.MachineID) Schedule {
+	s := Schedule{Windows: make([]Window, 0, len(batches))}
+	for _, batch := range batches {
+		if len(batch) == 0 {
+			continue
+		}
+		s.Windows = append(s.Windows, NewWindow(mesos.NewUnavailability(start, d), batch...))
+		start = start.Add(d + gap)
+	}
+	return s
+}
+
+// Machines returns the machines of all windows of the Schedule.
+func (s *Schedule) Machines() (result []mesos.MachineID) {
+	for i := range s.GetWindows() {
+		result = append(result, s.Windows[i].MachineIDs...)
+	}
+	return
+}
+
+// WindowOf returns the window that includes the given machine; nil if the machine isn't scheduled.
+func (s *Schedule) WindowOf(m mesos.MachineID) *Window {
+	for i := range s.GetWindows() {
+		if containsMachine(s.Windows[i].MachineIDs, m) {
+			return &s.Windows[i]
+		}
+	}
+	return nil
+}
+
+// Without returns a copy of the Schedule that excludes the given machines; windows that no longer include
+// any machines are dropped. Useful for updating a schedule once the maintenance of machines has completed.
+func (s Schedule) Without(machines ...mesos.MachineID) Schedule {
+	result := Schedule{Windows: make([]Window, 0, len(s.Windows))}
+	for _, w := range s.Windows {
+		var remaining []mesos.MachineID
+		for _, m := range w.MachineIDs {
+			if !containsMachine(machines, m) {
+				remaining = append(remaining, m)
+			}
+		}
+		if len(remaining) > 0 {
+			w.MachineIDs = remaining
+			result.Windows = append(result.Windows, w)
+		}
+	}
+	return result
+}
+
+func containsMachine(machines []mesos.MachineID, m mesos.MachineID) bool {
+	for i := range machines {
+		if machines[i].Equal(&m) {
+			return true
+		}
+	}
+	return false
+}
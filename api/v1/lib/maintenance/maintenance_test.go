@@ -0,0 +1,49 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestRolling(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		a, b  = Machine("a", ""), Machine("b", "10.0.0.2")
+		c     = Machine("", "10.0.0.3")
+		s     = Rolling(start, time.Hour, time.Minute, []mesos.MachineID{a, b}, nil, []mesos.MachineID{c})
+	)
+	if n := len(s.Windows); n != 2 {
+		t.Fatalf("expected 2 windows instead of %d", n)
+	}
+	second := s.Windows[1].Unavailability
+	if got, want := second.StartTime(), start.Add(time.Hour+time.Minute); !got.Equal(want) {
+		t.Errorf("expected second window to start at %v instead of %v", want, got)
+	}
+	if end, ok := second.EndTime(); !ok || !end.Equal(start.Add(2*time.Hour+time.Minute)) {
+		t.Errorf("unexpected end of second window: %v, %v", end, ok)
+	}
+	if !second.Contains(start.Add(90*time.Minute)) || second.Contains(start) {
+		t.Errorf("unexpected interval containment")
+	}
+	if w := s.WindowOf(c); w != &s.Windows[1] {
+		t.Errorf("expected machine c in second window")
+	}
+	if n := len(s.Machines()); n != 3 {
+		t.Errorf("expected 3 machines instead of %d", n)
+	}
+
+	s = s.Without(a, c)
+	if len(s.Windows) != 1 || len(s.Windows[0].MachineIDs) != 1 || !s.Windows[0].MachineIDs[0].Equal(&b) {
+		t.Errorf("expected only machine b to remain instead of %v", s.Windows)
+	}
+	if w := s.WindowOf(a); w != nil {
+		t.Errorf("expected machine a to be unscheduled")
+	}
+
+	u := mesos.NewUnavailability(start, 0)
+	if _, ok := u.EndTime(); ok || !u.Contains(start.Add(1000*time.Hour)) {
+		t.Errorf("expected unbounded interval")
+	}
+}
@@ -0,0 +1,35 @@
+package mesos
+
+import (
+	"encoding/binary"
+	"net"
+	"strconv"
+)
+
+// HostPort returns the "host:port" address of the master, preferring the Address field (reported by
+// Mesos 1.0 and later) over the deprecated hostname and IP fields. Returns "" if the master doesn't
+// report an address.
+func (mi *MasterInfo) HostPort() string {
+	if mi == nil {
+		return ""
+	}
+	if a := mi.GetAddress(); a != nil {
+		host := a.GetHostname()
+		if host == "" {
+			host = a.GetIP()
+		}
+		if host != "" {
+			return net.JoinHostPort(host, strconv.Itoa(int(a.Port)))
+		}
+	}
+	host := mi.GetHostname()
+	if host == "" && mi.IP != 0 {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, mi.IP) // network byte order is big-endian
+		host = ip.String()
+	}
+	if host == "" {
+		return ""
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(mi.GetPort())))
+}
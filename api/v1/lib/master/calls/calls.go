@@ -292,7 +292,8 @@ func RemoveQuota(role string) *master.Call {
 	}
 }
 
-// MarkAgentGone removes the quota for a particular role.
+// MarkAgentGone marks an agent as gone; its tasks are transitioned to TASK_GONE_BY_OPERATOR and the
+// agent is not allowed to re-register.
 func MarkAgentGone(id mesos.AgentID) *master.Call {
 	return &master.Call{
 		Type: master.Call_MARK_AGENT_GONE,
@@ -302,7 +303,7 @@ func MarkAgentGone(id mesos.AgentID) *master.Call {
 	}
 }
 
-// Teardown removes the quota for a particular role.
+// Teardown removes the framework w/ the given ID, killing all of its tasks and executors.
 func Teardown(id mesos.FrameworkID) *master.Call {
 	return &master.Call{
 		Type: master.Call_TEARDOWN,
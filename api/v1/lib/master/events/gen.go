@@ -0,0 +1,3 @@
+package events
+
+//go:generate go run ../../extras/gen/handlers.go ../../extras/gen/gen.go -import github.com/mesos/mesos-go/api/v1/lib/master -type E:*master.Event:&master.Event{} -type ET:master.Event_Type
@@ -0,0 +1,309 @@
+package mesostest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+)
+
+const (
+	// ExecutorPath is the path of the v1 executor API endpoint.
+	ExecutorPath = "/api/v1/executor"
+	// OperatorPath is the path of the v1 operator API endpoint.
+	OperatorPath = "/api/v1"
+)
+
+type (
+	// Agent is a fake Mesos agent that serves the v1 executor API and a subset of the v1 operator API:
+	// GET_HEALTH, GET_VERSION and GET_AGENT are answered by default, other calls may be answered by an
+	// OperatorHandler.
+	Agent struct {
+		server   *httptest.Server
+		info     mesos.AgentInfo
+		version  string
+		ackDelay time.Duration
+		operator func(*agent.Call) *agent.Response
+
+		closed    chan struct{}
+		closeOnce sync.Once
+
+		mu         sync.Mutex
+		down       time.Time // executor calls are refused until then, as if the agent were recovering
+		executors  map[executorKey]*subscription
+		calls      []executor.Call
+		opCalls    []agent.Call
+		subscribed chan mesos.ExecutorID
+	}
+
+	executorKey struct{ framework, executor string }
+
+	// AgentOpt is a functional option for an Agent.
+	AgentOpt func(*Agent)
+)
+
+// AgentInfo returns an AgentOpt that sets the info of the agent, as reported to executors and via the
+// operator API.
+func AgentInfo(info mesos.AgentInfo) AgentOpt { return func(a *Agent) { a.info = info } }
+
+// AgentVersion returns an AgentOpt that sets the version reported by GET_VERSION; the default is "1.5.0".
+func AgentVersion(v string) AgentOpt { return func(a *Agent) { a.version = v } }
+
+// AckDelay returns an AgentOpt that delays the acknowledgement of the status updates of executors;
+// a negative delay disables automatic acknowledgements, in which case a test acknowledges updates via
+// Acknowledge.
+func AckDelay(d time.Duration) AgentOpt { return func(a *Agent) { a.ackDelay = d } }
+
+// OperatorHandler returns an AgentOpt that answers operator calls w/ the given func; a nil response
+// falls back to the default behavior of the agent.
+func OperatorHandler(f func(*agent.Call) *agent.Response) AgentOpt {
+	return func(a *Agent) { a.operator = f }
+}
+
+// NewAgent starts and returns a fake agent; the caller should Close it when done.
+func NewAgent(opts ...AgentOpt) *Agent {
+	a := &Agent{
+		info:       mesos.AgentInfo{Hostname: "localhost", ID: &mesos.AgentID{Value: "agent-1"}},
+		version:    "1.5.0",
+		closed:     make(chan struct{}),
+		executors:  make(map[executorKey]*subscription),
+		subscribed: make(chan mesos.ExecutorID, 64),
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(a)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(ExecutorPath, a.serveExecutor)
+	mux.HandleFunc(OperatorPath, a.serveOperator)
+	a.server = httptest.NewServer(mux)
+	return a
+}
+
+// URL returns the base URL of the agent, e.g. http://127.0.0.1:5051.
+func (a *Agent) URL() string { return a.server.URL }
+
+// ExecutorEndpoint returns the URL of the executor API endpoint of the agent.
+func (a *Agent) ExecutorEndpoint() string { return a.server.URL + ExecutorPath }
+
+// OperatorEndpoint returns the URL of the operator API endpoint of the agent.
+func (a *Agent) OperatorEndpoint() string { return a.server.URL + OperatorPath }
+
+// Close hangs up all subscriptions and shuts down the agent.
+func (a *Agent) Close() {
+	a.closeOnce.Do(func() {
+		close(a.closed)
+		a.server.Close()
+	})
+}
+
+// Calls returns the executor calls that have been accepted by the agent, in order of receipt.
+func (a *Agent) Calls() []executor.Call {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]executor.Call(nil), a.calls...)
+}
+
+// OperatorCalls returns the operator calls that have been received by the agent, in order of receipt.
+func (a *Agent) OperatorCalls() []agent.Call {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]agent.Call(nil), a.opCalls...)
+}
+
+// WaitSubscribed waits for an executor to subscribe and returns its ID; subscriptions are reported in
+// order, once each.
+func (a *Agent) WaitSubscribed(ctx context.Context) (mesos.ExecutorID, error) {
+	select {
+	case eid := <-a.subscribed:
+		return eid, nil
+	case <-ctx.Done():
+		return mesos.ExecutorID{}, ctx.Err()
+	}
+}
+
+// Send sends an event via the subscription stream of the given executor. It blocks until the event has
+// been written to the stream, and returns ErrNotSubscribed if the executor isn't subscribed.
+func (a *Agent) Send(fid mesos.FrameworkID, eid mesos.ExecutorID, e *executor.Event) error {
+	a.mu.Lock()
+	sub := a.executors[executorKey{fid.Value, eid.Value}]
+	a.mu.Unlock()
+	return sub.send(e, a.closed)
+}
+
+// Launch sends a LAUNCH event for the given task to the given executor.
+func (a *Agent) Launch(fid mesos.FrameworkID, eid mesos.ExecutorID, task mesos.TaskInfo) error {
+	return a.Send(fid, eid, &executor.Event{Type: executor.Event_LAUNCH, Launch: &executor.Event_Launch{Task: task}})
+}
+
+// Kill sends a KILL event for the given task to the given executor.
+func (a *Agent) Kill(fid mesos.FrameworkID, eid mesos.ExecutorID, taskID mesos.TaskID) error {
+	return a.Send(fid, eid, &executor.Event{Type: executor.Event_KILL, Kill: &executor.Event_Kill{TaskID: taskID}})
+}
+
+// Acknowledge sends an ACKNOWLEDGED event for the given status update to the given executor.
+func (a *Agent) Acknowledge(fid mesos.FrameworkID, eid mesos.ExecutorID, taskID mesos.TaskID, uuid []byte) error {
+	return a.Send(fid, eid, &executor.Event{
+		Type:         executor.Event_ACKNOWLEDGED,
+		Acknowledged: &executor.Event_Acknowledged{TaskID: taskID, UUID: uuid},
+	})
+}
+
+// Restart simulates a restart of the agent: all executor subscriptions are hung up and executor calls are
+// refused (w/ 503 Service Unavailable) for the given downtime, after which executors may re-subscribe.
+func (a *Agent) Restart(downtime time.Duration) {
+	a.mu.Lock()
+	a.down = time.Now().Add(downtime)
+	subs := a.executors
+	a.executors = make(map[executorKey]*subscription)
+	a.mu.Unlock()
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+func (a *Agent) serveExecutor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "expecting a POST request", http.StatusMethodNotAllowed)
+		return
+	}
+	a.mu.Lock()
+	recovering := time.Now().Before(a.down)
+	a.mu.Unlock()
+	if recovering {
+		http.Error(w, "agent is recovering", http.StatusServiceUnavailable)
+		return
+	}
+
+	var call executor.Call
+	codec, err := decodeRequest(r, &call)
+	if err == errUnsupportedMediaType {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to decode call: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := executorKey{call.FrameworkID.Value, call.ExecutorID.Value}
+	a.mu.Lock()
+	a.calls = append(a.calls, call)
+	sub := a.executors[key]
+	a.mu.Unlock()
+
+	switch call.GetType() {
+	case executor.Call_SUBSCRIBE:
+		a.subscribe(w, r, &call, codec)
+		return
+	case executor.Call_UPDATE:
+		if sub == nil {
+			http.Error(w, "executor is not subscribed", http.StatusForbidden)
+			return
+		}
+		if status := call.GetUpdate().Status; a.ackDelay >= 0 {
+			go func() {
+				select {
+				case <-time.After(a.ackDelay):
+					sub.send(&executor.Event{
+						Type:         executor.Event_ACKNOWLEDGED,
+						Acknowledged: &executor.Event_Acknowledged{TaskID: status.TaskID, UUID: status.UUID},
+					}, a.closed)
+				case <-a.closed:
+				}
+			}()
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *Agent) subscribe(w http.ResponseWriter, r *http.Request, call *executor.Call, codec encoding.Codec) {
+	var (
+		key = executorKey{call.FrameworkID.Value, call.ExecutorID.Value}
+		sub = newSubscription("")
+	)
+	a.mu.Lock()
+	old := a.executors[key]
+	a.executors[key] = sub
+	a.mu.Unlock()
+	if old != nil {
+		old.close()
+	}
+	defer func() {
+		a.mu.Lock()
+		if a.executors[key] == sub {
+			delete(a.executors, key)
+		}
+		a.mu.Unlock()
+	}()
+
+	enc := streamEncoder(w, r, codec, nil)
+	err := enc.Encode(&executor.Event{
+		Type: executor.Event_SUBSCRIBED,
+		Subscribed: &executor.Event_Subscribed{
+			ExecutorInfo:  mesos.ExecutorInfo{ExecutorID: call.ExecutorID, FrameworkID: &call.FrameworkID},
+			FrameworkInfo: mesos.FrameworkInfo{ID: &call.FrameworkID, Name: "mesostest", User: "root"},
+			AgentInfo:     a.info,
+			ContainerID:   &mesos.ContainerID{Value: fmt.Sprintf("%s-%s", key.framework, key.executor)},
+		},
+	})
+	if err != nil {
+		return
+	}
+	select {
+	case a.subscribed <- call.ExecutorID:
+	default:
+	}
+	sub.serve(enc, a.closed, r.Context().Done(), 0, nil)
+}
+
+func (a *Agent) serveOperator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "expecting a POST request", http.StatusMethodNotAllowed)
+		return
+	}
+	var call agent.Call
+	codec, err := decodeRequest(r, &call)
+	if err == errUnsupportedMediaType {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to decode call: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.mu.Lock()
+	a.opCalls = append(a.opCalls, call)
+	a.mu.Unlock()
+
+	var resp *agent.Response
+	if a.operator != nil {
+		resp = a.operator(&call)
+	}
+	if resp == nil {
+		switch call.GetType() {
+		case agent.Call_GET_HEALTH:
+			resp = &agent.Response{Type: agent.Response_GET_HEALTH, GetHealth: &agent.Response_GetHealth{Healthy: true}}
+		case agent.Call_GET_VERSION:
+			resp = &agent.Response{
+				Type:       agent.Response_GET_VERSION,
+				GetVersion: &agent.Response_GetVersion{VersionInfo: mesos.VersionInfo{Version: a.version}},
+			}
+		case agent.Call_GET_AGENT:
+			info := a.info
+			resp = &agent.Response{Type: agent.Response_GET_AGENT, GetAgent: &agent.Response_GetAgent{AgentInfo: &info}}
+		default:
+			http.Error(w, "unsupported call: "+call.GetType().String(), http.StatusNotImplemented)
+			return
+		}
+	}
+	writeResponse(w, codec, resp)
+}
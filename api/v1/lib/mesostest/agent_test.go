@@ -0,0 +1,80 @@
+package mesostest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/apierrors"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpexec"
+)
+
+func TestAgent(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		a           = NewAgent(AckDelay(time.Millisecond), AgentVersion("1.5.1"))
+		fid, eid    = mesos.FrameworkID{Value: "f1"}, mesos.ExecutorID{Value: "e1"}
+		sender      = httpexec.NewSender(httpcli.New(
+			httpcli.Endpoint(a.ExecutorEndpoint()),
+			httpcli.Codec(codecs.ByMediaType[codecs.MediaTypeJSON]),
+		).Send)
+		call = func(c *executor.Call) (mesos.Response, error) {
+			return sender.Send(ctx, calls.NonStreaming(c.With(calls.Framework(fid.Value), calls.Executor(eid.Value))))
+		}
+	)
+	defer cancel()
+	defer a.Close()
+
+	resp, err := call(calls.Subscribe(nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Close()
+	if id, err := a.WaitSubscribed(ctx); err != nil || id != eid {
+		t.Fatalf("unexpected subscription %v, %v", id, err)
+	}
+	var e executor.Event
+	if err = resp.Decode(&e); err != nil || e.GetType() != executor.Event_SUBSCRIBED || e.GetSubscribed().AgentInfo.Hostname != "localhost" {
+		t.Fatalf("unexpected event %v, %v", e, err)
+	}
+
+	go a.Launch(fid, eid, mesos.TaskInfo{TaskID: mesos.TaskID{Value: "t1"}})
+	if err = resp.Decode(&e); err != nil || e.GetLaunch().GetTask().TaskID.Value != "t1" {
+		t.Fatalf("unexpected event %v, %v", e, err)
+	}
+
+	// status updates are acknowledged automatically
+	status := mesos.TaskStatus{TaskID: mesos.TaskID{Value: "t1"}, State: mesos.TASK_RUNNING.Enum(), UUID: []byte("u1")}
+	if _, err = call(calls.Update(status)); err != nil {
+		t.Fatal(err)
+	}
+	if err = resp.Decode(&e); err != nil || string(e.GetAcknowledged().GetUUID()) != "u1" {
+		t.Fatalf("unexpected event %v, %v", e, err)
+	}
+
+	// a restart hangs up the subscription, and the agent is unavailable for a while
+	a.Restart(time.Hour)
+	if err = resp.Decode(&e); err == nil {
+		t.Fatal("expected the subscription to be hung up")
+	}
+	if _, err = call(calls.Subscribe(nil, nil)); !apierrors.CodeMesosUnavailable.Matches(err) {
+		t.Fatalf("expected the agent to be unavailable instead of %v", err)
+	}
+
+	op := httpagent.NewClient(httpagent.NewSender(httpcli.New(httpcli.Endpoint(a.OperatorEndpoint())).Send))
+	if healthy, err := op.GetHealth(ctx); err != nil || !healthy {
+		t.Fatalf("unexpected health %v, %v", healthy, err)
+	}
+	if v, err := op.GetVersion(ctx); err != nil || v.Version != "1.5.1" {
+		t.Fatalf("unexpected version %v, %v", v, err)
+	}
+	if len(a.OperatorCalls()) != 2 || len(a.Calls()) != 2 {
+		t.Fatalf("unexpected calls %v, %v", a.OperatorCalls(), a.Calls())
+	}
+}
@@ -0,0 +1,63 @@
+package mesostest
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
+	"github.com/mesos/mesos-go/api/v1/lib/recordio"
+)
+
+const mediaTypeRecordIO = "application/recordio"
+
+// decodeRequest decodes the body of the request w/ the codec of its Content-Type.
+func decodeRequest(r *http.Request, u encoding.Unmarshaler) (encoding.Codec, error) {
+	codec, ok := codecs.DefaultRegistry.Lookup(r.Header.Get("Content-Type"))
+	if !ok {
+		return codec, errUnsupportedMediaType
+	}
+	return codec, codec.NewDecoder(encoding.SourceReader(r.Body)).Decode(u)
+}
+
+// streamEncoder prepares a streaming response and returns an Encoder of its recordio-framed messages.
+// As per the Mesos API, a request that accepts "application/recordio" receives messages that are encoded
+// w/ the codec of its Message-Accept header; otherwise messages are encoded w/ the given codec and the
+// Content-Type of the response is that of the codec.
+func streamEncoder(w http.ResponseWriter, r *http.Request, codec encoding.Codec, header http.Header) encoding.Encoder {
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Accept")); mt == mediaTypeRecordIO {
+		if c, ok := codecs.DefaultRegistry.Lookup(r.Header.Get("Message-Accept")); ok {
+			codec = c
+		}
+		w.Header().Set("Content-Type", mediaTypeRecordIO)
+		w.Header().Set("Message-Content-Type", codec.Type.ContentType())
+	} else {
+		w.Header().Set("Content-Type", codec.Type.ContentType())
+	}
+	w.WriteHeader(http.StatusOK)
+	return codec.NewEncoder(func() framing.Writer { return recordio.NewWriter(w, recordio.FlushEach()) })
+}
+
+type stringError string
+
+func (err stringError) Error() string { return string(err) }
+
+const (
+	errUnsupportedMediaType = stringError("unsupported media type")
+
+	// ErrNotSubscribed is returned when events are sent to a subscriber that isn't connected.
+	ErrNotSubscribed = stringError("not subscribed")
+	// ErrClosed is returned when events are sent to a fake server that's been closed.
+	ErrClosed = stringError("server closed")
+)
+
+// writeResponse sends a single (non-streaming) message, encoded w/ the given codec.
+func writeResponse(w http.ResponseWriter, codec encoding.Codec, m encoding.Marshaler) {
+	w.Header().Set("Content-Type", codec.Type.ContentType())
+	w.WriteHeader(http.StatusOK)
+	codec.NewEncoder(encoding.SinkWriter(w)).Encode(m)
+}
@@ -0,0 +1,16 @@
+// Package mesostest provides in-process fakes of Mesos API servers for integration tests of frameworks
+// and executors that are built on mesos-go, without a real cluster.
+//
+// A Master implements the subset of the v1 scheduler API that most frameworks depend on: SUBSCRIBE
+// streams w/ heartbeats, events that are injected by the test (offers, status updates, etc.), recording
+// of scheduler calls, and redirection to another (leading) master. An Agent implements the v1 executor
+// API, along w/ a few operator API calls, and simulates agent restarts and delayed acknowledgements of
+// status updates.
+//
+//	m := mesostest.NewMaster()
+//	defer m.Close()
+//	cli := httpsched.NewCaller(httpcli.New(httpcli.Endpoint(m.Endpoint())))
+//	... subscribe w/ cli ...
+//	fid, _ := m.WaitSubscribed(ctx)
+//	m.Offer(fid, offer)
+package mesostest
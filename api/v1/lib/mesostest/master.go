@@ -0,0 +1,263 @@
+package mesostest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+)
+
+// SchedulerPath is the path of the v1 scheduler API endpoint.
+const SchedulerPath = "/api/v1/scheduler"
+
+type (
+	// Master is a fake Mesos master that serves the v1 scheduler API.
+	Master struct {
+		server    *httptest.Server
+		heartbeat time.Duration
+		onCall    func(*scheduler.Call)
+		closed    chan struct{}
+		closeOnce sync.Once
+
+		mu         sync.Mutex
+		redirect   string // host:port of the leading master; empty unless redirecting
+		nextID     int
+		frameworks map[string]*subscription // keyed by framework ID
+		calls      []scheduler.Call
+		subscribed chan mesos.FrameworkID
+	}
+
+	// MasterOpt is a functional option for a Master.
+	MasterOpt func(*Master)
+)
+
+// HeartbeatInterval returns a MasterOpt that sets the interval of the HEARTBEAT events that are sent to
+// subscribed frameworks; the default is 15s, the same as that of a real master.
+func HeartbeatInterval(d time.Duration) MasterOpt { return func(m *Master) { m.heartbeat = d } }
+
+// OnCall returns a MasterOpt that invokes the given func for every (non-SUBSCRIBE) call that's accepted
+// by the master, for example to respond to an ACCEPT call w/ status updates. The func is invoked before
+// the response to the call is sent.
+func OnCall(f func(*scheduler.Call)) MasterOpt { return func(m *Master) { m.onCall = f } }
+
+// NewMaster starts and returns a fake master; the caller should Close it when done.
+func NewMaster(opts ...MasterOpt) *Master {
+	m := &Master{
+		heartbeat:  15 * time.Second,
+		closed:     make(chan struct{}),
+		frameworks: make(map[string]*subscription),
+		subscribed: make(chan mesos.FrameworkID, 64),
+	}
+	for _, f := range opts {
+		if f != nil {
+			f(m)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(SchedulerPath, m.serveScheduler)
+	m.server = httptest.NewServer(mux)
+	return m
+}
+
+// URL returns the base URL of the master, e.g. http://127.0.0.1:5050.
+func (m *Master) URL() string { return m.server.URL }
+
+// Endpoint returns the URL of the scheduler API endpoint of the master.
+func (m *Master) Endpoint() string { return m.server.URL + SchedulerPath }
+
+// Close hangs up all subscriptions and shuts down the master.
+func (m *Master) Close() {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+		m.server.Close()
+	})
+}
+
+// Calls returns the (non-SUBSCRIBE) calls that have been accepted by the master, in order of receipt.
+func (m *Master) Calls() []scheduler.Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]scheduler.Call(nil), m.calls...)
+}
+
+// WaitSubscribed waits for a framework to subscribe and returns its ID; subscriptions are reported in
+// order, once each.
+func (m *Master) WaitSubscribed(ctx context.Context) (mesos.FrameworkID, error) {
+	select {
+	case fid := <-m.subscribed:
+		return fid, nil
+	case <-ctx.Done():
+		return mesos.FrameworkID{}, ctx.Err()
+	}
+}
+
+// Redirect causes the master to respond to subsequent calls w/ a redirect to the master at the given URL,
+// as a non-leading master does. An empty URL stops the redirection.
+func (m *Master) Redirect(leader string) error {
+	var host string
+	if leader != "" {
+		u, err := url.Parse(leader)
+		if err != nil {
+			return err
+		}
+		host = u.Host
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.redirect = host
+	return nil
+}
+
+// Send sends an event via the subscription stream of the given framework. It blocks until the event has
+// been written to the stream, and returns ErrNotSubscribed if the framework isn't subscribed.
+func (m *Master) Send(fid mesos.FrameworkID, e *scheduler.Event) error {
+	m.mu.Lock()
+	sub := m.frameworks[fid.Value]
+	m.mu.Unlock()
+	return sub.send(e, m.closed)
+}
+
+// Offer sends an OFFERS event to the given framework; the framework ID of the offers is filled in.
+func (m *Master) Offer(fid mesos.FrameworkID, offers ...mesos.Offer) error {
+	for i := range offers {
+		offers[i].FrameworkID = fid
+	}
+	return m.Send(fid, &scheduler.Event{
+		Type:   scheduler.Event_OFFERS,
+		Offers: &scheduler.Event_Offers{Offers: offers},
+	})
+}
+
+// Rescind sends a RESCIND event for the given offer to the given framework.
+func (m *Master) Rescind(fid mesos.FrameworkID, offerID mesos.OfferID) error {
+	return m.Send(fid, &scheduler.Event{
+		Type:    scheduler.Event_RESCIND,
+		Rescind: &scheduler.Event_Rescind{OfferID: offerID},
+	})
+}
+
+// Update sends an UPDATE event for the given task status to the given framework.
+func (m *Master) Update(fid mesos.FrameworkID, status mesos.TaskStatus) error {
+	return m.Send(fid, &scheduler.Event{
+		Type:   scheduler.Event_UPDATE,
+		Update: &scheduler.Event_Update{Status: status},
+	})
+}
+
+// Disconnect hangs up the subscription stream of the given framework, if any.
+func (m *Master) Disconnect(fid mesos.FrameworkID) {
+	m.mu.Lock()
+	sub := m.frameworks[fid.Value]
+	delete(m.frameworks, fid.Value)
+	m.mu.Unlock()
+	if sub != nil {
+		sub.close()
+	}
+}
+
+func (m *Master) serveScheduler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "expecting a POST request", http.StatusMethodNotAllowed)
+		return
+	}
+	m.mu.Lock()
+	redirect := m.redirect
+	m.mu.Unlock()
+	if redirect != "" {
+		w.Header().Set("Location", "//"+redirect+SchedulerPath)
+		w.WriteHeader(http.StatusTemporaryRedirect)
+		return
+	}
+
+	var call scheduler.Call
+	codec, err := decodeRequest(r, &call)
+	if err == errUnsupportedMediaType {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to decode call: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if call.GetType() == scheduler.Call_SUBSCRIBE {
+		m.subscribe(w, r, &call, codec)
+		return
+	}
+
+	fid := call.GetFrameworkID().GetValue()
+	m.mu.Lock()
+	sub := m.frameworks[fid]
+	if sub == nil || r.Header.Get("Mesos-Stream-Id") != sub.streamID {
+		m.mu.Unlock()
+		http.Error(w, "framework is not subscribed", http.StatusForbidden)
+		return
+	}
+	m.calls = append(m.calls, call)
+	m.mu.Unlock()
+
+	if m.onCall != nil {
+		m.onCall(&call)
+	}
+	if call.GetType() == scheduler.Call_TEARDOWN {
+		m.Disconnect(*call.FrameworkID)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (m *Master) subscribe(w http.ResponseWriter, r *http.Request, call *scheduler.Call, codec encoding.Codec) {
+	info := call.GetSubscribe().GetFrameworkInfo()
+	if info.GetUser() == "" || info.GetName() == "" {
+		http.Error(w, "framework info must specify a user and a name", http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	m.nextID++
+	fid := info.GetID().GetValue()
+	if fid == "" {
+		fid = fmt.Sprintf("framework-%d", m.nextID)
+	}
+	sub := newSubscription(fmt.Sprintf("stream-%d", m.nextID))
+	old := m.frameworks[fid]
+	m.frameworks[fid] = sub
+	m.mu.Unlock()
+
+	if old != nil {
+		// a real master hangs up the former subscription of a re-subscribing framework
+		old.close()
+	}
+	defer func() {
+		m.mu.Lock()
+		if m.frameworks[fid] == sub {
+			delete(m.frameworks, fid)
+		}
+		m.mu.Unlock()
+	}()
+
+	enc := streamEncoder(w, r, codec, http.Header{"Mesos-Stream-Id": {sub.streamID}})
+	heartbeat := m.heartbeat.Seconds()
+	err := enc.Encode(&scheduler.Event{
+		Type: scheduler.Event_SUBSCRIBED,
+		Subscribed: &scheduler.Event_Subscribed{
+			FrameworkID:              &mesos.FrameworkID{Value: fid},
+			HeartbeatIntervalSeconds: &heartbeat,
+		},
+	})
+	if err != nil {
+		return
+	}
+	select {
+	case m.subscribed <- mesos.FrameworkID{Value: fid}:
+	default:
+	}
+
+	sub.serve(enc, m.closed, r.Context().Done(), m.heartbeat, &scheduler.Event{Type: scheduler.Event_HEARTBEAT})
+}
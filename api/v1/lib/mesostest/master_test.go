@@ -0,0 +1,74 @@
+package mesostest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+	"github.com/mesos/mesos-go/api/v1/lib/encoding/codecs"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpsched"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+)
+
+func TestMaster(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		leader      = NewMaster(HeartbeatInterval(10 * time.Millisecond))
+		follower    = NewMaster()
+		name, user  = "test", "root"
+	)
+	defer cancel()
+	defer leader.Close()
+	defer follower.Close()
+	follower.Redirect(leader.URL())
+
+	for _, mediaType := range []encoding.MediaType{codecs.MediaTypeProtobuf, codecs.MediaTypeJSON} {
+		cli := httpsched.NewCaller(httpcli.New(
+			httpcli.Endpoint(follower.Endpoint()),
+			httpcli.Codec(codecs.ByMediaType[mediaType]),
+		), httpsched.RedirectBackoffPeriod(time.Millisecond, time.Millisecond))
+
+		resp, err := cli.Call(ctx, calls.Subscribe(&mesos.FrameworkInfo{Name: name, User: user}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fid, err := leader.WaitSubscribed(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var e scheduler.Event
+		if err = resp.Decode(&e); err != nil || e.GetType() != scheduler.Event_SUBSCRIBED || e.GetSubscribed().GetFrameworkID().GetValue() != fid.Value {
+			t.Fatalf("unexpected event %v, %v", e, err)
+		}
+
+		go leader.Offer(fid, mesos.Offer{ID: mesos.OfferID{Value: "o1"}, AgentID: mesos.AgentID{Value: "a1"}, Hostname: "h1"})
+		for seen := map[scheduler.Event_Type]bool{}; !seen[scheduler.Event_OFFERS] || !seen[scheduler.Event_HEARTBEAT]; {
+			if err = resp.Decode(&e); err != nil {
+				t.Fatal(err)
+			}
+			seen[e.GetType()] = true
+		}
+
+		decline := calls.Decline(mesos.OfferID{Value: "o1"}).With(calls.Framework(fid.Value))
+		if _, err = cli.Call(ctx, decline); err != nil {
+			t.Fatal(err)
+		}
+		if cs := leader.Calls(); len(cs) == 0 || cs[len(cs)-1].GetType() != scheduler.Call_DECLINE {
+			t.Fatalf("unexpected calls %v", cs)
+		}
+
+		leader.Disconnect(fid)
+		if err = resp.Decode(&e); err == nil {
+			t.Fatal("expected the subscription to be hung up")
+		}
+		resp.Close()
+		if err = leader.Update(fid, mesos.TaskStatus{}); err != ErrNotSubscribed {
+			t.Fatalf("expected %v instead of %v", ErrNotSubscribed, err)
+		}
+	}
+}
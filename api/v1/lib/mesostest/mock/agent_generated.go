@@ -0,0 +1,334 @@
+package mock
+
+// go generate -source github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent -interface API -name Agent -output agent_generated.go
+// GENERATED CODE FOLLOWS; DO NOT EDIT.
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/agent"
+	"github.com/mesos/mesos-go/api/v1/lib/agent/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent"
+)
+
+// Agent is a mock implementation of httpagent.API. Each method delegates to the func field of the same name
+// suffixed w/ "Func", returning zero values if that field is nil. Invocations are recorded.
+type Agent struct {
+	SenderFunc                       func() calls.Sender
+	GetHealthFunc                    func(context.Context) (bool, error)
+	GetFlagsFunc                     func(context.Context) ([]mesos.Flag, error)
+	GetVersionFunc                   func(context.Context) (mesos.VersionInfo, error)
+	GetMetricsFunc                   func(context.Context, *time.Duration) ([]mesos.Metric, error)
+	GetLoggingLevelFunc              func(context.Context) (uint32, error)
+	SetLoggingLevelFunc              func(context.Context, uint32, time.Duration) error
+	ListFilesFunc                    func(context.Context, string) ([]mesos.FileInfo, error)
+	ReadFileFunc                     func(context.Context, string, uint64, *uint64) (uint64, []byte, error)
+	GetStateFunc                     func(context.Context) (*agent.Response_GetState, error)
+	GetContainersFunc                func(context.Context) ([]agent.Response_GetContainers_Container, error)
+	GetFrameworksFunc                func(context.Context) (*agent.Response_GetFrameworks, error)
+	GetExecutorsFunc                 func(context.Context) (*agent.Response_GetExecutors, error)
+	GetTasksFunc                     func(context.Context) (*agent.Response_GetTasks, error)
+	GetAgentFunc                     func(context.Context) (*mesos.AgentInfo, error)
+	LaunchNestedContainerFunc        func(context.Context, mesos.ContainerID, *mesos.CommandInfo, *mesos.ContainerInfo) error
+	WaitNestedContainerFunc          func(context.Context, mesos.ContainerID) (*agent.Response_WaitNestedContainer, error)
+	KillNestedContainerFunc          func(context.Context, mesos.ContainerID, *int32) error
+	RemoveNestedContainerFunc        func(context.Context, mesos.ContainerID) error
+	LaunchContainerFunc              func(context.Context, mesos.ContainerID, *mesos.CommandInfo, *mesos.ContainerInfo, []mesos.Resource) error
+	WaitContainerFunc                func(context.Context, mesos.ContainerID) (*agent.Response_WaitContainer, error)
+	KillContainerFunc                func(context.Context, mesos.ContainerID, *int32) error
+	RemoveContainerFunc              func(context.Context, mesos.ContainerID) error
+	AttachContainerOutputFunc        func(context.Context, mesos.ContainerID) (*httpagent.Output, error)
+	LaunchNestedContainerSessionFunc func(context.Context, mesos.ContainerID, *mesos.CommandInfo, *mesos.ContainerInfo) (*httpagent.Output, error)
+	AttachContainerInputFunc         func(context.Context, mesos.ContainerID) (*httpagent.Input, error)
+	TaskSandboxFunc                  func(context.Context, mesos.TaskID) (string, error)
+	TailFunc                         func(context.Context, mesos.TaskID, string, io.Writer, ...httpagent.TailOpt) error
+	TailFileFunc                     func(context.Context, string, io.Writer, ...httpagent.TailOpt) error
+	TaskContainerFunc                func(context.Context, mesos.TaskID) (mesos.ContainerID, error)
+	ExecFunc                         func(context.Context, mesos.TaskID, *mesos.CommandInfo, ...httpagent.ExecOpt) (*agent.Response_WaitNestedContainer, error)
+
+	recorder
+}
+
+var _ = httpagent.API(&Agent{})
+
+// Sender implements httpagent.API.
+func (m *Agent) Sender() (r0 calls.Sender) {
+	m.record("Sender")
+	if m.SenderFunc != nil {
+		return m.SenderFunc()
+	}
+	return
+}
+
+// GetHealth implements httpagent.API.
+func (m *Agent) GetHealth(a0 context.Context) (r0 bool, r1 error) {
+	m.record("GetHealth", a0)
+	if m.GetHealthFunc != nil {
+		return m.GetHealthFunc(a0)
+	}
+	return
+}
+
+// GetFlags implements httpagent.API.
+func (m *Agent) GetFlags(a0 context.Context) (r0 []mesos.Flag, r1 error) {
+	m.record("GetFlags", a0)
+	if m.GetFlagsFunc != nil {
+		return m.GetFlagsFunc(a0)
+	}
+	return
+}
+
+// GetVersion implements httpagent.API.
+func (m *Agent) GetVersion(a0 context.Context) (r0 mesos.VersionInfo, r1 error) {
+	m.record("GetVersion", a0)
+	if m.GetVersionFunc != nil {
+		return m.GetVersionFunc(a0)
+	}
+	return
+}
+
+// GetMetrics implements httpagent.API.
+func (m *Agent) GetMetrics(a0 context.Context, a1 *time.Duration) (r0 []mesos.Metric, r1 error) {
+	m.record("GetMetrics", a0, a1)
+	if m.GetMetricsFunc != nil {
+		return m.GetMetricsFunc(a0, a1)
+	}
+	return
+}
+
+// GetLoggingLevel implements httpagent.API.
+func (m *Agent) GetLoggingLevel(a0 context.Context) (r0 uint32, r1 error) {
+	m.record("GetLoggingLevel", a0)
+	if m.GetLoggingLevelFunc != nil {
+		return m.GetLoggingLevelFunc(a0)
+	}
+	return
+}
+
+// SetLoggingLevel implements httpagent.API.
+func (m *Agent) SetLoggingLevel(a0 context.Context, a1 uint32, a2 time.Duration) (r0 error) {
+	m.record("SetLoggingLevel", a0, a1, a2)
+	if m.SetLoggingLevelFunc != nil {
+		return m.SetLoggingLevelFunc(a0, a1, a2)
+	}
+	return
+}
+
+// ListFiles implements httpagent.API.
+func (m *Agent) ListFiles(a0 context.Context, a1 string) (r0 []mesos.FileInfo, r1 error) {
+	m.record("ListFiles", a0, a1)
+	if m.ListFilesFunc != nil {
+		return m.ListFilesFunc(a0, a1)
+	}
+	return
+}
+
+// ReadFile implements httpagent.API.
+func (m *Agent) ReadFile(a0 context.Context, a1 string, a2 uint64, a3 *uint64) (r0 uint64, r1 []byte, r2 error) {
+	m.record("ReadFile", a0, a1, a2, a3)
+	if m.ReadFileFunc != nil {
+		return m.ReadFileFunc(a0, a1, a2, a3)
+	}
+	return
+}
+
+// GetState implements httpagent.API.
+func (m *Agent) GetState(a0 context.Context) (r0 *agent.Response_GetState, r1 error) {
+	m.record("GetState", a0)
+	if m.GetStateFunc != nil {
+		return m.GetStateFunc(a0)
+	}
+	return
+}
+
+// GetContainers implements httpagent.API.
+func (m *Agent) GetContainers(a0 context.Context) (r0 []agent.Response_GetContainers_Container, r1 error) {
+	m.record("GetContainers", a0)
+	if m.GetContainersFunc != nil {
+		return m.GetContainersFunc(a0)
+	}
+	return
+}
+
+// GetFrameworks implements httpagent.API.
+func (m *Agent) GetFrameworks(a0 context.Context) (r0 *agent.Response_GetFrameworks, r1 error) {
+	m.record("GetFrameworks", a0)
+	if m.GetFrameworksFunc != nil {
+		return m.GetFrameworksFunc(a0)
+	}
+	return
+}
+
+// GetExecutors implements httpagent.API.
+func (m *Agent) GetExecutors(a0 context.Context) (r0 *agent.Response_GetExecutors, r1 error) {
+	m.record("GetExecutors", a0)
+	if m.GetExecutorsFunc != nil {
+		return m.GetExecutorsFunc(a0)
+	}
+	return
+}
+
+// GetTasks implements httpagent.API.
+func (m *Agent) GetTasks(a0 context.Context) (r0 *agent.Response_GetTasks, r1 error) {
+	m.record("GetTasks", a0)
+	if m.GetTasksFunc != nil {
+		return m.GetTasksFunc(a0)
+	}
+	return
+}
+
+// GetAgent implements httpagent.API.
+func (m *Agent) GetAgent(a0 context.Context) (r0 *mesos.AgentInfo, r1 error) {
+	m.record("GetAgent", a0)
+	if m.GetAgentFunc != nil {
+		return m.GetAgentFunc(a0)
+	}
+	return
+}
+
+// LaunchNestedContainer implements httpagent.API.
+func (m *Agent) LaunchNestedContainer(a0 context.Context, a1 mesos.ContainerID, a2 *mesos.CommandInfo, a3 *mesos.ContainerInfo) (r0 error) {
+	m.record("LaunchNestedContainer", a0, a1, a2, a3)
+	if m.LaunchNestedContainerFunc != nil {
+		return m.LaunchNestedContainerFunc(a0, a1, a2, a3)
+	}
+	return
+}
+
+// WaitNestedContainer implements httpagent.API.
+func (m *Agent) WaitNestedContainer(a0 context.Context, a1 mesos.ContainerID) (r0 *agent.Response_WaitNestedContainer, r1 error) {
+	m.record("WaitNestedContainer", a0, a1)
+	if m.WaitNestedContainerFunc != nil {
+		return m.WaitNestedContainerFunc(a0, a1)
+	}
+	return
+}
+
+// KillNestedContainer implements httpagent.API.
+func (m *Agent) KillNestedContainer(a0 context.Context, a1 mesos.ContainerID, a2 *int32) (r0 error) {
+	m.record("KillNestedContainer", a0, a1, a2)
+	if m.KillNestedContainerFunc != nil {
+		return m.KillNestedContainerFunc(a0, a1, a2)
+	}
+	return
+}
+
+// RemoveNestedContainer implements httpagent.API.
+func (m *Agent) RemoveNestedContainer(a0 context.Context, a1 mesos.ContainerID) (r0 error) {
+	m.record("RemoveNestedContainer", a0, a1)
+	if m.RemoveNestedContainerFunc != nil {
+		return m.RemoveNestedContainerFunc(a0, a1)
+	}
+	return
+}
+
+// LaunchContainer implements httpagent.API.
+func (m *Agent) LaunchContainer(a0 context.Context, a1 mesos.ContainerID, a2 *mesos.CommandInfo, a3 *mesos.ContainerInfo, a4 []mesos.Resource) (r0 error) {
+	m.record("LaunchContainer", a0, a1, a2, a3, a4)
+	if m.LaunchContainerFunc != nil {
+		return m.LaunchContainerFunc(a0, a1, a2, a3, a4)
+	}
+	return
+}
+
+// WaitContainer implements httpagent.API.
+func (m *Agent) WaitContainer(a0 context.Context, a1 mesos.ContainerID) (r0 *agent.Response_WaitContainer, r1 error) {
+	m.record("WaitContainer", a0, a1)
+	if m.WaitContainerFunc != nil {
+		return m.WaitContainerFunc(a0, a1)
+	}
+	return
+}
+
+// KillContainer implements httpagent.API.
+func (m *Agent) KillContainer(a0 context.Context, a1 mesos.ContainerID, a2 *int32) (r0 error) {
+	m.record("KillContainer", a0, a1, a2)
+	if m.KillContainerFunc != nil {
+		return m.KillContainerFunc(a0, a1, a2)
+	}
+	return
+}
+
+// RemoveContainer implements httpagent.API.
+func (m *Agent) RemoveContainer(a0 context.Context, a1 mesos.ContainerID) (r0 error) {
+	m.record("RemoveContainer", a0, a1)
+	if m.RemoveContainerFunc != nil {
+		return m.RemoveContainerFunc(a0, a1)
+	}
+	return
+}
+
+// AttachContainerOutput implements httpagent.API.
+func (m *Agent) AttachContainerOutput(a0 context.Context, a1 mesos.ContainerID) (r0 *httpagent.Output, r1 error) {
+	m.record("AttachContainerOutput", a0, a1)
+	if m.AttachContainerOutputFunc != nil {
+		return m.AttachContainerOutputFunc(a0, a1)
+	}
+	return
+}
+
+// LaunchNestedContainerSession implements httpagent.API.
+func (m *Agent) LaunchNestedContainerSession(a0 context.Context, a1 mesos.ContainerID, a2 *mesos.CommandInfo, a3 *mesos.ContainerInfo) (r0 *httpagent.Output, r1 error) {
+	m.record("LaunchNestedContainerSession", a0, a1, a2, a3)
+	if m.LaunchNestedContainerSessionFunc != nil {
+		return m.LaunchNestedContainerSessionFunc(a0, a1, a2, a3)
+	}
+	return
+}
+
+// AttachContainerInput implements httpagent.API.
+func (m *Agent) AttachContainerInput(a0 context.Context, a1 mesos.ContainerID) (r0 *httpagent.Input, r1 error) {
+	m.record("AttachContainerInput", a0, a1)
+	if m.AttachContainerInputFunc != nil {
+		return m.AttachContainerInputFunc(a0, a1)
+	}
+	return
+}
+
+// TaskSandbox implements httpagent.API.
+func (m *Agent) TaskSandbox(a0 context.Context, a1 mesos.TaskID) (r0 string, r1 error) {
+	m.record("TaskSandbox", a0, a1)
+	if m.TaskSandboxFunc != nil {
+		return m.TaskSandboxFunc(a0, a1)
+	}
+	return
+}
+
+// Tail implements httpagent.API.
+func (m *Agent) Tail(a0 context.Context, a1 mesos.TaskID, a2 string, a3 io.Writer, a4 ...httpagent.TailOpt) (r0 error) {
+	m.record("Tail", a0, a1, a2, a3, a4)
+	if m.TailFunc != nil {
+		return m.TailFunc(a0, a1, a2, a3, a4...)
+	}
+	return
+}
+
+// TailFile implements httpagent.API.
+func (m *Agent) TailFile(a0 context.Context, a1 string, a2 io.Writer, a3 ...httpagent.TailOpt) (r0 error) {
+	m.record("TailFile", a0, a1, a2, a3)
+	if m.TailFileFunc != nil {
+		return m.TailFileFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// TaskContainer implements httpagent.API.
+func (m *Agent) TaskContainer(a0 context.Context, a1 mesos.TaskID) (r0 mesos.ContainerID, r1 error) {
+	m.record("TaskContainer", a0, a1)
+	if m.TaskContainerFunc != nil {
+		return m.TaskContainerFunc(a0, a1)
+	}
+	return
+}
+
+// Exec implements httpagent.API.
+func (m *Agent) Exec(a0 context.Context, a1 mesos.TaskID, a2 *mesos.CommandInfo, a3 ...httpagent.ExecOpt) (r0 *agent.Response_WaitNestedContainer, r1 error) {
+	m.record("Exec", a0, a1, a2, a3)
+	if m.ExecFunc != nil {
+		return m.ExecFunc(a0, a1, a2, a3...)
+	}
+	return
+}
@@ -0,0 +1,30 @@
+package mock
+
+// go generate -source github.com/mesos/mesos-go/api/v1/lib/detector -interface Master -name Detector -output detector_generated.go
+// GENERATED CODE FOLLOWS; DO NOT EDIT.
+
+import (
+	"context"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/detector"
+)
+
+// Detector is a mock implementation of detector.Master. Each method delegates to the func field of the same name
+// suffixed w/ "Func", returning zero values if that field is nil. Invocations are recorded.
+type Detector struct {
+	DetectFunc func(context.Context) (<-chan mesos.MasterInfo, error)
+
+	recorder
+}
+
+var _ = detector.Master(&Detector{})
+
+// Detect implements detector.Master.
+func (m *Detector) Detect(a0 context.Context) (r0 <-chan mesos.MasterInfo, r1 error) {
+	m.record("Detect", a0)
+	if m.DetectFunc != nil {
+		return m.DetectFunc(a0)
+	}
+	return
+}
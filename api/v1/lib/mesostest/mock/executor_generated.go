@@ -0,0 +1,30 @@
+package mock
+
+// go generate -source github.com/mesos/mesos-go/api/v1/lib/executor/calls -interface Sender -name ExecutorSender -output executor_generated.go
+// GENERATED CODE FOLLOWS; DO NOT EDIT.
+
+import (
+	"context"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+)
+
+// ExecutorSender is a mock implementation of calls.Sender. Each method delegates to the func field of the same name
+// suffixed w/ "Func", returning zero values if that field is nil. Invocations are recorded.
+type ExecutorSender struct {
+	SendFunc func(context.Context, calls.Request) (mesos.Response, error)
+
+	recorder
+}
+
+var _ = calls.Sender(&ExecutorSender{})
+
+// Send implements calls.Sender.
+func (m *ExecutorSender) Send(a0 context.Context, a1 calls.Request) (r0 mesos.Response, r1 error) {
+	m.record("Send", a0, a1)
+	if m.SendFunc != nil {
+		return m.SendFunc(a0, a1)
+	}
+	return
+}
@@ -0,0 +1,6 @@
+package mock
+
+//go:generate go run ../../extras/gen/mock.go ../../extras/gen/gen.go -source github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster -interface API -name Master -output master_generated.go
+//go:generate go run ../../extras/gen/mock.go ../../extras/gen/gen.go -source github.com/mesos/mesos-go/api/v1/lib/httpcli/httpagent -interface API -name Agent -output agent_generated.go
+//go:generate go run ../../extras/gen/mock.go ../../extras/gen/gen.go -source github.com/mesos/mesos-go/api/v1/lib/executor/calls -interface Sender -name ExecutorSender -output executor_generated.go
+//go:generate go run ../../extras/gen/mock.go ../../extras/gen/gen.go -source github.com/mesos/mesos-go/api/v1/lib/detector -interface Master -name Detector -output detector_generated.go
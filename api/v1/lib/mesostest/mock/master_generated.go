@@ -0,0 +1,415 @@
+package mock
+
+// go generate -source github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster -interface API -name Master -output master_generated.go
+// GENERATED CODE FOLLOWS; DO NOT EDIT.
+
+import (
+	"context"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
+	"github.com/mesos/mesos-go/api/v1/lib/maintenance"
+	"github.com/mesos/mesos-go/api/v1/lib/master"
+	"github.com/mesos/mesos-go/api/v1/lib/master/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+)
+
+// Master is a mock implementation of httpmaster.API. Each method delegates to the func field of the same name
+// suffixed w/ "Func", returning zero values if that field is nil. Invocations are recorded.
+type Master struct {
+	SenderFunc                    func() calls.Sender
+	GetHealthFunc                 func(context.Context) (bool, error)
+	GetFlagsFunc                  func(context.Context) ([]mesos.Flag, error)
+	GetVersionFunc                func(context.Context) (mesos.VersionInfo, error)
+	GetMetricsFunc                func(context.Context, *time.Duration) ([]mesos.Metric, error)
+	GetLoggingLevelFunc           func(context.Context) (uint32, error)
+	SetLoggingLevelFunc           func(context.Context, uint32, time.Duration) error
+	ListFilesFunc                 func(context.Context, string) ([]mesos.FileInfo, error)
+	ReadFileFunc                  func(context.Context, string, uint64, *uint64) (uint64, []byte, error)
+	GetStateFunc                  func(context.Context) (*master.Response_GetState, error)
+	GetAgentsFunc                 func(context.Context) (*master.Response_GetAgents, error)
+	GetFrameworksFunc             func(context.Context) (*master.Response_GetFrameworks, error)
+	GetExecutorsFunc              func(context.Context) (*master.Response_GetExecutors, error)
+	GetTasksFunc                  func(context.Context) (*master.Response_GetTasks, error)
+	GetRolesFunc                  func(context.Context) ([]mesos.Role, error)
+	GetWeightsFunc                func(context.Context) ([]mesos.WeightInfo, error)
+	GetMasterFunc                 func(context.Context) (*master.Response_GetMaster, error)
+	GetMaintenanceStatusFunc      func(context.Context) (maintenance.ClusterStatus, error)
+	GetMaintenanceScheduleFunc    func(context.Context) (maintenance.Schedule, error)
+	UpdateMaintenanceScheduleFunc func(context.Context, maintenance.Schedule) error
+	StartMaintenanceFunc          func(context.Context, ...mesos.MachineID) error
+	StopMaintenanceFunc           func(context.Context, ...mesos.MachineID) error
+	GetQuotaFunc                  func(context.Context) (quota.QuotaStatus, error)
+	SetQuotaFunc                  func(context.Context, quota.QuotaRequest) error
+	RemoveQuotaFunc               func(context.Context, string) error
+	UpdateQuotaFunc               func(context.Context, quota.QuotaRequest) error
+	UpdateWeightsFunc             func(context.Context, ...mesos.WeightInfo) error
+	ReconcileWeightsFunc          func(context.Context, []mesos.WeightInfo, bool) ([]mesos.WeightInfo, error)
+	GetAgentFunc                  func(context.Context, mesos.AgentID) (*master.Response_GetAgents_Agent, error)
+	ReserveResourcesFunc          func(context.Context, mesos.AgentID, ...mesos.Resource) error
+	UnreserveResourcesFunc        func(context.Context, mesos.AgentID, ...mesos.Resource) error
+	CreateVolumesFunc             func(context.Context, mesos.AgentID, ...mesos.Resource) error
+	DestroyVolumesFunc            func(context.Context, mesos.AgentID, ...mesos.Resource) error
+	ForceDestroyVolumesFunc       func(context.Context, mesos.AgentID, ...mesos.Resource) error
+	MarkAgentGoneFunc             func(context.Context, mesos.AgentID) error
+	DrainProgressFunc             func(context.Context, mesos.AgentID) (*httpmaster.DrainStatus, error)
+	WaitForDrainFunc              func(context.Context, mesos.AgentID, time.Duration, func(*httpmaster.DrainStatus)) error
+	TeardownFunc                  func(context.Context, mesos.FrameworkID) error
+	ConfirmTeardownFunc           func(context.Context, mesos.FrameworkID, func(*httpmaster.TeardownSummary) bool) (bool, error)
+
+	recorder
+}
+
+var _ = httpmaster.API(&Master{})
+
+// Sender implements httpmaster.API.
+func (m *Master) Sender() (r0 calls.Sender) {
+	m.record("Sender")
+	if m.SenderFunc != nil {
+		return m.SenderFunc()
+	}
+	return
+}
+
+// GetHealth implements httpmaster.API.
+func (m *Master) GetHealth(a0 context.Context) (r0 bool, r1 error) {
+	m.record("GetHealth", a0)
+	if m.GetHealthFunc != nil {
+		return m.GetHealthFunc(a0)
+	}
+	return
+}
+
+// GetFlags implements httpmaster.API.
+func (m *Master) GetFlags(a0 context.Context) (r0 []mesos.Flag, r1 error) {
+	m.record("GetFlags", a0)
+	if m.GetFlagsFunc != nil {
+		return m.GetFlagsFunc(a0)
+	}
+	return
+}
+
+// GetVersion implements httpmaster.API.
+func (m *Master) GetVersion(a0 context.Context) (r0 mesos.VersionInfo, r1 error) {
+	m.record("GetVersion", a0)
+	if m.GetVersionFunc != nil {
+		return m.GetVersionFunc(a0)
+	}
+	return
+}
+
+// GetMetrics implements httpmaster.API.
+func (m *Master) GetMetrics(a0 context.Context, a1 *time.Duration) (r0 []mesos.Metric, r1 error) {
+	m.record("GetMetrics", a0, a1)
+	if m.GetMetricsFunc != nil {
+		return m.GetMetricsFunc(a0, a1)
+	}
+	return
+}
+
+// GetLoggingLevel implements httpmaster.API.
+func (m *Master) GetLoggingLevel(a0 context.Context) (r0 uint32, r1 error) {
+	m.record("GetLoggingLevel", a0)
+	if m.GetLoggingLevelFunc != nil {
+		return m.GetLoggingLevelFunc(a0)
+	}
+	return
+}
+
+// SetLoggingLevel implements httpmaster.API.
+func (m *Master) SetLoggingLevel(a0 context.Context, a1 uint32, a2 time.Duration) (r0 error) {
+	m.record("SetLoggingLevel", a0, a1, a2)
+	if m.SetLoggingLevelFunc != nil {
+		return m.SetLoggingLevelFunc(a0, a1, a2)
+	}
+	return
+}
+
+// ListFiles implements httpmaster.API.
+func (m *Master) ListFiles(a0 context.Context, a1 string) (r0 []mesos.FileInfo, r1 error) {
+	m.record("ListFiles", a0, a1)
+	if m.ListFilesFunc != nil {
+		return m.ListFilesFunc(a0, a1)
+	}
+	return
+}
+
+// ReadFile implements httpmaster.API.
+func (m *Master) ReadFile(a0 context.Context, a1 string, a2 uint64, a3 *uint64) (r0 uint64, r1 []byte, r2 error) {
+	m.record("ReadFile", a0, a1, a2, a3)
+	if m.ReadFileFunc != nil {
+		return m.ReadFileFunc(a0, a1, a2, a3)
+	}
+	return
+}
+
+// GetState implements httpmaster.API.
+func (m *Master) GetState(a0 context.Context) (r0 *master.Response_GetState, r1 error) {
+	m.record("GetState", a0)
+	if m.GetStateFunc != nil {
+		return m.GetStateFunc(a0)
+	}
+	return
+}
+
+// GetAgents implements httpmaster.API.
+func (m *Master) GetAgents(a0 context.Context) (r0 *master.Response_GetAgents, r1 error) {
+	m.record("GetAgents", a0)
+	if m.GetAgentsFunc != nil {
+		return m.GetAgentsFunc(a0)
+	}
+	return
+}
+
+// GetFrameworks implements httpmaster.API.
+func (m *Master) GetFrameworks(a0 context.Context) (r0 *master.Response_GetFrameworks, r1 error) {
+	m.record("GetFrameworks", a0)
+	if m.GetFrameworksFunc != nil {
+		return m.GetFrameworksFunc(a0)
+	}
+	return
+}
+
+// GetExecutors implements httpmaster.API.
+func (m *Master) GetExecutors(a0 context.Context) (r0 *master.Response_GetExecutors, r1 error) {
+	m.record("GetExecutors", a0)
+	if m.GetExecutorsFunc != nil {
+		return m.GetExecutorsFunc(a0)
+	}
+	return
+}
+
+// GetTasks implements httpmaster.API.
+func (m *Master) GetTasks(a0 context.Context) (r0 *master.Response_GetTasks, r1 error) {
+	m.record("GetTasks", a0)
+	if m.GetTasksFunc != nil {
+		return m.GetTasksFunc(a0)
+	}
+	return
+}
+
+// GetRoles implements httpmaster.API.
+func (m *Master) GetRoles(a0 context.Context) (r0 []mesos.Role, r1 error) {
+	m.record("GetRoles", a0)
+	if m.GetRolesFunc != nil {
+		return m.GetRolesFunc(a0)
+	}
+	return
+}
+
+// GetWeights implements httpmaster.API.
+func (m *Master) GetWeights(a0 context.Context) (r0 []mesos.WeightInfo, r1 error) {
+	m.record("GetWeights", a0)
+	if m.GetWeightsFunc != nil {
+		return m.GetWeightsFunc(a0)
+	}
+	return
+}
+
+// GetMaster implements httpmaster.API.
+func (m *Master) GetMaster(a0 context.Context) (r0 *master.Response_GetMaster, r1 error) {
+	m.record("GetMaster", a0)
+	if m.GetMasterFunc != nil {
+		return m.GetMasterFunc(a0)
+	}
+	return
+}
+
+// GetMaintenanceStatus implements httpmaster.API.
+func (m *Master) GetMaintenanceStatus(a0 context.Context) (r0 maintenance.ClusterStatus, r1 error) {
+	m.record("GetMaintenanceStatus", a0)
+	if m.GetMaintenanceStatusFunc != nil {
+		return m.GetMaintenanceStatusFunc(a0)
+	}
+	return
+}
+
+// GetMaintenanceSchedule implements httpmaster.API.
+func (m *Master) GetMaintenanceSchedule(a0 context.Context) (r0 maintenance.Schedule, r1 error) {
+	m.record("GetMaintenanceSchedule", a0)
+	if m.GetMaintenanceScheduleFunc != nil {
+		return m.GetMaintenanceScheduleFunc(a0)
+	}
+	return
+}
+
+// UpdateMaintenanceSchedule implements httpmaster.API.
+func (m *Master) UpdateMaintenanceSchedule(a0 context.Context, a1 maintenance.Schedule) (r0 error) {
+	m.record("UpdateMaintenanceSchedule", a0, a1)
+	if m.UpdateMaintenanceScheduleFunc != nil {
+		return m.UpdateMaintenanceScheduleFunc(a0, a1)
+	}
+	return
+}
+
+// StartMaintenance implements httpmaster.API.
+func (m *Master) StartMaintenance(a0 context.Context, a1 ...mesos.MachineID) (r0 error) {
+	m.record("StartMaintenance", a0, a1)
+	if m.StartMaintenanceFunc != nil {
+		return m.StartMaintenanceFunc(a0, a1...)
+	}
+	return
+}
+
+// StopMaintenance implements httpmaster.API.
+func (m *Master) StopMaintenance(a0 context.Context, a1 ...mesos.MachineID) (r0 error) {
+	m.record("StopMaintenance", a0, a1)
+	if m.StopMaintenanceFunc != nil {
+		return m.StopMaintenanceFunc(a0, a1...)
+	}
+	return
+}
+
+// GetQuota implements httpmaster.API.
+func (m *Master) GetQuota(a0 context.Context) (r0 quota.QuotaStatus, r1 error) {
+	m.record("GetQuota", a0)
+	if m.GetQuotaFunc != nil {
+		return m.GetQuotaFunc(a0)
+	}
+	return
+}
+
+// SetQuota implements httpmaster.API.
+func (m *Master) SetQuota(a0 context.Context, a1 quota.QuotaRequest) (r0 error) {
+	m.record("SetQuota", a0, a1)
+	if m.SetQuotaFunc != nil {
+		return m.SetQuotaFunc(a0, a1)
+	}
+	return
+}
+
+// RemoveQuota implements httpmaster.API.
+func (m *Master) RemoveQuota(a0 context.Context, a1 string) (r0 error) {
+	m.record("RemoveQuota", a0, a1)
+	if m.RemoveQuotaFunc != nil {
+		return m.RemoveQuotaFunc(a0, a1)
+	}
+	return
+}
+
+// UpdateQuota implements httpmaster.API.
+func (m *Master) UpdateQuota(a0 context.Context, a1 quota.QuotaRequest) (r0 error) {
+	m.record("UpdateQuota", a0, a1)
+	if m.UpdateQuotaFunc != nil {
+		return m.UpdateQuotaFunc(a0, a1)
+	}
+	return
+}
+
+// UpdateWeights implements httpmaster.API.
+func (m *Master) UpdateWeights(a0 context.Context, a1 ...mesos.WeightInfo) (r0 error) {
+	m.record("UpdateWeights", a0, a1)
+	if m.UpdateWeightsFunc != nil {
+		return m.UpdateWeightsFunc(a0, a1...)
+	}
+	return
+}
+
+// ReconcileWeights implements httpmaster.API.
+func (m *Master) ReconcileWeights(a0 context.Context, a1 []mesos.WeightInfo, a2 bool) (r0 []mesos.WeightInfo, r1 error) {
+	m.record("ReconcileWeights", a0, a1, a2)
+	if m.ReconcileWeightsFunc != nil {
+		return m.ReconcileWeightsFunc(a0, a1, a2)
+	}
+	return
+}
+
+// GetAgent implements httpmaster.API.
+func (m *Master) GetAgent(a0 context.Context, a1 mesos.AgentID) (r0 *master.Response_GetAgents_Agent, r1 error) {
+	m.record("GetAgent", a0, a1)
+	if m.GetAgentFunc != nil {
+		return m.GetAgentFunc(a0, a1)
+	}
+	return
+}
+
+// ReserveResources implements httpmaster.API.
+func (m *Master) ReserveResources(a0 context.Context, a1 mesos.AgentID, a2 ...mesos.Resource) (r0 error) {
+	m.record("ReserveResources", a0, a1, a2)
+	if m.ReserveResourcesFunc != nil {
+		return m.ReserveResourcesFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// UnreserveResources implements httpmaster.API.
+func (m *Master) UnreserveResources(a0 context.Context, a1 mesos.AgentID, a2 ...mesos.Resource) (r0 error) {
+	m.record("UnreserveResources", a0, a1, a2)
+	if m.UnreserveResourcesFunc != nil {
+		return m.UnreserveResourcesFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// CreateVolumes implements httpmaster.API.
+func (m *Master) CreateVolumes(a0 context.Context, a1 mesos.AgentID, a2 ...mesos.Resource) (r0 error) {
+	m.record("CreateVolumes", a0, a1, a2)
+	if m.CreateVolumesFunc != nil {
+		return m.CreateVolumesFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// DestroyVolumes implements httpmaster.API.
+func (m *Master) DestroyVolumes(a0 context.Context, a1 mesos.AgentID, a2 ...mesos.Resource) (r0 error) {
+	m.record("DestroyVolumes", a0, a1, a2)
+	if m.DestroyVolumesFunc != nil {
+		return m.DestroyVolumesFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ForceDestroyVolumes implements httpmaster.API.
+func (m *Master) ForceDestroyVolumes(a0 context.Context, a1 mesos.AgentID, a2 ...mesos.Resource) (r0 error) {
+	m.record("ForceDestroyVolumes", a0, a1, a2)
+	if m.ForceDestroyVolumesFunc != nil {
+		return m.ForceDestroyVolumesFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// MarkAgentGone implements httpmaster.API.
+func (m *Master) MarkAgentGone(a0 context.Context, a1 mesos.AgentID) (r0 error) {
+	m.record("MarkAgentGone", a0, a1)
+	if m.MarkAgentGoneFunc != nil {
+		return m.MarkAgentGoneFunc(a0, a1)
+	}
+	return
+}
+
+// DrainProgress implements httpmaster.API.
+func (m *Master) DrainProgress(a0 context.Context, a1 mesos.AgentID) (r0 *httpmaster.DrainStatus, r1 error) {
+	m.record("DrainProgress", a0, a1)
+	if m.DrainProgressFunc != nil {
+		return m.DrainProgressFunc(a0, a1)
+	}
+	return
+}
+
+// WaitForDrain implements httpmaster.API.
+func (m *Master) WaitForDrain(a0 context.Context, a1 mesos.AgentID, a2 time.Duration, a3 func(*httpmaster.DrainStatus)) (r0 error) {
+	m.record("WaitForDrain", a0, a1, a2, a3)
+	if m.WaitForDrainFunc != nil {
+		return m.WaitForDrainFunc(a0, a1, a2, a3)
+	}
+	return
+}
+
+// Teardown implements httpmaster.API.
+func (m *Master) Teardown(a0 context.Context, a1 mesos.FrameworkID) (r0 error) {
+	m.record("Teardown", a0, a1)
+	if m.TeardownFunc != nil {
+		return m.TeardownFunc(a0, a1)
+	}
+	return
+}
+
+// ConfirmTeardown implements httpmaster.API.
+func (m *Master) ConfirmTeardown(a0 context.Context, a1 mesos.FrameworkID, a2 func(*httpmaster.TeardownSummary) bool) (r0 bool, r1 error) {
+	m.record("ConfirmTeardown", a0, a1, a2)
+	if m.ConfirmTeardownFunc != nil {
+		return m.ConfirmTeardownFunc(a0, a1, a2)
+	}
+	return
+}
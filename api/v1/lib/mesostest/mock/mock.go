@@ -0,0 +1,56 @@
+// Package mock provides mock implementations of the mesos-go client interfaces, for unit tests of code
+// that depends on them. Unlike the fakes of package mesostest no network servers are involved: each
+// mock method delegates to an optional func field and records its invocation.
+//
+//	m := &mock.Master{
+//		GetHealthFunc: func(context.Context) (bool, error) { return true, nil },
+//	}
+//	healthy, _ := m.GetHealth(ctx)
+//	if m.Count("GetHealth") != 1 { ... }
+package mock
+
+import "sync"
+
+// Invocation records a single invocation of a mock method.
+type Invocation struct {
+	Method string
+	Args   []interface{}
+}
+
+// recorder records the invocations of a mock; it's embedded by every generated mock type.
+type recorder struct {
+	mu          sync.Mutex
+	invocations []Invocation
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invocations = append(r.invocations, Invocation{Method: method, Args: args})
+}
+
+// Invocations returns the recorded invocations, in the order they were made.
+func (r *recorder) Invocations() []Invocation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Invocation(nil), r.invocations...)
+}
+
+// Count returns the number of recorded invocations of the given method.
+func (r *recorder) Count(method string) (n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.invocations {
+		if r.invocations[i].Method == method {
+			n++
+		}
+	}
+	return
+}
+
+// Reset discards the recorded invocations.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invocations = nil
+}
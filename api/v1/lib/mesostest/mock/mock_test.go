@@ -0,0 +1,88 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/executor"
+	"github.com/mesos/mesos-go/api/v1/lib/executor/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/httpcli/httpmaster"
+)
+
+// drain is an example of code under test that depends on httpmaster.API.
+func drain(ctx context.Context, api httpmaster.API, agents ...mesos.AgentID) error {
+	for _, id := range agents {
+		if err := api.MarkAgentGone(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestMaster(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		expected = errors.New("agent not found")
+		m        = &Master{}
+	)
+	if err := drain(ctx, m, mesos.AgentID{Value: "a1"}, mesos.AgentID{Value: "a2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := m.Count("MarkAgentGone"); n != 2 {
+		t.Fatalf("expected 2 invocations instead of %d", n)
+	}
+	want := []Invocation{
+		{Method: "MarkAgentGone", Args: []interface{}{ctx, mesos.AgentID{Value: "a1"}}},
+		{Method: "MarkAgentGone", Args: []interface{}{ctx, mesos.AgentID{Value: "a2"}}},
+	}
+	if got := m.Invocations(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected invocations %+v instead of %+v", want, got)
+	}
+
+	m.Reset()
+	m.MarkAgentGoneFunc = func(_ context.Context, id mesos.AgentID) error {
+		if id.Value == "a2" {
+			return expected
+		}
+		return nil
+	}
+	if err := drain(ctx, m, mesos.AgentID{Value: "a1"}, mesos.AgentID{Value: "a2"}, mesos.AgentID{Value: "a3"}); err != expected {
+		t.Fatalf("expected error %v instead of %v", expected, err)
+	}
+	if n := len(m.Invocations()); n != 2 {
+		t.Fatalf("expected 2 invocations instead of %d", n)
+	}
+}
+
+func TestVariadic(t *testing.T) {
+	var (
+		m   = &Master{}
+		got []mesos.Resource
+	)
+	m.ReserveResourcesFunc = func(_ context.Context, _ mesos.AgentID, rs ...mesos.Resource) error {
+		got = rs
+		return nil
+	}
+	rs := []mesos.Resource{{Name: "cpus"}, {Name: "mem"}}
+	_ = m.ReserveResources(context.Background(), mesos.AgentID{Value: "a1"}, rs...)
+	if !reflect.DeepEqual(got, rs) {
+		t.Fatalf("expected %v instead of %v", rs, got)
+	}
+	if args := m.Invocations()[0].Args; !reflect.DeepEqual(args[2], rs) {
+		t.Fatalf("expected variadic args to be recorded as a slice, got %v", args[2])
+	}
+}
+
+func TestExecutorSender(t *testing.T) {
+	s := &ExecutorSender{}
+	resp, err := calls.Sender(s).Send(context.Background(), calls.NonStreaming(&executor.Call{Type: executor.Call_MESSAGE}))
+	if resp != nil || err != nil {
+		t.Fatalf("expected zero values instead of %v, %v", resp, err)
+	}
+	if n := s.Count("Send"); n != 1 {
+		t.Fatalf("expected 1 invocation instead of %d", n)
+	}
+}
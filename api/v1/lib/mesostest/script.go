@@ -0,0 +1,162 @@
+package mesostest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+type (
+	// Script is a programmable sequence of scheduler events that's fed to an event handler under test;
+	// it's intended for table-driven tests of scheduling logic, usually in concert w/ a Recorder that
+	// stands in for the caller of the handler.
+	//
+	//	rec := &mesostest.Recorder{}
+	//	err := mesostest.NewScript().
+	//		Offers(offer).
+	//		Check(func() error { return expectDecline(rec.Calls()) }).
+	//		Run(ctx, newHandler(rec))
+	Script struct {
+		steps []step
+	}
+
+	step func(context.Context, events.Handler) error
+
+	// Recorder is a calls.Caller that records the calls that it receives, for later assertions. The
+	// zero value is ready to use.
+	Recorder struct {
+		// Respond optionally generates the result of a call; by default calls succeed w/o a response.
+		Respond func(*scheduler.Call) (mesos.Response, error)
+
+		mu    sync.Mutex
+		calls []scheduler.Call
+	}
+
+	// StepError is returned by Script.Run when a step fails.
+	StepError struct {
+		Step int   // Step is the (zero-based) index of the failed step
+		Err  error // Err is the error reported by the step
+	}
+)
+
+var _ = calls.Caller(&Recorder{})
+
+func (err *StepError) Error() string {
+	return fmt.Sprintf("script step %d failed: %v", err.Step, err.Err)
+}
+
+// NewScript returns an empty Script.
+func NewScript() *Script { return &Script{} }
+
+// Event appends a step that feeds the given event to the handler.
+func (s *Script) Event(e *scheduler.Event) *Script {
+	s.steps = append(s.steps, func(ctx context.Context, h events.Handler) error { return h.HandleEvent(ctx, e) })
+	return s
+}
+
+// Subscribed appends a step that feeds a SUBSCRIBED event for the given framework to the handler.
+func (s *Script) Subscribed(fid mesos.FrameworkID) *Script {
+	return s.Event(&scheduler.Event{
+		Type:       scheduler.Event_SUBSCRIBED,
+		Subscribed: &scheduler.Event_Subscribed{FrameworkID: &fid},
+	})
+}
+
+// Offers appends a step that feeds an OFFERS event w/ the given offers to the handler.
+func (s *Script) Offers(offers ...mesos.Offer) *Script {
+	return s.Event(&scheduler.Event{Type: scheduler.Event_OFFERS, Offers: &scheduler.Event_Offers{Offers: offers}})
+}
+
+// Rescind appends a step that feeds a RESCIND event for the given offer to the handler.
+func (s *Script) Rescind(offerID mesos.OfferID) *Script {
+	return s.Event(&scheduler.Event{Type: scheduler.Event_RESCIND, Rescind: &scheduler.Event_Rescind{OfferID: offerID}})
+}
+
+// Update appends a step that feeds an UPDATE event for the given task status to the handler.
+func (s *Script) Update(status mesos.TaskStatus) *Script {
+	return s.Event(&scheduler.Event{Type: scheduler.Event_UPDATE, Update: &scheduler.Event_Update{Status: status}})
+}
+
+// Heartbeat appends a step that feeds a HEARTBEAT event to the handler.
+func (s *Script) Heartbeat() *Script {
+	return s.Event(&scheduler.Event{Type: scheduler.Event_HEARTBEAT})
+}
+
+// Wait appends a step that pauses the script for the given duration, e.g. to let timers of the handler
+// under test expire. The pause is cut short if the context is canceled.
+func (s *Script) Wait(d time.Duration) *Script {
+	s.steps = append(s.steps, func(ctx context.Context, _ events.Handler) error {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	return s
+}
+
+// Check appends a step that invokes the given func, typically to assert the calls that were recorded
+// so far; a non-nil error fails the script.
+func (s *Script) Check(f func() error) *Script {
+	s.steps = append(s.steps, func(context.Context, events.Handler) error { return f() })
+	return s
+}
+
+// Run executes the steps of the script, in order, w/ the given handler. It stops at the first step that
+// fails (including events that the handler fails to process) and returns a *StepError.
+func (s *Script) Run(ctx context.Context, h events.Handler) error {
+	for i, f := range s.steps {
+		if err := ctx.Err(); err != nil {
+			return &StepError{Step: i, Err: err}
+		}
+		if err := f(ctx, h); err != nil {
+			return &StepError{Step: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// Call implements calls.Caller.
+func (r *Recorder) Call(_ context.Context, c *scheduler.Call) (mesos.Response, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, *c)
+	r.mu.Unlock()
+	if r.Respond != nil {
+		return r.Respond(c)
+	}
+	return nil, nil
+}
+
+// Calls returns the recorded calls, in order of receipt.
+func (r *Recorder) Calls() []scheduler.Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]scheduler.Call(nil), r.calls...)
+}
+
+// CallTypes returns the types of the recorded calls, in order of receipt.
+func (r *Recorder) CallTypes() []scheduler.Call_Type {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]scheduler.Call_Type, len(r.calls))
+	for i := range r.calls {
+		types[i] = r.calls[i].GetType()
+	}
+	return types
+}
+
+// Reset discards the recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
@@ -0,0 +1,86 @@
+package mesostest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
+	"github.com/mesos/mesos-go/api/v1/lib/scheduler/events"
+)
+
+// declineAll is a trivial scheduler that declines every offer, and acknowledges every status update.
+func declineAll(caller calls.Caller) events.Handler {
+	return events.Handlers{
+		scheduler.Event_OFFERS: events.HandlerFunc(func(ctx context.Context, e *scheduler.Event) error {
+			for _, o := range e.GetOffers().GetOffers() {
+				if err := calls.CallNoData(ctx, caller, calls.Decline(o.ID)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		scheduler.Event_UPDATE: events.HandlerFunc(func(ctx context.Context, e *scheduler.Event) error {
+			s := e.GetUpdate().GetStatus()
+			if s.UUID == nil {
+				return nil
+			}
+			return calls.CallNoData(ctx, caller, calls.Acknowledge(s.GetAgentID().GetValue(), s.TaskID.Value, s.UUID))
+		}),
+	}
+}
+
+func TestScript(t *testing.T) {
+	offer := func(id string) mesos.Offer { return mesos.Offer{ID: mesos.OfferID{Value: id}} }
+	expect := func(rec *Recorder, types ...scheduler.Call_Type) func() error {
+		return func() error {
+			if got := rec.CallTypes(); fmt.Sprint(got) != fmt.Sprint(types) {
+				return fmt.Errorf("expected calls %v instead of %v", types, got)
+			}
+			return nil
+		}
+	}
+	failure := errors.New("failure")
+
+	for i, tc := range []struct {
+		script  func(*Recorder) *Script
+		respond func(*scheduler.Call) (mesos.Response, error)
+		step    int // index of the failed step, or -1
+	}{
+		{
+			script: func(rec *Recorder) *Script {
+				return NewScript().
+					Subscribed(mesos.FrameworkID{Value: "f1"}).
+					Check(expect(rec)).
+					Offers(offer("o1"), offer("o2")).
+					Check(expect(rec, scheduler.Call_DECLINE, scheduler.Call_DECLINE)).
+					Heartbeat().
+					Wait(time.Millisecond).
+					Update(mesos.TaskStatus{UUID: []byte("u1")}).
+					Update(mesos.TaskStatus{}).
+					Check(expect(rec, scheduler.Call_DECLINE, scheduler.Call_DECLINE, scheduler.Call_ACKNOWLEDGE))
+			},
+			step: -1,
+		},
+		{
+			script: func(rec *Recorder) *Script {
+				return NewScript().Heartbeat().Offers(offer("o1")).Check(expect(rec))
+			},
+			respond: func(*scheduler.Call) (mesos.Response, error) { return nil, failure },
+			step:    1,
+		},
+	} {
+		rec := &Recorder{Respond: tc.respond}
+		err := tc.script(rec).Run(context.Background(), declineAll(rec))
+		if tc.step < 0 && err != nil {
+			t.Errorf("test case %d failed: %v", i, err)
+		}
+		if se, ok := err.(*StepError); tc.step >= 0 && (!ok || se.Step != tc.step || se.Err != failure) {
+			t.Errorf("test case %d: unexpected error %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package mesostest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mesos/mesos-go/api/v1/lib/encoding"
+)
+
+// subscription is a SUBSCRIBE stream of a fake server.
+type subscription struct {
+	streamID string
+	events   chan encoding.Marshaler
+	done     chan struct{} // closed to hang up the stream
+	hangup   sync.Once
+}
+
+func newSubscription(streamID string) *subscription {
+	return &subscription{
+		streamID: streamID,
+		events:   make(chan encoding.Marshaler),
+		done:     make(chan struct{}),
+	}
+}
+
+// close hangs up the stream.
+func (s *subscription) close() { s.hangup.Do(func() { close(s.done) }) }
+
+// send blocks until the event is written to the stream, or the stream is hung up, or the server is closed.
+func (s *subscription) send(e encoding.Marshaler, closed <-chan struct{}) error {
+	if s == nil {
+		return ErrNotSubscribed
+	}
+	select {
+	case s.events <- e:
+		return nil
+	case <-s.done:
+		return ErrNotSubscribed
+	case <-closed:
+		return ErrClosed
+	}
+}
+
+// serve writes events to the stream until it's hung up, the server is closed, the request is done or
+// writing fails. A heartbeat event is written every interval, unless the heartbeat is nil.
+func (s *subscription) serve(enc encoding.Encoder, closed, requestDone <-chan struct{}, interval time.Duration, heartbeat encoding.Marshaler) {
+	var tick <-chan time.Time
+	if heartbeat != nil {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		var e encoding.Marshaler
+		select {
+		case e = <-s.events:
+		case <-tick:
+			e = heartbeat
+		case <-s.done:
+			return
+		case <-closed:
+			return
+		case <-requestDone:
+			return
+		}
+		if err := enc.Encode(e); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package mesos
+
+// IsTerminal returns true if the state is terminal, i.e. an operation in this state will not transition
+// to any other state. OPERATION_UNSUPPORTED, the value of a state that is not set, is not terminal: it
+// says nothing about the progress of the operation.
+func (s OperationState) IsTerminal() bool {
+	switch s {
+	case OPERATION_FINISHED, OPERATION_FAILED, OPERATION_ERROR, OPERATION_DROPPED:
+		return true
+	}
+	return false
+}
+
+type (
+	// OperationIndex is a read-only index of operations, keyed by framework, agent, and operation ID.
+	// Operation IDs are specified by frameworks and are therefore only unique within a framework.
+	OperationIndex struct {
+		operations  []Operation
+		byFramework map[string][]int
+		byAgent     map[string][]int
+		byID        map[operationKey]int
+	}
+
+	operationKey struct{ framework, operation string }
+)
+
+// IndexOperations returns an index of the given operations. Operations that don't specify a framework
+// ID (e.g. those initiated via the operator API) are indexed by agent only; operations that don't specify
+// an operation ID are not indexed by ID.
+func IndexOperations(ops ...Operation) *OperationIndex {
+	x := &OperationIndex{
+		operations:  ops,
+		byFramework: make(map[string][]int),
+		byAgent:     make(map[string][]int),
+		byID:        make(map[operationKey]int),
+	}
+	for i := range ops {
+		op := &ops[i]
+		if fid := op.GetFrameworkID(); fid != nil {
+			x.byFramework[fid.Value] = append(x.byFramework[fid.Value], i)
+			if id := op.Info.GetID(); id != nil {
+				x.byID[operationKey{fid.Value, id.Value}] = i
+			}
+		}
+		if aid := op.GetAgentID(); aid != nil {
+			x.byAgent[aid.Value] = append(x.byAgent[aid.Value], i)
+		}
+	}
+	return x
+}
+
+// Len returns the number of indexed operations.
+func (x *OperationIndex) Len() int { return len(x.operations) }
+
+// Get returns the operation w/ the given ID that was submitted by the given framework; nil if the
+// operation isn't indexed.
+func (x *OperationIndex) Get(fid FrameworkID, id OperationID) *Operation {
+	if i, ok := x.byID[operationKey{fid.Value, id.Value}]; ok {
+		return &x.operations[i]
+	}
+	return nil
+}
+
+// ByFramework returns the operations that were submitted by the given framework.
+func (x *OperationIndex) ByFramework(fid FrameworkID) []Operation {
+	return x.lookup(x.byFramework[fid.Value])
+}
+
+// ByAgent returns the operations that apply to resources of the given agent.
+func (x *OperationIndex) ByAgent(aid AgentID) []Operation {
+	return x.lookup(x.byAgent[aid.Value])
+}
+
+// Pending returns the operations whose latest status is non-terminal, optionally restricted to
+// the given operation types. Useful for auditing RESERVE or CREATE operations after a failover.
+// Operations whose latest status doesn't set a state (OPERATION_UNSUPPORTED) are not pending, since
+// their progress is unknown.
+func (x *OperationIndex) Pending(types ...Offer_Operation_Type) (result []Operation) {
+	for i := range x.operations {
+		op := &x.operations[i]
+		if s := op.LatestStatus.GetState(); s == OPERATION_UNSUPPORTED || s.IsTerminal() ||
+			!hasOperationType(op.Info.GetType(), types) {
+			continue
+		}
+		result = append(result, *op)
+	}
+	return
+}
+
+func (x *OperationIndex) lookup(indices []int) []Operation {
+	if len(indices) == 0 {
+		return nil
+	}
+	result := make([]Operation, len(indices))
+	for i, j := range indices {
+		result[i] = x.operations[j]
+	}
+	return result
+}
+
+func hasOperationType(t Offer_Operation_Type, types []Offer_Operation_Type) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, tt := range types {
+		if t == tt {
+			return true
+		}
+	}
+	return false
+}
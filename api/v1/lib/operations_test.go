@@ -0,0 +1,68 @@
+package mesos_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestOperationIndex(t *testing.T) {
+	op := func(fid, aid, id string, t mesos.Offer_Operation_Type, s mesos.OperationState) (o mesos.Operation) {
+		if fid != "" {
+			o.FrameworkID = &mesos.FrameworkID{Value: fid}
+		}
+		o.AgentID = &mesos.AgentID{Value: aid}
+		o.Info.Type = t
+		if id != "" {
+			o.Info.ID = &mesos.OperationID{Value: id}
+		}
+		o.LatestStatus.State = s
+		return
+	}
+	x := mesos.IndexOperations(
+		op("f1", "a1", "op1", mesos.Offer_Operation_RESERVE, mesos.OPERATION_PENDING),
+		op("f1", "a2", "op2", mesos.Offer_Operation_CREATE, mesos.OPERATION_FINISHED),
+		op("f2", "a1", "op1", mesos.Offer_Operation_CREATE, mesos.OPERATION_PENDING),
+		op("", "a2", "", mesos.Offer_Operation_UNRESERVE, mesos.OPERATION_PENDING),
+		op("f2", "a2", "op3", mesos.Offer_Operation_RESERVE, mesos.OPERATION_UNSUPPORTED),
+	)
+	if n := x.Len(); n != 5 {
+		t.Fatalf("expected 5 operations instead of %d", n)
+	}
+	if o := x.Get(mesos.FrameworkID{Value: "f2"}, mesos.OperationID{Value: "op1"}); o == nil || o.Info.Type != mesos.Offer_Operation_CREATE {
+		t.Fatalf("unexpected operation %v", o)
+	}
+	if o := x.Get(mesos.FrameworkID{Value: "f2"}, mesos.OperationID{Value: "op2"}); o != nil {
+		t.Fatalf("unexpected operation %v", o)
+	}
+	if ops := x.ByFramework(mesos.FrameworkID{Value: "f1"}); len(ops) != 2 {
+		t.Fatalf("expected 2 operations instead of %v", ops)
+	}
+	if ops := x.ByAgent(mesos.AgentID{Value: "a2"}); len(ops) != 3 {
+		t.Fatalf("expected 3 operations instead of %v", ops)
+	}
+	if ops := x.ByAgent(mesos.AgentID{Value: "a3"}); ops != nil {
+		t.Fatalf("expected no operations instead of %v", ops)
+	}
+	if ops := x.Pending(); len(ops) != 3 {
+		t.Fatalf("expected 3 pending operations instead of %v", ops)
+	}
+	if ops := x.Pending(mesos.Offer_Operation_RESERVE, mesos.Offer_Operation_CREATE); len(ops) != 2 {
+		t.Fatalf("expected 2 pending operations instead of %v", ops)
+	}
+}
+
+func TestOperationState_IsTerminal(t *testing.T) {
+	for s, want := range map[mesos.OperationState]bool{
+		mesos.OPERATION_UNSUPPORTED: false,
+		mesos.OPERATION_PENDING:     false,
+		mesos.OPERATION_FINISHED:    true,
+		mesos.OPERATION_FAILED:      true,
+		mesos.OPERATION_ERROR:       true,
+		mesos.OPERATION_DROPPED:     true,
+	} {
+		if got := s.IsTerminal(); got != want {
+			t.Errorf("%v: expected %v instead of %v", s, want, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+	"github.com/mesos/mesos-go/api/v1/lib/roles"
+)
+
+// RequestOpt is a functional option type for QuotaRequest
+type RequestOpt func(*QuotaRequest)
+
+// Force returns an option that instructs the master to skip the capacity heuristic check when it
+// applies the quota request.
+func Force() RequestOpt {
+	return func(r *QuotaRequest) {
+		force := true
+		r.Force = &force
+	}
+}
+
+// NewRequest returns a QuotaRequest that guarantees the given resources to the role.
+func NewRequest(role string, guarantee []mesos.Resource, opts ...RequestOpt) QuotaRequest {
+	r := QuotaRequest{Role: &role, Guarantee: guarantee}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&r)
+		}
+	}
+	return r
+}
+
+// Validate checks the QuotaRequest in the same way that the master does before it applies the request:
+// the role must be a valid, non-default role, and every guaranteed resource must be a valid, unique,
+// non-revocable, unreserved scalar that doesn't specify disk or resource provider information.
+func (r *QuotaRequest) Validate() error {
+	role := r.GetRole()
+	if role == "" {
+		return errors.New("quota request must specify a role")
+	}
+	if roles.Role(role).IsDefault() {
+		return errors.New("quota request may not specify the default role")
+	}
+	if err := roles.Validate(role); err != nil {
+		return err
+	}
+	if len(r.Guarantee) == 0 {
+		return errors.New("quota request must specify a guarantee")
+	}
+	return ValidateGuarantee(r.Guarantee...)
+}
+
+// ValidateGuarantee checks that the given resources may be used as a quota guarantee.
+func ValidateGuarantee(guarantee ...mesos.Resource) error {
+	if err := resources.Validate(guarantee...); err != nil {
+		return err
+	}
+	names := make(map[string]struct{}, len(guarantee))
+	for i := range guarantee {
+		r := &guarantee[i]
+		name := r.GetName()
+		switch {
+		case r.GetType() != mesos.SCALAR:
+			return fmt.Errorf("quota guarantee for %q must be a scalar", name)
+		case r.IsReserved(""):
+			return fmt.Errorf("quota guarantee for %q may not be reserved", name)
+		case r.IsRevocable():
+			return fmt.Errorf("quota guarantee for %q may not be revocable", name)
+		case r.GetDisk() != nil:
+			return fmt.Errorf("quota guarantee for %q may not specify DiskInfo", name)
+		case r.HasResourceProvider():
+			return fmt.Errorf("quota guarantee for %q may not specify a resource provider", name)
+		}
+		if _, found := names[name]; found {
+			return fmt.Errorf("quota guarantee for %q is specified more than once", name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+// Find returns the QuotaInfo for the given role; nil if there's no quota for the role.
+func (s *QuotaStatus) Find(role string) *QuotaInfo {
+	for i := range s.GetInfos() {
+		if s.Infos[i].GetRole() == role {
+			return &s.Infos[i]
+		}
+	}
+	return nil
+}
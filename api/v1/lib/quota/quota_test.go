@@ -0,0 +1,55 @@
+package quota_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/quota"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestQuotaRequest_Validate(t *testing.T) {
+	var (
+		cpus = resources.NewCPUs(2).Resource
+		mem  = resources.NewMemory(1024).Resource
+	)
+	for ti, tc := range []struct {
+		req     quota.QuotaRequest
+		wantErr bool
+	}{
+		{quota.NewRequest("dev", []mesos.Resource{cpus, mem}), false},
+		{quota.NewRequest("dev/team", []mesos.Resource{cpus}, quota.Force()), false},
+		{quota.NewRequest("", []mesos.Resource{cpus}), true},
+		{quota.NewRequest("*", []mesos.Resource{cpus}), true},
+		{quota.NewRequest("/dev", []mesos.Resource{cpus}), true},
+		{quota.NewRequest("dev", nil), true},
+		{quota.NewRequest("dev", []mesos.Resource{cpus, cpus}), true},
+		{quota.NewRequest("dev", []mesos.Resource{resources.NewCPUs(1).Role("dev").Resource}), true},
+		{quota.NewRequest("dev", []mesos.Resource{resources.NewCPUs(1).Revocable().Resource}), true},
+		{quota.NewRequest("dev", []mesos.Resource{resources.NewDisk(1).Disk("", "").Resource}), true},
+		{quota.NewRequest("dev", []mesos.Resource{resources.Build().Name(resources.NamePorts).Ranges(
+			resources.BuildRanges().Span(1, 2).Ranges).Resource}), true},
+		{quota.NewRequest("dev", []mesos.Resource{resources.NewCPUs(-1).Resource}), true},
+	} {
+		err := tc.req.Validate()
+		if tc.wantErr != (err != nil) {
+			t.Errorf("test case %d failed: wantErr=%v, err=%v", ti, tc.wantErr, err)
+		}
+	}
+	if r := quota.NewRequest("dev", nil, quota.Force()); !r.GetForce() {
+		t.Errorf("expected forced quota request")
+	}
+}
+
+func TestQuotaStatus_Find(t *testing.T) {
+	var (
+		dev    = "dev"
+		status = quota.QuotaStatus{Infos: []quota.QuotaInfo{{Role: &dev}}}
+	)
+	if status.Find("dev") == nil {
+		t.Errorf("expected to find quota for role dev")
+	}
+	if status.Find("prod") != nil {
+		t.Errorf("unexpected quota for role prod")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"sync"
 
 	logger "github.com/mesos/mesos-go/api/v1/lib/debug"
 	"github.com/mesos/mesos-go/api/v1/lib/encoding/framing"
@@ -11,14 +12,30 @@ import (
 
 const debug = logger.Logger(false)
 
+const (
+	defaultBufferSize     = 16 * 1024
+	defaultMaxMessageSize = 1 << 22 // max protobuf size
+
+	maxInt = uint64(^uint(0) >> 1)
+)
+
+// bufferPool holds the initial scan buffers of Pooled readers.
+var bufferPool = sync.Pool{New: func() interface{} {
+	b := make([]byte, defaultBufferSize)
+	return &b
+}}
+
 type (
 	Opt func(*reader)
 
 	reader struct {
 		*bufio.Scanner
-		pend   int
-		splitf func(data []byte, atEOF bool) (int, []byte, error)
-		maxf   int // max frame size
+		pend    int
+		splitf  func(data []byte, atEOF bool) (int, []byte, error)
+		maxf    int     // max frame size
+		maxb    int     // max scan buffer size
+		bufsize int     // initial scan buffer size, unless pooled
+		pooled  *[]byte // initial scan buffer, obtained from bufferPool
 	}
 )
 
@@ -31,8 +48,9 @@ func NewReader(read io.Reader, opt ...Opt) framing.Reader {
 		// use this proxy func as a work-around.
 		return r.splitf(data, atEOF)
 	})
-	buf := make([]byte, 16*1024)
-	r.Buffer(buf, 1<<22) // 1<<22 == max protobuf size
+	r.bufsize = defaultBufferSize
+	r.maxb = defaultMaxMessageSize
+	r.maxf = defaultMaxMessageSize
 	r.splitf = r.splitSize
 	// apply options
 	for _, f := range opt {
@@ -40,16 +58,37 @@ func NewReader(read io.Reader, opt ...Opt) framing.Reader {
 			f(r)
 		}
 	}
+	var buf []byte
+	if r.pooled != nil {
+		buf = *r.pooled
+	} else {
+		buf = make([]byte, r.bufsize)
+	}
+	r.Buffer(buf, r.maxb)
 	return r
 }
 
 // MaxMessageSize returns a functional option that configures the internal Scanner's buffer and max token (message)
-// length, in bytes.
+// length, in bytes. Frames w/ a length prefix that exceeds the max yield framing.ErrorOversizedFrame; the frame
+// data is not buffered. The default max is 4MiB, the max size of a protobuf message.
 func MaxMessageSize(max int) Opt {
 	return func(r *reader) {
-		buf := make([]byte, max>>1)
-		r.Buffer(buf, max)
+		r.bufsize = max >> 1
 		r.maxf = max
+		r.maxb = max
+	}
+}
+
+// Pooled returns a functional option that configures the reader to obtain its initial scan buffer from
+// a shared pool, rather than allocating a new one. The buffer is returned to the pool once ReadFrame
+// reports an error (including io.EOF), after which the reader must not be used. Frames are never copied
+// by the reader: a frame is only valid until the next invocation of ReadFrame, regardless of this option.
+// Useful for processes that open many (short-lived) streams.
+func Pooled() Opt {
+	return func(r *reader) {
+		if r.pooled == nil {
+			r.pooled = bufferPool.Get().(*[]byte)
+		}
 	}
 }
 
@@ -87,7 +126,7 @@ func (r *reader) splitSize(data []byte, atEOF bool) (int, []byte, error) {
 			debug.Log("failed to parse frame size field:", err)
 			return 0, nil, framing.ErrorBadSize
 		}
-		if r.maxf != 0 && int(n) > r.maxf {
+		if n > maxInt || (r.maxf > 0 && int(n) > r.maxf) {
 			debug.Log("frame size max length exceeded:", n)
 			return 0, nil, framing.ErrorOversizedFrame
 		}
@@ -113,7 +152,8 @@ func (r *reader) splitFrame(data []byte, atEOF bool) (advance int, token []byte,
 		}
 	}
 	if r.pend == 0 {
-		panic("asked to read frame data, but no data left in frame")
+		// should never happen; don't crash the process because of a bug in the split funcs
+		return 0, nil, framing.ErrorBadSize
 	}
 	if x < int(r.pend) {
 		// need more data
@@ -141,5 +181,10 @@ func (r *reader) ReadFrame() (tok []byte, err error) {
 	if err == nil && len(tok) == 0 {
 		err = io.EOF
 	}
+	if err != nil && r.pooled != nil {
+		// the scanner has stopped and won't touch its buffer again
+		bufferPool.Put(r.pooled)
+		r.pooled = nil
+	}
 	return
 }
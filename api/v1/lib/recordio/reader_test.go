@@ -45,6 +45,13 @@ func TestReadFrame(t *testing.T) {
 		{"one-byte", iotest.OneByteReader},
 		{"half", iotest.HalfReader},
 	}
+	options := []struct {
+		name string
+		opts []recordio.Opt
+	}{
+		{"default", []recordio.Opt{recordio.MaxMessageSize(22)}},
+		{"pooled", []recordio.Opt{recordio.MaxMessageSize(22), recordio.Pooled()}},
+	}
 	for ti, tc := range []struct {
 		in     string
 		frames []string
@@ -70,31 +77,45 @@ func TestReadFrame(t *testing.T) {
 		/* 17 */ {"23\n", nil, framing.ErrorOversizedFrame}, // 23 exceeds max of 22
 	} {
 		for _, v := range variants {
-			t.Run(fmt.Sprintf("test case %d %s", ti, v.name), func(t *testing.T) {
-				var (
-					r = recordio.NewReader(v.decorate(
-						strings.NewReader(tc.in)), recordio.MaxMessageSize(22))
-					frames  []string
-					lastErr error
-				)
-				for lastErr == nil {
-					fr, err := r.ReadFrame()
-					if err == nil {
-						t.Log("read frame " + string(fr))
-						frames = append(frames, string(fr))
+			for _, o := range options {
+				t.Run(fmt.Sprintf("test case %d %s %s", ti, v.name, o.name), func(t *testing.T) {
+					var (
+						r = recordio.NewReader(v.decorate(
+							strings.NewReader(tc.in)), o.opts...)
+						frames  []string
+						lastErr error
+					)
+					for lastErr == nil {
+						fr, err := r.ReadFrame()
+						if err == nil {
+							t.Log("read frame " + string(fr))
+							frames = append(frames, string(fr))
+						}
+						lastErr = err
 					}
-					lastErr = err
-				}
-				if tc.err == nil && lastErr != io.EOF {
-					t.Fatalf("unexpected error %q", lastErr)
-				}
-				if tc.err != nil && lastErr != tc.err {
-					t.Fatalf("expected error %q instead of error %q", tc.err, lastErr)
-				}
-				if !reflect.DeepEqual(tc.frames, frames) {
-					t.Fatalf("expected frames %#v instead of frames %#v", tc.frames, frames)
-				}
-			})
+					if tc.err == nil && lastErr != io.EOF {
+						t.Fatalf("unexpected error %q", lastErr)
+					}
+					if tc.err != nil && lastErr != tc.err {
+						t.Fatalf("expected error %q instead of error %q", tc.err, lastErr)
+					}
+					if !reflect.DeepEqual(tc.frames, frames) {
+						t.Fatalf("expected frames %#v instead of frames %#v", tc.frames, frames)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestReadFrame_Oversized(t *testing.T) {
+	for ti, in := range []string{
+		"4194305\n",              // exceeds the default max of 4MiB
+		"18446744073709551615\n", // max uint64, overflows int
+	} {
+		r := recordio.NewReader(strings.NewReader(in))
+		if _, err := r.ReadFrame(); err != framing.ErrorOversizedFrame {
+			t.Errorf("test case %d failed: expected error %q instead of %q", ti, framing.ErrorOversizedFrame, err)
 		}
 	}
 }
@@ -118,6 +139,31 @@ func BenchmarkReader(b *testing.B) {
 	}
 }
 
+func BenchmarkReader_Streams(b *testing.B) {
+	const stream = "5\nhello6\nworld!"
+	for _, bc := range []struct {
+		name string
+		opts []recordio.Opt
+	}{
+		{"default", nil},
+		{"pooled", []recordio.Opt{recordio.Pooled()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r := recordio.NewReader(strings.NewReader(stream), bc.opts...)
+				for {
+					if _, err := r.ReadFrame(); err == io.EOF {
+						break
+					} else if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
 func genRecords(tb testing.TB, w io.Writer) {
 	rnd := rng{rand.New(rand.NewSource(0xdeadbeef))}
 	buf := make([]byte, 2<<12)
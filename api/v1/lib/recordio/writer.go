@@ -1,34 +1,99 @@
 package recordio
 
 import (
+	"bufio"
 	"io"
 	"strconv"
 )
 
 var lf = []byte{'\n'}
 
-type Writer struct {
-	out io.Writer
+type (
+	// WriterOpt is a functional option for a Writer.
+	WriterOpt func(*Writer)
+
+	// Writer generates a recordio stream, one frame at a time.
+	Writer struct {
+		out       io.Writer
+		buf       *bufio.Writer // optional; when set, wraps out
+		flushEach bool
+	}
+
+	// flusher is implemented by buffered writers, for example bufio.Writer.
+	flusher interface {
+		Flush() error
+	}
+
+	// httpFlusher is implemented by http.ResponseWriter (as http.Flusher).
+	httpFlusher interface {
+		Flush()
+	}
+)
+
+// NewWriter returns a Writer that sends recordio frames to out.
+func NewWriter(out io.Writer, opts ...WriterOpt) *Writer {
+	w := &Writer{out: out}
+	for _, f := range opts {
+		if f != nil {
+			f(w)
+		}
+	}
+	return w
 }
 
-func NewWriter(out io.Writer) *Writer {
-	return &Writer{out}
+// Buffered returns a WriterOpt that buffers frames, up to size bytes, before they're written to the
+// underlying io.Writer. Buffered frames are written upon Flush or once the buffer is full.
+func Buffered(size int) WriterOpt {
+	return func(w *Writer) {
+		w.buf = bufio.NewWriterSize(w.out, size)
+	}
+}
+
+// FlushEach returns a WriterOpt that flushes the Writer after every frame; useful for streams that are
+// consumed interactively (e.g. container input) and for http.ResponseWriter sinks.
+func FlushEach() WriterOpt {
+	return func(w *Writer) { w.flushEach = true }
 }
 
 func (w *Writer) writeBuffer(b []byte, err error) error {
 	if err != nil {
 		return err
 	}
-	n, err := w.out.Write(b)
+	var out io.Writer = w.out
+	if w.buf != nil {
+		out = w.buf
+	}
+	n, err := out.Write(b)
 	if err == nil && n != len(b) {
 		return io.ErrShortWrite
 	}
 	return err
 }
 
+// WriteFrame writes a single frame to the stream.
 func (w *Writer) WriteFrame(b []byte) (err error) {
 	err = w.writeBuffer(([]byte)(strconv.Itoa(len(b))), err)
 	err = w.writeBuffer(lf, err)
 	err = w.writeBuffer(b, err)
+	if err == nil && w.flushEach {
+		err = w.Flush()
+	}
 	return
 }
+
+// Flush writes any buffered frames to the underlying io.Writer, and then flushes the underlying
+// io.Writer if it supports flushing (for example, a bufio.Writer or an http.Flusher).
+func (w *Writer) Flush() error {
+	if w.buf != nil {
+		if err := w.buf.Flush(); err != nil {
+			return err
+		}
+	}
+	switch f := w.out.(type) {
+	case flusher:
+		return f.Flush()
+	case httpFlusher:
+		f.Flush()
+	}
+	return nil
+}
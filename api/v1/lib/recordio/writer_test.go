@@ -39,3 +39,62 @@ func TestWriter(t *testing.T) {
 		}
 	}
 }
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushRecorder) Flush() { f.flushes++ }
+
+func TestWriter_Flush(t *testing.T) {
+	var out flushRecorder
+	w := NewWriter(&out, Buffered(64))
+	for _, s := range []string{"a", "bc"} {
+		if err := w.WriteFrame(([]byte)(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if out.Len() != 0 || out.flushes != 0 {
+		t.Fatalf("expected buffered frames instead of %q (%d flushes)", out.String(), out.flushes)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if s := out.String(); s != "1\na2\nbc" || out.flushes != 1 {
+		t.Fatalf("unexpected output %q (%d flushes)", s, out.flushes)
+	}
+
+	out = flushRecorder{}
+	w = NewWriter(&out, Buffered(64), FlushEach())
+	for i, s := range []string{"a", "bc"} {
+		if err := w.WriteFrame(([]byte)(s)); err != nil {
+			t.Fatal(err)
+		}
+		if out.flushes != i+1 {
+			t.Fatalf("expected %d flushes instead of %d", i+1, out.flushes)
+		}
+	}
+	if s := out.String(); s != "1\na2\nbc" {
+		t.Fatalf("unexpected output %q", s)
+	}
+
+	// frames written by a Writer are consumed by a Reader
+	var buf bytes.Buffer
+	w = NewWriter(&buf)
+	for _, s := range []string{"abc", "de\nf"} {
+		if err := w.WriteFrame(([]byte)(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := NewReader(&buf)
+	for _, want := range []string{"abc", "de\nf"} {
+		b, err := r.ReadFrame()
+		if err != nil || string(b) != want {
+			t.Fatalf("expected frame %q instead of %q (err=%v)", want, string(b), err)
+		}
+	}
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Fatalf("expected io.EOF instead of %v", err)
+	}
+}
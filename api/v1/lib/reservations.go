@@ -0,0 +1,78 @@
+package mesos
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Validate returns the first validation error of any resource in the collection, including errors in
+// reservation refinement stacks (see Resource.Validate).
+func (rs Resources) Validate() error {
+	for i := range rs {
+		if err := rs[i].Validate(); err != nil {
+			return fmt.Errorf("resource %q: %v", rs[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// ToPostRefinementFormat returns a (cloned) view of the Resources w/ reservations expressed in the
+// "post-reservation-refinement" format (Resource.Reservations); the legacy Resource.Role and
+// Resource.Reservation fields are cleared. It does not modify the receiver.
+func (rs Resources) ToPostRefinementFormat() (result Resources) {
+	if rs == nil {
+		return nil
+	}
+	result = make(Resources, 0, len(rs))
+	for i := range rs {
+		r := proto.Clone(&rs[i]).(*Resource)
+		if len(r.Reservations) == 0 && r.Role != nil && *r.Role != "*" {
+			ri := Resource_ReservationInfo{}
+			if r.Reservation != nil {
+				ri = *r.Reservation
+				ri.Type = Resource_ReservationInfo_DYNAMIC.Enum()
+			} else {
+				ri.Type = Resource_ReservationInfo_STATIC.Enum()
+			}
+			ri.Role = r.Role
+			r.Reservations = []Resource_ReservationInfo{ri}
+		}
+		r.Role = nil
+		r.Reservation = nil
+		result = append(result, *r)
+	}
+	return
+}
+
+// ToPreRefinementFormat returns a (cloned) view of the Resources w/ reservations expressed in the
+// "pre-reservation-refinement" format (Resource.Role and Resource.Reservation), as understood by
+// masters that don't support the RESERVATION_REFINEMENT capability. Returns an error if any resource
+// has a refined reservation, which cannot be expressed in that format. It does not modify the receiver.
+func (rs Resources) ToPreRefinementFormat() (Resources, error) {
+	if rs == nil {
+		return nil, nil
+	}
+	result := make(Resources, 0, len(rs))
+	for i := range rs {
+		r := proto.Clone(&rs[i]).(*Resource)
+		switch len(r.Reservations) {
+		case 0:
+			if r.Role == nil {
+				r.Role = proto.String("*")
+			}
+		case 1:
+			ri := r.Reservations[0]
+			r.Role = ri.Role
+			if ri.GetType() == Resource_ReservationInfo_DYNAMIC {
+				ri.Type, ri.Role = nil, nil
+				r.Reservation = &ri
+			}
+			r.Reservations = nil
+		default:
+			return nil, fmt.Errorf("resource %q has refined reservations for role %q", r.Name, r.ReservationRole())
+		}
+		result = append(result, *r)
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package mesos_test
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mesos/mesos-go/api/v1/lib"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestRefinementFormat(t *testing.T) {
+	var (
+		unreserved = rez.NewCPUs(1).Resource
+		static     = rez.NewCPUs(2).Role("a").Resource
+		dynamic    = func() mesos.Resource {
+			r := rez.NewCPUs(3).Role("a").Resource
+			r.Reservation = &mesos.Resource_ReservationInfo{Principal: proto.String("p")}
+			return r
+		}()
+		pre  = mesos.Resources{unreserved, static, dynamic}
+		post = mesos.Resources{
+			unreserved,
+			rez.Build().Name(rez.NameCPUs).Scalar(2).Resource,
+			rez.NewCPUs(3).Reserve("a", "p").Resource,
+		}
+	)
+	post[1].Reservations = []mesos.Resource_ReservationInfo{rez.StaticReservation("a")}
+
+	got := pre.ToPostRefinementFormat()
+	if err := got.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for i := range post {
+		if !got[i].Equivalent(post[i]) {
+			t.Errorf("expected %v instead of %v", post[i], got[i])
+		}
+	}
+	if pre[2].Reservation == nil || len(pre[2].Reservations) != 0 {
+		t.Fatalf("ToPostRefinementFormat modified its receiver: %v", pre[2])
+	}
+
+	back, err := got.ToPreRefinementFormat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range pre {
+		if r := back[i]; r.GetRole() != pre[i].GetRole() || (r.Reservation == nil) != (pre[i].Reservation == nil) || len(r.Reservations) > 0 {
+			t.Errorf("expected %v instead of %v", pre[i], r)
+		}
+	}
+
+	refined := mesos.Resources{rez.NewCPUs(1).Reserve("a", "").Reserve("a/b", "").Resource}
+	if _, err := refined.ToPreRefinementFormat(); err == nil {
+		t.Fatal("expected an error for refined reservations")
+	}
+}
@@ -37,6 +37,10 @@ func Revocable(r *mesos.Resource) bool {
 	return r.IsRevocable()
 }
 
+func NonRevocable(r *mesos.Resource) bool {
+	return !r.IsRevocable()
+}
+
 func Scalar(r *mesos.Resource) bool {
 	return r.GetType() == mesos.SCALAR
 }
@@ -49,6 +53,28 @@ func Set(r *mesos.Resource) bool {
 	return r.GetType() == mesos.SET
 }
 
+// DiskSource returns a filter that accepts disk resources of the given source type.
+func DiskSource(t mesos.Resource_DiskInfo_Source_Type) Filter {
+	return Filter(func(r *mesos.Resource) bool {
+		return r.IsDisk(t)
+	})
+}
+
+// DiskProfile returns a filter that accepts disk resources whose source was created w/ the given profile.
+func DiskProfile(profile string) Filter {
+	return Filter(func(r *mesos.Resource) bool {
+		s := r.GetDisk().GetSource()
+		return s != nil && s.GetProfile() == profile
+	})
+}
+
+// ResourceProvider returns a filter that accepts resources offered by the given resource provider.
+func ResourceProvider(id mesos.ResourceProviderID) Filter {
+	return Filter(func(r *mesos.Resource) bool {
+		return r.GetProviderID().Equal(&id)
+	})
+}
+
 func (f Filter) OrElse(other Filter) Filter {
 	return Filter(func(r *mesos.Resource) bool {
 		return f.Accepts(r) || other.Accepts(r)
@@ -96,5 +122,19 @@ func ReservedByRole(role string) Filter {
 	})
 }
 
+// ReservedByPrincipal returns a filter that accepts dynamically reserved resources whose (most refined)
+// reservation was made by the given principal.
+func ReservedByPrincipal(principal string) Filter {
+	return Filter(func(r *mesos.Resource) bool {
+		if rs := r.GetReservations(); len(rs) > 0 {
+			return rs[len(rs)-1].GetPrincipal() == principal
+		}
+		if ri := r.GetReservation(); ri != nil {
+			return ri.GetPrincipal() == principal
+		}
+		return false
+	})
+}
+
 // New concatenates the given filters
 func New(filters ...Filter) Filters { return Filters(filters) }
@@ -943,14 +943,15 @@ func (left *Resource) checkType(t Value_Type) Value_Type {
 }
 
 // IsEmpty returns true if the value of this resource is equivalent to the zero-value,
-// where a zero-length slice or map is equivalent to a nil reference to such.
+// where a zero-length slice or map is equivalent to a nil reference to such. Scalars are
+// compared using fixed-point arithmetic (three decimal places), like Mesos does.
 func (left *Resource) IsEmpty() bool {
 	if left == nil {
 		return true
 	}
 	switch left.GetType() {
 	case SCALAR:
-		return left.GetScalar().GetValue() == 0
+		return left.GetScalar().Compare(nil) == 0
 	case RANGES:
 		return len(left.GetRanges().GetRange()) == 0
 	case SET:
@@ -69,8 +69,8 @@ func SumAndCompare(expected []mesos.Resource, resources ...mesos.Resource) bool
 	}
 	calcTotals := func(r []mesos.Resource) (m map[Name]total) {
 		m = make(map[Name]total)
-		for n, t := range TypesOf(expected...) {
-			v, ok := n.Sum(expected...)
+		for n, t := range TypesOf(r...) {
+			v, ok := n.Sum(r...)
 			m[n] = total{v, t, ok}
 		}
 		return
@@ -89,7 +89,7 @@ func SumAndCompare(expected []mesos.Resource, resources ...mesos.Resource) bool
 		}
 		switch r.t {
 		case mesos.SCALAR:
-			if v1, v2 := tot.v.GetScalar().GetValue(), r.v.GetScalar().GetValue(); v1 != v2 {
+			if tot.v.GetScalar().Compare(r.v.GetScalar()) != 0 {
 				return false
 			}
 		case mesos.RANGES:
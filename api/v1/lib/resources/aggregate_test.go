@@ -72,3 +72,24 @@ func TestFlatten(t *testing.T) {
 		Expect(t, rez.Equivalent(r, tc.wants), "test case %d failed: expected %+v instead of %+v", i, tc.wants, r)
 	}
 }
+
+func TestSumAndCompare(t *testing.T) {
+	var (
+		cpus     = Resources(Resource(Name("cpus"), ValueScalar(0.1)), Resource(Name("cpus"), ValueScalar(0.2), Role("role1")))
+		cpusSum  = Resources(Resource(Name("cpus"), ValueScalar(0.3)))
+		cpusMore = Resources(Resource(Name("cpus"), ValueScalar(0.4)))
+		mem      = Resources(Resource(Name("mem"), ValueScalar(0.3)))
+	)
+	for i, tc := range []struct {
+		expected, resources mesos.Resources
+		want                bool
+	}{
+		{cpus, cpusSum, true}, // 0.1 + 0.2 == 0.3 w/ fixed-point arithmetic
+		{cpus, cpusMore, false},
+		{cpus, mem, false},
+	} {
+		if got := rez.SumAndCompare(tc.expected, tc.resources...); got != tc.want {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+}
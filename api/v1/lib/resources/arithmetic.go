@@ -0,0 +1,34 @@
+package resources
+
+import (
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// Add returns the sum of the given resources, w/o modifying either of the inputs. Resources are combined
+// according to the semantics of Mesos: resources are only merged if they share name, type, reservations,
+// allocation, disk info, revocability and provider. Persistent volumes are never merged; identical shared
+// volumes are kept as multiple copies, one for every addition (see SharedCount).
+func Add(subject []mesos.Resource, that ...mesos.Resource) mesos.Resources {
+	return mesos.Resources(subject).Plus(that...)
+}
+
+// Subtract returns the difference of the given resources, w/o modifying either of the inputs. Resources
+// of `that` that aren't (fully) contained in subject are subtracted as far as possible; subtracting a shared
+// volume removes a single copy of it.
+func Subtract(subject []mesos.Resource, that ...mesos.Resource) mesos.Resources {
+	return mesos.Resources(subject).Minus(that...)
+}
+
+// SharedCount returns the number of copies of the shared resource r that are present in subject; it
+// returns 0 if r isn't a shared resource.
+func SharedCount(subject []mesos.Resource, r mesos.Resource) (n int) {
+	if r.GetShared() == nil {
+		return 0
+	}
+	for i := range subject {
+		if subject[i].GetShared() != nil && subject[i].Equivalent(r) {
+			n++
+		}
+	}
+	return
+}
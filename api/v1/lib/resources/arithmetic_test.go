@@ -0,0 +1,73 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestArithmetic(t *testing.T) {
+	var (
+		cpus     = rez.NewCPUs(2).Resource
+		revCPUs  = rez.NewCPUs(1).Revocable().Resource
+		resCPUs  = rez.NewCPUs(1).Reserve("role", "p").Resource
+		mem      = rez.NewMemory(512).Resource
+		subject  = mesos.Resources{cpus, mem}
+		sum      = rez.Add(subject, cpus, revCPUs, resCPUs)
+		cpusOnly = rez.Add(nil, cpus, cpus)
+	)
+	if len(subject) != 2 {
+		t.Fatalf("Add modified its input: %v", subject)
+	}
+	// revocable and reserved resources aren't merged w/ unreserved, non-revocable resources
+	if len(sum) != 4 {
+		t.Fatalf("expected 4 resources instead of %v", sum)
+	}
+	if x, _ := rez.CPUs(cpusOnly...); x != 4 {
+		t.Fatalf("expected 4 cpus instead of %v", cpusOnly)
+	}
+	diff := rez.Subtract(sum, revCPUs, resCPUs, cpus)
+	if !rez.Equivalent(diff, subject) {
+		t.Fatalf("expected %v instead of %v", subject, diff)
+	}
+	if len(sum) != 4 {
+		t.Fatalf("Subtract modified its input: %v", sum)
+	}
+}
+
+func TestArithmetic_Shared(t *testing.T) {
+	var (
+		vol = rez.NewDisk(10).Reserve("role", "p").Persistence("id1", "p").
+			Volume("path", mesos.RW).Shared().Resource
+		unshared = rez.NewDisk(10).Reserve("role", "p").Persistence("id1", "p").
+				Volume("path", mesos.RW).Resource
+		twice = rez.Add(nil, vol, vol)
+		once  = rez.Subtract(twice, vol)
+	)
+	for _, tc := range []struct {
+		rs   mesos.Resources
+		want int
+	}{
+		{twice, 2},
+		{once, 1},
+		{rez.Subtract(once, vol), 0},
+		{rez.Add(once, unshared), 1},
+	} {
+		if n := rez.SharedCount(tc.rs, vol); n != tc.want {
+			t.Errorf("expected shared count %d instead of %d for %v", tc.want, n, tc.rs)
+		}
+	}
+	if n := rez.SharedCount(twice, unshared); n != 0 {
+		t.Errorf("expected zero count for unshared resource instead of %d", n)
+	}
+	if !rez.ContainsAll(twice, mesos.Resources{vol, vol}) {
+		t.Errorf("expected %v to contain two copies of %v", twice, vol)
+	}
+	if rez.ContainsAll(once, mesos.Resources{vol, vol}) {
+		t.Errorf("expected %v to contain only one copy of %v", once, vol)
+	}
+	if !rez.Contains(once, vol) || rez.Contains(once, unshared) {
+		t.Errorf("expected %v to contain the shared volume only", once)
+	}
+}
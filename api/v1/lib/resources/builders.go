@@ -98,3 +98,68 @@ func (rb *Builder) Revocable() *Builder {
 	rb.Resource.Revocable = &mesos.Resource_RevocableInfo{}
 	return rb
 }
+
+// Reserve pushes a dynamic reservation for the given role onto the reservation stack of the resource;
+// the principal is optional. Successive invocations produce a stack of reservation refinements, for
+// which each role must be a strict subrole of the previous one.
+func (rb *Builder) Reserve(role, principal string, labels ...mesos.Label) *Builder {
+	rb.Resource.Reservations = append(rb.Resource.Reservations, DynamicReservation(role, principal, labels...))
+	return rb
+}
+
+// Persistence marks the disk resource as a persistent volume w/ the given ID; the principal is optional.
+func (rb *Builder) Persistence(id, principal string) *Builder {
+	if rb.Resource.Disk == nil {
+		rb.Resource.Disk = &mesos.Resource_DiskInfo{}
+	}
+	rb.Resource.Disk.Persistence = &mesos.Resource_DiskInfo_Persistence{ID: id}
+	if principal != "" {
+		rb.Resource.Disk.Persistence.Principal = &principal
+	}
+	return rb
+}
+
+// Volume specifies the path at which a (persistent) disk resource is mounted into a container.
+func (rb *Builder) Volume(containerPath string, mode mesos.Volume_Mode) *Builder {
+	if rb.Resource.Disk == nil {
+		rb.Resource.Disk = &mesos.Resource_DiskInfo{}
+	}
+	rb.Resource.Disk.Volume = &mesos.Volume{ContainerPath: containerPath, Mode: &mode}
+	return rb
+}
+
+// Shared marks the resource as shared; only persistent volumes may be shared.
+func (rb *Builder) Shared() *Builder {
+	rb.Resource.Shared = &mesos.Resource_SharedInfo{}
+	return rb
+}
+
+// Provider marks the resource as being offered by the resource provider w/ the given ID.
+func (rb *Builder) Provider(id string) *Builder {
+	rb.Resource.ProviderID = &mesos.ResourceProviderID{Value: id}
+	return rb
+}
+
+// SourceID sets the ID of the disk source (e.g. a CSI volume ID); requires a prior call to DiskSource.
+func (rb *Builder) SourceID(id string) *Builder {
+	if s := rb.Resource.GetDisk().GetSource(); s != nil {
+		s.ID = &id
+	}
+	return rb
+}
+
+// SourceProfile sets the profile of the disk source; requires a prior call to DiskSource.
+func (rb *Builder) SourceProfile(profile string) *Builder {
+	if s := rb.Resource.GetDisk().GetSource(); s != nil {
+		s.Profile = &profile
+	}
+	return rb
+}
+
+// SourceMetadata sets the metadata of the disk source; requires a prior call to DiskSource.
+func (rb *Builder) SourceMetadata(labels ...mesos.Label) *Builder {
+	if s := rb.Resource.GetDisk().GetSource(); s != nil {
+		s.Metadata = &mesos.Labels{Labels: labels}
+	}
+	return rb
+}
@@ -0,0 +1,45 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resourcefilters"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestBuilder_DiskSource(t *testing.T) {
+	var (
+		fast = rez.NewDisk(100).Disk("", "").DiskSource("", mesos.Resource_DiskInfo_Source_RAW).
+			Provider("rp1").SourceID("vol1").SourceProfile("fast").
+			SourceMetadata(mesos.Label{Key: "k", Value: proto("v")}).Resource
+		slow = rez.NewDisk(200).Disk("", "").DiskSource("", mesos.Resource_DiskInfo_Source_RAW).
+			Provider("rp2").SourceProfile("slow").Resource
+		mount = rez.NewDisk(300).Disk("", "").DiskSource("/mnt", mesos.Resource_DiskInfo_Source_MOUNT).Resource
+		plain = rez.NewDisk(400).SourceProfile("ignored").Resource
+		rs    = mesos.Resources{fast, slow, mount, plain}
+	)
+	if plain.Disk != nil {
+		t.Fatalf("unexpected disk info %v", plain.Disk)
+	}
+	s := fast.GetDisk().GetSource()
+	if s.GetID() != "vol1" || s.GetProfile() != "fast" || len(s.GetMetadata().GetLabels()) != 1 {
+		t.Fatalf("unexpected disk source %v", s)
+	}
+	for i, tc := range []struct {
+		filter resourcefilters.Filter
+		want   mesos.Resources
+	}{
+		{resourcefilters.DiskSource(mesos.Resource_DiskInfo_Source_RAW), mesos.Resources{fast, slow}},
+		{resourcefilters.DiskSource(mesos.Resource_DiskInfo_Source_MOUNT), mesos.Resources{mount}},
+		{resourcefilters.DiskProfile("slow"), mesos.Resources{slow}},
+		{resourcefilters.DiskProfile("none"), nil},
+		{resourcefilters.ResourceProvider(mesos.ResourceProviderID{Value: "rp1"}), mesos.Resources{fast}},
+	} {
+		if got := resourcefilters.Select(tc.filter, rs...); !rez.Equivalent(got, tc.want) {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+}
+
+func proto(s string) *string { return &s }
@@ -35,7 +35,8 @@ func Contains(subject []mesos.Resource, that mesos.Resource) bool {
 }
 
 func contains(subject []mesos.Resource, that mesos.Resource) bool {
-	// TODO(jdef): take into account the "count" of shared resources
+	// NOTE: shared resources are represented by one copy per "count", so a single copy suffices here;
+	// ContainsAll accounts for multiple copies by subtracting each persistent volume that it finds.
 	for i := range subject {
 		if subject[i].Contains(that) {
 			return true
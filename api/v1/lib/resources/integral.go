@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// ValidateIntegral returns an error if the value of any scalar resource w/ the given name is not a whole
+// number. Mesos requires, for example, that gpus are allocated in whole units.
+func ValidateIntegral(name Name, rs ...mesos.Resource) error {
+	for i := range rs {
+		r := &rs[i]
+		if !name.Filter(r) || r.GetType() != mesos.SCALAR {
+			continue
+		}
+		if v := r.GetScalar().GetValue(); v != math.Trunc(v) {
+			return fmt.Errorf("%s must be a whole number, not %v", name, v)
+		}
+	}
+	return nil
+}
+
+// TakeIntegral takes exactly n whole units of the scalar resources w/ the given name, with all-or-nothing
+// semantics: if fewer than n units are available then nothing is taken and ok is false. Units are taken
+// from the resources in order, so a single offer is drawn from before any resources that follow it. The
+// input resources are not modified.
+func TakeIntegral(name Name, n uint64, rs ...mesos.Resource) (taken, remaining mesos.Resources, ok bool) {
+	var available uint64
+	for i := range rs {
+		if r := &rs[i]; name.Filter(r) && r.GetType() == mesos.SCALAR {
+			available += uint64(r.GetScalar().GetValue())
+		}
+	}
+	if available < n {
+		return nil, mesos.Resources(rs).Clone(), false
+	}
+	need := float64(n)
+	for i := range rs {
+		r := rs[i]
+		if need == 0 || !name.Filter(&r) || r.GetType() != mesos.SCALAR {
+			remaining.Add1(r)
+			continue
+		}
+		units := math.Trunc(r.GetScalar().GetValue())
+		take := math.Min(units, need)
+		if take == 0 {
+			remaining.Add1(r)
+			continue
+		}
+		need -= take
+
+		t := r
+		t.Scalar = &mesos.Value_Scalar{Value: take}
+		taken.Add1(t)
+
+		if left := r.GetScalar().GetValue() - take; left > 0 {
+			r.Scalar = &mesos.Value_Scalar{Value: left}
+			remaining.Add1(r)
+		}
+	}
+	return taken, remaining, true
+}
+
+// RequiredCapabilities returns the framework capabilities that are required to accept (or launch tasks
+// that consume) the given resources: GPU_RESOURCES for gpus, SHARED_RESOURCES for shared resources,
+// REVOCABLE_RESOURCES for revocable resources and RESERVATION_REFINEMENT for refined reservations.
+func RequiredCapabilities(rs ...mesos.Resource) (caps []mesos.FrameworkInfo_Capability_Type) {
+	required := make(map[mesos.FrameworkInfo_Capability_Type]struct{})
+	for i := range rs {
+		r := &rs[i]
+		if NameGPUs.Filter(r) {
+			required[mesos.FrameworkInfo_Capability_GPU_RESOURCES] = struct{}{}
+		}
+		if r.GetShared() != nil {
+			required[mesos.FrameworkInfo_Capability_SHARED_RESOURCES] = struct{}{}
+		}
+		if r.IsRevocable() {
+			required[mesos.FrameworkInfo_Capability_REVOCABLE_RESOURCES] = struct{}{}
+		}
+		if len(r.GetReservations()) > 1 {
+			required[mesos.FrameworkInfo_Capability_RESERVATION_REFINEMENT] = struct{}{}
+		}
+	}
+	for _, c := range []mesos.FrameworkInfo_Capability_Type{
+		mesos.FrameworkInfo_Capability_REVOCABLE_RESOURCES,
+		mesos.FrameworkInfo_Capability_GPU_RESOURCES,
+		mesos.FrameworkInfo_Capability_SHARED_RESOURCES,
+		mesos.FrameworkInfo_Capability_RESERVATION_REFINEMENT,
+	} {
+		if _, ok := required[c]; ok {
+			caps = append(caps, c)
+		}
+	}
+	return
+}
+
+// ValidateCapabilities returns an error if the framework lacks any of the RequiredCapabilities of the
+// resources, or if gpus are requested in fractional units.
+func ValidateCapabilities(fi *mesos.FrameworkInfo, rs ...mesos.Resource) error {
+	for _, c := range RequiredCapabilities(rs...) {
+		if !fi.HasCapability(c) {
+			return fmt.Errorf("framework lacks the %v capability that's required by the resources", c)
+		}
+	}
+	return ValidateIntegral(NameGPUs, rs...)
+}
@@ -0,0 +1,66 @@
+package resources_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestTakeIntegral(t *testing.T) {
+	var (
+		gpus1 = rez.NewGPUs(1).Resource
+		gpus3 = rez.NewGPUs(3).Reserve("role", "").Resource
+		cpus  = rez.NewCPUs(1).Resource
+		rs    = mesos.Resources{gpus1, cpus, gpus3}
+	)
+	taken, remaining, ok := rez.TakeIntegral(rez.NameGPUs, 2, rs...)
+	if !ok {
+		t.Fatal("expected to take 2 gpus")
+	}
+	if want := (mesos.Resources{gpus1, rez.NewGPUs(1).Reserve("role", "").Resource}); !rez.Equivalent(taken, want) {
+		t.Errorf("expected %v instead of %v", want, taken)
+	}
+	if want := (mesos.Resources{cpus, rez.NewGPUs(2).Reserve("role", "").Resource}); !rez.Equivalent(remaining, want) {
+		t.Errorf("expected %v instead of %v", want, remaining)
+	}
+
+	taken, remaining, ok = rez.TakeIntegral(rez.NameGPUs, 5, rs...)
+	if ok || taken != nil || !rez.Equivalent(remaining, rs) {
+		t.Errorf("expected all-or-nothing semantics, got %v and %v", taken, remaining)
+	}
+	if gpus3.GetScalar().GetValue() != 3 {
+		t.Fatal("input resources were modified")
+	}
+}
+
+func TestValidateCapabilities(t *testing.T) {
+	var (
+		gpus   = rez.NewGPUs(1).Resource
+		shared = rez.NewDisk(1).Reserve("role", "").Persistence("v", "").Shared().Resource
+		fi     = func(caps ...mesos.FrameworkInfo_Capability_Type) *mesos.FrameworkInfo {
+			f := &mesos.FrameworkInfo{}
+			for _, c := range caps {
+				f.Capabilities = append(f.Capabilities, mesos.FrameworkInfo_Capability{Type: c})
+			}
+			return f
+		}
+	)
+	if caps, want := rez.RequiredCapabilities(shared, gpus), []mesos.FrameworkInfo_Capability_Type{
+		mesos.FrameworkInfo_Capability_GPU_RESOURCES,
+		mesos.FrameworkInfo_Capability_SHARED_RESOURCES,
+	}; !reflect.DeepEqual(caps, want) {
+		t.Errorf("expected %v instead of %v", want, caps)
+	}
+	if err := rez.ValidateCapabilities(fi(), gpus); err == nil {
+		t.Error("expected an error for a framework w/o the GPU_RESOURCES capability")
+	}
+	if err := rez.ValidateCapabilities(fi(mesos.FrameworkInfo_Capability_GPU_RESOURCES), gpus); err != nil {
+		t.Error(err)
+	}
+	fractional := rez.Build().Name(rez.NameGPUs).Scalar(0.5).Resource
+	if err := rez.ValidateCapabilities(fi(mesos.FrameworkInfo_Capability_GPU_RESOURCES), fractional); err == nil {
+		t.Error("expected an error for fractional gpus")
+	}
+}
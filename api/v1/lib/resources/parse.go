@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/roles"
+)
+
+// Parse parses the textual resource syntax accepted by the Mesos agent's --resources flag, for example
+// "cpus:0.5;mem:128;ports:[31000-32000]". Each resource may name a (static reservation) role, as in
+// "cpus(role):2". Scalar values are plain numbers, ranges are enclosed in brackets and sets in braces:
+// "ports:[21000-24000,30000-34000];zones:{a,b}". Resources that share a name, role and type are merged.
+// Unreserved resources produced by Parse are rendered in the same syntax by Resources.String.
+func Parse(text string) (mesos.Resources, error) {
+	var result mesos.Resources
+	for _, token := range strings.Split(text, ";") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		r, err := parseResource(token)
+		if err != nil {
+			return nil, err
+		}
+		if err = r.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid resource %q: %v", token, err)
+		}
+		if r.IsEmpty() {
+			continue
+		}
+		result.Add1(r)
+	}
+	return result, nil
+}
+
+func parseResource(token string) (r mesos.Resource, err error) {
+	i := strings.Index(token, ":")
+	if i < 0 {
+		return r, fmt.Errorf("bad resource %q: expected name:value", token)
+	}
+	name, value := strings.TrimSpace(token[:i]), strings.TrimSpace(token[i+1:])
+
+	if j := strings.Index(name, "("); j >= 0 {
+		if !strings.HasSuffix(name, ")") {
+			return r, fmt.Errorf("bad resource %q: unterminated role", token)
+		}
+		role, err := roles.Parse(strings.TrimSpace(name[j+1 : len(name)-1]))
+		if err != nil {
+			return r, fmt.Errorf("bad resource %q: %v", token, err)
+		}
+		if role != "*" {
+			r.Role = &role
+		}
+		name = strings.TrimSpace(name[:j])
+	}
+	r.Name = name
+
+	switch {
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return r, fmt.Errorf("bad resource %q: unterminated ranges", token)
+		}
+		ranges, err := parseRanges(value[1 : len(value)-1])
+		if err != nil {
+			return r, fmt.Errorf("bad resource %q: %v", token, err)
+		}
+		r.Type = mesos.RANGES.Enum()
+		r.Ranges = &mesos.Value_Ranges{Range: ranges}
+	case strings.HasPrefix(value, "{"):
+		if !strings.HasSuffix(value, "}") {
+			return r, fmt.Errorf("bad resource %q: unterminated set", token)
+		}
+		var items []string
+		for _, item := range strings.Split(value[1:len(value)-1], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		r.Type = mesos.SET.Enum()
+		r.Set = &mesos.Value_Set{Item: items}
+	default:
+		x, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(x) || math.IsInf(x, 0) {
+			return r, fmt.Errorf("bad resource %q: illegal scalar value %q", token, value)
+		}
+		r.Type = mesos.SCALAR.Enum()
+		r.Scalar = &mesos.Value_Scalar{Value: x}
+	}
+	return r, nil
+}
+
+// parseRanges parses a comma-separated list of ranges, e.g. "1-10,15,20-25"; a single number is
+// shorthand for a range that begins and ends w/ that number.
+func parseRanges(s string) (ranges []mesos.Value_Range, err error) {
+	for _, span := range strings.Split(s, ",") {
+		span = strings.TrimSpace(span)
+		if span == "" {
+			continue
+		}
+		var (
+			begin, end = span, span
+			rg         mesos.Value_Range
+		)
+		if k := strings.Index(span, "-"); k >= 0 {
+			begin, end = strings.TrimSpace(span[:k]), strings.TrimSpace(span[k+1:])
+		}
+		if rg.Begin, err = strconv.ParseUint(begin, 10, 64); err != nil {
+			return nil, fmt.Errorf("illegal range %q", span)
+		}
+		if rg.End, err = strconv.ParseUint(end, 10, 64); err != nil {
+			return nil, fmt.Errorf("illegal range %q", span)
+		}
+		ranges = append(ranges, rg)
+	}
+	return ranges, nil
+}
@@ -0,0 +1,69 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestParse(t *testing.T) {
+	for i, tc := range []struct {
+		text    string
+		want    mesos.Resources
+		wantErr bool
+	}{
+		{text: ""},
+		{text: " ; "},
+		{
+			text: "cpus:0.5;mem:128;ports:[31000-32000]",
+			want: mesos.Resources{
+				rez.NewCPUs(0.5).Resource,
+				rez.NewMemory(128).Resource,
+				rez.Build().Name(rez.NamePorts).Ranges(rez.BuildRanges().Span(31000, 32000).Ranges).Resource,
+			},
+		},
+		{
+			text: " cpus(role1) : 2 ; cpus:1; cpus(role1):1.5 ; zones:{a, b}; ports:[80, 8000-8080]",
+			want: mesos.Resources{
+				rez.NewCPUs(3.5).Role("role1").Resource,
+				rez.NewCPUs(1).Resource,
+				rez.Build().Name(rez.Name("zones")).Set("a", "b").Resource,
+				rez.Build().Name(rez.NamePorts).Ranges(rez.BuildRanges().Span(80, 80).Span(8000, 8080).Ranges).Resource,
+			},
+		},
+		{text: "cpus(*):1", want: mesos.Resources{rez.NewCPUs(1).Resource}},
+		{text: "cpus:0;mem:0", want: nil},
+		{text: "cpus", wantErr: true},
+		{text: ":1", wantErr: true},
+		{text: "cpus:abc", wantErr: true},
+		{text: "cpus:NaN", wantErr: true},
+		{text: "cpus:-1", wantErr: true},
+		{text: "cpus(role:1", wantErr: true},
+		{text: "cpus(/role):1", wantErr: true},
+		{text: "ports:[1-2", wantErr: true},
+		{text: "ports:[2-1]", wantErr: true},
+		{text: "ports:[1-x]", wantErr: true},
+		{text: "zones:{a,a}", wantErr: true},
+	} {
+		got, err := rez.Parse(tc.text)
+		if tc.wantErr != (err != nil) {
+			t.Errorf("test case %d failed: unexpected error %v", i, err)
+			continue
+		}
+		if !rez.Equivalent(got, tc.want) {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	const text = "cpus:0.5;mem:128;ports:[80,31000-32000];zones:{a,b}"
+	rs, err := rez.Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := rs.String(); s != text {
+		t.Fatalf("expected %q instead of %q", text, s)
+	}
+}
@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// GroupByRole returns the given resources grouped by the role for which they're reserved; unreserved
+// resources are grouped under mesos.DefaultRole. For refined reservations the role of the most refined
+// reservation is used. The input is not modified.
+func GroupByRole(resources ...mesos.Resource) map[string]mesos.Resources {
+	result := make(map[string]mesos.Resources)
+	for i := range resources {
+		role := mesos.DefaultRole
+		if r := &resources[i]; !r.IsUnreserved() {
+			role = r.ReservationRole()
+		}
+		rs := result[role]
+		result[role] = rs.Add1(resources[i])
+	}
+	return result
+}
+
+// SumScalars returns the sum of the scalar resources, by name; sums are calculated w/ fixed-point
+// arithmetic (like Mesos does). The input is not modified.
+func SumScalars(resources ...mesos.Resource) map[Name]float64 {
+	sums := make(map[Name]*mesos.Value_Scalar)
+	for i := range resources {
+		if resources[i].GetType() != mesos.SCALAR {
+			continue
+		}
+		n := Name(resources[i].GetName())
+		sums[n] = sums[n].Add(resources[i].GetScalar())
+	}
+	result := make(map[Name]float64, len(sums))
+	for n, v := range sums {
+		result[n] = v.GetValue()
+	}
+	return result
+}
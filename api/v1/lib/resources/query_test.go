@@ -0,0 +1,59 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resourcefilters"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestQueries(t *testing.T) {
+	var (
+		cpus      = rez.NewCPUs(1).Resource
+		revocable = rez.NewCPUs(0.5).Revocable().Resource
+		reserved  = rez.NewCPUs(2).Reserve("a", "p1").Resource
+		refined   = rez.NewMemory(64).Reserve("a", "p1").Reserve("a/b", "p2").Resource
+		mem       = rez.NewMemory(128).Resource
+		rs        = mesos.Resources{cpus, revocable, reserved, refined, mem}
+		original  = rs.Clone()
+	)
+	for i, tc := range []struct {
+		filter resourcefilters.Interface
+		want   mesos.Resources
+	}{
+		{resourcefilters.Filter(rez.NameMem.Filter), mesos.Resources{refined, mem}},
+		{resourcefilters.ReservedByRole("a"), mesos.Resources{reserved}},
+		{resourcefilters.ReservedByPrincipal("p1"), mesos.Resources{reserved}},
+		{resourcefilters.ReservedByPrincipal("p2"), mesos.Resources{refined}},
+		{resourcefilters.Filter(resourcefilters.Revocable), mesos.Resources{revocable}},
+		{resourcefilters.New(resourcefilters.NonRevocable, rez.NameCPUs.Filter), mesos.Resources{cpus, reserved}},
+	} {
+		if got := resourcefilters.Select(tc.filter, rs...); !rez.Equivalent(got, tc.want) {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+
+	groups := rez.GroupByRole(rs...)
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups instead of %v", groups)
+	}
+	for role, want := range map[string]mesos.Resources{
+		"*":   {cpus, revocable, mem},
+		"a":   {reserved},
+		"a/b": {refined},
+	} {
+		if got := groups[role]; !rez.Equivalent(got, want) {
+			t.Errorf("role %q: expected %v instead of %v", role, want, got)
+		}
+	}
+
+	sums := rez.SumScalars(rs...)
+	if sums[rez.NameCPUs] != 3.5 || sums[rez.NameMem] != 192 || len(sums) != 2 {
+		t.Errorf("unexpected sums %v", sums)
+	}
+
+	if !rez.Equivalent(rs, original) || len(rs) != len(original) {
+		t.Fatalf("queries modified their input: %v", rs)
+	}
+}
@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/roles"
+)
+
+// DynamicReservation returns a DYNAMIC reservation for the given role; the principal is optional.
+func DynamicReservation(role, principal string, labels ...mesos.Label) mesos.Resource_ReservationInfo {
+	ri := mesos.Resource_ReservationInfo{
+		Type: mesos.Resource_ReservationInfo_DYNAMIC.Enum(),
+		Role: &role,
+	}
+	if principal != "" {
+		ri.Principal = &principal
+	}
+	if len(labels) > 0 {
+		ri.Labels = &mesos.Labels{Labels: labels}
+	}
+	return ri
+}
+
+// StaticReservation returns a STATIC reservation for the given role. Static reservations may only
+// appear at the bottom of a reservation stack.
+func StaticReservation(role string) mesos.Resource_ReservationInfo {
+	return mesos.Resource_ReservationInfo{
+		Type: mesos.Resource_ReservationInfo_STATIC.Enum(),
+		Role: &role,
+	}
+}
+
+// Refine returns a (cloned) set of resources w/ the given reservation pushed onto the reservation stack of
+// each resource; the result is suitable for a RESERVE operation. Unlike Resources.PushReservation, an error
+// is returned (instead of a panic) if the reservation is not a valid refinement: each resource must either
+// be unreserved or reserved for a strict ancestor of the reservation's role. Resources in the legacy
+// "pre-reservation-refinement" format are converted prior to refinement.
+func Refine(rs mesos.Resources, ri mesos.Resource_ReservationInfo) (mesos.Resources, error) {
+	role, err := roles.Parse(ri.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	if role == "*" {
+		return nil, fmt.Errorf("role %q cannot be reserved", role)
+	}
+	rs = rs.ToPostRefinementFormat()
+	if err = rs.Validate(); err != nil {
+		return nil, err
+	}
+	for i := range rs {
+		r := &rs[i]
+		if r.IsUnreserved() {
+			continue
+		}
+		if ri.GetType() == mesos.Resource_ReservationInfo_STATIC {
+			return nil, fmt.Errorf("resource %q: a refined reservation cannot be STATIC", r.Name)
+		}
+		if parent := r.ReservationRole(); !roles.IsStrictSubroleOf(role, parent) {
+			return nil, fmt.Errorf("resource %q: role %q is not a refinement of %q", r.Name, role, parent)
+		}
+	}
+	return rs.PushReservation(ri), nil
+}
+
+// Unrefine returns a (cloned) set of resources w/ the most recent reservation refinement removed from
+// each resource; the input is suitable for an UNRESERVE operation. Unlike Resources.PopReservation, an
+// error is returned (instead of a panic) if any resource has no reservation to remove.
+func Unrefine(rs mesos.Resources) (mesos.Resources, error) {
+	rs = rs.ToPostRefinementFormat()
+	for i := range rs {
+		if len(rs[i].Reservations) == 0 {
+			return nil, fmt.Errorf("resource %q is not reserved", rs[i].Name)
+		}
+	}
+	return rs.PopReservation(), nil
+}
@@ -0,0 +1,59 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestRefine(t *testing.T) {
+	var (
+		unreserved = mesos.Resources{rez.NewCPUs(1).Resource}
+		reserved   = mesos.Resources{rez.NewCPUs(1).Reserve("a", "p").Resource}
+		refined    = mesos.Resources{rez.NewCPUs(1).Reserve("a", "p").Reserve("a/b", "p").Resource}
+		legacy     = mesos.Resources{rez.NewCPUs(1).Role("a").Resource}
+	)
+	for i, tc := range []struct {
+		rs      mesos.Resources
+		ri      mesos.Resource_ReservationInfo
+		want    mesos.Resources
+		wantErr bool
+	}{
+		{rs: unreserved, ri: rez.DynamicReservation("a", "p"), want: reserved},
+		{rs: reserved, ri: rez.DynamicReservation("a/b", "p"), want: refined},
+		{rs: unreserved, ri: rez.DynamicReservation("*", "p"), wantErr: true},
+		{rs: unreserved, ri: rez.DynamicReservation("/a", "p"), wantErr: true},
+		{rs: reserved, ri: rez.DynamicReservation("a", "p"), wantErr: true},
+		{rs: reserved, ri: rez.DynamicReservation("c/d", "p"), wantErr: true},
+		{rs: reserved, ri: rez.StaticReservation("a/b"), wantErr: true},
+		{
+			rs: legacy, ri: rez.DynamicReservation("a/b", ""),
+			want: func() mesos.Resources {
+				r := rez.NewCPUs(1).Reserve("a/b", "").Resource
+				r.Reservations = append([]mesos.Resource_ReservationInfo{rez.StaticReservation("a")}, r.Reservations...)
+				return mesos.Resources{r}
+			}(),
+		},
+	} {
+		got, err := rez.Refine(tc.rs, tc.ri)
+		if tc.wantErr != (err != nil) {
+			t.Errorf("test case %d failed: unexpected error %v", i, err)
+			continue
+		}
+		if !rez.Equivalent(got, tc.want) {
+			t.Errorf("test case %d failed: expected %v instead of %v", i, tc.want, got)
+		}
+	}
+
+	got, err := rez.Unrefine(refined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rez.Equivalent(got, reserved) {
+		t.Fatalf("expected %v instead of %v", reserved, got)
+	}
+	if _, err = rez.Unrefine(unreserved); err == nil {
+		t.Fatal("expected an error for unreserved resources")
+	}
+}
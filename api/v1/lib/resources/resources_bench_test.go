@@ -0,0 +1,107 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/internal/race"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+	. "github.com/mesos/mesos-go/api/v1/lib/resourcetest"
+)
+
+// agentResources resemble the resources that a typical agent offers to a framework w/ a single role.
+func agentResources() mesos.Resources {
+	return Resources(
+		Resource(Name("cpus"), ValueScalar(16)),
+		Resource(Name("mem"), ValueScalar(65536)),
+		Resource(Name("disk"), ValueScalar(512000)),
+		Resource(Name("ports"), ValueRange(Span(31000, 32000))),
+		Resource(Name("cpus"), ValueScalar(4), Role("role1")),
+		Resource(Name("mem"), ValueScalar(8192), Role("role1")),
+		Resource(Name("ports"), ValueRange(Span(21000, 21100), Span(22000, 22100)), Role("role1")),
+	)
+}
+
+func taskResources() mesos.Resources {
+	return Resources(
+		Resource(Name("cpus"), ValueScalar(0.5)),
+		Resource(Name("mem"), ValueScalar(512)),
+		Resource(Name("ports"), ValueRange(Span(31000, 31001))),
+	)
+}
+
+func BenchmarkArithmetic(b *testing.B) {
+	var (
+		agent = agentResources()
+		task  = taskResources()
+	)
+	b.Run("plus", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = agent.Plus(task...)
+		}
+	})
+	b.Run("minus", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = agent.Minus(task...)
+		}
+	})
+	b.Run("contains", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !rez.ContainsAll(agent, task) {
+				b.Fatal("expected agent resources to contain task resources")
+			}
+		}
+	})
+	b.Run("flatten", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = rez.Flatten(agent)
+		}
+	})
+	b.Run("find", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if rez.Find(task, agent...) == nil {
+				b.Fatal("expected to find task resources")
+			}
+		}
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	const text = "cpus:16;mem:65536;disk:512000;ports:[31000-32000];cpus(role1):4;mem(role1):8192"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := rez.Parse(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestAllocs guards against regressions of the allocations of resource math; the limits leave some
+// headroom over what's currently observed.
+func TestAllocs(t *testing.T) {
+	if race.Enabled {
+		t.Skip("allocation counts are skewed by the race detector")
+	}
+	var (
+		agent = agentResources()
+		task  = taskResources()
+	)
+	for _, tc := range []struct {
+		name string
+		max  float64
+		f    func()
+	}{
+		{"contains", 45, func() { rez.ContainsAll(agent, task) }},
+		{"minus", 50, func() { agent.Minus(task...) }},
+		{"plus", 55, func() { agent.Plus(task...) }},
+	} {
+		if n := testing.AllocsPerRun(100, tc.f); n > tc.max {
+			t.Errorf("%s: %v allocations exceed the limit of %v", tc.name, n, tc.max)
+		}
+	}
+}
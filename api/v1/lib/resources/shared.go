@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+// MarkShared returns a (cloned) set of resources in which every persistent volume is marked as shared;
+// other resources are returned as is. The input resources are not modified.
+func MarkShared(rs ...mesos.Resource) mesos.Resources {
+	result := mesos.Resources(rs).Clone()
+	for i := range result {
+		if result[i].IsPersistentVolume() {
+			result[i].Shared = &mesos.Resource_SharedInfo{}
+		}
+	}
+	return result
+}
+
+// ValidateShared returns an error if any resource other than a persistent volume is marked as shared.
+func ValidateShared(rs ...mesos.Resource) error {
+	for i := range rs {
+		if r := &rs[i]; r.GetShared() != nil && !r.IsPersistentVolume() {
+			return fmt.Errorf("resource %q: only persistent volumes may be shared", r.Name)
+		}
+	}
+	return nil
+}
+
+// Consume returns the resources that remain after `used` has been consumed from `offered`, w/o modifying
+// either of the inputs. Shared resources may have multiple consumers: a shared resource in `used` must be
+// present in `offered` but it's not removed from the result, so that subsequent tasks may consume it too.
+// Returns false (and the offered resources) if `offered` doesn't contain all of the used resources.
+func Consume(offered, used []mesos.Resource) (mesos.Resources, bool) {
+	remaining := mesos.Resources(offered).Clone()
+	for i := range used {
+		r := used[i]
+		if r.GetShared() != nil {
+			if SharedCount(remaining, r) == 0 {
+				return mesos.Resources(offered).Clone(), false
+			}
+			continue
+		}
+		if !contains(remaining, r) {
+			return mesos.Resources(offered).Clone(), false
+		}
+		remaining.Subtract1(r)
+	}
+	return remaining, true
+}
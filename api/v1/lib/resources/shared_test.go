@@ -0,0 +1,52 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+	rez "github.com/mesos/mesos-go/api/v1/lib/resources"
+)
+
+func TestShared(t *testing.T) {
+	var (
+		volume = rez.NewDisk(64).Reserve("role", "p").Persistence("v1", "p").Volume("data", mesos.RW).Resource
+		cpus   = rez.NewCPUs(1).Resource
+	)
+	marked := rez.MarkShared(volume, cpus)
+	if marked[0].GetShared() == nil || marked[1].GetShared() != nil || volume.GetShared() != nil {
+		t.Fatalf("unexpected result %v", marked)
+	}
+	if err := rez.ValidateShared(marked...); err != nil {
+		t.Fatal(err)
+	}
+	if err := rez.ValidateShared(rez.NewCPUs(1).Shared().Resource); err == nil {
+		t.Fatal("expected an error for shared cpus")
+	}
+
+	var (
+		shared  = marked[0]
+		offered = mesos.Resources{shared, rez.NewCPUs(2).Resource}
+		task    = mesos.Resources{shared, cpus}
+	)
+	// two tasks may consume the same shared volume
+	remaining, ok := rez.Consume(offered, task)
+	if !ok {
+		t.Fatal("expected the offer to contain the resources of the first task")
+	}
+	remaining, ok = rez.Consume(remaining, task)
+	if !ok {
+		t.Fatal("expected the offer to contain the resources of the second task")
+	}
+	if want := (mesos.Resources{shared}); !rez.Equivalent(remaining, want) {
+		t.Fatalf("expected %v instead of %v", want, remaining)
+	}
+	if _, ok = rez.Consume(remaining, task); ok {
+		t.Fatal("expected insufficient cpus for a third task")
+	}
+	if _, ok = rez.Consume(mesos.Resources{cpus}, mesos.Resources{shared}); ok {
+		t.Fatal("expected a missing shared volume")
+	}
+	if rez.SharedCount(offered, shared) != 1 {
+		t.Fatal("Consume modified its input")
+	}
+}
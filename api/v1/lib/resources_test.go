@@ -56,6 +56,30 @@ func TestResources_PrecisionManyConsecutiveOps(t *testing.T) {
 	}
 }
 
+func TestResources_PrecisionFractions(t *testing.T) {
+	var (
+		cpu     = Resources(Resource(Name("cpus"), ValueScalar(0.1)))
+		current mesos.Resources
+	)
+	for i := 0; i < 1000; i++ {
+		current.Add(cpu...)
+	}
+	if actual, ok := rez.CPUs(current...); !(ok && actual == 100) {
+		t.Fatalf("expected 100 cpus instead of %v", actual)
+	}
+	for i := 0; i < 1000; i++ {
+		current.Subtract(cpu...)
+	}
+	if len(current) != 0 {
+		t.Fatalf("expected no remaining resources instead of %v", current)
+	}
+	// values below the fixed-point precision are empty, as far as Mesos is concerned
+	tiny := Resource(Name("cpus"), ValueScalar(0.0004))
+	if !tiny.IsEmpty() {
+		t.Fatalf("expected %v to be empty", tiny)
+	}
+}
+
 func TestResources_PrecisionManyOps(t *testing.T) {
 	var (
 		start   = Resources(Resource(Name("cpus"), ValueScalar(1.001)))
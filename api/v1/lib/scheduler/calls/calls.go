@@ -237,6 +237,14 @@ func OpDestroyBlock(blk mesos.Resource) mesos.Offer_Operation {
 	}
 }
 
+// OpWithID returns a copy of the operation w/ the given ID. Status updates (that must be acknowledged,
+// see AcknowledgeOperationStatus) are only generated for operations that specify an ID, and only for
+// operations on resources that are offered by a resource provider.
+func OpWithID(id string, op mesos.Offer_Operation) mesos.Offer_Operation {
+	op.ID = &mesos.OperationID{Value: id}
+	return op
+}
+
 // Revive returns a revive call.
 // Callers are expected to fill in the FrameworkID.
 func Revive() *scheduler.Call {
@@ -369,6 +377,31 @@ func Request(requests ...mesos.Request) *scheduler.Call {
 	}
 }
 
+// ResourceRequest returns a request for the given resources, on the identified agent or else (if agentID
+// is "") on any agent; see Request.
+func ResourceRequest(agentID string, rs ...mesos.Resource) mesos.Request {
+	return mesos.Request{AgentID: optionalAgentID(agentID), Resources: rs}
+}
+
+// RequestBatches returns the REQUEST calls that carry the given requests, w/ at most max requests per call;
+// a max of zero (or less) yields a single call. Requests that target the same agent (or no specific agent)
+// are not merged since allocators may take the shape of individual requests into account.
+// Callers are expected to fill in the FrameworkID.
+func RequestBatches(max int, requests ...mesos.Request) (batches []*scheduler.Call) {
+	if max <= 0 {
+		max = len(requests)
+	}
+	for len(requests) > 0 {
+		n := max
+		if n > len(requests) {
+			n = len(requests)
+		}
+		batches = append(batches, Request(requests[:n:n]...))
+		requests = requests[n:]
+	}
+	return
+}
+
 func optionalAgentID(agentID string) *mesos.AgentID {
 	if agentID == "" {
 		return nil
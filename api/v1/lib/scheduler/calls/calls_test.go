@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/mesos/mesos-go/api/v1/lib"
+	"github.com/mesos/mesos-go/api/v1/lib/resources"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler"
 	"github.com/mesos/mesos-go/api/v1/lib/scheduler/calls"
 )
@@ -49,3 +51,36 @@ func TestRole(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestBatches(t *testing.T) {
+	var requests []mesos.Request
+	for i := 0; i < 5; i++ {
+		requests = append(requests, calls.ResourceRequest("", resources.NewCPUs(float64(i+1)).Resource))
+	}
+	if r := calls.ResourceRequest("a1"); r.GetAgentID().GetValue() != "a1" {
+		t.Fatalf("unexpected request %v", r)
+	}
+	for _, tc := range []struct {
+		max   int
+		sizes []int
+	}{
+		{0, []int{5}},
+		{2, []int{2, 2, 1}},
+		{5, []int{5}},
+		{10, []int{5}},
+	} {
+		var sizes []int
+		for _, c := range calls.RequestBatches(tc.max, requests...) {
+			if c.GetType() != scheduler.Call_REQUEST {
+				t.Fatalf("unexpected call %v", c)
+			}
+			sizes = append(sizes, len(c.GetRequest().GetRequests()))
+		}
+		if !reflect.DeepEqual(sizes, tc.sizes) {
+			t.Errorf("max %d: expected batches of %v instead of %v", tc.max, tc.sizes, sizes)
+		}
+	}
+	if batches := calls.RequestBatches(2); len(batches) != 0 {
+		t.Fatalf("expected no batches instead of %v", batches)
+	}
+}
@@ -99,7 +99,7 @@ func popReservation(r mesos.Resource) mesos.Resource {
 		// duplicate the slice, truncated. we don't want the optimized form of
 		// the "delete the last object from a slice" because it would mutate the
 		// contents of the original reservation (in an attempt to avoid a mem leak).
-		rs := make([]mesos.Resource_ReservationInfo, 0, x-1)
+		rs := make([]mesos.Resource_ReservationInfo, x-1)
 		copy(rs, r.Reservations[:x-1])
 		r.Reservations = rs
 	}
@@ -250,3 +250,24 @@ func TestOpReserve(t *testing.T) {
 		t.Fatalf("expected reservation error")
 	}
 }
+
+func TestOpReserve_Refinement(t *testing.T) {
+	var (
+		unreservedCPU = Resources(Resource(Name("cpus"), ValueScalar(1)))
+		reservedCPU   = unreservedCPU.PushReservation(DynamicReservation("role", "principal"))
+		refinedCPU    = reservedCPU.PushReservation(DynamicReservation("role/sub", "principal"))
+	)
+	actualReserved, err := operations.Apply(Reserve(refinedCPU), reservedCPU, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rez.Equivalent(refinedCPU, actualReserved) {
+		t.Errorf("expected resources %+v instead of %+v", refinedCPU, actualReserved)
+	}
+
+	// refinements may not be applied to unreserved resources
+	_, err = operations.Apply(Reserve(refinedCPU), unreservedCPU, nil)
+	if err == nil {
+		t.Fatalf("expected reservation error")
+	}
+}
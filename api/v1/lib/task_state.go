@@ -0,0 +1,11 @@
+package mesos
+
+// IsTerminal returns true if the state is terminal, i.e. a task in this state will not transition to
+// any other state and no longer consumes resources.
+func (s TaskState) IsTerminal() bool {
+	switch s {
+	case TASK_FINISHED, TASK_FAILED, TASK_KILLED, TASK_ERROR, TASK_LOST, TASK_DROPPED, TASK_GONE, TASK_GONE_BY_OPERATOR:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package mesos
+
+import "time"
+
+// NewUnavailability returns an Unavailability that begins at start and lasts for the given duration; a
+// non-positive duration represents an interval without an end.
+func NewUnavailability(start time.Time, d time.Duration) Unavailability {
+	u := Unavailability{Start: TimeInfo{Nanoseconds: start.UnixNano()}}
+	if d > 0 {
+		u.Duration = &DurationInfo{Nanoseconds: int64(d)}
+	}
+	return u
+}
+
+// StartTime returns the beginning of the interval.
+func (u *Unavailability) StartTime() time.Time {
+	return time.Unix(0, u.GetStart().Nanoseconds)
+}
+
+// EndTime returns the end of the interval; false if the interval is without end.
+func (u *Unavailability) EndTime() (time.Time, bool) {
+	d := u.GetDuration()
+	if d == nil {
+		return time.Time{}, false
+	}
+	return u.StartTime().Add(time.Duration(d.GetNanoseconds())), true
+}
+
+// Contains returns true if the given time falls within the interval.
+func (u *Unavailability) Contains(t time.Time) bool {
+	if u == nil || t.Before(u.StartTime()) {
+		return false
+	}
+	end, ok := u.EndTime()
+	return !ok || t.Before(end)
+}
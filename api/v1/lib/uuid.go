@@ -0,0 +1,23 @@
+package mesos
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewUUID returns a random (version 4) UUID in its binary form; for example, for the UUID of a status
+// update. An error is returned if the system's source of randomness fails.
+func NewUUID() ([]byte, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return b, nil
+}
+
+// FormatUUID returns the canonical textual representation of a binary UUID, as returned by NewUUID.
+func FormatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,45 @@
+package mesos
+
+import "sort"
+
+// DefaultWeight is the weight of a role for which no weight has been configured.
+const DefaultWeight = 1.0
+
+// NewWeight returns a WeightInfo that assigns the weight to the role.
+func NewWeight(role string, weight float64) WeightInfo {
+	return WeightInfo{Role: &role, Weight: weight}
+}
+
+// DiffWeights returns the weight updates that are required to reconcile the current role weights with
+// the desired role weights; roles that are absent from current are assumed to have the DefaultWeight.
+// If prune is true then roles that have a non-default current weight, but are absent from desired, are
+// reset to the DefaultWeight. Updates are sorted by role.
+func DiffWeights(current, desired []WeightInfo, prune bool) (updates []WeightInfo) {
+	var (
+		have = make(map[string]float64, len(current))
+		want = make(map[string]struct{}, len(desired))
+	)
+	for i := range current {
+		have[current[i].GetRole()] = current[i].Weight
+	}
+	for i := range desired {
+		role := desired[i].GetRole()
+		want[role] = struct{}{}
+		w, ok := have[role]
+		if !ok {
+			w = DefaultWeight
+		}
+		if w != desired[i].Weight {
+			updates = append(updates, NewWeight(role, desired[i].Weight))
+		}
+	}
+	if prune {
+		for role, w := range have {
+			if _, ok := want[role]; !ok && w != DefaultWeight {
+				updates = append(updates, NewWeight(role, DefaultWeight))
+			}
+		}
+	}
+	sort.Slice(updates, func(i, j int) bool { return updates[i].GetRole() < updates[j].GetRole() })
+	return
+}
@@ -0,0 +1,38 @@
+package mesos_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mesos/mesos-go/api/v1/lib"
+)
+
+func TestDiffWeights(t *testing.T) {
+	var (
+		current = []mesos.WeightInfo{
+			mesos.NewWeight("a", 2),
+			mesos.NewWeight("b", 3),
+			mesos.NewWeight("c", 1),
+		}
+		desired = []mesos.WeightInfo{
+			mesos.NewWeight("a", 2),
+			mesos.NewWeight("d", 4),
+			mesos.NewWeight("e", 1),
+		}
+	)
+	for ti, tc := range []struct {
+		prune bool
+		want  []mesos.WeightInfo
+	}{
+		{false, []mesos.WeightInfo{mesos.NewWeight("d", 4)}},
+		{true, []mesos.WeightInfo{mesos.NewWeight("b", mesos.DefaultWeight), mesos.NewWeight("d", 4)}},
+	} {
+		got := mesos.DiffWeights(current, desired, tc.prune)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("test case %d failed: expected %v instead of %v", ti, tc.want, got)
+		}
+	}
+	if got := mesos.DiffWeights(current, current, true); len(got) != 0 {
+		t.Errorf("expected no updates instead of %v", got)
+	}
+}